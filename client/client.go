@@ -0,0 +1,221 @@
+// Package client — типизированный клиент Bank API. Типы и методы в
+// client_gen.go генерируются по спецификации OpenAPI командой go generate.
+package client
+
+//go:generate go run ../cmd/genclient -o client_gen.go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type authKind int
+
+const (
+	authNone authKind = iota
+	authUser
+	authOperator
+)
+
+type Client struct {
+	baseURL       string
+	httpClient    *http.Client
+	token         string
+	operatorToken string
+	language      string
+}
+
+type Option func(*Client)
+
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithToken задает JWT пользователя, полученный через Login.
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithOperatorToken задает токен для операторских методов.
+func WithOperatorToken(token string) Option {
+	return func(c *Client) { c.operatorToken = token }
+}
+
+// WithLanguage задает Accept-Language, например "en".
+func WithLanguage(language string) Option {
+	return func(c *Client) { c.language = language }
+}
+
+// New создает клиент. baseURL включает префикс API, например
+// http://localhost:8080/api.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// SetToken заменяет JWT пользователя, например после Login.
+func (c *Client) SetToken(token string) {
+	c.token = token
+}
+
+// Error — ответ API об ошибке в формате RFC 7807.
+type Error struct {
+	StatusCode int
+	Problem    Problem
+}
+
+func (e *Error) Error() string {
+	if e.Problem.Detail != "" {
+		return fmt.Sprintf("bank api: %d %s: %s", e.StatusCode, e.Problem.Code, e.Problem.Detail)
+	}
+	return fmt.Sprintf("bank api: %d %s", e.StatusCode, e.Problem.Code)
+}
+
+// AltResponse возвращается как ошибка, когда API ответил успешным кодом,
+// отличным от основного, например 202 на перевод, ожидающий подтверждения.
+// Body разбирается в тип, указанный в описании метода.
+type AltResponse struct {
+	StatusCode int
+	Body       json.RawMessage
+}
+
+func (e *AltResponse) Error() string {
+	return fmt.Sprintf("bank api: ответ %d вместо основного", e.StatusCode)
+}
+
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	auth        authKind
+	body        io.Reader
+	contentType string
+}
+
+func (r *request) setJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("ошибка кодирования запроса: %w", err)
+	}
+	r.body = bytes.NewReader(data)
+	r.contentType = "application/json"
+	return nil
+}
+
+// setMultipart кодирует форму с текстовыми полями и одним файлом.
+func (r *request) setMultipart(fields map[string]string, fileField, fileName string, file io.Reader) error {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for name, value := range fields {
+		if err := mw.WriteField(name, value); err != nil {
+			return fmt.Errorf("ошибка кодирования формы: %w", err)
+		}
+	}
+	fw, err := mw.CreateFormFile(fileField, fileName)
+	if err != nil {
+		return fmt.Errorf("ошибка кодирования формы: %w", err)
+	}
+	if _, err := io.Copy(fw, file); err != nil {
+		return fmt.Errorf("ошибка чтения файла: %w", err)
+	}
+	if err := mw.Close(); err != nil {
+		return fmt.Errorf("ошибка кодирования формы: %w", err)
+	}
+	r.body = &buf
+	r.contentType = mw.FormDataContentType()
+	return nil
+}
+
+// send выполняет запрос. Ответы 4xx и 5xx возвращаются как *Error.
+func (c *Client) send(ctx context.Context, req request) (*http.Response, error) {
+	u := c.baseURL + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u, req.body)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if c.language != "" {
+		httpReq.Header.Set("Accept-Language", c.language)
+	}
+	switch req.auth {
+	case authUser:
+		httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	case authOperator:
+		httpReq.Header.Set("Authorization", "Bearer "+c.operatorToken)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		apiErr := &Error{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr.Problem); err != nil {
+			apiErr.Problem.Code = http.StatusText(resp.StatusCode)
+		}
+		return nil, apiErr
+	}
+	return resp, nil
+}
+
+// do выполняет запрос и разбирает ответ с кодом status в out. out == nil —
+// тело не ожидается; *[]byte получает тело как есть.
+func (c *Client) do(ctx context.Context, req request, status int, out any) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != status {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("ошибка чтения ответа: %w", err)
+		}
+		return &AltResponse{StatusCode: resp.StatusCode, Body: body}
+	}
+
+	switch out := out.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*out, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("ошибка чтения ответа: %w", err)
+		}
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("ошибка разбора ответа: %w", err)
+	}
+	return nil
+}
+
+// stream выполняет запрос и возвращает тело ответа; закрывает его вызывающий.
+func (c *Client) stream(ctx context.Context, req request) (io.ReadCloser, error) {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,1820 @@
+// Code generated by cmd/genclient; DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type AMLCaseResponse struct {
+	ID               int64           `json:"id,omitempty"`
+	Scenario         string          `json:"scenario,omitempty"`
+	AccountID        int64           `json:"account_id,omitempty"`
+	UserID           int64           `json:"user_id,omitempty"`
+	Summary          string          `json:"summary,omitempty"`
+	TotalAmount      decimal.Decimal `json:"total_amount,omitempty"`
+	TransactionCount int             `json:"transaction_count,omitempty"`
+	Status           string          `json:"status,omitempty"`
+	ReviewComment    string          `json:"review_comment,omitempty"`
+	CreatedAt        time.Time       `json:"created_at,omitempty"`
+	UpdatedAt        time.Time       `json:"updated_at,omitempty"`
+	ReportedAt       *time.Time      `json:"reported_at,omitempty"`
+	Transactions     []Transaction   `json:"transactions,omitempty"`
+}
+
+type AMLReviewRequest struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+type AccountResponse struct {
+	ID             int64           `json:"id,omitempty"`
+	AccountNumber  string          `json:"account_number,omitempty"`
+	UserID         int64           `json:"user_id,omitempty"`
+	Balance        decimal.Decimal `json:"balance,omitempty"`
+	Currency       string          `json:"currency,omitempty"`
+	Product        string          `json:"product,omitempty"`
+	InterestRate   decimal.Decimal `json:"interest_rate,omitempty"`
+	DayCount       string          `json:"day_count,omitempty"`
+	OverdraftLimit decimal.Decimal `json:"overdraft_limit,omitempty"`
+	OverdraftRate  decimal.Decimal `json:"overdraft_rate,omitempty"`
+	CreatedAt      string          `json:"created_at,omitempty"`
+}
+
+type AccountsListResponse struct {
+	Accounts []AccountResponse `json:"accounts,omitempty"`
+}
+
+type AddMemberRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type ApprovalPolicyRequest struct {
+	Bands []PolicyBand `json:"bands"`
+}
+
+type ApprovalPolicyResponse struct {
+	Bands []PolicyBand `json:"bands,omitempty"`
+}
+
+type AuthResponse struct {
+	Token string `json:"token,omitempty"`
+}
+
+type BatchItemRequest struct {
+	Account   string          `json:"account,omitempty"`
+	Amount    decimal.Decimal `json:"amount,omitempty"`
+	Reference string          `json:"reference,omitempty"`
+}
+
+type BatchItemResponse struct {
+	RowNumber   int             `json:"row_number,omitempty"`
+	Account     string          `json:"account,omitempty"`
+	ToAccountID *int64          `json:"to_account_id,omitempty"`
+	Amount      decimal.Decimal `json:"amount,omitempty"`
+	Fee         decimal.Decimal `json:"fee,omitempty"`
+	Reference   string          `json:"reference,omitempty"`
+	Status      string          `json:"status,omitempty"`
+	Error       string          `json:"error,omitempty"`
+}
+
+type BatchListResponse struct {
+	Batches []BatchResponse `json:"batches,omitempty"`
+}
+
+type BatchResponse struct {
+	ID            int64               `json:"id,omitempty"`
+	FromAccountID int64               `json:"from_account_id,omitempty"`
+	Mode          string              `json:"mode,omitempty"`
+	Status        string              `json:"status,omitempty"`
+	ItemCount     int                 `json:"item_count,omitempty"`
+	ValidCount    int                 `json:"valid_count,omitempty"`
+	Amount        decimal.Decimal     `json:"amount,omitempty"`
+	Fee           decimal.Decimal     `json:"fee,omitempty"`
+	Total         decimal.Decimal     `json:"total,omitempty"`
+	Items         []BatchItemResponse `json:"items,omitempty"`
+	CreatedAt     string              `json:"created_at,omitempty"`
+}
+
+type BeneficiaryListResponse struct {
+	Beneficiaries []BeneficiaryResponse `json:"beneficiaries,omitempty"`
+}
+
+type BeneficiaryResponse struct {
+	ID            int64  `json:"id,omitempty"`
+	Nickname      string `json:"nickname,omitempty"`
+	AccountID     int64  `json:"account_id,omitempty"`
+	AccountNumber string `json:"account_number,omitempty"`
+	Status        string `json:"status,omitempty"`
+	AvailableAt   string `json:"available_at,omitempty"`
+	CreatedAt     string `json:"created_at,omitempty"`
+}
+
+type CardDetailsResponse struct {
+	ID         int64  `json:"id,omitempty"`
+	CardNumber string `json:"card_number,omitempty"`
+	Expire     string `json:"expire,omitempty"`
+}
+
+type CardListResponse struct {
+	Cards []CardResponse `json:"cards,omitempty"`
+}
+
+type CardPaymentRequest struct {
+	CardID      int64               `json:"card_id"`
+	Amount      decimal.Decimal     `json:"amount"`
+	CVV         string              `json:"cvv"`
+	PGPKey      string              `json:"pgp_key"`
+	ExpectedFee decimal.NullDecimal `json:"expected_fee,omitempty"`
+}
+
+type CardPaymentResponse struct {
+	Success     bool            `json:"success,omitempty"`
+	PaymentID   string          `json:"payment_id,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Fee         decimal.Decimal `json:"fee,omitempty"`
+	Total       decimal.Decimal `json:"total,omitempty"`
+}
+
+type CardResponse struct {
+	ID        int64  `json:"id,omitempty"`
+	UserID    int64  `json:"user_id,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+type Case struct {
+	ID               int64           `json:"id,omitempty"`
+	Scenario         string          `json:"scenario,omitempty"`
+	AccountID        int64           `json:"account_id,omitempty"`
+	UserID           int64           `json:"user_id,omitempty"`
+	Summary          string          `json:"summary,omitempty"`
+	TotalAmount      decimal.Decimal `json:"total_amount,omitempty"`
+	TransactionCount int             `json:"transaction_count,omitempty"`
+	Status           string          `json:"status,omitempty"`
+	ReviewComment    string          `json:"review_comment,omitempty"`
+	CreatedAt        time.Time       `json:"created_at,omitempty"`
+	UpdatedAt        time.Time       `json:"updated_at,omitempty"`
+	ReportedAt       *time.Time      `json:"reported_at,omitempty"`
+}
+
+type ConfirmChallengeRequest struct {
+	Password string `json:"password"`
+}
+
+type ConfirmCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type CreateAccountRequest struct {
+	Currency string `json:"currency"`
+	Product  string `json:"product,omitempty"`
+}
+
+type CreateBatchRequest struct {
+	FromAccountID     int64              `json:"from_account_id,omitempty"`
+	FromAccountNumber string             `json:"from_account_number,omitempty"`
+	Mode              string             `json:"mode,omitempty"`
+	Items             []BatchItemRequest `json:"items,omitempty"`
+}
+
+type CreateBeneficiaryRequest struct {
+	Nickname      string `json:"nickname"`
+	AccountID     int64  `json:"account_id,omitempty"`
+	AccountNumber string `json:"account_number,omitempty"`
+}
+
+type CreateCardRequest struct {
+	PGPKey string `json:"pgp_key"`
+}
+
+type CreateCardResponse struct {
+	ID         int64  `json:"id,omitempty"`
+	UserID     int64  `json:"user_id,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	CardNumber string `json:"card_number,omitempty"`
+	Expire     string `json:"expire,omitempty"`
+	CVV        string `json:"cvv,omitempty"`
+}
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+}
+
+type DecisionRequest struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+type DecisionResponse struct {
+	UserID    int64  `json:"user_id,omitempty"`
+	Decision  string `json:"decision,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+type DepositListResponse struct {
+	Deposits []DepositResponse `json:"deposits,omitempty"`
+}
+
+type DepositResponse struct {
+	ID              int64           `json:"id,omitempty"`
+	AccountID       int64           `json:"account_id,omitempty"`
+	SourceAccountID int64           `json:"source_account_id,omitempty"`
+	Principal       decimal.Decimal `json:"principal,omitempty"`
+	InterestRate    decimal.Decimal `json:"interest_rate,omitempty"`
+	PenaltyRate     decimal.Decimal `json:"penalty_rate,omitempty"`
+	DayCount        string          `json:"day_count,omitempty"`
+	TermMonths      int             `json:"term_months,omitempty"`
+	StartDate       string          `json:"start_date,omitempty"`
+	MaturityDate    string          `json:"maturity_date,omitempty"`
+	AutoRollover    bool            `json:"auto_rollover,omitempty"`
+	Status          string          `json:"status,omitempty"`
+	CreatedAt       string          `json:"created_at,omitempty"`
+}
+
+type EmailRequest struct {
+	Email string `json:"email"`
+}
+
+type FeeQuoteResponse struct {
+	Operation string          `json:"operation,omitempty"`
+	Amount    decimal.Decimal `json:"amount,omitempty"`
+	Fee       decimal.Decimal `json:"fee,omitempty"`
+	Total     decimal.Decimal `json:"total,omitempty"`
+}
+
+type FeeRuleListResponse struct {
+	Rules []FeeRuleResponse `json:"rules,omitempty"`
+}
+
+type FeeRuleResponse struct {
+	ID         int64               `json:"id,omitempty"`
+	Operation  string              `json:"operation,omitempty"`
+	VolumeFrom decimal.Decimal     `json:"volume_from,omitempty"`
+	Fixed      decimal.Decimal     `json:"fixed,omitempty"`
+	Percent    decimal.Decimal     `json:"percent,omitempty"`
+	MinAmount  decimal.Decimal     `json:"min_amount,omitempty"`
+	MaxAmount  decimal.NullDecimal `json:"max_amount,omitempty"`
+	Active     bool                `json:"active,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type HeldTransferResponse struct {
+	Status         string          `json:"status,omitempty"`
+	HeldTransferID int64           `json:"held_transfer_id,omitempty"`
+	Amount         decimal.Decimal `json:"amount,omitempty"`
+	Message        string          `json:"message,omitempty"`
+}
+
+type Hit struct {
+	ID             int64           `json:"id,omitempty"`
+	SubjectType    string          `json:"subject_type,omitempty"`
+	UserID         int64           `json:"user_id,omitempty"`
+	ScreenedName   string          `json:"screened_name,omitempty"`
+	EntryID        string          `json:"entry_id,omitempty"`
+	EntryName      string          `json:"entry_name,omitempty"`
+	ListSource     string          `json:"list_source,omitempty"`
+	Score          decimal.Decimal `json:"score,omitempty"`
+	Matches        json.RawMessage `json:"matches,omitempty"`
+	HeldTransferID *int64          `json:"held_transfer_id,omitempty"`
+	Status         string          `json:"status,omitempty"`
+	ReviewComment  string          `json:"review_comment,omitempty"`
+	CreatedAt      time.Time       `json:"created_at,omitempty"`
+	ReviewedAt     *time.Time      `json:"reviewed_at,omitempty"`
+}
+
+type KYCDocumentResponse struct {
+	ID          int64  `json:"id,omitempty"`
+	Type        string `json:"type,omitempty"`
+	FileName    string `json:"file_name,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	Sha256      string `json:"sha256,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
+
+type Lockout struct {
+	ID          int64      `json:"id,omitempty"`
+	Scope       string     `json:"scope,omitempty"`
+	Subject     string     `json:"subject,omitempty"`
+	Failures    int        `json:"failures,omitempty"`
+	LockedUntil time.Time  `json:"locked_until,omitempty"`
+	CreatedAt   time.Time  `json:"created_at,omitempty"`
+	ReleasedAt  *time.Time `json:"released_at,omitempty"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type MarkAllReadResponse struct {
+	Marked int `json:"marked,omitempty"`
+}
+
+type MemberListResponse struct {
+	Members []MemberResponse `json:"members,omitempty"`
+}
+
+type MemberResponse struct {
+	UserID    int64  `json:"user_id,omitempty"`
+	Role      string `json:"role,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications,omitempty"`
+	Unread        int                    `json:"unread,omitempty"`
+}
+
+type NotificationPreference struct {
+	Kind    string `json:"kind,omitempty"`
+	Channel string `json:"channel,omitempty"`
+	Enabled bool   `json:"enabled,omitempty"`
+}
+
+type NotificationResponse struct {
+	ID          int64   `json:"id,omitempty"`
+	Kind        string  `json:"kind,omitempty"`
+	Subject     string  `json:"subject,omitempty"`
+	Body        string  `json:"body,omitempty"`
+	Read        bool    `json:"read,omitempty"`
+	EmailStatus *string `json:"email_status,omitempty"`
+	CreatedAt   string  `json:"created_at,omitempty"`
+}
+
+type NotificationSettingsResponse struct {
+	Locale      string                   `json:"locale,omitempty"`
+	Preferences []NotificationPreference `json:"preferences,omitempty"`
+}
+
+type OpenDepositRequest struct {
+	SourceAccountID     int64           `json:"source_account_id,omitempty"`
+	SourceAccountNumber string          `json:"source_account_number,omitempty"`
+	Amount              decimal.Decimal `json:"amount,omitempty"`
+	TermMonths          int             `json:"term_months"`
+	AutoRollover        bool            `json:"auto_rollover,omitempty"`
+}
+
+type OverdraftRequest struct {
+	Limit decimal.Decimal `json:"limit,omitempty"`
+}
+
+type P2PTransferRequest struct {
+	FromAccountID     int64           `json:"from_account_id,omitempty"`
+	FromAccountNumber string          `json:"from_account_number,omitempty"`
+	Recipient         string          `json:"recipient"`
+	Amount            decimal.Decimal `json:"amount,omitempty"`
+}
+
+type P2PTransferResponse struct {
+	ID            int64           `json:"id,omitempty"`
+	FromAccountID int64           `json:"from_account_id,omitempty"`
+	RecipientName string          `json:"recipient_name,omitempty"`
+	Amount        decimal.Decimal `json:"amount,omitempty"`
+	Fee           decimal.Decimal `json:"fee,omitempty"`
+	Total         decimal.Decimal `json:"total,omitempty"`
+	Status        string          `json:"status,omitempty"`
+	ExpiresAt     string          `json:"expires_at,omitempty"`
+}
+
+type PendingPaymentListResponse struct {
+	Payments []PendingPaymentResponse `json:"payments,omitempty"`
+}
+
+type PendingPaymentResponse struct {
+	ID                int64              `json:"id,omitempty"`
+	FromAccountID     int64              `json:"from_account_id,omitempty"`
+	ToAccountID       int64              `json:"to_account_id,omitempty"`
+	InitiatorID       int64              `json:"initiator_id,omitempty"`
+	Amount            decimal.Decimal    `json:"amount,omitempty"`
+	RequiredApprovals int                `json:"required_approvals,omitempty"`
+	Status            string             `json:"status,omitempty"`
+	Decisions         []DecisionResponse `json:"decisions,omitempty"`
+	CreatedAt         string             `json:"created_at,omitempty"`
+	UpdatedAt         string             `json:"updated_at,omitempty"`
+}
+
+type PolicyBand struct {
+	AmountFrom        decimal.Decimal `json:"amount_from,omitempty"`
+	RequiredApprovals int             `json:"required_approvals,omitempty"`
+}
+
+type Problem struct {
+	Type   string       `json:"type,omitempty"`
+	Title  string       `json:"title,omitempty"`
+	Status int          `json:"status,omitempty"`
+	Detail string       `json:"detail,omitempty"`
+	Code   string       `json:"code,omitempty"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+type ProfileResponse struct {
+	UserID       int64   `json:"user_id,omitempty"`
+	FullName     *string `json:"full_name,omitempty"`
+	Phone        *string `json:"phone,omitempty"`
+	DateOfBirth  *string `json:"date_of_birth,omitempty"`
+	Citizenship  string  `json:"citizenship,omitempty"`
+	Address      string  `json:"address,omitempty"`
+	KYCStatus    string  `json:"kyc_status,omitempty"`
+	RejectReason string  `json:"reject_reason,omitempty"`
+	SubmittedAt  *string `json:"submitted_at,omitempty"`
+	ReviewedAt   *string `json:"reviewed_at,omitempty"`
+}
+
+type RegisterRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	FullName string `json:"full_name"`
+}
+
+type RegisterResponse struct {
+	Message string `json:"message,omitempty"`
+	UserID  int64  `json:"user_id,omitempty"`
+}
+
+type RejectKYCRequest struct {
+	Reason string `json:"reason"`
+}
+
+type Request struct {
+	ID             int64           `json:"id,omitempty"`
+	AccountID      int64           `json:"account_id,omitempty"`
+	UserID         int64           `json:"user_id,omitempty"`
+	RequestedLimit decimal.Decimal `json:"requested_limit,omitempty"`
+	Status         string          `json:"status,omitempty"`
+	ReviewComment  string          `json:"review_comment,omitempty"`
+	CreatedAt      time.Time       `json:"created_at,omitempty"`
+	ReviewedAt     *time.Time      `json:"reviewed_at,omitempty"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type ResolveHitRequest struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+type ResolveOverdraftRequest struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+type Transaction struct {
+	ID        int64           `json:"id,omitempty"`
+	AccountID int64           `json:"account_id,omitempty"`
+	Amount    decimal.Decimal `json:"amount,omitempty"`
+	Type      string          `json:"type,omitempty"`
+	Status    string          `json:"status,omitempty"`
+	CreatedAt time.Time       `json:"created_at,omitempty"`
+}
+
+type TransactionListResponse struct {
+	Transactions []TransactionResponse `json:"transactions,omitempty"`
+}
+
+type TransactionResponse struct {
+	ID        int64           `json:"id,omitempty"`
+	AccountID int64           `json:"account_id,omitempty"`
+	Amount    decimal.Decimal `json:"amount,omitempty"`
+	Type      string          `json:"type,omitempty"`
+	Status    string          `json:"status,omitempty"`
+	CreatedAt string          `json:"created_at,omitempty"`
+}
+
+type TransferRequest struct {
+	FromAccountID     int64               `json:"from_account_id,omitempty"`
+	FromAccountNumber string              `json:"from_account_number,omitempty"`
+	ToAccountID       int64               `json:"to_account_id,omitempty"`
+	ToAccountNumber   string              `json:"to_account_number,omitempty"`
+	BeneficiaryID     int64               `json:"beneficiary_id,omitempty"`
+	Amount            decimal.Decimal     `json:"amount,omitempty"`
+	ExpectedFee       decimal.NullDecimal `json:"expected_fee,omitempty"`
+}
+
+type TransferResponse struct {
+	Status string          `json:"status,omitempty"`
+	Amount decimal.Decimal `json:"amount,omitempty"`
+	Fee    decimal.Decimal `json:"fee,omitempty"`
+	Total  decimal.Decimal `json:"total,omitempty"`
+}
+
+type UpdateBalanceRequest struct {
+	Amount decimal.Decimal `json:"amount"`
+}
+
+type UpdateBeneficiaryRequest struct {
+	Nickname string `json:"nickname"`
+}
+
+type UpdateContactsRequest struct {
+	Phone        *string `json:"phone,omitempty"`
+	Discoverable *bool   `json:"discoverable,omitempty"`
+}
+
+type UpdateNotificationSettingsRequest struct {
+	Locale      string                   `json:"locale,omitempty"`
+	Preferences []NotificationPreference `json:"preferences,omitempty"`
+}
+
+type UpdateProfileRequest struct {
+	FullName    string `json:"full_name,omitempty"`
+	DateOfBirth string `json:"date_of_birth,omitempty"`
+	Citizenship string `json:"citizenship,omitempty"`
+	Address     string `json:"address,omitempty"`
+}
+
+type UserResponse struct {
+	ID            int64   `json:"id,omitempty"`
+	Email         string  `json:"email,omitempty"`
+	FullName      *string `json:"full_name,omitempty"`
+	Phone         *string `json:"phone,omitempty"`
+	PhoneVerified bool    `json:"phone_verified,omitempty"`
+	Discoverable  bool    `json:"discoverable,omitempty"`
+	KYCStatus     string  `json:"kyc_status,omitempty"`
+	CreatedAt     string  `json:"created_at,omitempty"`
+}
+
+type VerifyBeneficiaryRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+type WebhookAttemptResponse struct {
+	StatusCode *int   `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int    `json:"duration_ms,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries,omitempty"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int64                    `json:"id,omitempty"`
+	SubscriptionID int64                    `json:"subscription_id,omitempty"`
+	EventID        int64                    `json:"event_id,omitempty"`
+	EventType      string                   `json:"event_type,omitempty"`
+	Status         string                   `json:"status,omitempty"`
+	Attempts       int                      `json:"attempts,omitempty"`
+	NextAttemptAt  string                   `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int                     `json:"last_status_code,omitempty"`
+	LastError      string                   `json:"last_error,omitempty"`
+	Payload        json.RawMessage          `json:"payload,omitempty"`
+	AttemptLog     []WebhookAttemptResponse `json:"attempt_log,omitempty"`
+	CreatedAt      string                   `json:"created_at,omitempty"`
+}
+
+type WebhookListResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks,omitempty"`
+}
+
+type WebhookResponse struct {
+	ID         int64    `json:"id,omitempty"`
+	URL        string   `json:"url,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	Active     bool     `json:"active,omitempty"`
+	Secret     string   `json:"secret,omitempty"`
+	CreatedAt  string   `json:"created_at,omitempty"`
+}
+
+// Register — регистрация пользователя.
+//
+// POST /register
+func (c *Client) Register(ctx context.Context, body RegisterRequest) (*RegisterResponse, error) {
+	req := request{method: http.MethodPost, path: "/register", auth: authNone}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out RegisterResponse
+	if err := c.do(ctx, req, http.StatusCreated, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Login — вход по email и паролю.
+//
+// POST /login
+func (c *Client) Login(ctx context.Context, body LoginRequest) (*AuthResponse, error) {
+	req := request{method: http.MethodPost, path: "/login", auth: authNone}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out AuthResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// VerifyEmail — подтверждение email по токену из письма.
+//
+// POST /email/verify
+func (c *Client) VerifyEmail(ctx context.Context, body VerifyEmailRequest) error {
+	req := request{method: http.MethodPost, path: "/email/verify", auth: authNone}
+	if err := req.setJSON(body); err != nil {
+		return err
+	}
+	return c.do(ctx, req, http.StatusNoContent, nil)
+}
+
+// ResendVerification — повторная отправка письма для подтверждения email.
+//
+// POST /email/verify/resend
+func (c *Client) ResendVerification(ctx context.Context, body EmailRequest) error {
+	req := request{method: http.MethodPost, path: "/email/verify/resend", auth: authNone}
+	if err := req.setJSON(body); err != nil {
+		return err
+	}
+	return c.do(ctx, req, http.StatusAccepted, nil)
+}
+
+// ForgotPassword — запрос на сброс пароля.
+//
+// POST /password/forgot
+func (c *Client) ForgotPassword(ctx context.Context, body EmailRequest) error {
+	req := request{method: http.MethodPost, path: "/password/forgot", auth: authNone}
+	if err := req.setJSON(body); err != nil {
+		return err
+	}
+	return c.do(ctx, req, http.StatusAccepted, nil)
+}
+
+// ResetPassword — сброс пароля по токену из письма.
+//
+// POST /password/reset
+func (c *Client) ResetPassword(ctx context.Context, body ResetPasswordRequest) error {
+	req := request{method: http.MethodPost, path: "/password/reset", auth: authNone}
+	if err := req.setJSON(body); err != nil {
+		return err
+	}
+	return c.do(ctx, req, http.StatusNoContent, nil)
+}
+
+// ListScreeningHitsParams — параметры запроса ListScreeningHits.
+type ListScreeningHitsParams struct {
+	// Фильтр по статусу
+	Status string
+}
+
+// ListScreeningHits — совпадения по санкционным спискам.
+//
+// GET /operator/screening/hits
+func (c *Client) ListScreeningHits(ctx context.Context, params *ListScreeningHitsParams) ([]Hit, error) {
+	req := request{method: http.MethodGet, path: "/operator/screening/hits", auth: authOperator}
+	req.query = url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			req.query.Set("status", params.Status)
+		}
+	}
+	var out []Hit
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetScreeningHit — совпадение по санкционным спискам.
+//
+// GET /operator/screening/hits/{id}
+func (c *Client) GetScreeningHit(ctx context.Context, id int64) (*Hit, error) {
+	req := request{method: http.MethodGet, path: "/operator/screening/hits/" + strconv.FormatInt(id, 10), auth: authOperator}
+	var out Hit
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ClearScreeningHit — признать совпадение ложным.
+//
+// POST /operator/screening/hits/{id}/clear
+func (c *Client) ClearScreeningHit(ctx context.Context, id int64, body ResolveHitRequest) (*Hit, error) {
+	req := request{method: http.MethodPost, path: "/operator/screening/hits/" + strconv.FormatInt(id, 10) + "/clear", auth: authOperator}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out Hit
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConfirmScreeningHit — подтвердить совпадение.
+//
+// POST /operator/screening/hits/{id}/confirm
+func (c *Client) ConfirmScreeningHit(ctx context.Context, id int64, body ResolveHitRequest) (*Hit, error) {
+	req := request{method: http.MethodPost, path: "/operator/screening/hits/" + strconv.FormatInt(id, 10) + "/confirm", auth: authOperator}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out Hit
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListPendingKYC — анкеты, ожидающие проверки.
+//
+// GET /operator/kyc
+func (c *Client) ListPendingKYC(ctx context.Context) ([]ProfileResponse, error) {
+	req := request{method: http.MethodGet, path: "/operator/kyc", auth: authOperator}
+	var out []ProfileResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetKYCCustomer — анкета клиента.
+//
+// GET /operator/kyc/{userId}
+func (c *Client) GetKYCCustomer(ctx context.Context, userID int64) (*ProfileResponse, error) {
+	req := request{method: http.MethodGet, path: "/operator/kyc/" + strconv.FormatInt(userID, 10), auth: authOperator}
+	var out ProfileResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListKYCCustomerDocuments — документы клиента.
+//
+// GET /operator/kyc/{userId}/documents
+func (c *Client) ListKYCCustomerDocuments(ctx context.Context, userID int64) ([]KYCDocumentResponse, error) {
+	req := request{method: http.MethodGet, path: "/operator/kyc/" + strconv.FormatInt(userID, 10) + "/documents", auth: authOperator}
+	var out []KYCDocumentResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DownloadKYCDocument — скачать документ клиента.
+//
+// GET /operator/kyc/{userId}/documents/{docId}
+func (c *Client) DownloadKYCDocument(ctx context.Context, userID int64, docID int64) ([]byte, error) {
+	req := request{method: http.MethodGet, path: "/operator/kyc/" + strconv.FormatInt(userID, 10) + "/documents/" + strconv.FormatInt(docID, 10), auth: authOperator}
+	var out []byte
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VerifyKYC — подтвердить личность клиента.
+//
+// POST /operator/kyc/{userId}/verify
+func (c *Client) VerifyKYC(ctx context.Context, userID int64) (*ProfileResponse, error) {
+	req := request{method: http.MethodPost, path: "/operator/kyc/" + strconv.FormatInt(userID, 10) + "/verify", auth: authOperator}
+	var out ProfileResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RejectKYC — отклонить анкету клиента.
+//
+// POST /operator/kyc/{userId}/reject
+func (c *Client) RejectKYC(ctx context.Context, userID int64, body RejectKYCRequest) (*ProfileResponse, error) {
+	req := request{method: http.MethodPost, path: "/operator/kyc/" + strconv.FormatInt(userID, 10) + "/reject", auth: authOperator}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out ProfileResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListAMLCasesParams — параметры запроса ListAMLCases.
+type ListAMLCasesParams struct {
+	// Фильтр по статусу
+	Status string
+}
+
+// ListAMLCases — кейсы мониторинга операций.
+//
+// GET /operator/aml/cases
+func (c *Client) ListAMLCases(ctx context.Context, params *ListAMLCasesParams) ([]Case, error) {
+	req := request{method: http.MethodGet, path: "/operator/aml/cases", auth: authOperator}
+	req.query = url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			req.query.Set("status", params.Status)
+		}
+	}
+	var out []Case
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAMLCase — кейс с операциями.
+//
+// GET /operator/aml/cases/{id}
+func (c *Client) GetAMLCase(ctx context.Context, id int64) (*AMLCaseResponse, error) {
+	req := request{method: http.MethodGet, path: "/operator/aml/cases/" + strconv.FormatInt(id, 10), auth: authOperator}
+	var out AMLCaseResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// EscalateAMLCase — передать кейс на расследование.
+//
+// POST /operator/aml/cases/{id}/escalate
+func (c *Client) EscalateAMLCase(ctx context.Context, id int64, body AMLReviewRequest) (*Case, error) {
+	req := request{method: http.MethodPost, path: "/operator/aml/cases/" + strconv.FormatInt(id, 10) + "/escalate", auth: authOperator}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out Case
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DismissAMLCase — закрыть кейс без последствий.
+//
+// POST /operator/aml/cases/{id}/dismiss
+func (c *Client) DismissAMLCase(ctx context.Context, id int64, body AMLReviewRequest) (*Case, error) {
+	req := request{method: http.MethodPost, path: "/operator/aml/cases/" + strconv.FormatInt(id, 10) + "/dismiss", auth: authOperator}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out Case
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExportSAR — выгрузить сообщение о подозрительной операции.
+//
+// POST /operator/aml/cases/{id}/sar
+func (c *Client) ExportSAR(ctx context.Context, id int64) (json.RawMessage, error) {
+	req := request{method: http.MethodPost, path: "/operator/aml/cases/" + strconv.FormatInt(id, 10) + "/sar", auth: authOperator}
+	var out json.RawMessage
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListLockoutsParams — параметры запроса ListLockouts.
+type ListLockoutsParams struct {
+	// Фильтр по виду блокировки
+	Scope string
+}
+
+// ListLockouts — действующие блокировки после неудачных попыток.
+//
+// GET /operator/lockouts
+func (c *Client) ListLockouts(ctx context.Context, params *ListLockoutsParams) ([]Lockout, error) {
+	req := request{method: http.MethodGet, path: "/operator/lockouts", auth: authOperator}
+	req.query = url.Values{}
+	if params != nil {
+		if params.Scope != "" {
+			req.query.Set("scope", params.Scope)
+		}
+	}
+	var out []Lockout
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReleaseLockout — снять блокировку.
+//
+// POST /operator/lockouts/{id}/release
+func (c *Client) ReleaseLockout(ctx context.Context, id int64) (*Lockout, error) {
+	req := request{method: http.MethodPost, path: "/operator/lockouts/" + strconv.FormatInt(id, 10) + "/release", auth: authOperator}
+	var out Lockout
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListOverdraftRequestsParams — параметры запроса ListOverdraftRequests.
+type ListOverdraftRequestsParams struct {
+	// Фильтр по статусу
+	Status string
+}
+
+// ListOverdraftRequests — заявки на увеличение овердрафта.
+//
+// GET /operator/overdraft-requests
+func (c *Client) ListOverdraftRequests(ctx context.Context, params *ListOverdraftRequestsParams) ([]Request, error) {
+	req := request{method: http.MethodGet, path: "/operator/overdraft-requests", auth: authOperator}
+	req.query = url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			req.query.Set("status", params.Status)
+		}
+	}
+	var out []Request
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApproveOverdraftRequest — одобрить заявку на овердрафт.
+//
+// POST /operator/overdraft-requests/{id}/approve
+func (c *Client) ApproveOverdraftRequest(ctx context.Context, id int64, body ResolveOverdraftRequest) (*Request, error) {
+	req := request{method: http.MethodPost, path: "/operator/overdraft-requests/" + strconv.FormatInt(id, 10) + "/approve", auth: authOperator}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out Request
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RejectOverdraftRequest — отклонить заявку на овердрафт.
+//
+// POST /operator/overdraft-requests/{id}/reject
+func (c *Client) RejectOverdraftRequest(ctx context.Context, id int64, body ResolveOverdraftRequest) (*Request, error) {
+	req := request{method: http.MethodPost, path: "/operator/overdraft-requests/" + strconv.FormatInt(id, 10) + "/reject", auth: authOperator}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out Request
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMe — текущий пользователь.
+//
+// GET /users/me
+func (c *Client) GetMe(ctx context.Context) (*UserResponse, error) {
+	req := request{method: http.MethodGet, path: "/users/me", auth: authUser}
+	var out UserResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateContacts — изменить контакты.
+//
+// PATCH /users/me
+func (c *Client) UpdateContacts(ctx context.Context, body UpdateContactsRequest) (*UserResponse, error) {
+	req := request{method: http.MethodPatch, path: "/users/me", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out UserResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SendPhoneCode — повторно отправить код подтверждения номера.
+//
+// POST /users/me/phone/code
+func (c *Client) SendPhoneCode(ctx context.Context) error {
+	req := request{method: http.MethodPost, path: "/users/me/phone/code", auth: authUser}
+	return c.do(ctx, req, http.StatusAccepted, nil)
+}
+
+// ConfirmPhone — подтвердить номер кодом из SMS.
+//
+// POST /users/me/phone/confirm
+func (c *Client) ConfirmPhone(ctx context.Context, body ConfirmCodeRequest) (*UserResponse, error) {
+	req := request{method: http.MethodPost, path: "/users/me/phone/confirm", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out UserResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetProfile — анкета клиента.
+//
+// GET /users/me/profile
+func (c *Client) GetProfile(ctx context.Context) (*ProfileResponse, error) {
+	req := request{method: http.MethodGet, path: "/users/me/profile", auth: authUser}
+	var out ProfileResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateProfile — заполнить анкету.
+//
+// PUT /users/me/profile
+func (c *Client) UpdateProfile(ctx context.Context, body UpdateProfileRequest) (*ProfileResponse, error) {
+	req := request{method: http.MethodPut, path: "/users/me/profile", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out ProfileResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListKYCDocuments — загруженные документы.
+//
+// GET /kyc/documents
+func (c *Client) ListKYCDocuments(ctx context.Context) ([]KYCDocumentResponse, error) {
+	req := request{method: http.MethodGet, path: "/kyc/documents", auth: authUser}
+	var out []KYCDocumentResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UploadKYCDocument — загрузить документ.
+//
+// POST /kyc/documents
+func (c *Client) UploadKYCDocument(ctx context.Context, typeValue string, fileName string, file io.Reader) (*KYCDocumentResponse, error) {
+	req := request{method: http.MethodPost, path: "/kyc/documents", auth: authUser}
+	err := req.setMultipart(map[string]string{"type": typeValue}, "file", fileName, file)
+	if err != nil {
+		return nil, err
+	}
+	var out KYCDocumentResponse
+	if err := c.do(ctx, req, http.StatusCreated, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SubmitKYC — отправить анкету на проверку.
+//
+// POST /kyc/submit
+func (c *Client) SubmitKYC(ctx context.Context) (*ProfileResponse, error) {
+	req := request{method: http.MethodPost, path: "/kyc/submit", auth: authUser}
+	var out ProfileResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateAccount — открыть счет.
+//
+// POST /accounts
+func (c *Client) CreateAccount(ctx context.Context, body CreateAccountRequest) (*AccountResponse, error) {
+	req := request{method: http.MethodPost, path: "/accounts", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out AccountResponse
+	if err := c.do(ctx, req, http.StatusCreated, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListAccounts — счета пользователя.
+//
+// GET /accounts
+func (c *Client) ListAccounts(ctx context.Context) (*AccountsListResponse, error) {
+	req := request{method: http.MethodGet, path: "/accounts", auth: authUser}
+	var out AccountsListResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateBalance — пополнить или списать средства.
+//
+// PATCH /accounts/{id}/balance
+func (c *Client) UpdateBalance(ctx context.Context, id string, body UpdateBalanceRequest) (*AccountResponse, error) {
+	req := request{method: http.MethodPatch, path: "/accounts/" + url.PathEscape(id) + "/balance", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out AccountResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetOverdraft — установить лимит овердрафта.
+//
+// PUT /accounts/{id}/overdraft
+//
+// Ответ 202 возвращается как *AltResponse с телом Request.
+func (c *Client) SetOverdraft(ctx context.Context, id string, body OverdraftRequest) (*AccountResponse, error) {
+	req := request{method: http.MethodPut, path: "/accounts/" + url.PathEscape(id) + "/overdraft", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out AccountResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AddMember — добавить участника счета.
+//
+// POST /accounts/{id}/members
+func (c *Client) AddMember(ctx context.Context, id string, body AddMemberRequest) (*MemberResponse, error) {
+	req := request{method: http.MethodPost, path: "/accounts/" + url.PathEscape(id) + "/members", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out MemberResponse
+	if err := c.do(ctx, req, http.StatusCreated, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListMembers — участники счета.
+//
+// GET /accounts/{id}/members
+func (c *Client) ListMembers(ctx context.Context, id string) (*MemberListResponse, error) {
+	req := request{method: http.MethodGet, path: "/accounts/" + url.PathEscape(id) + "/members", auth: authUser}
+	var out MemberListResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RemoveMember — удалить участника счета.
+//
+// DELETE /accounts/{id}/members/{userId}
+func (c *Client) RemoveMember(ctx context.Context, id string, userID int64) error {
+	req := request{method: http.MethodDelete, path: "/accounts/" + url.PathEscape(id) + "/members/" + strconv.FormatInt(userID, 10), auth: authUser}
+	return c.do(ctx, req, http.StatusNoContent, nil)
+}
+
+// GetApprovalPolicy — политика подтверждения платежей.
+//
+// GET /accounts/{id}/approval-policy
+func (c *Client) GetApprovalPolicy(ctx context.Context, id string) (*ApprovalPolicyResponse, error) {
+	req := request{method: http.MethodGet, path: "/accounts/" + url.PathEscape(id) + "/approval-policy", auth: authUser}
+	var out ApprovalPolicyResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetApprovalPolicy — задать политику подтверждения платежей.
+//
+// PUT /accounts/{id}/approval-policy
+func (c *Client) SetApprovalPolicy(ctx context.Context, id string, body ApprovalPolicyRequest) (*ApprovalPolicyResponse, error) {
+	req := request{method: http.MethodPut, path: "/accounts/" + url.PathEscape(id) + "/approval-policy", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out ApprovalPolicyResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListPendingPayments — платежи, ожидающие подтверждения.
+//
+// GET /approvals
+func (c *Client) ListPendingPayments(ctx context.Context) (*PendingPaymentListResponse, error) {
+	req := request{method: http.MethodGet, path: "/approvals", auth: authUser}
+	var out PendingPaymentListResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPendingPayment — платеж с решениями участников.
+//
+// GET /approvals/{id}
+func (c *Client) GetPendingPayment(ctx context.Context, id int64) (*PendingPaymentResponse, error) {
+	req := request{method: http.MethodGet, path: "/approvals/" + strconv.FormatInt(id, 10), auth: authUser}
+	var out PendingPaymentResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ApprovePayment — одобрить платеж.
+//
+// POST /approvals/{id}/approve
+func (c *Client) ApprovePayment(ctx context.Context, id int64, body *DecisionRequest) (*PendingPaymentResponse, error) {
+	req := request{method: http.MethodPost, path: "/approvals/" + strconv.FormatInt(id, 10) + "/approve", auth: authUser}
+	if body != nil {
+		if err := req.setJSON(body); err != nil {
+			return nil, err
+		}
+	}
+	var out PendingPaymentResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RejectPayment — отклонить платеж.
+//
+// POST /approvals/{id}/reject
+func (c *Client) RejectPayment(ctx context.Context, id int64, body *DecisionRequest) (*PendingPaymentResponse, error) {
+	req := request{method: http.MethodPost, path: "/approvals/" + strconv.FormatInt(id, 10) + "/reject", auth: authUser}
+	if body != nil {
+		if err := req.setJSON(body); err != nil {
+			return nil, err
+		}
+	}
+	var out PendingPaymentResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListTransactions — история операций по счету.
+//
+// GET /accounts/{id}/transactions
+func (c *Client) ListTransactions(ctx context.Context, id string) (*TransactionListResponse, error) {
+	req := request{method: http.MethodGet, path: "/accounts/" + url.PathEscape(id) + "/transactions", auth: authUser}
+	var out TransactionListResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Transfer — перевод между счетами.
+//
+// POST /transfer
+//
+// Ответ 202 возвращается как *AltResponse с телом PendingPaymentResponse или
+// HeldTransferResponse.
+func (c *Client) Transfer(ctx context.Context, body TransferRequest) (*TransferResponse, error) {
+	req := request{method: http.MethodPost, path: "/transfer", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out TransferResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateBeneficiary — добавить получателя.
+//
+// POST /beneficiaries
+func (c *Client) CreateBeneficiary(ctx context.Context, body CreateBeneficiaryRequest) (*BeneficiaryResponse, error) {
+	req := request{method: http.MethodPost, path: "/beneficiaries", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out BeneficiaryResponse
+	if err := c.do(ctx, req, http.StatusCreated, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListBeneficiaries — сохраненные получатели.
+//
+// GET /beneficiaries
+func (c *Client) ListBeneficiaries(ctx context.Context) (*BeneficiaryListResponse, error) {
+	req := request{method: http.MethodGet, path: "/beneficiaries", auth: authUser}
+	var out BeneficiaryListResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateBeneficiary — изменить получателя.
+//
+// PATCH /beneficiaries/{id}
+func (c *Client) UpdateBeneficiary(ctx context.Context, id int64, body UpdateBeneficiaryRequest) (*BeneficiaryResponse, error) {
+	req := request{method: http.MethodPatch, path: "/beneficiaries/" + strconv.FormatInt(id, 10), auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out BeneficiaryResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteBeneficiary — удалить получателя.
+//
+// DELETE /beneficiaries/{id}
+func (c *Client) DeleteBeneficiary(ctx context.Context, id int64) error {
+	req := request{method: http.MethodDelete, path: "/beneficiaries/" + strconv.FormatInt(id, 10), auth: authUser}
+	return c.do(ctx, req, http.StatusNoContent, nil)
+}
+
+// SendBeneficiaryCode — отправить код подтверждения получателя на email.
+//
+// POST /beneficiaries/{id}/verify/code
+func (c *Client) SendBeneficiaryCode(ctx context.Context, id int64) error {
+	req := request{method: http.MethodPost, path: "/beneficiaries/" + strconv.FormatInt(id, 10) + "/verify/code", auth: authUser}
+	return c.do(ctx, req, http.StatusAccepted, nil)
+}
+
+// VerifyBeneficiary — подтвердить получателя паролем и кодом из письма.
+//
+// POST /beneficiaries/{id}/verify
+func (c *Client) VerifyBeneficiary(ctx context.Context, id int64, body VerifyBeneficiaryRequest) (*BeneficiaryResponse, error) {
+	req := request{method: http.MethodPost, path: "/beneficiaries/" + strconv.FormatInt(id, 10) + "/verify", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out BeneficiaryResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateBatchParams — параметры запроса CreateBatch.
+type CreateBatchParams struct {
+	// Счет списания для CSV: идентификатор или номер
+	FromAccount string
+	// Режим исполнения для CSV
+	Mode string
+}
+
+// CreateBatch — загрузить пакет платежей в JSON или CSV.
+//
+// POST /batches
+func (c *Client) CreateBatch(ctx context.Context, params *CreateBatchParams, body CreateBatchRequest) (*BatchResponse, error) {
+	req := request{method: http.MethodPost, path: "/batches", auth: authUser}
+	req.query = url.Values{}
+	if params != nil {
+		if params.FromAccount != "" {
+			req.query.Set("from_account", params.FromAccount)
+		}
+		if params.Mode != "" {
+			req.query.Set("mode", params.Mode)
+		}
+	}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out BatchResponse
+	if err := c.do(ctx, req, http.StatusCreated, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateBatchCSV — загрузить пакет платежей в JSON или CSV.
+//
+// POST /batches
+func (c *Client) CreateBatchCSV(ctx context.Context, params *CreateBatchParams, csv io.Reader) (*BatchResponse, error) {
+	req := request{method: http.MethodPost, path: "/batches", auth: authUser}
+	req.query = url.Values{}
+	if params != nil {
+		if params.FromAccount != "" {
+			req.query.Set("from_account", params.FromAccount)
+		}
+		if params.Mode != "" {
+			req.query.Set("mode", params.Mode)
+		}
+	}
+	req.body, req.contentType = csv, "text/csv"
+	var out BatchResponse
+	if err := c.do(ctx, req, http.StatusCreated, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListBatches — пакеты платежей.
+//
+// GET /batches
+func (c *Client) ListBatches(ctx context.Context) (*BatchListResponse, error) {
+	req := request{method: http.MethodGet, path: "/batches", auth: authUser}
+	var out BatchListResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBatch — пакет со строками.
+//
+// GET /batches/{id}
+func (c *Client) GetBatch(ctx context.Context, id int64) (*BatchResponse, error) {
+	req := request{method: http.MethodGet, path: "/batches/" + strconv.FormatInt(id, 10), auth: authUser}
+	var out BatchResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExecuteBatch — исполнить пакет.
+//
+// POST /batches/{id}/execute
+func (c *Client) ExecuteBatch(ctx context.Context, id int64) (*BatchResponse, error) {
+	req := request{method: http.MethodPost, path: "/batches/" + strconv.FormatInt(id, 10) + "/execute", auth: authUser}
+	var out BatchResponse
+	if err := c.do(ctx, req, http.StatusAccepted, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// StreamParams — параметры запроса Stream.
+type StreamParams struct {
+	// Идентификатор последнего полученного события, если нельзя передать заголовок Last-Event-ID
+	LastEventID string
+}
+
+// Stream — поток событий (Server-Sent Events).
+//
+// GET /stream
+func (c *Client) Stream(ctx context.Context, params *StreamParams) (io.ReadCloser, error) {
+	req := request{method: http.MethodGet, path: "/stream", auth: authUser}
+	req.query = url.Values{}
+	if params != nil {
+		if params.LastEventID != "" {
+			req.query.Set("last_event_id", params.LastEventID)
+		}
+	}
+	return c.stream(ctx, req)
+}
+
+// ListNotificationsParams — параметры запроса ListNotifications.
+type ListNotificationsParams struct {
+	// Только непрочитанные
+	Unread bool
+}
+
+// ListNotifications — уведомления.
+//
+// GET /notifications
+func (c *Client) ListNotifications(ctx context.Context, params *ListNotificationsParams) (*NotificationListResponse, error) {
+	req := request{method: http.MethodGet, path: "/notifications", auth: authUser}
+	req.query = url.Values{}
+	if params != nil {
+		if params.Unread {
+			req.query.Set("unread", strconv.FormatBool(params.Unread))
+		}
+	}
+	var out NotificationListResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// MarkAllNotificationsRead — отметить все уведомления прочитанными.
+//
+// POST /notifications/read-all
+func (c *Client) MarkAllNotificationsRead(ctx context.Context) (*MarkAllReadResponse, error) {
+	req := request{method: http.MethodPost, path: "/notifications/read-all", auth: authUser}
+	var out MarkAllReadResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetNotificationSettings — настройки уведомлений.
+//
+// GET /notifications/settings
+func (c *Client) GetNotificationSettings(ctx context.Context) (*NotificationSettingsResponse, error) {
+	req := request{method: http.MethodGet, path: "/notifications/settings", auth: authUser}
+	var out NotificationSettingsResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateNotificationSettings — изменить настройки уведомлений.
+//
+// PUT /notifications/settings
+func (c *Client) UpdateNotificationSettings(ctx context.Context, body UpdateNotificationSettingsRequest) (*NotificationSettingsResponse, error) {
+	req := request{method: http.MethodPut, path: "/notifications/settings", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out NotificationSettingsResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// MarkNotificationRead — отметить уведомление прочитанным.
+//
+// POST /notifications/{id}/read
+func (c *Client) MarkNotificationRead(ctx context.Context, id int64) error {
+	req := request{method: http.MethodPost, path: "/notifications/" + strconv.FormatInt(id, 10) + "/read", auth: authUser}
+	return c.do(ctx, req, http.StatusNoContent, nil)
+}
+
+// CreateWebhook — подписаться на события.
+//
+// POST /webhooks
+func (c *Client) CreateWebhook(ctx context.Context, body CreateWebhookRequest) (*WebhookResponse, error) {
+	req := request{method: http.MethodPost, path: "/webhooks", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out WebhookResponse
+	if err := c.do(ctx, req, http.StatusCreated, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListWebhooks — подписки на события.
+//
+// GET /webhooks
+func (c *Client) ListWebhooks(ctx context.Context) (*WebhookListResponse, error) {
+	req := request{method: http.MethodGet, path: "/webhooks", auth: authUser}
+	var out WebhookListResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteWebhook — удалить подписку.
+//
+// DELETE /webhooks/{id}
+func (c *Client) DeleteWebhook(ctx context.Context, id int64) error {
+	req := request{method: http.MethodDelete, path: "/webhooks/" + strconv.FormatInt(id, 10), auth: authUser}
+	return c.do(ctx, req, http.StatusNoContent, nil)
+}
+
+// ListWebhookDeliveriesParams — параметры запроса ListWebhookDeliveries.
+type ListWebhookDeliveriesParams struct {
+	// Фильтр по статусу
+	Status string
+}
+
+// ListWebhookDeliveries — доставки по подписке.
+//
+// GET /webhooks/{id}/deliveries
+func (c *Client) ListWebhookDeliveries(ctx context.Context, id int64, params *ListWebhookDeliveriesParams) (*WebhookDeliveryListResponse, error) {
+	req := request{method: http.MethodGet, path: "/webhooks/" + strconv.FormatInt(id, 10) + "/deliveries", auth: authUser}
+	req.query = url.Values{}
+	if params != nil {
+		if params.Status != "" {
+			req.query.Set("status", params.Status)
+		}
+	}
+	var out WebhookDeliveryListResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWebhookDelivery — доставка с попытками.
+//
+// GET /webhook-deliveries/{id}
+func (c *Client) GetWebhookDelivery(ctx context.Context, id int64) (*WebhookDeliveryResponse, error) {
+	req := request{method: http.MethodGet, path: "/webhook-deliveries/" + strconv.FormatInt(id, 10), auth: authUser}
+	var out WebhookDeliveryResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RedeliverWebhook — повторить доставку.
+//
+// POST /webhook-deliveries/{id}/redeliver
+func (c *Client) RedeliverWebhook(ctx context.Context, id int64) (*WebhookDeliveryResponse, error) {
+	req := request{method: http.MethodPost, path: "/webhook-deliveries/" + strconv.FormatInt(id, 10) + "/redeliver", auth: authUser}
+	var out WebhookDeliveryResponse
+	if err := c.do(ctx, req, http.StatusAccepted, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConfirmChallenge — подтвердить операцию паролем.
+//
+// POST /risk/challenges/{id}/confirm
+func (c *Client) ConfirmChallenge(ctx context.Context, id int64, body ConfirmChallengeRequest) error {
+	req := request{method: http.MethodPost, path: "/risk/challenges/" + strconv.FormatInt(id, 10) + "/confirm", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return err
+	}
+	return c.do(ctx, req, http.StatusNoContent, nil)
+}
+
+// PrepareP2PTransfer — подготовить перевод по телефону или email.
+//
+// POST /p2p/transfers
+func (c *Client) PrepareP2PTransfer(ctx context.Context, body P2PTransferRequest) (*P2PTransferResponse, error) {
+	req := request{method: http.MethodPost, path: "/p2p/transfers", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out P2PTransferResponse
+	if err := c.do(ctx, req, http.StatusCreated, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ConfirmP2PTransfer — подтвердить перевод.
+//
+// POST /p2p/transfers/{id}/confirm
+//
+// Ответ 202 возвращается как *AltResponse с телом HeldTransferResponse.
+func (c *Client) ConfirmP2PTransfer(ctx context.Context, id int64) (*P2PTransferResponse, error) {
+	req := request{method: http.MethodPost, path: "/p2p/transfers/" + strconv.FormatInt(id, 10) + "/confirm", auth: authUser}
+	var out P2PTransferResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// OpenDeposit — открыть вклад.
+//
+// POST /deposits
+func (c *Client) OpenDeposit(ctx context.Context, body OpenDepositRequest) (*DepositResponse, error) {
+	req := request{method: http.MethodPost, path: "/deposits", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out DepositResponse
+	if err := c.do(ctx, req, http.StatusCreated, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListDeposits — вклады.
+//
+// GET /deposits
+func (c *Client) ListDeposits(ctx context.Context) (*DepositListResponse, error) {
+	req := request{method: http.MethodGet, path: "/deposits", auth: authUser}
+	var out DepositListResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CloseDeposit — закрыть вклад.
+//
+// POST /deposits/{id}/close
+func (c *Client) CloseDeposit(ctx context.Context, id int64) (*DepositResponse, error) {
+	req := request{method: http.MethodPost, path: "/deposits/" + strconv.FormatInt(id, 10) + "/close", auth: authUser}
+	var out DepositResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListFeeRules — тарифы.
+//
+// GET /fees
+func (c *Client) ListFeeRules(ctx context.Context) (*FeeRuleListResponse, error) {
+	req := request{method: http.MethodGet, path: "/fees", auth: authUser}
+	var out FeeRuleListResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// QuoteFeeParams — параметры запроса QuoteFee.
+type QuoteFeeParams struct {
+	// Вид операции
+	Operation string
+	// Сумма операции
+	Amount decimal.Decimal
+}
+
+// QuoteFee — рассчитать комиссию.
+//
+// GET /fees/quote
+func (c *Client) QuoteFee(ctx context.Context, params QuoteFeeParams) (*FeeQuoteResponse, error) {
+	req := request{method: http.MethodGet, path: "/fees/quote", auth: authUser}
+	req.query = url.Values{}
+	req.query.Set("operation", params.Operation)
+	req.query.Set("amount", params.Amount.String())
+	var out FeeQuoteResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateCard — выпустить карту.
+//
+// POST /cards
+func (c *Client) CreateCard(ctx context.Context, body CreateCardRequest) (*CreateCardResponse, error) {
+	req := request{method: http.MethodPost, path: "/cards", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out CreateCardResponse
+	if err := c.do(ctx, req, http.StatusCreated, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListCards — карты.
+//
+// GET /cards
+func (c *Client) ListCards(ctx context.Context) (*CardListResponse, error) {
+	req := request{method: http.MethodGet, path: "/cards", auth: authUser}
+	var out CardListResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCardDetailsParams — параметры запроса GetCardDetails.
+type GetCardDetailsParams struct {
+	// Открытый ключ PGP в ASCII armor
+	PGPKey string
+}
+
+// GetCardDetails — реквизиты карты, зашифрованные ключом PGP клиента.
+//
+// GET /cards/{id}
+func (c *Client) GetCardDetails(ctx context.Context, id int64, params GetCardDetailsParams) (*CardDetailsResponse, error) {
+	req := request{method: http.MethodGet, path: "/cards/" + strconv.FormatInt(id, 10), auth: authUser}
+	req.query = url.Values{}
+	req.query.Set("pgp_key", params.PGPKey)
+	var out CardDetailsResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ProcessCardPayment — оплата картой.
+//
+// POST /payments
+func (c *Client) ProcessCardPayment(ctx context.Context, body CardPaymentRequest) (*CardPaymentResponse, error) {
+	req := request{method: http.MethodPost, path: "/payments", auth: authUser}
+	if err := req.setJSON(body); err != nil {
+		return nil, err
+	}
+	var out CardPaymentResponse
+	if err := c.do(ctx, req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,544 @@
+// Команда genclient генерирует Go-клиент Bank API (пакет client) по
+// спецификации OpenAPI из internal/openapi. Запускается через go generate
+// в каталоге client.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/therealadik/bank-api/internal/openapi"
+)
+
+// initialisms пишутся в именах Go целиком заглавными.
+var initialisms = map[string]bool{
+	"id": true, "url": true, "kyc": true, "pgp": true, "cvv": true,
+	"p2p": true, "aml": true, "sar": true, "api": true, "jwt": true,
+}
+
+// mediaSuffixes — суффиксы методов для дополнительных типов тела запроса.
+var mediaSuffixes = map[string]string{
+	"text/csv": "CSV",
+}
+
+type generator struct {
+	doc     *openapi.Document
+	buf     bytes.Buffer
+	imports map[string]bool
+}
+
+func main() {
+	out := flag.String("o", "client_gen.go", "файл для сгенерированного кода")
+	flag.Parse()
+
+	g := &generator{doc: openapi.Build(), imports: make(map[string]bool)}
+	src, err := g.generate()
+	if err != nil {
+		log.Fatalf("Ошибка генерации клиента: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatalf("Ошибка записи клиента: %v", err)
+	}
+}
+
+func (g *generator) generate() ([]byte, error) {
+	g.imports["context"] = true
+
+	names := make([]string, 0, len(g.doc.Components.Schemas))
+	for name := range g.doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		g.writeType(name, g.doc.Components.Schemas[name])
+	}
+
+	for _, op := range openapi.Operations {
+		g.writeParams(op)
+		if err := g.writeMethods(op); err != nil {
+			return nil, err
+		}
+	}
+
+	var file bytes.Buffer
+	file.WriteString("// Code generated by cmd/genclient; DO NOT EDIT.\n\npackage client\n\nimport (\n")
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	// Сначала стандартная библиотека, затем внешние модули.
+	for _, external := range []bool{false, true} {
+		file.WriteString("\n")
+		for _, path := range imports {
+			if strings.Contains(path, ".") == external {
+				fmt.Fprintf(&file, "%q\n", path)
+			}
+		}
+	}
+	file.WriteString(")\n")
+	file.Write(g.buf.Bytes())
+
+	src, err := format.Source(file.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("ошибка форматирования: %w", err)
+	}
+	return src, nil
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) writeType(name string, s *openapi.Schema) {
+	required := make(map[string]bool, len(s.Required))
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	g.printf("\ntype %s struct {\n", name)
+	for _, prop := range s.PropertyOrder {
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+		}
+		g.printf("%s %s `json:%q`\n", exportedName(prop), g.goType(s.Properties[prop]), tag)
+	}
+	g.printf("}\n")
+}
+
+// goType возвращает тип Go для схемы.
+func (g *generator) goType(s *openapi.Schema) string {
+	if s.Ref != "" {
+		return refName(s.Ref)
+	}
+
+	var t string
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "decimal":
+			g.imports["github.com/shopspring/decimal"] = true
+			if s.Nullable {
+				return "decimal.NullDecimal"
+			}
+			t = "decimal.Decimal"
+		case "date-time":
+			g.imports["time"] = true
+			t = "time.Time"
+		case "byte", "binary":
+			return "[]byte"
+		default:
+			t = "string"
+		}
+	case "integer":
+		switch s.Format {
+		case "int64":
+			t = "int64"
+		case "int32":
+			t = "int32"
+		default:
+			t = "int"
+		}
+	case "number":
+		t = "float64"
+	case "boolean":
+		t = "bool"
+	case "array":
+		return "[]" + g.goType(s.Items)
+	case "object":
+		if s.AdditionalProperties != nil && len(s.Properties) == 0 {
+			return "map[string]" + g.goType(s.AdditionalProperties)
+		}
+		g.imports["encoding/json"] = true
+		return "json.RawMessage"
+	default:
+		g.imports["encoding/json"] = true
+		return "json.RawMessage"
+	}
+
+	if s.Nullable {
+		return "*" + t
+	}
+	return t
+}
+
+func (g *generator) writeParams(op openapi.Operation) {
+	if len(op.Query) == 0 {
+		return
+	}
+
+	g.printf("\n// %sParams — параметры запроса %s.\ntype %sParams struct {\n", op.ID, op.ID, op.ID)
+	for _, p := range op.Query {
+		if p.Description != "" {
+			g.printf("// %s\n", p.Description)
+		}
+		g.printf("%s %s\n", exportedName(p.Name), g.paramType(op, p))
+	}
+	g.printf("}\n")
+}
+
+func (g *generator) paramType(op openapi.Operation, p openapi.Param) string {
+	switch p.Value.(type) {
+	case nil, string:
+		return "string"
+	}
+	for _, param := range g.doc.Paths[op.Path][strings.ToLower(op.Method)].Parameters {
+		if param.In == "query" && param.Name == p.Name {
+			return g.goType(param.Schema)
+		}
+	}
+	panic("genclient: нет параметра " + p.Name)
+}
+
+// requiredQuery сообщает, есть ли у операции обязательные параметры запроса;
+// тогда параметры передаются значением, иначе — указателем.
+func requiredQuery(op openapi.Operation) bool {
+	for _, p := range op.Query {
+		if p.Required {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) writeMethods(op openapi.Operation) error {
+	if len(op.Body) == 0 {
+		return g.writeMethod(op, op.ID, nil)
+	}
+	for i, body := range op.Body {
+		name := op.ID
+		if i > 0 {
+			suffix, ok := mediaSuffixes[body.MediaType]
+			if !ok {
+				return fmt.Errorf("операция %s: нет суффикса для %s", op.ID, body.MediaType)
+			}
+			name += suffix
+		}
+		if err := g.writeMethod(op, name, &body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) writeMethod(op openapi.Operation, name string, body *openapi.Content) error {
+	o := g.doc.Paths[op.Path][strings.ToLower(op.Method)]
+	primary := op.Responses[0]
+
+	args := []string{"ctx context.Context"}
+	for _, p := range openapi.PathParams(op) {
+		args = append(args, paramName(p.Name)+" "+g.pathType(p))
+	}
+	if len(op.Query) > 0 {
+		if requiredQuery(op) {
+			args = append(args, "params "+op.ID+"Params")
+		} else {
+			args = append(args, "params *"+op.ID+"Params")
+		}
+	}
+
+	var bodyCode string
+	if body != nil {
+		a, code, err := g.bodyCode(op, o, body)
+		if err != nil {
+			return err
+		}
+		args = append(args, a...)
+		bodyCode = code
+	}
+
+	ret, zero, call, err := g.responseCode(op, primary)
+	if err != nil {
+		return err
+	}
+	bodyCode = strings.ReplaceAll(bodyCode, "$ZERO", zero)
+
+	summary := []rune(op.Summary)
+	g.printf("\n// %s — %s.\n//\n// %s %s", name, strings.ToLower(string(summary[:1]))+string(summary[1:]), op.Method, op.Path)
+	for _, alt := range op.Responses[1:] {
+		note := fmt.Sprintf("Ответ %d возвращается как *AltResponse", alt.Status)
+		if len(alt.Content) > 0 {
+			note += " с телом " + describe(o.Responses[fmt.Sprint(alt.Status)].Content[alt.Content[0].MediaType].Schema)
+		}
+		g.printf("\n//%s", wrapComment(note+".", 76))
+	}
+	g.printf("\nfunc (c *Client) %s(%s) %s {\n", name, strings.Join(args, ", "), ret)
+
+	g.printf("req := request{method: http.Method%s, path: %s, auth: %s}\n",
+		methodConst(op.Method), g.pathExpr(op), authName(op.Auth))
+	g.imports["net/http"] = true
+
+	if len(op.Query) > 0 {
+		g.writeQuery(op)
+	}
+	g.printf("%s", bodyCode)
+	g.printf("%s}\n", call)
+	return nil
+}
+
+func (g *generator) pathType(p openapi.Param) string {
+	if _, ok := p.Value.(string); ok {
+		return "string"
+	}
+	return "int64"
+}
+
+func (g *generator) pathExpr(op openapi.Operation) string {
+	params := make(map[string]openapi.Param)
+	for _, p := range openapi.PathParams(op) {
+		params[p.Name] = p
+	}
+
+	var parts []string
+	literal := ""
+	for _, seg := range strings.Split(strings.TrimPrefix(op.Path, "/"), "/") {
+		literal += "/"
+		if strings.HasPrefix(seg, "{") {
+			p := params[strings.Trim(seg, "{}")]
+			parts = append(parts, fmt.Sprintf("%q", literal))
+			literal = ""
+			if g.pathType(p) == "string" {
+				g.imports["net/url"] = true
+				parts = append(parts, "url.PathEscape("+paramName(p.Name)+")")
+			} else {
+				g.imports["strconv"] = true
+				parts = append(parts, "strconv.FormatInt("+paramName(p.Name)+", 10)")
+			}
+			continue
+		}
+		literal += seg
+	}
+	if literal != "" {
+		parts = append(parts, fmt.Sprintf("%q", literal))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func (g *generator) writeQuery(op openapi.Operation) {
+	g.imports["net/url"] = true
+	g.printf("req.query = url.Values{}\n")
+	if !requiredQuery(op) {
+		g.printf("if params != nil {\n")
+	}
+	for _, p := range op.Query {
+		field := "params." + exportedName(p.Name)
+		var value, cond string
+		switch g.paramType(op, p) {
+		case "string":
+			value, cond = field, field+` != ""`
+		case "bool":
+			g.imports["strconv"] = true
+			value, cond = "strconv.FormatBool("+field+")", field
+		case "decimal.Decimal":
+			value, cond = field+".String()", "!"+field+".IsZero()"
+		default:
+			panic("genclient: тип параметра " + p.Name + " не поддерживается")
+		}
+		if p.Required {
+			g.printf("req.query.Set(%q, %s)\n", p.Name, value)
+		} else {
+			g.printf("if %s {\nreq.query.Set(%q, %s)\n}\n", cond, p.Name, value)
+		}
+	}
+	if !requiredQuery(op) {
+		g.printf("}\n")
+	}
+}
+
+// bodyCode возвращает аргументы метода и код, кладущий тело в req.
+func (g *generator) bodyCode(op openapi.Operation, o *openapi.OperationObject, body *openapi.Content) ([]string, string, error) {
+	schema := o.RequestBody.Content[body.MediaType].Schema
+	errReturn := "if err != nil {\nreturn $ZERO\n}\n"
+
+	switch body.MediaType {
+	case "application/json":
+		t := g.goType(schema)
+		if op.BodyOptional {
+			return []string{"body *" + t},
+				"if body != nil {\nif err := req.setJSON(body); err != nil {\nreturn $ZERO\n}\n}\n", nil
+		}
+		return []string{"body " + t}, "if err := req.setJSON(body); err != nil {\nreturn $ZERO\n}\n", nil
+
+	case "multipart/form-data":
+		g.imports["io"] = true
+		var args, fields []string
+		fileField := ""
+		for _, prop := range schema.PropertyOrder {
+			if schema.Properties[prop].Format == "binary" {
+				fileField = prop
+				continue
+			}
+			args = append(args, paramName(prop)+" string")
+			fields = append(fields, fmt.Sprintf("%q: %s", prop, paramName(prop)))
+		}
+		if fileField == "" {
+			return nil, "", fmt.Errorf("операция %s: в форме нет файла", op.ID)
+		}
+		args = append(args, "fileName string", "file io.Reader")
+		code := fmt.Sprintf("err := req.setMultipart(map[string]string{%s}, %q, fileName, file)\n",
+			strings.Join(fields, ", "), fileField) + errReturn
+		return args, code, nil
+
+	case "text/csv":
+		g.imports["io"] = true
+		return []string{"csv io.Reader"}, "req.body, req.contentType = csv, \"text/csv\"\n", nil
+	}
+	return nil, "", fmt.Errorf("операция %s: тип тела %s не поддерживается", op.ID, body.MediaType)
+}
+
+// responseCode возвращает тип результата метода, значения для возврата
+// ошибки (с err) и код вызова.
+func (g *generator) responseCode(op openapi.Operation, resp openapi.Response) (string, string, string, error) {
+	status := fmt.Sprintf("http.Status%s", statusConst(resp.Status))
+	if len(resp.Content) == 0 {
+		return "error", "err", fmt.Sprintf("return c.do(ctx, req, %s, nil)\n", status), nil
+	}
+
+	content := resp.Content[0]
+	schema := g.doc.Paths[op.Path][strings.ToLower(op.Method)].Responses[fmt.Sprint(resp.Status)].Content[content.MediaType].Schema
+
+	// Файл возвращается байтами независимо от формата.
+	if schema.Type == "string" && schema.Format == "binary" {
+		return "([]byte, error)", "nil, err", fmt.Sprintf(
+			"var out []byte\nif err := c.do(ctx, req, %s, &out); err != nil {\nreturn nil, err\n}\nreturn out, nil\n", status), nil
+	}
+
+	switch content.MediaType {
+	case "text/event-stream":
+		g.imports["io"] = true
+		return "(io.ReadCloser, error)", "nil, err", "return c.stream(ctx, req)\n", nil
+	case "application/json":
+		t := g.goType(schema)
+		if schema.Ref != "" {
+			return "(*" + t + ", error)", "nil, err", fmt.Sprintf(
+				"var out %s\nif err := c.do(ctx, req, %s, &out); err != nil {\nreturn nil, err\n}\nreturn &out, nil\n", t, status), nil
+		}
+		return "(" + t + ", error)", "nil, err", fmt.Sprintf(
+			"var out %s\nif err := c.do(ctx, req, %s, &out); err != nil {\nreturn nil, err\n}\nreturn out, nil\n", t, status), nil
+	}
+	return "", "", "", fmt.Errorf("операция %s: тип ответа %s не поддерживается", op.ID, content.MediaType)
+}
+
+func describe(s *openapi.Schema) string {
+	if s.Ref != "" {
+		return refName(s.Ref)
+	}
+	if len(s.OneOf) > 0 {
+		names := make([]string, 0, len(s.OneOf))
+		for _, alt := range s.OneOf {
+			names = append(names, describe(alt))
+		}
+		return strings.Join(names, " или ")
+	}
+	return "JSON"
+}
+
+// wrapComment разбивает текст на строки комментария не длиннее width.
+func wrapComment(text string, width int) string {
+	var b strings.Builder
+	line := 0
+	b.WriteString("\n//")
+	for _, word := range strings.Fields(text) {
+		n := len([]rune(word))
+		if line > 0 && line+1+n > width {
+			b.WriteString("\n//")
+			line = 0
+		}
+		b.WriteString(" " + word)
+		line += 1 + n
+	}
+	return b.String()
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+func authName(a openapi.Auth) string {
+	switch a {
+	case openapi.Bearer:
+		return "authUser"
+	case openapi.OperatorToken:
+		return "authOperator"
+	}
+	return "authNone"
+}
+
+func methodConst(method string) string {
+	return method[:1] + strings.ToLower(method[1:])
+}
+
+var statusConsts = map[int]string{
+	http.StatusOK:        "OK",
+	http.StatusCreated:   "Created",
+	http.StatusAccepted:  "Accepted",
+	http.StatusNoContent: "NoContent",
+}
+
+func statusConst(status int) string {
+	name, ok := statusConsts[status]
+	if !ok {
+		panic(fmt.Sprintf("genclient: код %d не поддерживается", status))
+	}
+	return name
+}
+
+// exportedName переводит имя из snake_case или camelCase в экспортируемое
+// имя Go: from_account_id -> FromAccountID.
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, word := range splitWords(name) {
+		if initialisms[word] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// paramName — имя аргумента метода: userId -> userID, type -> typeValue.
+func paramName(name string) string {
+	words := splitWords(name)
+	exported := exportedName(strings.Join(words[1:], "_"))
+	result := words[0] + exported
+	if token.IsKeyword(result) {
+		result += "Value"
+	}
+	return result
+}
+
+func splitWords(name string) []string {
+	var words []string
+	var cur strings.Builder
+	for _, r := range name {
+		switch {
+		case r == '_' || r == '-':
+			if cur.Len() > 0 {
+				words = append(words, cur.String())
+				cur.Reset()
+			}
+		case r >= 'A' && r <= 'Z':
+			if cur.Len() > 0 {
+				words = append(words, cur.String())
+				cur.Reset()
+			}
+			cur.WriteRune(r + ('a' - 'A'))
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		words = append(words, cur.String())
+	}
+	return words
+}
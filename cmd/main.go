@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,16 +15,31 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/blob"
 	"github.com/therealadik/bank-api/internal/config"
 	"github.com/therealadik/bank-api/internal/db"
+	"github.com/therealadik/bank-api/internal/events"
+	"github.com/therealadik/bank-api/internal/grpcserver"
 	"github.com/therealadik/bank-api/internal/handler"
+	"github.com/therealadik/bank-api/internal/jobs"
+	"github.com/therealadik/bank-api/internal/mailer"
+	"github.com/therealadik/bank-api/internal/metrics"
 	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/openapi"
+	"github.com/therealadik/bank-api/internal/problem"
 	"github.com/therealadik/bank-api/internal/repository"
+	"github.com/therealadik/bank-api/internal/risk"
+	"github.com/therealadik/bank-api/internal/sanctions"
 	"github.com/therealadik/bank-api/internal/service"
+	"github.com/therealadik/bank-api/internal/sms"
+	"github.com/therealadik/bank-api/internal/tracing"
+	"google.golang.org/grpc"
 )
 
-func runMigrations(dsn string) {
+// runMigrations применяет миграции и возвращает версию схемы.
+func runMigrations(dsn string) uint {
 	m, err := migrate.New("file://migrations", dsn)
 	if err != nil {
 		logrus.Fatalf("Ошибка миграций : %v", err)
@@ -34,27 +50,76 @@ func runMigrations(dsn string) {
 	switch {
 	case errors.Is(err, migrate.ErrNoChange):
 		logrus.Info("Миграции не требуются, схема в актуальном состоянии")
-		return
 
 	case err != nil:
 		logrus.Fatalf("Ошибка при применении миграций: %v", err)
+
+	default:
+		logrus.Info("Миграции успешно применены")
 	}
 
-	logrus.Info("Миграции успешно применены")
+	version, _, err := m.Version()
+	if err != nil {
+		logrus.Fatalf("Ошибка чтения версии схемы: %v", err)
+	}
+	return version
+}
+
+// stopGRPC дожидается завершения вызовов, но не дольше ctx: потоки
+// WatchTransactions сами не заканчиваются.
+func stopGRPC(ctx context.Context, srv *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		srv.Stop()
+	}
 }
 
 func main() {
 	logger := logrus.New()
 	logger.SetFormatter(&logrus.JSONFormatter{})
 	logger.SetLevel(logrus.InfoLevel)
+	logger.AddHook(tracing.LogHook{})
 
 	ctx := context.Background()
 	dbCfg := config.LoadDB()
 	jwtCfg := config.LoadJWT()
 	cryptoCfg := config.LoadCrypto()
+	interestCfg := config.LoadInterest()
+	overdraftCfg := config.LoadOverdraft()
+	accountNumberCfg := config.LoadAccountNumber()
+	fxCfg := config.LoadFX()
+	beneficiaryCfg := config.LoadBeneficiary()
+	approvalCfg := config.LoadApproval()
+	batchCfg := config.LoadBatch()
+	outboxCfg := config.LoadOutbox()
+	webhookCfg := config.LoadWebhook()
+	notificationCfg := config.LoadNotification()
+	smtpCfg := config.LoadSMTP()
+	smsCfg := config.LoadSMS()
+	riskCfg := config.LoadRisk()
+	sanctionsCfg := config.LoadSanctions()
+	operatorCfg := config.LoadOperator()
+	kycCfg := config.LoadKYC()
+	amlCfg := config.LoadAML()
+	lockoutCfg := config.LoadLockout()
+	authTokensCfg := config.LoadAuthTokens()
+	grpcCfg := config.LoadGRPC()
+	tracingCfg := config.LoadTracing()
+
+	shutdownTracing, err := tracing.Setup(ctx, tracingCfg)
+	if err != nil {
+		logger.Fatalf("Ошибка настройки трейсинга: %v", err)
+	}
 
 	dsn := db.BuildDSN(dbCfg)
-	runMigrations(dsn)
+	schemaVersion := runMigrations(dsn)
 
 	pool, err := db.New(ctx, dbCfg)
 	if err != nil {
@@ -67,40 +132,308 @@ func main() {
 	accountRepo := repository.NewAccountRepository(pool)
 	transactionRepo := repository.NewTransactionRepository(pool)
 	cardRepo := repository.NewCardRepository(pool)
+	interestRepo := repository.NewInterestRepository(pool)
+	depositRepo := repository.NewDepositRepository(pool)
+	overdraftRepo := repository.NewOverdraftRepository(pool)
+	feeRepo := repository.NewFeeRepository(pool)
+	p2pRepo := repository.NewP2PRepository(pool)
+	beneficiaryRepo := repository.NewBeneficiaryRepository(pool)
+	approvalRepo := repository.NewApprovalRepository(pool)
+	batchRepo := repository.NewBatchRepository(pool)
+	outboxRepo := repository.NewOutboxRepository(pool)
+	webhookRepo := repository.NewWebhookRepository(pool)
+	notificationRepo := repository.NewNotificationRepository(pool)
+	riskRepo := repository.NewRiskRepository(pool)
+	screeningRepo := repository.NewScreeningRepository(pool)
+	kycRepo := repository.NewKYCRepository(pool)
+	amlRepo := repository.NewAMLRepository(pool)
+	lockoutRepo := repository.NewLockoutRepository(pool)
+	authTokenRepo := repository.NewAuthTokenRepository(pool)
+	schemaRepo := repository.NewSchemaRepository(pool)
+
+	var publisher events.EventPublisher
+	if outboxCfg.Publisher == "memory" {
+		logger.Warnf("События хранятся в памяти процесса (последние %d) и теряются при перезапуске", outboxCfg.MemoryLimit)
+		publisher = events.NewMemoryPublisher(outboxCfg.MemoryLimit)
+	} else {
+		filePublisher, err := events.NewFilePublisher(outboxCfg.FilePath)
+		if err != nil {
+			logger.Fatalf("Ошибка открытия файла событий: %v", err)
+		}
+		defer filePublisher.Close()
+		publisher = filePublisher
+	}
+
+	var mail mailer.Mailer = mailer.NewLogMailer(logger)
+	if smtpCfg.Host != "" {
+		mail = mailer.NewSMTPMailer(smtpCfg)
+	}
 
-	authService := service.NewAuthService(userRepo, jwtCfg)
-	accountService := service.NewAccountService(accountRepo, transactionRepo)
-	cardService := service.NewCardService(cardRepo, pool, cryptoCfg.HMACKey)
+	var smsSender sms.Sender = sms.NewLogSender(logger)
+	if smsCfg.GatewayURL != "" {
+		smsSender = sms.NewHTTPSender(smsCfg)
+	}
+
+	if sanctionsCfg.ListFile == "" {
+		logger.Warn("SANCTIONS_LIST_FILE не задан: проверка по санкционным спискам ничего не найдет")
+	}
+
+	screeningService := service.NewScreeningService(screeningRepo, userRepo, accountRepo,
+		sanctions.NewScreener(sanctionsCfg.MatchThreshold), sanctionsCfg)
+	lockoutService := service.NewLockoutService(lockoutRepo, lockoutCfg, logger)
+	authService := service.NewAuthService(userRepo, authTokenRepo, screeningService, lockoutService, mail, jwtCfg, authTokensCfg, logger)
+
+	blobStore, err := blob.NewFSStore(kycCfg.BlobDir)
+	if err != nil {
+		logger.Fatalf("Ошибка инициализации хранилища документов: %v", err)
+	}
+	kycService := service.NewKYCService(kycRepo, userRepo, screeningService, blobStore, kycCfg)
+	amlService := service.NewAMLService(amlRepo, kycRepo, accountRepo, amlCfg)
+	feeService := service.NewFeeService(feeRepo, accountRepo)
+	riskService := service.NewRiskService(riskRepo, userRepo, lockoutService,
+		risk.NewEngine(riskCfg.Thresholds, risk.DefaultRules()...), riskCfg)
+	accountService := service.NewAccountService(accountRepo, transactionRepo, feeService, approvalRepo, riskService, screeningService, kycService, interestCfg.Products, accountNumberCfg, fxCfg)
+	cardService := service.NewCardService(cardRepo, accountRepo, feeService, riskService, kycService, lockoutService, pool, cryptoCfg.HMACKey)
+	interestService := service.NewInterestService(accountRepo, interestRepo)
+	otpService := service.NewOTPService(authTokenRepo, lockoutService, mail, smsSender, authTokensCfg, logger)
+	userService := service.NewUserService(userRepo, otpService)
+	p2pService := service.NewP2PService(accountService, feeService, accountRepo, userRepo, p2pRepo)
+	beneficiaryService := service.NewBeneficiaryService(accountService, accountRepo, beneficiaryRepo, userRepo, lockoutService, otpService, beneficiaryCfg)
+	approvalService := service.NewApprovalService(accountService, beneficiaryService, accountRepo, approvalRepo, userRepo,
+		approvalCfg)
+	batchService := service.NewBatchService(accountService, accountRepo, beneficiaryService, feeService, approvalRepo,
+		batchRepo, kycService, riskService, screeningService, batchCfg)
+	webhookService := service.NewWebhookService(webhookRepo, webhookCfg)
+	notificationService := service.NewNotificationService(notificationRepo, userRepo, accountRepo, mail, notificationCfg)
+	streamService := service.NewStreamService(outboxRepo, accountRepo, cardRepo)
+	outboxService := service.NewOutboxService(outboxRepo,
+		events.NewFanOutPublisher(publisher, webhookService, notificationService), outboxCfg)
+	healthService := service.NewHealthService(schemaRepo, schemaVersion)
+	depositService := service.NewDepositService(accountService, depositRepo, interestCfg)
+	overdraftService := service.NewOverdraftService(accountService, accountRepo, overdraftRepo, kycService, overdraftCfg)
+
+	assigned, err := accountService.AssignMissingNumbers(ctx)
+	if err != nil {
+		logger.Fatalf("Ошибка присвоения номеров счетам: %v", err)
+	}
+	if assigned > 0 {
+		logger.Infof("Присвоены номера %d счетам", assigned)
+	}
 
 	authHandler := handler.NewAuthHandler(authService, logger)
-	accountHandler := handler.NewAccountHandler(accountService, logger)
+	accountHandler := handler.NewAccountHandler(accountService, beneficiaryService, approvalService, logger)
 	cardHandler := handler.NewCardHandler(cardService, logger)
+	depositHandler := handler.NewDepositHandler(depositService, accountService, logger)
+	overdraftHandler := handler.NewOverdraftHandler(overdraftService, accountService, logger)
+	feeHandler := handler.NewFeeHandler(feeService, logger)
+	userHandler := handler.NewUserHandler(userService, logger)
+	p2pHandler := handler.NewP2PHandler(p2pService, accountService, logger)
+	beneficiaryHandler := handler.NewBeneficiaryHandler(beneficiaryService, logger)
+	approvalHandler := handler.NewApprovalHandler(approvalService, accountService, logger)
+	batchHandler := handler.NewBatchHandler(batchService, accountService, logger)
+	webhookHandler := handler.NewWebhookHandler(webhookService, logger)
+	streamHandler := handler.NewStreamHandler(streamService, logger)
+	notificationHandler := handler.NewNotificationHandler(notificationService, logger)
+	riskHandler := handler.NewRiskHandler(riskService, logger)
+	screeningHandler := handler.NewScreeningHandler(screeningService, logger)
+	kycHandler := handler.NewKYCHandler(kycService, kycCfg.MaxDocumentSize, logger)
+	amlHandler := handler.NewAMLHandler(amlService, logger)
+	lockoutHandler := handler.NewLockoutHandler(lockoutService, logger)
+	healthHandler := handler.NewHealthHandler(healthService, logger)
 
 	jwtMiddleware := middleware.NewJWTMiddleware(authService, logger)
+	operatorMiddleware := middleware.NewOperatorMiddleware(operatorCfg.Token, logger)
+
+	// Фоновые задачи
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+
+	interestJob := jobs.NewInterestJob(interestService, interestCfg.JobInterval, logger)
+	go interestJob.Run(jobsCtx)
+
+	depositJob := jobs.NewDepositJob(depositService, interestCfg.JobInterval, logger)
+	go depositJob.Run(jobsCtx)
+
+	feeJob := jobs.NewFeeJob(feeService, interestCfg.JobInterval, logger)
+	go feeJob.Run(jobsCtx)
+
+	batchJob := jobs.NewBatchJob(batchService, batchCfg.PollInterval, logger)
+	go batchJob.Run(jobsCtx)
+
+	approvalJob := jobs.NewApprovalJob(approvalService, approvalCfg.PollInterval, logger)
+	go approvalJob.Run(jobsCtx)
 
-	r := mux.NewRouter().PathPrefix("/api").Subrouter()
+	outboxJob := jobs.NewOutboxJob(outboxService, outboxCfg.PollInterval, logger)
+	go outboxJob.Run(jobsCtx)
+
+	webhookJob := jobs.NewWebhookJob(webhookService, webhookCfg.PollInterval, logger)
+	go webhookJob.Run(jobsCtx)
+
+	notificationJob := jobs.NewNotificationJob(notificationService, notificationCfg.PollInterval, logger)
+	go notificationJob.Run(jobsCtx)
+
+	lockoutJob := jobs.NewLockoutJob(lockoutService, lockoutCfg.PruneInterval, logger)
+	go lockoutJob.Run(jobsCtx)
+
+	riskJob := jobs.NewRiskJob(riskService, riskCfg.ReloadInterval, logger)
+	go riskJob.Run(jobsCtx)
+
+	// Список загружается до приема запросов, чтобы первые регистрации
+	// проверялись по нему.
+	screeningJob := jobs.NewScreeningJob(screeningService, accountService, sanctionsCfg.ReloadInterval,
+		sanctionsCfg.PollInterval, logger)
+	screeningJob.RunOnce(ctx)
+	go screeningJob.Run(jobsCtx)
+
+	amlJob := jobs.NewAMLJob(amlService, amlCfg.JobInterval, logger)
+	go amlJob.Run(jobsCtx)
+
+	streamJob := jobs.NewStreamJob(streamService, outboxCfg.StreamRetryDelay, logger)
+	go streamJob.Run(jobsCtx)
+
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		problem.Error(w, r, http.StatusNotFound, "not_found")
+	})
+	methodNotAllowed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		problem.Error(w, r, http.StatusMethodNotAllowed, "method_not_allowed")
+	})
+
+	// Пробы и метрики живут вне /api: их опрашивают Kubernetes и Prometheus,
+	// а не клиенты API.
+	root := mux.NewRouter()
+	root.NotFoundHandler = notFound
+	root.MethodNotAllowedHandler = methodNotAllowed
+	root.HandleFunc("/healthz", healthHandler.Liveness).Methods(http.MethodGet)
+	root.HandleFunc("/readyz", healthHandler.Readiness).Methods(http.MethodGet)
+	root.Handle("/metrics", promhttp.HandlerFor(metrics.NewRegistry(pool), promhttp.HandlerOpts{})).
+		Methods(http.MethodGet)
+
+	r := root.PathPrefix("/api").Subrouter()
+	r.NotFoundHandler = notFound
+	r.MethodNotAllowedHandler = methodNotAllowed
+	r.Use(tracing.Middleware, metrics.Middleware)
 
 	r.HandleFunc("/register", authHandler.Register).Methods(http.MethodPost)
 	r.HandleFunc("/login", authHandler.Login).Methods(http.MethodPost)
+	r.HandleFunc("/email/verify", authHandler.VerifyEmail).Methods(http.MethodPost)
+	r.HandleFunc("/email/verify/resend", authHandler.ResendVerification).Methods(http.MethodPost)
+	r.HandleFunc("/password/forgot", authHandler.ForgotPassword).Methods(http.MethodPost)
+	r.HandleFunc("/password/reset", authHandler.ResetPassword).Methods(http.MethodPost)
+
+	operatorRouter := r.PathPrefix("/operator").Subrouter()
+	operatorRouter.Use(operatorMiddleware.Middleware)
+	operatorRouter.HandleFunc("/screening/hits", screeningHandler.GetHits).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/screening/hits/{id}", screeningHandler.GetHit).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/screening/hits/{id}/clear", screeningHandler.Clear).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/screening/hits/{id}/confirm", screeningHandler.Confirm).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/kyc", kycHandler.GetPending).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/kyc/{userId}", kycHandler.GetCustomer).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/kyc/{userId}/documents", kycHandler.GetCustomerDocuments).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/kyc/{userId}/documents/{docId}", kycHandler.DownloadDocument).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/kyc/{userId}/verify", kycHandler.Verify).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/kyc/{userId}/reject", kycHandler.Reject).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/aml/cases", amlHandler.GetCases).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/aml/cases/{id}", amlHandler.GetCase).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/aml/cases/{id}/escalate", amlHandler.Escalate).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/aml/cases/{id}/dismiss", amlHandler.Dismiss).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/aml/cases/{id}/sar", amlHandler.ExportSAR).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/lockouts", lockoutHandler.GetActive).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/lockouts/{id}/release", lockoutHandler.Release).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/overdraft-requests", overdraftHandler.GetRequests).Methods(http.MethodGet)
+	operatorRouter.HandleFunc("/overdraft-requests/{id}/approve", overdraftHandler.Approve).Methods(http.MethodPost)
+	operatorRouter.HandleFunc("/overdraft-requests/{id}/reject", overdraftHandler.Reject).Methods(http.MethodPost)
 
 	apiRouter := r.PathPrefix("").Subrouter()
 	apiRouter.Use(jwtMiddleware.Middleware)
 
+	apiRouter.HandleFunc("/users/me", userHandler.GetMe).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/users/me", userHandler.UpdateContacts).Methods(http.MethodPatch)
+	apiRouter.HandleFunc("/users/me/phone/code", userHandler.SendPhoneCode).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/users/me/phone/confirm", userHandler.ConfirmPhone).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/users/me/profile", kycHandler.GetProfile).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/users/me/profile", kycHandler.UpdateProfile).Methods(http.MethodPut)
+
+	apiRouter.HandleFunc("/kyc/documents", kycHandler.GetDocuments).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/kyc/documents", kycHandler.UploadDocument).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/kyc/submit", kycHandler.Submit).Methods(http.MethodPost)
+
 	apiRouter.HandleFunc("/accounts", accountHandler.CreateAccount).Methods(http.MethodPost)
 	apiRouter.HandleFunc("/accounts", accountHandler.GetAccounts).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/accounts/{id}/balance", accountHandler.UpdateBalance).Methods(http.MethodPatch)
+	apiRouter.HandleFunc("/accounts/{id}/overdraft", overdraftHandler.SetOverdraft).Methods(http.MethodPut)
+	apiRouter.HandleFunc("/accounts/{id}/members", approvalHandler.AddMember).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/accounts/{id}/members", approvalHandler.GetMembers).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/accounts/{id}/members/{userId}", approvalHandler.RemoveMember).Methods(http.MethodDelete)
+	apiRouter.HandleFunc("/accounts/{id}/approval-policy", approvalHandler.GetPolicy).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/accounts/{id}/approval-policy", approvalHandler.SetPolicy).Methods(http.MethodPut)
+	apiRouter.HandleFunc("/approvals", approvalHandler.GetPendingPayments).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/approvals/{id}", approvalHandler.GetPayment).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/approvals/{id}/approve", approvalHandler.Approve).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/approvals/{id}/reject", approvalHandler.Reject).Methods(http.MethodPost)
 	apiRouter.HandleFunc("/accounts/{id}/transactions", accountHandler.GetTransactions).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/transfer", accountHandler.Transfer).Methods(http.MethodPost)
 
+	apiRouter.HandleFunc("/beneficiaries", beneficiaryHandler.CreateBeneficiary).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/beneficiaries", beneficiaryHandler.GetBeneficiaries).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/beneficiaries/{id}", beneficiaryHandler.UpdateBeneficiary).Methods(http.MethodPatch)
+	apiRouter.HandleFunc("/beneficiaries/{id}", beneficiaryHandler.DeleteBeneficiary).Methods(http.MethodDelete)
+	apiRouter.HandleFunc("/beneficiaries/{id}/verify", beneficiaryHandler.VerifyBeneficiary).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/beneficiaries/{id}/verify/code", beneficiaryHandler.SendVerificationCode).Methods(http.MethodPost)
+
+	apiRouter.HandleFunc("/batches", batchHandler.CreateBatch).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/batches", batchHandler.GetBatches).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/batches/{id}", batchHandler.GetBatch).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/batches/{id}/execute", batchHandler.ExecuteBatch).Methods(http.MethodPost)
+
+	apiRouter.HandleFunc("/stream", streamHandler.Stream).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc("/notifications", notificationHandler.GetNotifications).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/notifications/read-all", notificationHandler.MarkAllRead).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/notifications/settings", notificationHandler.GetSettings).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/notifications/settings", notificationHandler.UpdateSettings).Methods(http.MethodPut)
+	apiRouter.HandleFunc("/notifications/{id}/read", notificationHandler.MarkRead).Methods(http.MethodPost)
+
+	apiRouter.HandleFunc("/webhooks", webhookHandler.CreateWebhook).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/webhooks", webhookHandler.GetWebhooks).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/webhooks/{id}", webhookHandler.DeleteWebhook).Methods(http.MethodDelete)
+	apiRouter.HandleFunc("/webhooks/{id}/deliveries", webhookHandler.GetDeliveries).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/webhook-deliveries/{id}", webhookHandler.GetDelivery).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/webhook-deliveries/{id}/redeliver", webhookHandler.Redeliver).Methods(http.MethodPost)
+
+	apiRouter.HandleFunc("/risk/challenges/{id}/confirm", riskHandler.ConfirmChallenge).Methods(http.MethodPost)
+
+	apiRouter.HandleFunc("/p2p/transfers", p2pHandler.Prepare).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/p2p/transfers/{id}/confirm", p2pHandler.Confirm).Methods(http.MethodPost)
+
+	apiRouter.HandleFunc("/deposits", depositHandler.OpenDeposit).Methods(http.MethodPost)
+	apiRouter.HandleFunc("/deposits", depositHandler.GetDeposits).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/deposits/{id}/close", depositHandler.CloseDeposit).Methods(http.MethodPost)
+
+	apiRouter.HandleFunc("/fees", feeHandler.GetRules).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/fees/quote", feeHandler.Quote).Methods(http.MethodGet)
+
 	apiRouter.HandleFunc("/cards", cardHandler.CreateCard).Methods(http.MethodPost)
 	apiRouter.HandleFunc("/cards", cardHandler.GetCards).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/cards/{id}", cardHandler.GetCardDetails).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/payments", cardHandler.ProcessPayment).Methods(http.MethodPost)
 
+	// Спецификация описывает все маршруты выше; расхождение — ошибка сборки
+	// API, сервер с ней не запускается.
+	if err := openapi.Verify(r, "/api"); err != nil {
+		logger.Fatalf("Ошибка проверки маршрутов: %v", err)
+	}
+
+	docsHandler, err := handler.NewDocsHandler(openapi.Build(), logger)
+	if err != nil {
+		logger.Fatalf("Ошибка инициализации документации API: %v", err)
+	}
+	r.HandleFunc("/openapi.json", docsHandler.Spec).Methods(http.MethodGet)
+	r.HandleFunc("/docs", docsHandler.SwaggerUI).Methods(http.MethodGet)
+
 	// Настройка сервера
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", "8080"),
-		Handler:      r,
+		Handler:      middleware.Locale(root),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -114,11 +447,33 @@ func main() {
 		}
 	}()
 
+	// gRPC API для внутренних сервисов на отдельном порту
+	var grpcSrv *grpc.Server
+	if grpcCfg.Port != "" {
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcCfg.Port))
+		if err != nil {
+			logger.Fatalf("Ошибка открытия порта gRPC: %v", err)
+		}
+
+		grpcSrv = grpcserver.New(authService,
+			grpcserver.NewAccountServer(accountService, approvalService, streamService, logger),
+			grpcserver.NewCardServer(cardService, logger),
+			logger)
+
+		go func() {
+			logger.Infof("gRPC-сервер запущен на порту %s", grpcCfg.Port)
+			if err := grpcSrv.Serve(lis); err != nil {
+				logger.Fatalf("Ошибка запуска gRPC-сервера: %v", err)
+			}
+		}()
+	}
+
 	// Канал для сигналов завершения
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	logger.Info("Завершение работы сервера...")
+	stopJobs()
 
 	// Ожидание завершения текущих запросов
 	ctxShutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -127,5 +482,11 @@ func main() {
 	if err := srv.Shutdown(ctxShutdown); err != nil {
 		logger.Fatalf("Ошибка при остановке сервера: %v", err)
 	}
+	if grpcSrv != nil {
+		stopGRPC(ctxShutdown, grpcSrv)
+	}
+	if err := shutdownTracing(ctxShutdown); err != nil {
+		logger.Errorf("Ошибка отправки трейсов: %v", err)
+	}
 	logger.Info("Сервер успешно остановлен")
 }
@@ -0,0 +1,175 @@
+// Package apierror сопоставляет ошибки сервисов с ответами API: HTTP-статусом
+// для REST, кодом gRPC и стабильным кодом каталога i18n, общим для обоих API.
+package apierror
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/therealadik/bank-api/internal/repository"
+	"github.com/therealadik/bank-api/internal/service"
+	"google.golang.org/grpc/codes"
+)
+
+// Mapping — ответ API на ошибку сервиса. Сообщение для клиента берется из
+// каталога i18n по Code.
+type Mapping struct {
+	Status int
+	GRPC   codes.Code
+	Code   string
+}
+
+type entry struct {
+	err    error
+	status int
+	grpc   codes.Code
+	code   string
+}
+
+// wrappers оборачивают причину вместе с собой (fmt.Errorf("%w: %w")) и
+// проверяются раньше errorTable, иначе ответ получил бы код причины.
+var wrappers = []entry{
+	{service.ErrPaymentFailed, http.StatusConflict, codes.FailedPrecondition, "payment_failed"},
+}
+
+// errorTable — известные ошибки сервисов. Ошибки 422, вызванные состоянием
+// счета или карты, а не содержимым запроса, получают FailedPrecondition;
+// конфликты 409 из-за уже существующей записи — AlreadyExists.
+var errorTable = []entry{
+	// Счета и переводы.
+	{service.ErrInsufficientFunds, http.StatusUnprocessableEntity, codes.FailedPrecondition, "insufficient_funds"},
+	{service.ErrSameAccount, http.StatusUnprocessableEntity, codes.InvalidArgument, "same_account"},
+	{service.ErrNegativeAmount, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_amount"},
+	{service.ErrZeroAmount, http.StatusUnprocessableEntity, codes.InvalidArgument, "zero_amount"},
+	{service.ErrUnknownProduct, http.StatusUnprocessableEntity, codes.InvalidArgument, "unknown_product"},
+	{service.ErrDepositProduct, http.StatusUnprocessableEntity, codes.InvalidArgument, "deposit_product"},
+	{service.ErrAccountLocked, http.StatusConflict, codes.FailedPrecondition, "account_locked"},
+	{service.ErrUnsupportedCurrency, http.StatusUnprocessableEntity, codes.InvalidArgument, "unsupported_currency"},
+	{service.ErrCurrencyMismatch, http.StatusUnprocessableEntity, codes.InvalidArgument, "currency_mismatch"},
+	{service.ErrOverdraftProduct, http.StatusUnprocessableEntity, codes.InvalidArgument, "overdraft_not_available"},
+	{service.ErrOverdraftLimit, http.StatusUnprocessableEntity, codes.FailedPrecondition, "overdraft_limit_exceeded"},
+	{service.ErrOverdraftInUse, http.StatusConflict, codes.FailedPrecondition, "overdraft_in_use"},
+	{service.ErrOverdraftRequestPending, http.StatusConflict, codes.FailedPrecondition, "overdraft_request_pending"},
+	{service.ErrOverdraftRequestNotFound, http.StatusNotFound, codes.NotFound, "overdraft_request_not_found"},
+	{service.ErrOverdraftRequestNotPending, http.StatusConflict, codes.FailedPrecondition, "overdraft_request_not_pending"},
+	{service.ErrUnknownOverdraftRequestStatus, http.StatusBadRequest, codes.InvalidArgument, "unknown_overdraft_request_status"},
+	{service.ErrInvalidAccountRef, http.StatusBadRequest, codes.InvalidArgument, "invalid_account_ref"},
+	{service.ErrInvalidAccountNumber, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_account_number"},
+	{service.ErrAccountNotFound, http.StatusNotFound, codes.NotFound, "account_not_found"},
+	{service.ErrNoPaymentAccount, http.StatusUnprocessableEntity, codes.FailedPrecondition, "no_payment_account"},
+	{service.ErrCardNotFound, http.StatusNotFound, codes.NotFound, "card_not_found"},
+	{service.ErrInvalidCVV, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_cvv"},
+	{service.ErrCardExpired, http.StatusUnprocessableEntity, codes.FailedPrecondition, "card_expired"},
+	{service.ErrRecipientNotFound, http.StatusNotFound, codes.NotFound, "recipient_not_found"},
+	{service.ErrSelfTransfer, http.StatusUnprocessableEntity, codes.InvalidArgument, "self_transfer"},
+	{service.ErrP2PNotPending, http.StatusNotFound, codes.NotFound, "p2p_transfer_not_pending"},
+	{service.ErrUnknownOperation, http.StatusBadRequest, codes.InvalidArgument, "unknown_operation"},
+	{service.ErrFeeChanged, http.StatusConflict, codes.FailedPrecondition, "fee_changed"},
+
+	// Вклады.
+	{service.ErrInvalidTerm, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_term"},
+	{service.ErrDepositNotFound, http.StatusNotFound, codes.NotFound, "deposit_not_found"},
+	{service.ErrDepositNotActive, http.StatusConflict, codes.FailedPrecondition, "deposit_closed"},
+	{service.ErrInvalidSourceAccount, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_source_account"},
+
+	// Получатели.
+	{service.ErrBeneficiaryNotFound, http.StatusNotFound, codes.NotFound, "beneficiary_not_found"},
+	{service.ErrBeneficiaryExists, http.StatusConflict, codes.AlreadyExists, "beneficiary_exists"},
+	{service.ErrBeneficiaryCoolingOff, http.StatusForbidden, codes.PermissionDenied, "beneficiary_cooling_off"},
+	{service.ErrInvalidNickname, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_nickname"},
+	{service.ErrOwnAccountBeneficiary, http.StatusUnprocessableEntity, codes.InvalidArgument, "own_account_beneficiary"},
+	{service.ErrBeneficiaryRequired, http.StatusForbidden, codes.PermissionDenied, "beneficiary_required"},
+	{service.ErrBeneficiaryVerified, http.StatusConflict, codes.FailedPrecondition, "beneficiary_already_verified"},
+
+	// Совместные счета и подтверждения.
+	{service.ErrApprovalRequired, http.StatusConflict, codes.FailedPrecondition, "approval_required"},
+	{service.ErrNotAccountOwner, http.StatusForbidden, codes.PermissionDenied, "not_account_owner"},
+	{service.ErrNotInitiator, http.StatusForbidden, codes.PermissionDenied, "not_initiator"},
+	{service.ErrNotApprover, http.StatusForbidden, codes.PermissionDenied, "not_approver"},
+	{service.ErrSelfApproval, http.StatusForbidden, codes.PermissionDenied, "self_approval"},
+	{service.ErrAlreadyDecided, http.StatusConflict, codes.FailedPrecondition, "already_decided"},
+	{service.ErrPaymentNotFound, http.StatusNotFound, codes.NotFound, "payment_not_found"},
+	{service.ErrPaymentNotPending, http.StatusConflict, codes.FailedPrecondition, "payment_not_pending"},
+	{service.ErrInvalidRole, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_role"},
+	{service.ErrSelfMember, http.StatusUnprocessableEntity, codes.InvalidArgument, "self_member"},
+	{service.ErrMemberNotFound, http.StatusNotFound, codes.NotFound, "member_not_found"},
+	{service.ErrMemberUserUnknown, http.StatusUnprocessableEntity, codes.InvalidArgument, "member_user_unknown"},
+	{service.ErrInvalidPolicy, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_policy"},
+
+	// Пакетные платежи.
+	{service.ErrBatchNotFound, http.StatusNotFound, codes.NotFound, "batch_not_found"},
+	{service.ErrBatchEmpty, http.StatusUnprocessableEntity, codes.InvalidArgument, "batch_empty"},
+	{service.ErrBatchTooLarge, http.StatusUnprocessableEntity, codes.InvalidArgument, "batch_too_large"},
+	{service.ErrBatchInvalidRows, http.StatusUnprocessableEntity, codes.InvalidArgument, "batch_invalid_rows"},
+	{service.ErrBatchRowHeld, http.StatusUnprocessableEntity, codes.InvalidArgument, "batch_row_held"},
+	{service.ErrBatchNotDraft, http.StatusConflict, codes.FailedPrecondition, "batch_not_draft"},
+	{service.ErrInvalidBatchMode, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_batch_mode"},
+
+	// Пользователи и вход.
+	{service.ErrInvalidCredentials, http.StatusUnauthorized, codes.Unauthenticated, "invalid_credentials"},
+	{service.ErrUserExists, http.StatusConflict, codes.AlreadyExists, "user_exists"},
+	{service.ErrFullNameRequired, http.StatusUnprocessableEntity, codes.InvalidArgument, "full_name_required"},
+	{service.ErrUserHeld, http.StatusForbidden, codes.PermissionDenied, "user_held"},
+	{service.ErrUserBlocked, http.StatusForbidden, codes.PermissionDenied, "user_blocked"},
+	{service.ErrEmailNotVerified, http.StatusForbidden, codes.PermissionDenied, "email_not_verified"},
+	{service.ErrInvalidToken, http.StatusBadRequest, codes.InvalidArgument, "invalid_token"},
+	{service.ErrWeakPassword, http.StatusUnprocessableEntity, codes.InvalidArgument, "weak_password"},
+	{service.ErrInvalidPhone, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_phone"},
+	{service.ErrPhoneTaken, http.StatusConflict, codes.AlreadyExists, "phone_taken"},
+	{service.ErrNoPhone, http.StatusConflict, codes.FailedPrecondition, "no_phone"},
+	{service.ErrPhoneVerified, http.StatusConflict, codes.FailedPrecondition, "phone_already_verified"},
+	{service.ErrInvalidCode, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_code"},
+	{repository.ErrUserNotFound, http.StatusNotFound, codes.NotFound, "user_not_found"},
+	{service.ErrTooManyAttempts, http.StatusTooManyRequests, codes.ResourceExhausted, "too_many_attempts"},
+	{service.ErrLockoutNotFound, http.StatusNotFound, codes.NotFound, "lockout_not_found"},
+	{service.ErrUnknownLockoutScope, http.StatusBadRequest, codes.InvalidArgument, "unknown_lockout_scope"},
+
+	// Антифрод, санкционные списки, KYC и AML.
+	{service.ErrRiskBlocked, http.StatusForbidden, codes.PermissionDenied, "risk_blocked"},
+	{service.ErrRiskChallenge, http.StatusForbidden, codes.PermissionDenied, "risk_challenge"},
+	{service.ErrChallengeNotFound, http.StatusNotFound, codes.NotFound, "challenge_not_found"},
+	{service.ErrCounterpartyBlocked, http.StatusForbidden, codes.PermissionDenied, "counterparty_blocked"},
+	{service.ErrHitNotFound, http.StatusNotFound, codes.NotFound, "hit_not_found"},
+	{service.ErrHitNotPending, http.StatusConflict, codes.FailedPrecondition, "hit_not_pending"},
+	{service.ErrUnknownHitStatus, http.StatusBadRequest, codes.InvalidArgument, "unknown_hit_status"},
+	{service.ErrKYCRequired, http.StatusForbidden, codes.PermissionDenied, "kyc_required"},
+	{service.ErrKYCProfileLocked, http.StatusConflict, codes.FailedPrecondition, "kyc_profile_locked"},
+	{service.ErrKYCProfileIncomplete, http.StatusUnprocessableEntity, codes.InvalidArgument, "kyc_profile_incomplete"},
+	{service.ErrKYCDocumentsMissing, http.StatusUnprocessableEntity, codes.InvalidArgument, "kyc_documents_missing"},
+	{service.ErrKYCNotPending, http.StatusConflict, codes.FailedPrecondition, "kyc_not_pending"},
+	{service.ErrKYCRejectReason, http.StatusUnprocessableEntity, codes.InvalidArgument, "kyc_reject_reason_required"},
+	{service.ErrInvalidDateOfBirth, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_date_of_birth"},
+	{service.ErrInvalidCitizenship, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_citizenship"},
+	{service.ErrUnknownDocumentType, http.StatusUnprocessableEntity, codes.InvalidArgument, "unknown_document_type"},
+	{service.ErrDocumentTooLarge, http.StatusRequestEntityTooLarge, codes.InvalidArgument, "document_too_large"},
+	{service.ErrDocumentFormat, http.StatusUnsupportedMediaType, codes.InvalidArgument, "document_format"},
+	{service.ErrDocumentNotFound, http.StatusNotFound, codes.NotFound, "document_not_found"},
+	{service.ErrAMLCaseNotFound, http.StatusNotFound, codes.NotFound, "aml_case_not_found"},
+	{service.ErrAMLCaseNotOpen, http.StatusConflict, codes.FailedPrecondition, "aml_case_not_open"},
+	{service.ErrAMLCaseNotEscalated, http.StatusConflict, codes.FailedPrecondition, "aml_case_not_escalated"},
+	{service.ErrUnknownAMLCaseStatus, http.StatusBadRequest, codes.InvalidArgument, "unknown_aml_case_status"},
+
+	// Уведомления и webhook.
+	{service.ErrUnsupportedLocale, http.StatusUnprocessableEntity, codes.InvalidArgument, "unsupported_locale"},
+	{service.ErrUnknownNotificationKind, http.StatusUnprocessableEntity, codes.InvalidArgument, "unknown_notification_kind"},
+	{service.ErrUnknownChannel, http.StatusUnprocessableEntity, codes.InvalidArgument, "unknown_channel"},
+	{service.ErrNotificationNotFound, http.StatusNotFound, codes.NotFound, "notification_not_found"},
+	{service.ErrInvalidWebhookURL, http.StatusUnprocessableEntity, codes.InvalidArgument, "invalid_webhook_url"},
+	{service.ErrWebhookHostForbidden, http.StatusUnprocessableEntity, codes.InvalidArgument, "webhook_host_forbidden"},
+	{service.ErrUnknownEventType, http.StatusUnprocessableEntity, codes.InvalidArgument, "unknown_event_type"},
+	{service.ErrSubscriptionNotFound, http.StatusNotFound, codes.NotFound, "subscription_not_found"},
+	{service.ErrDeliveryNotFound, http.StatusNotFound, codes.NotFound, "delivery_not_found"},
+	{service.ErrInvalidStatusFilter, http.StatusBadRequest, codes.InvalidArgument, "invalid_status_filter"},
+}
+
+// Lookup возвращает ответ API для известной ошибки сервиса.
+func Lookup(err error) (Mapping, bool) {
+	for _, table := range [][]entry{wrappers, errorTable} {
+		for _, e := range table {
+			if errors.Is(err, e.err) {
+				return Mapping{Status: e.status, GRPC: e.grpc, Code: e.code}, true
+			}
+		}
+	}
+	return Mapping{}, false
+}
@@ -0,0 +1,40 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/therealadik/bank-api/internal/service"
+	"google.golang.org/grpc/codes"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Mapping
+		ok   bool
+	}{
+		{"ошибка сервиса", service.ErrInsufficientFunds,
+			Mapping{http.StatusUnprocessableEntity, codes.FailedPrecondition, "insufficient_funds"}, true},
+		{"обернутая ошибка сервиса", fmt.Errorf("перевод: %w", service.ErrAccountNotFound),
+			Mapping{http.StatusNotFound, codes.NotFound, "account_not_found"}, true},
+		// Причина тоже есть в таблице, но ответ должен описывать неисполненный платеж.
+		{"ошибка-обертка с известной причиной", fmt.Errorf("%w: %w", service.ErrPaymentFailed, service.ErrInsufficientFunds),
+			Mapping{http.StatusConflict, codes.FailedPrecondition, "payment_failed"}, true},
+		{"ошибка-обертка с неизвестной причиной", fmt.Errorf("%w: %w", service.ErrPaymentFailed, errors.New("сбой")),
+			Mapping{http.StatusConflict, codes.FailedPrecondition, "payment_failed"}, true},
+		{"неизвестная ошибка", errors.New("сбой"), Mapping{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Lookup(tt.err)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Lookup() = %+v, %v, ожидалось %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/iban"
+)
+
+type AccountNumberConfig struct {
+	CountryCode string
+	BankCode    string
+}
+
+// LoadAccountNumber читает параметры выпуска номеров счетов. Если IBAN_COUNTRY_CODE
+// не задан, номера выпускаются в национальном формате с контрольными цифрами MOD 97-10.
+// Неверные параметры останавливают запуск: иначе выпускались бы номера,
+// которые не проходят собственную проверку.
+func LoadAccountNumber() AccountNumberConfig {
+	cfg := AccountNumberConfig{
+		CountryCode: strings.ToUpper(strings.TrimSpace(getEnv("IBAN_COUNTRY_CODE", ""))),
+		BankCode:    strings.ToUpper(strings.TrimSpace(getEnv("BANK_CODE", "04525"))),
+	}
+
+	gen := iban.Generator{CountryCode: cfg.CountryCode, BankCode: cfg.BankCode}
+	if err := gen.Validate(); err != nil {
+		logrus.Fatalf("Неверные параметры номеров счетов (IBAN_COUNTRY_CODE=%q, BANK_CODE=%q): %v",
+			cfg.CountryCode, cfg.BankCode, err)
+	}
+
+	return cfg
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type AMLConfig struct {
+	JobInterval time.Duration
+
+	// StructuringThreshold — порог обязательного контроля. Операции на сумму
+	// от порога минус StructuringMargin (доля) до порога считаются дроблением,
+	// если их не меньше StructuringMinCount за StructuringWindow.
+	StructuringThreshold decimal.Decimal
+	StructuringMargin    decimal.Decimal
+	StructuringWindow    time.Duration
+	StructuringMinCount  int
+
+	// Транзит: за RapidWindow поступило не меньше RapidMinAmount и ушло
+	// не меньше RapidOutRatio от поступившего.
+	RapidWindow    time.Duration
+	RapidMinAmount decimal.Decimal
+	RapidOutRatio  decimal.Decimal
+
+	// Реактивация: по счету не было операций DormantPeriod, а за DormantWindow
+	// прошло операций на сумму не меньше DormantMinAmount.
+	DormantPeriod    time.Duration
+	DormantWindow    time.Duration
+	DormantMinAmount decimal.Decimal
+}
+
+func LoadAML() AMLConfig {
+	return AMLConfig{
+		JobInterval:          getEnvDuration("AML_JOB_INTERVAL", time.Hour),
+		StructuringThreshold: getEnvDecimal("AML_STRUCTURING_THRESHOLD", "1000000"),
+		StructuringMargin:    getEnvDecimal("AML_STRUCTURING_MARGIN", "0.1"),
+		StructuringWindow:    getEnvDuration("AML_STRUCTURING_WINDOW", 7*24*time.Hour),
+		StructuringMinCount:  getEnvInt("AML_STRUCTURING_MIN_COUNT", 3),
+		RapidWindow:          getEnvDuration("AML_RAPID_WINDOW", 24*time.Hour),
+		RapidMinAmount:       getEnvDecimal("AML_RAPID_MIN_AMOUNT", "100000"),
+		RapidOutRatio:        getEnvDecimal("AML_RAPID_OUT_RATIO", "0.9"),
+		DormantPeriod:        getEnvDuration("AML_DORMANT_PERIOD", 180*24*time.Hour),
+		DormantWindow:        getEnvDuration("AML_DORMANT_WINDOW", 24*time.Hour),
+		DormantMinAmount:     getEnvDecimal("AML_DORMANT_MIN_AMOUNT", "50000"),
+	}
+}
@@ -0,0 +1,17 @@
+package config
+
+import "time"
+
+// ApprovalConfig задает восстановление платежей, застрявших в PROCESSING
+// после набора кворума.
+type ApprovalConfig struct {
+	StaleAfter   time.Duration
+	PollInterval time.Duration
+}
+
+func LoadApproval() ApprovalConfig {
+	return ApprovalConfig{
+		StaleAfter:   getEnvDuration("APPROVAL_STALE_AFTER", 5*time.Minute),
+		PollInterval: getEnvDuration("APPROVAL_POLL_INTERVAL", time.Minute),
+	}
+}
@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+type AuthTokensConfig struct {
+	VerifyTTL time.Duration
+	ResetTTL  time.Duration
+	// BaseURL — адрес клиентского приложения для ссылок в письмах.
+	BaseURL string
+	// SendTimeout ограничивает фоновую отправку письма со ссылкой.
+	SendTimeout time.Duration
+	// CodeTTL — срок действия одноразового кода подтверждения.
+	CodeTTL time.Duration
+}
+
+func LoadAuthTokens() AuthTokensConfig {
+	return AuthTokensConfig{
+		VerifyTTL:   getEnvDuration("EMAIL_VERIFY_TTL", 24*time.Hour),
+		ResetTTL:    getEnvDuration("PASSWORD_RESET_TTL", time.Hour),
+		BaseURL:     getEnv("APP_BASE_URL", "http://localhost:8080"),
+		SendTimeout: getEnvDuration("AUTH_MAIL_TIMEOUT", 30*time.Second),
+		CodeTTL:     getEnvDuration("OTP_CODE_TTL", 10*time.Minute),
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type BatchConfig struct {
+	MaxRows      int
+	PollInterval time.Duration
+}
+
+func LoadBatch() BatchConfig {
+	return BatchConfig{
+		MaxRows:      getEnvInt("BATCH_MAX_ROWS", 1000),
+		PollInterval: getEnvDuration("BATCH_POLL_INTERVAL", 5*time.Second),
+	}
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnv(key, strconv.Itoa(defaultValue)))
+	if err != nil {
+		logrus.Warnf("Неверное значение %s, используется %d", key, defaultValue)
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+type BeneficiaryConfig struct {
+	CoolingOff time.Duration
+}
+
+func LoadBeneficiary() BeneficiaryConfig {
+	return BeneficiaryConfig{
+		CoolingOff: getEnvDuration("BENEFICIARY_COOLING_OFF", 24*time.Hour),
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/models/account"
+)
+
+// FXConfig — курсы конвертации при переводах между счетами в разных
+// валютах: сколько рублей стоит единица валюты.
+type FXConfig struct {
+	Rates map[account.Currency]decimal.Decimal
+}
+
+func LoadFX() FXConfig {
+	cfg := FXConfig{
+		Rates: map[account.Currency]decimal.Decimal{
+			account.RUB: decimal.NewFromInt(1),
+			account.USD: getEnvDecimal("FX_RATE_USD", "90"),
+			account.EUR: getEnvDecimal("FX_RATE_EUR", "100"),
+		},
+	}
+
+	for currency, rate := range cfg.Rates {
+		if !rate.IsPositive() {
+			logrus.Fatalf("Курс %s должен быть положительным, получено %s", currency, rate)
+		}
+	}
+
+	return cfg
+}
@@ -0,0 +1,12 @@
+package config
+
+type GRPCConfig struct {
+	// Port — порт gRPC API. Пустое значение отключает gRPC-сервер.
+	Port string
+}
+
+func LoadGRPC() GRPCConfig {
+	return GRPCConfig{
+		Port: getEnv("GRPC_PORT", "9090"),
+	}
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/models/account"
+)
+
+type ProductTerms struct {
+	InterestRate decimal.Decimal
+	DayCount     account.DayCount
+}
+
+type InterestConfig struct {
+	Products           map[account.Product]ProductTerms
+	DepositPenaltyRate decimal.Decimal
+	JobInterval        time.Duration
+}
+
+func LoadInterest() InterestConfig {
+	cfg := InterestConfig{
+		Products: map[account.Product]ProductTerms{
+			account.CURRENT: {
+				InterestRate: getEnvDecimal("CURRENT_INTEREST_RATE", "0"),
+				DayCount:     getEnvDayCount("CURRENT_DAY_COUNT", account.ACT365),
+			},
+			account.SAVINGS: {
+				InterestRate: getEnvDecimal("SAVINGS_INTEREST_RATE", "0.05"),
+				DayCount:     getEnvDayCount("SAVINGS_DAY_COUNT", account.ACT365),
+			},
+			account.DEPOSIT: {
+				InterestRate: getEnvDecimal("DEPOSIT_INTEREST_RATE", "0.08"),
+				DayCount:     getEnvDayCount("DEPOSIT_DAY_COUNT", account.THIRTY360),
+			},
+		},
+		DepositPenaltyRate: getEnvDecimal("DEPOSIT_PENALTY_RATE", "0.001"),
+		JobInterval:        getEnvDuration("INTEREST_JOB_INTERVAL", time.Hour),
+	}
+
+	logrus.Info("Конфигурация процентных ставок загружена")
+
+	return cfg
+}
+
+func getEnvDecimal(key, defaultValue string) decimal.Decimal {
+	value, err := decimal.NewFromString(getEnv(key, defaultValue))
+	if err != nil {
+		logrus.Warnf("Неверное значение %s, используется %s", key, defaultValue)
+		return decimal.RequireFromString(defaultValue)
+	}
+	return value
+}
+
+// getEnvDayCount читает конвенцию расчета дней. Неизвестное значение
+// останавливает запуск: иначе проценты молча считались бы по ACT/365.
+func getEnvDayCount(key string, defaultValue account.DayCount) account.DayCount {
+	value := account.DayCount(getEnv(key, string(defaultValue)))
+	if !value.Valid() {
+		logrus.Fatalf("Неверное значение %s: %q, допустимы %s и %s", key, value, account.ACT365, account.THIRTY360)
+	}
+	return value
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, defaultValue.String()))
+	if err != nil {
+		logrus.Warnf("Неверное значение %s, используется %s", key, defaultValue)
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,21 @@
+package config
+
+import "github.com/shopspring/decimal"
+
+type KYCConfig struct {
+	// BlobDir — каталог локального хранилища документов.
+	BlobDir string
+	// MaxDocumentSize — максимальный размер загружаемого документа в байтах.
+	MaxDocumentSize int64
+	// TransferLimit — переводы на сумму выше лимита доступны только
+	// клиентам с подтвержденной личностью.
+	TransferLimit decimal.Decimal
+}
+
+func LoadKYC() KYCConfig {
+	return KYCConfig{
+		BlobDir:         getEnv("BLOB_DIR", "./data/blobs"),
+		MaxDocumentSize: int64(getEnvInt("KYC_MAX_DOCUMENT_SIZE", 10<<20)),
+		TransferLimit:   getEnvDecimal("KYC_TRANSFER_LIMIT", "15000"),
+	}
+}
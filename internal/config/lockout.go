@@ -0,0 +1,48 @@
+package config
+
+import (
+	"time"
+
+	"github.com/therealadik/bank-api/internal/models/lockout"
+)
+
+type LockoutConfig struct {
+	// Account ограничивает попытки входа в одну учетную запись, IP — попытки
+	// входа с одного адреса в любые учетные записи, Card — проверки CVV
+	// по одной карте.
+	Account lockout.Policy
+	IP      lockout.Policy
+	Card    lockout.Policy
+	// PruneInterval — как часто удаляются попытки, вышедшие за окно.
+	PruneInterval time.Duration
+}
+
+func LoadLockout() LockoutConfig {
+	return LockoutConfig{
+		Account: lockout.Policy{
+			Window:       getEnvDuration("LOGIN_ACCOUNT_WINDOW", 15*time.Minute),
+			FreeAttempts: getEnvInt("LOGIN_ACCOUNT_FREE_ATTEMPTS", 3),
+			BaseDelay:    getEnvDuration("LOGIN_ACCOUNT_BASE_DELAY", time.Second),
+			MaxDelay:     getEnvDuration("LOGIN_ACCOUNT_MAX_DELAY", 30*time.Second),
+			LockAfter:    getEnvInt("LOGIN_ACCOUNT_LOCK_AFTER", 10),
+			LockDuration: getEnvDuration("LOGIN_ACCOUNT_LOCK_DURATION", 30*time.Minute),
+		},
+		IP: lockout.Policy{
+			Window:       getEnvDuration("LOGIN_IP_WINDOW", 15*time.Minute),
+			FreeAttempts: getEnvInt("LOGIN_IP_FREE_ATTEMPTS", 10),
+			BaseDelay:    getEnvDuration("LOGIN_IP_BASE_DELAY", time.Second),
+			MaxDelay:     getEnvDuration("LOGIN_IP_MAX_DELAY", 30*time.Second),
+			LockAfter:    getEnvInt("LOGIN_IP_LOCK_AFTER", 100),
+			LockDuration: getEnvDuration("LOGIN_IP_LOCK_DURATION", time.Hour),
+		},
+		Card: lockout.Policy{
+			Window:       getEnvDuration("CARD_CVV_WINDOW", 24*time.Hour),
+			FreeAttempts: getEnvInt("CARD_CVV_FREE_ATTEMPTS", 2),
+			BaseDelay:    getEnvDuration("CARD_CVV_BASE_DELAY", 5*time.Second),
+			MaxDelay:     getEnvDuration("CARD_CVV_MAX_DELAY", time.Minute),
+			LockAfter:    getEnvInt("CARD_CVV_LOCK_AFTER", 5),
+			LockDuration: getEnvDuration("CARD_CVV_LOCK_DURATION", 24*time.Hour),
+		},
+		PruneInterval: getEnvDuration("LOCKOUT_PRUNE_INTERVAL", time.Hour),
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type NotificationConfig struct {
+	DefaultLocale string
+	// LargeTransferThreshold — сумма перевода, начиная с которой отправителю
+	// приходит уведомление.
+	LargeTransferThreshold decimal.Decimal
+	MaxAttempts            int
+	BaseBackoff            time.Duration
+	MaxBackoff             time.Duration
+	PollInterval           time.Duration
+	BatchSize              int
+}
+
+func LoadNotification() NotificationConfig {
+	return NotificationConfig{
+		DefaultLocale:          getEnv("NOTIFY_DEFAULT_LOCALE", "ru"),
+		LargeTransferThreshold: getEnvDecimal("NOTIFY_LARGE_TRANSFER", "100000"),
+		MaxAttempts:            getEnvInt("NOTIFY_EMAIL_MAX_ATTEMPTS", 5),
+		BaseBackoff:            getEnvDuration("NOTIFY_EMAIL_BASE_BACKOFF", time.Minute),
+		MaxBackoff:             getEnvDuration("NOTIFY_EMAIL_MAX_BACKOFF", time.Hour),
+		PollInterval:           getEnvDuration("NOTIFY_POLL_INTERVAL", 10*time.Second),
+		BatchSize:              getEnvInt("NOTIFY_BATCH_SIZE", 50),
+	}
+}
+
+// SMTPConfig — параметры почтового сервера. Если SMTP_HOST не задан, письма
+// только пишутся в лог.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+func LoadSMTP() SMTPConfig {
+	return SMTPConfig{
+		Host:     getEnv("SMTP_HOST", ""),
+		Port:     getEnv("SMTP_PORT", "1025"),
+		Username: getEnv("SMTP_USERNAME", ""),
+		Password: getEnv("SMTP_PASSWORD", ""),
+		From:     getEnv("SMTP_FROM", "no-reply@bank.local"),
+		Timeout:  getEnvDuration("SMTP_TIMEOUT", 10*time.Second),
+	}
+}
+
+// SMSConfig — параметры SMS-шлюза. Если SMS_GATEWAY_URL не задан, сообщения
+// только пишутся в лог.
+type SMSConfig struct {
+	GatewayURL string
+	Token      string
+	Timeout    time.Duration
+}
+
+func LoadSMS() SMSConfig {
+	return SMSConfig{
+		GatewayURL: getEnv("SMS_GATEWAY_URL", ""),
+		Token:      getEnv("SMS_GATEWAY_TOKEN", ""),
+		Timeout:    getEnvDuration("SMS_TIMEOUT", 10*time.Second),
+	}
+}
@@ -0,0 +1,15 @@
+package config
+
+import "os"
+
+type OperatorConfig struct {
+	// Token — статический токен операторского API. Пустое значение
+	// отключает операторские маршруты.
+	Token string
+}
+
+func LoadOperator() OperatorConfig {
+	return OperatorConfig{
+		Token: os.Getenv("OPERATOR_TOKEN"),
+	}
+}
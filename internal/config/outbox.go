@@ -0,0 +1,42 @@
+package config
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type OutboxConfig struct {
+	// Publisher — способ доставки событий: file или memory. Публикатор memory
+	// хранит только последние MemoryLimit событий и годится лишь для отладки.
+	Publisher    string
+	FilePath     string
+	MemoryLimit  int
+	PollInterval time.Duration
+	BatchSize    int
+	// StreamRetryDelay — пауза перед повторной подпиской LISTEN/NOTIFY
+	// после обрыва соединения.
+	StreamRetryDelay time.Duration
+}
+
+func LoadOutbox() OutboxConfig {
+	cfg := OutboxConfig{
+		Publisher:        getEnv("OUTBOX_PUBLISHER", "file"),
+		FilePath:         getEnv("OUTBOX_FILE", "events.jsonl"),
+		MemoryLimit:      getEnvInt("OUTBOX_MEMORY_LIMIT", 1000),
+		PollInterval:     getEnvDuration("OUTBOX_POLL_INTERVAL", time.Second),
+		BatchSize:        getEnvInt("OUTBOX_BATCH_SIZE", 100),
+		StreamRetryDelay: getEnvDuration("OUTBOX_STREAM_RETRY_DELAY", 2*time.Second),
+	}
+
+	switch cfg.Publisher {
+	case "file", "memory":
+	default:
+		logrus.Fatalf("Неизвестный OUTBOX_PUBLISHER %q: ожидается file или memory", cfg.Publisher)
+	}
+	if cfg.MemoryLimit <= 0 {
+		logrus.Fatalf("OUTBOX_MEMORY_LIMIT должен быть положительным, получено %d", cfg.MemoryLimit)
+	}
+
+	return cfg
+}
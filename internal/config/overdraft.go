@@ -0,0 +1,17 @@
+package config
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+type OverdraftConfig struct {
+	MaxLimit decimal.Decimal
+	Rate     decimal.Decimal
+}
+
+func LoadOverdraft() OverdraftConfig {
+	return OverdraftConfig{
+		MaxLimit: getEnvDecimal("OVERDRAFT_MAX_LIMIT", "50000"),
+		Rate:     getEnvDecimal("OVERDRAFT_INTEREST_RATE", "0.25"),
+	}
+}
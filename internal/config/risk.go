@@ -0,0 +1,133 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/risk"
+)
+
+type RiskConfig struct {
+	// Thresholds — пороги из переменных окружения; значения из RulesFile
+	// применяются поверх них.
+	Thresholds risk.Thresholds
+	// RulesFile — JSON-файл с порогами, который перечитывается при изменении.
+	RulesFile      string
+	ReloadInterval time.Duration
+	// ChallengeTTL — сколько подтвержденный запрос проверки действует для
+	// повтора операции.
+	ChallengeTTL time.Duration
+}
+
+func LoadRisk() RiskConfig {
+	return RiskConfig{
+		Thresholds: risk.Thresholds{
+			VelocityWindow:  getEnvDuration("RISK_VELOCITY_WINDOW", time.Minute),
+			VelocityLimit:   getEnvInt("RISK_VELOCITY_LIMIT", 5),
+			VelocityScore:   getEnvInt("RISK_VELOCITY_SCORE", 60),
+			HistoryWindow:   getEnvDuration("RISK_HISTORY_WINDOW", 90*24*time.Hour),
+			SpikeMultiplier: getEnvDecimal("RISK_SPIKE_MULTIPLIER", "5"),
+			SpikeMinHistory: getEnvInt("RISK_SPIKE_MIN_HISTORY", 3),
+			SpikeScore:      getEnvInt("RISK_SPIKE_SCORE", 40),
+			NewPayeeAmount:  getEnvDecimal("RISK_NEW_PAYEE_AMOUNT", "50000"),
+			NewPayeeScore:   getEnvInt("RISK_NEW_PAYEE_SCORE", 50),
+			NewPayeeAge:     getEnvDuration("RISK_NEW_PAYEE_AGE", 30*24*time.Hour),
+			CVVWindow:       getEnvDuration("RISK_CVV_WINDOW", 15*time.Minute),
+			CVVFailureLimit: getEnvInt("RISK_CVV_FAILURE_LIMIT", 3),
+			CVVScore:        getEnvInt("RISK_CVV_SCORE", 90),
+			ChallengeScore:  getEnvInt("RISK_CHALLENGE_SCORE", 50),
+			BlockScore:      getEnvInt("RISK_BLOCK_SCORE", 90),
+		},
+		RulesFile:      getEnv("RISK_RULES_FILE", ""),
+		ReloadInterval: getEnvDuration("RISK_RELOAD_INTERVAL", 30*time.Second),
+		ChallengeTTL:   getEnvDuration("RISK_CHALLENGE_TTL", 10*time.Minute),
+	}
+}
+
+// riskFile — формат файла порогов. Незаданные поля сохраняют значения по
+// умолчанию, длительности записываются строками вида "1m" или "2160h".
+type riskFile struct {
+	VelocityWindow  *string          `json:"velocity_window"`
+	VelocityLimit   *int             `json:"velocity_limit"`
+	VelocityScore   *int             `json:"velocity_score"`
+	HistoryWindow   *string          `json:"history_window"`
+	SpikeMultiplier *decimal.Decimal `json:"spike_multiplier"`
+	SpikeMinHistory *int             `json:"spike_min_history"`
+	SpikeScore      *int             `json:"spike_score"`
+	NewPayeeAmount  *decimal.Decimal `json:"new_payee_amount"`
+	NewPayeeScore   *int             `json:"new_payee_score"`
+	NewPayeeAge     *string          `json:"new_payee_age"`
+	CVVWindow       *string          `json:"cvv_window"`
+	CVVFailureLimit *int             `json:"cvv_failure_limit"`
+	CVVScore        *int             `json:"cvv_score"`
+	ChallengeScore  *int             `json:"challenge_score"`
+	BlockScore      *int             `json:"block_score"`
+}
+
+// LoadRiskThresholds читает файл порогов и накладывает его на base.
+func LoadRiskThresholds(path string, base risk.Thresholds) (risk.Thresholds, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return base, err
+	}
+
+	var f riskFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return base, fmt.Errorf("неверный формат файла порогов: %w", err)
+	}
+
+	th := base
+	durations := []struct {
+		value *string
+		dst   *time.Duration
+	}{
+		{f.VelocityWindow, &th.VelocityWindow},
+		{f.HistoryWindow, &th.HistoryWindow},
+		{f.NewPayeeAge, &th.NewPayeeAge},
+		{f.CVVWindow, &th.CVVWindow},
+	}
+	for _, d := range durations {
+		if d.value == nil {
+			continue
+		}
+		if *d.dst, err = time.ParseDuration(*d.value); err != nil {
+			return base, fmt.Errorf("неверная длительность %q: %w", *d.value, err)
+		}
+	}
+
+	ints := []struct {
+		value *int
+		dst   *int
+	}{
+		{f.VelocityLimit, &th.VelocityLimit},
+		{f.VelocityScore, &th.VelocityScore},
+		{f.SpikeMinHistory, &th.SpikeMinHistory},
+		{f.SpikeScore, &th.SpikeScore},
+		{f.NewPayeeScore, &th.NewPayeeScore},
+		{f.CVVFailureLimit, &th.CVVFailureLimit},
+		{f.CVVScore, &th.CVVScore},
+		{f.ChallengeScore, &th.ChallengeScore},
+		{f.BlockScore, &th.BlockScore},
+	}
+	for _, i := range ints {
+		if i.value != nil {
+			*i.dst = *i.value
+		}
+	}
+
+	if f.SpikeMultiplier != nil {
+		th.SpikeMultiplier = *f.SpikeMultiplier
+	}
+	if f.NewPayeeAmount != nil {
+		th.NewPayeeAmount = *f.NewPayeeAmount
+	}
+
+	if th.ChallengeScore <= 0 || th.BlockScore < th.ChallengeScore {
+		return base, fmt.Errorf("порог блокировки должен быть не меньше порога проверки")
+	}
+
+	return th, nil
+}
@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+type SanctionsConfig struct {
+	// ListFile — файл санкционного списка (OFAC SDN CSV или XML ЕС).
+	// Пустое значение — список пуст, проверка ничего не находит.
+	ListFile string
+	// MatchThreshold — минимальная схожесть имени (0..1), с которой
+	// совпадение ставится на разбор.
+	MatchThreshold float64
+	ReloadInterval time.Duration
+	// PollInterval — как часто исполняются переводы, освобожденные оператором.
+	PollInterval time.Duration
+}
+
+func LoadSanctions() SanctionsConfig {
+	return SanctionsConfig{
+		ListFile:       getEnv("SANCTIONS_LIST_FILE", ""),
+		MatchThreshold: getEnvDecimal("SANCTIONS_MATCH_THRESHOLD", "0.9").InexactFloat64(),
+		ReloadInterval: getEnvDuration("SANCTIONS_RELOAD_INTERVAL", time.Hour),
+		PollInterval:   getEnvDuration("SANCTIONS_POLL_INTERVAL", 10*time.Second),
+	}
+}
@@ -0,0 +1,24 @@
+package config
+
+type TracingConfig struct {
+	// Exporter — куда отправлять трейсы: none, stdout или otlp.
+	Exporter string
+	// OTLPEndpoint — адрес OTLP/gRPC коллектора, например otel-collector:4317.
+	OTLPEndpoint string
+	// OTLPInsecure отключает TLS при подключении к коллектору.
+	OTLPInsecure bool
+	ServiceName  string
+	// SampleRatio — доля трейсов, начатых этим сервисом; решение вызывающего
+	// сервиса из traceparent соблюдается.
+	SampleRatio float64
+}
+
+func LoadTracing() TracingConfig {
+	return TracingConfig{
+		Exporter:     getEnv("TRACING_EXPORTER", "none"),
+		OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+		OTLPInsecure: getEnv("OTEL_EXPORTER_OTLP_INSECURE", "true") == "true",
+		ServiceName:  getEnv("OTEL_SERVICE_NAME", "bank-api"),
+		SampleRatio:  getEnvDecimal("TRACING_SAMPLE_RATIO", "1").InexactFloat64(),
+	}
+}
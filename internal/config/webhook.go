@@ -0,0 +1,30 @@
+package config
+
+import "time"
+
+type WebhookConfig struct {
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	Timeout      time.Duration
+	PollInterval time.Duration
+	BatchSize    int
+	// AllowHTTP разрешает адреса без TLS — только для локальной разработки.
+	AllowHTTP bool
+	// AllowPrivate разрешает адреса во внутренних сетях и loopback — только
+	// для локальной разработки.
+	AllowPrivate bool
+}
+
+func LoadWebhook() WebhookConfig {
+	return WebhookConfig{
+		MaxAttempts:  getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		BaseBackoff:  getEnvDuration("WEBHOOK_BASE_BACKOFF", 30*time.Second),
+		MaxBackoff:   getEnvDuration("WEBHOOK_MAX_BACKOFF", 6*time.Hour),
+		Timeout:      getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		PollInterval: getEnvDuration("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+		BatchSize:    getEnvInt("WEBHOOK_BATCH_SIZE", 50),
+		AllowHTTP:    getEnv("WEBHOOK_ALLOW_HTTP", "false") == "true",
+		AllowPrivate: getEnv("WEBHOOK_ALLOW_PRIVATE", "false") == "true",
+	}
+}
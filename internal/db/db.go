@@ -7,6 +7,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/tracing"
 )
 
 func BuildDSN(cfg config.DBConfig) string {
@@ -26,6 +27,7 @@ func New(ctx context.Context, cfg config.DBConfig) (*pgxpool.Pool, error) {
 	poolConfig.MinConns = 5
 	poolConfig.MaxConnLifetime = 1 * time.Hour
 	poolConfig.MaxConnIdleTime = 30 * time.Minute
+	poolConfig.ConnConfig.Tracer = tracing.QueryTracer{}
 
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
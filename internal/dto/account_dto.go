@@ -7,25 +7,55 @@ import (
 )
 
 type CreateAccountRequest struct {
-	Currency account.Currency `json:"currency"`
+	Currency account.Currency `json:"currency" validate:"required,oneof=RUB"`
+	Product  account.Product  `json:"product"`
 }
 
 type UpdateBalanceRequest struct {
-	Amount decimal.Decimal `json:"amount"`
+	Amount decimal.Decimal `json:"amount" validate:"required"`
+}
+
+type OverdraftRequest struct {
+	Limit decimal.Decimal `json:"limit"`
+}
+
+type ResolveOverdraftRequest struct {
+	Comment string `json:"comment"`
 }
 
+// TransferRequest принимает счета по внутреннему ID или по внешнему номеру;
+// ID имеет приоритет, если заданы оба. Получателя можно указать через
+// BeneficiaryID из сохраненного списка. Чужой счет, указанный по ID или
+// номеру, тоже должен быть в этом списке и пройти период охлаждения.
 type TransferRequest struct {
-	FromAccountID int64           `json:"from_account_id"`
-	ToAccountID   int64           `json:"to_account_id"`
-	Amount        decimal.Decimal `json:"amount"`
+	FromAccountID     int64               `json:"from_account_id"`
+	FromAccountNumber string              `json:"from_account_number"`
+	ToAccountID       int64               `json:"to_account_id"`
+	ToAccountNumber   string              `json:"to_account_number"`
+	BeneficiaryID     int64               `json:"beneficiary_id"`
+	Amount            decimal.Decimal     `json:"amount" validate:"positive"`
+	ExpectedFee       decimal.NullDecimal `json:"expected_fee"`
+}
+
+type TransferResponse struct {
+	Status string          `json:"status"`
+	Amount decimal.Decimal `json:"amount"`
+	Fee    decimal.Decimal `json:"fee"`
+	Total  decimal.Decimal `json:"total"`
 }
 
 type AccountResponse struct {
-	ID        int64            `json:"id"`
-	UserID    int64            `json:"user_id"`
-	Balance   decimal.Decimal  `json:"balance"`
-	Currency  account.Currency `json:"currency"`
-	CreatedAt string           `json:"created_at"`
+	ID             int64            `json:"id"`
+	AccountNumber  string           `json:"account_number"`
+	UserID         int64            `json:"user_id"`
+	Balance        decimal.Decimal  `json:"balance"`
+	Currency       account.Currency `json:"currency"`
+	Product        account.Product  `json:"product"`
+	InterestRate   decimal.Decimal  `json:"interest_rate"`
+	DayCount       account.DayCount `json:"day_count"`
+	OverdraftLimit decimal.Decimal  `json:"overdraft_limit"`
+	OverdraftRate  decimal.Decimal  `json:"overdraft_rate"`
+	CreatedAt      string           `json:"created_at"`
 }
 
 type TransactionResponse struct {
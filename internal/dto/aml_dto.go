@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"github.com/therealadik/bank-api/internal/models/aml"
+	"github.com/therealadik/bank-api/internal/models/transaction"
+)
+
+type AMLCaseResponse struct {
+	*aml.Case
+	Transactions []*transaction.Transaction `json:"transactions"`
+}
+
+type AMLReviewRequest struct {
+	Comment string `json:"comment"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models/approval"
+)
+
+type AddMemberRequest struct {
+	Email string        `json:"email" validate:"required,email"`
+	Role  approval.Role `json:"role" validate:"required"`
+}
+
+type MemberResponse struct {
+	UserID    int64         `json:"user_id"`
+	Role      approval.Role `json:"role"`
+	CreatedAt string        `json:"created_at"`
+}
+
+type MemberListResponse struct {
+	Members []MemberResponse `json:"members"`
+}
+
+type PolicyBand struct {
+	AmountFrom        decimal.Decimal `json:"amount_from"`
+	RequiredApprovals int             `json:"required_approvals"`
+}
+
+type ApprovalPolicyRequest struct {
+	Bands []PolicyBand `json:"bands" validate:"required"`
+}
+
+type ApprovalPolicyResponse struct {
+	Bands []PolicyBand `json:"bands"`
+}
+
+type DecisionRequest struct {
+	Comment string `json:"comment"`
+}
+
+type DecisionResponse struct {
+	UserID    int64             `json:"user_id"`
+	Decision  approval.Decision `json:"decision"`
+	Comment   string            `json:"comment,omitempty"`
+	CreatedAt string            `json:"created_at"`
+}
+
+type PendingPaymentResponse struct {
+	ID                int64              `json:"id"`
+	FromAccountID     int64              `json:"from_account_id"`
+	ToAccountID       int64              `json:"to_account_id"`
+	InitiatorID       int64              `json:"initiator_id"`
+	Amount            decimal.Decimal    `json:"amount"`
+	RequiredApprovals int                `json:"required_approvals"`
+	Status            approval.Status    `json:"status"`
+	Decisions         []DecisionResponse `json:"decisions,omitempty"`
+	CreatedAt         string             `json:"created_at"`
+	UpdatedAt         string             `json:"updated_at"`
+}
+
+type PendingPaymentListResponse struct {
+	Payments []PendingPaymentResponse `json:"payments"`
+}
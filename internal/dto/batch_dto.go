@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models/batch"
+)
+
+type BatchItemRequest struct {
+	Account   string          `json:"account"`
+	Amount    decimal.Decimal `json:"amount"`
+	Reference string          `json:"reference"`
+}
+
+type CreateBatchRequest struct {
+	FromAccountID     int64              `json:"from_account_id"`
+	FromAccountNumber string             `json:"from_account_number"`
+	Mode              batch.Mode         `json:"mode"`
+	Items             []BatchItemRequest `json:"items"`
+}
+
+type BatchItemResponse struct {
+	RowNumber   int              `json:"row_number"`
+	Account     string           `json:"account"`
+	ToAccountID *int64           `json:"to_account_id,omitempty"`
+	Amount      decimal.Decimal  `json:"amount"`
+	Fee         decimal.Decimal  `json:"fee"`
+	Reference   string           `json:"reference,omitempty"`
+	Status      batch.ItemStatus `json:"status"`
+	Error       string           `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	ID            int64               `json:"id"`
+	FromAccountID int64               `json:"from_account_id"`
+	Mode          batch.Mode          `json:"mode"`
+	Status        batch.Status        `json:"status"`
+	ItemCount     int                 `json:"item_count"`
+	ValidCount    int                 `json:"valid_count"`
+	Amount        decimal.Decimal     `json:"amount"`
+	Fee           decimal.Decimal     `json:"fee"`
+	Total         decimal.Decimal     `json:"total"`
+	Items         []BatchItemResponse `json:"items,omitempty"`
+	CreatedAt     string              `json:"created_at"`
+}
+
+type BatchListResponse struct {
+	Batches []BatchResponse `json:"batches"`
+}
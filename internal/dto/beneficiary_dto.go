@@ -0,0 +1,34 @@
+package dto
+
+import "github.com/therealadik/bank-api/internal/models/beneficiary"
+
+type CreateBeneficiaryRequest struct {
+	Nickname      string `json:"nickname" validate:"required,max=100"`
+	AccountID     int64  `json:"account_id"`
+	AccountNumber string `json:"account_number"`
+}
+
+type UpdateBeneficiaryRequest struct {
+	Nickname string `json:"nickname" validate:"required,max=100"`
+}
+
+// VerifyBeneficiaryRequest — пароль и код из письма, отправленного через
+// POST /beneficiaries/{id}/verify/code.
+type VerifyBeneficiaryRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+type BeneficiaryResponse struct {
+	ID            int64              `json:"id"`
+	Nickname      string             `json:"nickname"`
+	AccountID     int64              `json:"account_id"`
+	AccountNumber string             `json:"account_number"`
+	Status        beneficiary.Status `json:"status"`
+	AvailableAt   string             `json:"available_at"`
+	CreatedAt     string             `json:"created_at"`
+}
+
+type BeneficiaryListResponse struct {
+	Beneficiaries []BeneficiaryResponse `json:"beneficiaries"`
+}
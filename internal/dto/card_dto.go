@@ -1,7 +1,9 @@
 package dto
 
+import "github.com/shopspring/decimal"
+
 type CreateCardRequest struct {
-	PGPKey string `json:"pgp_key"`
+	PGPKey string `json:"pgp_key" validate:"required"`
 }
 
 type CreateCardResponse struct {
@@ -30,14 +32,17 @@ type CardListResponse struct {
 }
 
 type CardPaymentRequest struct {
-	CardID int64  `json:"card_id"`
-	Amount string `json:"amount"`
-	CVV    string `json:"cvv"`
-	PGPKey string `json:"pgp_key"`
+	CardID      int64               `json:"card_id" validate:"required"`
+	Amount      string              `json:"amount" validate:"required,decimal"`
+	CVV         string              `json:"cvv" validate:"required,digits,len=3"`
+	PGPKey      string              `json:"pgp_key" validate:"required"`
+	ExpectedFee decimal.NullDecimal `json:"expected_fee"`
 }
 
 type CardPaymentResponse struct {
-	Success     bool   `json:"success"`
-	PaymentID   string `json:"payment_id,omitempty"`
-	Description string `json:"description,omitempty"`
+	Success     bool            `json:"success"`
+	PaymentID   string          `json:"payment_id,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Fee         decimal.Decimal `json:"fee"`
+	Total       decimal.Decimal `json:"total"`
 }
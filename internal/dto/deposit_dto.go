@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/deposit"
+)
+
+type OpenDepositRequest struct {
+	SourceAccountID     int64           `json:"source_account_id"`
+	SourceAccountNumber string          `json:"source_account_number"`
+	Amount              decimal.Decimal `json:"amount" validate:"positive"`
+	TermMonths          int             `json:"term_months" validate:"required"`
+	AutoRollover        bool            `json:"auto_rollover"`
+}
+
+type DepositResponse struct {
+	ID              int64            `json:"id"`
+	AccountID       int64            `json:"account_id"`
+	SourceAccountID int64            `json:"source_account_id"`
+	Principal       decimal.Decimal  `json:"principal"`
+	InterestRate    decimal.Decimal  `json:"interest_rate"`
+	PenaltyRate     decimal.Decimal  `json:"penalty_rate"`
+	DayCount        account.DayCount `json:"day_count"`
+	TermMonths      int              `json:"term_months"`
+	StartDate       string           `json:"start_date"`
+	MaturityDate    string           `json:"maturity_date"`
+	AutoRollover    bool             `json:"auto_rollover"`
+	Status          deposit.Status   `json:"status"`
+	CreatedAt       string           `json:"created_at"`
+}
+
+type DepositListResponse struct {
+	Deposits []DepositResponse `json:"deposits"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models/fee"
+)
+
+type FeeRuleResponse struct {
+	ID         int64               `json:"id"`
+	Operation  fee.Operation       `json:"operation"`
+	VolumeFrom decimal.Decimal     `json:"volume_from"`
+	Fixed      decimal.Decimal     `json:"fixed"`
+	Percent    decimal.Decimal     `json:"percent"`
+	MinAmount  decimal.Decimal     `json:"min_amount"`
+	MaxAmount  decimal.NullDecimal `json:"max_amount"`
+	Active     bool                `json:"active"`
+}
+
+type FeeRuleListResponse struct {
+	Rules []FeeRuleResponse `json:"rules"`
+}
+
+type FeeQuoteResponse struct {
+	Operation fee.Operation   `json:"operation"`
+	Amount    decimal.Decimal `json:"amount"`
+	Fee       decimal.Decimal `json:"fee"`
+	Total     decimal.Decimal `json:"total"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+type ReadinessResponse struct {
+	Status           string `json:"status"`
+	Database         string `json:"database"`
+	MigrationVersion uint   `json:"migration_version"`
+	ExpectedVersion  uint   `json:"expected_version"`
+	Dirty            bool   `json:"dirty"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import "github.com/therealadik/bank-api/internal/models/kyc"
+
+type UpdateProfileRequest struct {
+	FullName string `json:"full_name"`
+	// DateOfBirth — дата в формате YYYY-MM-DD.
+	DateOfBirth string `json:"date_of_birth"`
+	// Citizenship — двухбуквенный код страны ISO 3166-1.
+	Citizenship string `json:"citizenship"`
+	Address     string `json:"address"`
+}
+
+type ProfileResponse struct {
+	UserID       int64      `json:"user_id"`
+	FullName     *string    `json:"full_name"`
+	Phone        *string    `json:"phone"`
+	DateOfBirth  *string    `json:"date_of_birth"`
+	Citizenship  string     `json:"citizenship"`
+	Address      string     `json:"address"`
+	KYCStatus    kyc.Status `json:"kyc_status"`
+	RejectReason string     `json:"reject_reason,omitempty"`
+	SubmittedAt  *string    `json:"submitted_at,omitempty"`
+	ReviewedAt   *string    `json:"reviewed_at,omitempty"`
+}
+
+type KYCDocumentResponse struct {
+	ID          int64            `json:"id"`
+	Type        kyc.DocumentType `json:"type"`
+	FileName    string           `json:"file_name"`
+	ContentType string           `json:"content_type"`
+	Size        int64            `json:"size"`
+	SHA256      string           `json:"sha256"`
+	CreatedAt   string           `json:"created_at"`
+}
+
+type RejectKYCRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "github.com/therealadik/bank-api/internal/models/notification"
+
+type NotificationResponse struct {
+	ID          int64                     `json:"id"`
+	Kind        notification.Kind         `json:"kind"`
+	Subject     string                    `json:"subject"`
+	Body        string                    `json:"body"`
+	Read        bool                      `json:"read"`
+	EmailStatus *notification.EmailStatus `json:"email_status,omitempty"`
+	CreatedAt   string                    `json:"created_at"`
+}
+
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	Unread        int                    `json:"unread"`
+}
+
+type NotificationPreference struct {
+	Kind    notification.Kind    `json:"kind"`
+	Channel notification.Channel `json:"channel"`
+	Enabled bool                 `json:"enabled"`
+}
+
+type UpdateNotificationSettingsRequest struct {
+	Locale      notification.Locale      `json:"locale"`
+	Preferences []NotificationPreference `json:"preferences"`
+}
+
+// NotificationSettingsResponse содержит предпочтения для всех видов
+// уведомлений и каналов, включая не заданные явно.
+type NotificationSettingsResponse struct {
+	Locale      notification.Locale      `json:"locale"`
+	Preferences []NotificationPreference `json:"preferences"`
+}
+
+type MarkAllReadResponse struct {
+	Marked int `json:"marked"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models/p2p"
+)
+
+type P2PTransferRequest struct {
+	FromAccountID     int64           `json:"from_account_id"`
+	FromAccountNumber string          `json:"from_account_number"`
+	Recipient         string          `json:"recipient" validate:"required"`
+	Amount            decimal.Decimal `json:"amount" validate:"positive"`
+}
+
+type P2PTransferResponse struct {
+	ID            int64           `json:"id"`
+	FromAccountID int64           `json:"from_account_id"`
+	RecipientName string          `json:"recipient_name,omitempty"`
+	Amount        decimal.Decimal `json:"amount"`
+	Fee           decimal.Decimal `json:"fee"`
+	Total         decimal.Decimal `json:"total"`
+	Status        p2p.Status      `json:"status"`
+	ExpiresAt     string          `json:"expires_at"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type ConfirmChallengeRequest struct {
+	Password string `json:"password" validate:"required"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+// HeldTransferResponse возвращается со статусом 202, когда перевод задержан
+// до проверки получателя по санкционным спискам.
+type HeldTransferResponse struct {
+	Status     string          `json:"status"`
+	TransferID int64           `json:"held_transfer_id"`
+	Amount     decimal.Decimal `json:"amount"`
+	Message    string          `json:"message"`
+}
+
+type ResolveHitRequest struct {
+	Comment string `json:"comment"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "github.com/therealadik/bank-api/internal/models/kyc"
+
+type UpdateContactsRequest struct {
+	Phone        *string `json:"phone"`
+	Discoverable *bool   `json:"discoverable"`
+}
+
+// ConfirmCodeRequest — одноразовый код подтверждения из SMS или письма.
+type ConfirmCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type UserResponse struct {
+	ID            int64      `json:"id"`
+	Email         string     `json:"email"`
+	FullName      *string    `json:"full_name,omitempty"`
+	Phone         *string    `json:"phone,omitempty"`
+	PhoneVerified bool       `json:"phone_verified"`
+	Discoverable  bool       `json:"discoverable"`
+	KYCStatus     kyc.Status `json:"kyc_status"`
+	CreatedAt     string     `json:"created_at"`
+}
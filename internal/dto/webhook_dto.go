@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/therealadik/bank-api/internal/models/webhook"
+)
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required"`
+	EventTypes []string `json:"event_types" validate:"required"`
+}
+
+type WebhookResponse struct {
+	ID         int64    `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+	// Secret возвращается только при создании подписки.
+	Secret    string `json:"secret,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+type WebhookListResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+type WebhookAttemptResponse struct {
+	StatusCode *int   `json:"status_code"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int    `json:"duration_ms"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int64                    `json:"id"`
+	SubscriptionID int64                    `json:"subscription_id"`
+	EventID        int64                    `json:"event_id"`
+	EventType      string                   `json:"event_type"`
+	Status         webhook.Status           `json:"status"`
+	Attempts       int                      `json:"attempts"`
+	NextAttemptAt  string                   `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int                     `json:"last_status_code"`
+	LastError      string                   `json:"last_error,omitempty"`
+	Payload        json.RawMessage          `json:"payload,omitempty"`
+	AttemptLog     []WebhookAttemptResponse `json:"attempt_log,omitempty"`
+	CreatedAt      string                   `json:"created_at"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+}
@@ -0,0 +1,107 @@
+// Package events описывает доменные события, которые записываются в outbox
+// в одной транзакции с изменением состояния и затем доставляются внешним
+// потребителям через EventPublisher.
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type Type string
+
+const (
+	ACCOUNT_CREATED    Type = "AccountCreated"
+	BALANCE_UPDATED    Type = "BalanceUpdated"
+	TRANSFER_COMPLETED Type = "TransferCompleted"
+	CARD_ISSUED        Type = "CardIssued"
+	PAYMENT_AUTHORIZED Type = "PaymentAuthorized"
+	FEE_CHARGED        Type = "FeeCharged"
+	USER_REGISTERED    Type = "UserRegistered"
+	// OVERDRAFT_LIMIT_BREACHED — начисленные проценты вывели баланс за
+	// одобренный лимит овердрафта.
+	OVERDRAFT_LIMIT_BREACHED Type = "OverdraftLimitBreached"
+)
+
+// Тип агрегата определяет границу упорядочивания: события одного агрегата
+// доставляются в порядке записи.
+const (
+	AggregateAccount = "account"
+	AggregateCard    = "card"
+	AggregateUser    = "user"
+	AggregateCredit  = "credit"
+)
+
+type Event struct {
+	ID            int64           `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   int64           `json:"aggregate_id"`
+	Type          Type            `json:"type"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+func New(aggregateType string, aggregateID int64, eventType Type, payload any) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Type:          eventType,
+		Payload:       data,
+	}, nil
+}
+
+type AccountCreated struct {
+	AccountID     int64  `json:"account_id"`
+	UserID        int64  `json:"user_id"`
+	AccountNumber string `json:"account_number"`
+	Currency      string `json:"currency"`
+	Product       string `json:"product"`
+}
+
+type BalanceUpdated struct {
+	AccountID int64           `json:"account_id"`
+	Amount    decimal.Decimal `json:"amount"`
+}
+
+type TransferCompleted struct {
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	Fee           decimal.Decimal `json:"fee"`
+}
+
+type CardIssued struct {
+	CardID int64 `json:"card_id"`
+	UserID int64 `json:"user_id"`
+}
+
+type PaymentAuthorized struct {
+	AccountID int64           `json:"account_id"`
+	Amount    decimal.Decimal `json:"amount"`
+	Fee       decimal.Decimal `json:"fee"`
+}
+
+type FeeCharged struct {
+	AccountID int64           `json:"account_id"`
+	Operation string          `json:"operation"`
+	Amount    decimal.Decimal `json:"amount"`
+	Fee       decimal.Decimal `json:"fee"`
+}
+
+type UserRegistered struct {
+	UserID int64  `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+type OverdraftLimitBreached struct {
+	AccountID      int64           `json:"account_id"`
+	Balance        decimal.Decimal `json:"balance"`
+	OverdraftLimit decimal.Decimal `json:"overdraft_limit"`
+}
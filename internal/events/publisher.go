@@ -0,0 +1,99 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// EventPublisher доставляет события потребителям. Доставка «как минимум
+// один раз»: при ошибке событие будет отправлено повторно, поэтому
+// потребители должны быть идемпотентны по Event.ID.
+type EventPublisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// MemoryPublisher хранит последние limit событий в памяти процесса, более
+// старые вытесняются. Подходит для локальной разработки и отладки.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	limit  int
+	events []Event
+}
+
+func NewMemoryPublisher(limit int) *MemoryPublisher {
+	return &MemoryPublisher{limit: limit}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, e Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.events) >= p.limit {
+		n := copy(p.events, p.events[len(p.events)-p.limit+1:])
+		p.events = p.events[:n]
+	}
+	p.events = append(p.events, e)
+	return nil
+}
+
+func (p *MemoryPublisher) Events() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Event(nil), p.events...)
+}
+
+// FilePublisher дописывает события в файл в формате JSON Lines.
+type FilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FilePublisher{file: file}, nil
+}
+
+func (p *FilePublisher) Publish(_ context.Context, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return p.file.Sync()
+}
+
+func (p *FilePublisher) Close() error {
+	return p.file.Close()
+}
+
+// FanOutPublisher передает событие всем публикаторам по очереди. Ошибка
+// любого из них приводит к повторной публикации события во все публикаторы,
+// что допустимо при доставке «как минимум один раз».
+type FanOutPublisher struct {
+	publishers []EventPublisher
+}
+
+func NewFanOutPublisher(publishers ...EventPublisher) *FanOutPublisher {
+	return &FanOutPublisher{publishers: publishers}
+}
+
+func (p *FanOutPublisher) Publish(ctx context.Context, e Event) error {
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
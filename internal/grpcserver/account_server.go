@@ -0,0 +1,265 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/events"
+	bankv1 "github.com/therealadik/bank-api/internal/gen/bank/v1"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/transaction"
+	"github.com/therealadik/bank-api/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// transactionEventTypes — события outbox, которые отдает WatchTransactions.
+var transactionEventTypes = map[events.Type]bool{
+	events.BALANCE_UPDATED:    true,
+	events.TRANSFER_COMPLETED: true,
+	events.PAYMENT_AUTHORIZED: true,
+	events.FEE_CHARGED:        true,
+}
+
+type AccountServer struct {
+	bankv1.UnimplementedAccountServiceServer
+
+	accountService  *service.AccountService
+	approvalService *service.ApprovalService
+	streamService   *service.StreamService
+	logger          *logrus.Logger
+}
+
+func NewAccountServer(accountService *service.AccountService, approvalService *service.ApprovalService,
+	streamService *service.StreamService, logger *logrus.Logger) *AccountServer {
+	return &AccountServer{
+		accountService:  accountService,
+		approvalService: approvalService,
+		streamService:   streamService,
+		logger:          logger,
+	}
+}
+
+func (s *AccountServer) ListAccounts(ctx context.Context, _ *bankv1.ListAccountsRequest) (*bankv1.ListAccountsResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, statusError(ctx, codes.Unauthenticated, "unauthorized")
+	}
+
+	accounts, err := s.accountService.GetAccountsByUserID(ctx, userID)
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err, "Не удалось получить счета")
+	}
+
+	resp := &bankv1.ListAccountsResponse{Accounts: make([]*bankv1.Account, 0, len(accounts))}
+	for _, acc := range accounts {
+		resp.Accounts = append(resp.Accounts, accountMessage(acc))
+	}
+	return resp, nil
+}
+
+func (s *AccountServer) GetAccount(ctx context.Context, req *bankv1.GetAccountRequest) (*bankv1.Account, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, statusError(ctx, codes.Unauthenticated, "unauthorized")
+	}
+
+	acc, err := s.accountService.GetAccountByID(ctx, req.GetAccountId(), userID)
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err, "Не удалось получить счет")
+	}
+	return accountMessage(acc), nil
+}
+
+func (s *AccountServer) ListTransactions(ctx context.Context, req *bankv1.ListTransactionsRequest) (*bankv1.ListTransactionsResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, statusError(ctx, codes.Unauthenticated, "unauthorized")
+	}
+
+	transactions, err := s.accountService.GetTransactionsByAccountID(ctx, req.GetAccountId(), userID)
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err, "Не удалось получить транзакции")
+	}
+
+	resp := &bankv1.ListTransactionsResponse{Transactions: make([]*bankv1.Transaction, 0, len(transactions))}
+	for _, t := range transactions {
+		resp.Transactions = append(resp.Transactions, transactionMessage(t))
+	}
+	return resp, nil
+}
+
+// Transfer выполняет перевод так же, как POST /transfer: переводы
+// с совместных счетов ждут подтверждения, а переводы получателям под
+// проверкой санкционных списков задерживаются.
+func (s *AccountServer) Transfer(ctx context.Context, req *bankv1.TransferRequest) (*bankv1.TransferResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, statusError(ctx, codes.Unauthenticated, "unauthorized")
+	}
+
+	amount, err := decimal.NewFromString(req.GetAmount())
+	if err != nil {
+		return nil, fieldError(ctx, "amount", "validation.decimal")
+	}
+
+	var expectedFee decimal.NullDecimal
+	if req.ExpectedFee != nil {
+		fee, err := decimal.NewFromString(req.GetExpectedFee())
+		if err != nil {
+			return nil, fieldError(ctx, "expected_fee", "validation.decimal")
+		}
+		expectedFee = decimal.NewNullDecimal(fee)
+	}
+
+	fromID, err := s.resolveAccount(ctx, req.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	toID, err := s.resolveAccount(ctx, req.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	quote, payment, err := s.approvalService.SubmitTransfer(ctx, fromID, toID, userID, amount, expectedFee)
+	if err != nil {
+		var held *service.HeldTransferError
+		if errors.As(err, &held) {
+			s.logger.WithContext(ctx).Warnf("Перевод задержан до проверки получателя: %v", held)
+			return &bankv1.TransferResponse{Result: &bankv1.TransferResponse_Held{Held: &bankv1.HeldTransfer{
+				TransferId: held.Transfer.ID,
+				Amount:     held.Transfer.Amount.String(),
+				Status:     string(held.Transfer.Status),
+			}}}, nil
+		}
+		return nil, toStatus(ctx, s.logger, err, "Не удалось выполнить перевод")
+	}
+
+	if payment != nil {
+		return &bankv1.TransferResponse{Result: &bankv1.TransferResponse_PendingApproval{PendingApproval: &bankv1.PendingApproval{
+			PaymentId:         payment.ID,
+			RequiredApprovals: int32(payment.RequiredApprovals),
+			Status:            string(payment.Status),
+		}}}, nil
+	}
+
+	return &bankv1.TransferResponse{Result: &bankv1.TransferResponse_Completed{Completed: &bankv1.TransferCompleted{
+		Amount: quote.Amount.String(),
+		Fee:    quote.Fee.String(),
+		Total:  quote.Total.String(),
+	}}}, nil
+}
+
+func (s *AccountServer) resolveAccount(ctx context.Context, ref *bankv1.AccountRef) (int64, error) {
+	if ref.GetId() == 0 && ref.GetNumber() == "" {
+		return 0, statusError(ctx, codes.InvalidArgument, "invalid_account_ref")
+	}
+
+	id, err := s.accountService.ResolveAccountID(ctx, ref.GetId(), ref.GetNumber())
+	if err != nil {
+		return 0, toStatus(ctx, s.logger, err, "Не удалось найти счет")
+	}
+	return id, nil
+}
+
+// WatchTransactions отдает события по счетам пользователя. Как и поток SSE,
+// подписка оформляется до чтения истории, чтобы не потерять события между
+// повтором и живым потоком.
+func (s *AccountServer) WatchTransactions(req *bankv1.WatchTransactionsRequest,
+	stream bankv1.AccountService_WatchTransactionsServer) error {
+	ctx := stream.Context()
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return statusError(ctx, codes.Unauthenticated, "unauthorized")
+	}
+
+	if req.GetAfterEventId() < 0 {
+		return statusError(ctx, codes.InvalidArgument, "invalid_last_event_id")
+	}
+
+	sub := s.streamService.Subscribe(userID)
+	defer s.streamService.Unsubscribe(sub)
+
+	var replay []service.StreamMessage
+	if req.GetAfterEventId() > 0 {
+		replay, err = s.streamService.Replay(ctx, userID, req.GetAfterEventId())
+		if err != nil {
+			return toStatus(ctx, s.logger, err, "Не удалось открыть поток событий")
+		}
+	}
+
+	sent := make(map[int64]bool, len(replay))
+	for _, msg := range replay {
+		sent[msg.Event.ID] = true
+		if !transactionEventTypes[msg.Event.Type] {
+			continue
+		}
+		if err := stream.Send(transactionEventMessage(msg)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-sub.C:
+			if !ok {
+				return statusError(ctx, codes.Unavailable, "stream_lagging")
+			}
+			if sent[msg.Event.ID] || !transactionEventTypes[msg.Event.Type] {
+				continue
+			}
+			if err := stream.Send(transactionEventMessage(msg)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func accountMessage(acc *account.Account) *bankv1.Account {
+	msg := &bankv1.Account{
+		Id:             acc.ID,
+		Balance:        acc.Balance.String(),
+		Currency:       string(acc.Currency),
+		Product:        string(acc.Product),
+		OverdraftLimit: acc.OverdraftLimit.String(),
+		CreatedAt:      timestamppb.New(acc.CreatedAt),
+	}
+	if acc.AccountNumber != nil {
+		msg.AccountNumber = *acc.AccountNumber
+	}
+	return msg
+}
+
+func transactionMessage(t *transaction.Transaction) *bankv1.Transaction {
+	return &bankv1.Transaction{
+		Id:        t.ID,
+		AccountId: t.AccountID,
+		Amount:    t.Amount.String(),
+		Type:      string(t.Type),
+		Status:    string(t.Status),
+		CreatedAt: timestamppb.New(t.CreatedAt),
+	}
+}
+
+func transactionEventMessage(msg service.StreamMessage) *bankv1.TransactionEvent {
+	event := &bankv1.TransactionEvent{
+		EventId:       msg.Event.ID,
+		Type:          string(msg.Event.Type),
+		AggregateType: msg.Event.AggregateType,
+		AggregateId:   msg.Event.AggregateID,
+		Payload:       string(msg.Event.Payload),
+		CreatedAt:     timestamppb.New(msg.Event.CreatedAt),
+	}
+	for _, b := range msg.Balances {
+		event.Balances = append(event.Balances, &bankv1.AccountBalance{
+			AccountId: b.AccountID,
+			Balance:   b.Balance.String(),
+		})
+	}
+	return event
+}
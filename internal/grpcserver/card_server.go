@@ -0,0 +1,97 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	bankv1 "github.com/therealadik/bank-api/internal/gen/bank/v1"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/models"
+	"github.com/therealadik/bank-api/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CardServer struct {
+	bankv1.UnimplementedCardServiceServer
+
+	cardService *service.CardService
+	logger      *logrus.Logger
+}
+
+func NewCardServer(cardService *service.CardService, logger *logrus.Logger) *CardServer {
+	return &CardServer{
+		cardService: cardService,
+		logger:      logger,
+	}
+}
+
+func (s *CardServer) ListCards(ctx context.Context, _ *bankv1.ListCardsRequest) (*bankv1.ListCardsResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, statusError(ctx, codes.Unauthenticated, "unauthorized")
+	}
+
+	cards, err := s.cardService.GetUserCards(ctx, userID)
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err, "Не удалось получить карты")
+	}
+
+	resp := &bankv1.ListCardsResponse{Cards: make([]*bankv1.Card, 0, len(cards))}
+	for _, card := range cards {
+		resp.Cards = append(resp.Cards, cardMessage(card))
+	}
+	return resp, nil
+}
+
+func (s *CardServer) CreateCard(ctx context.Context, req *bankv1.CreateCardRequest) (*bankv1.CreateCardResponse, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, statusError(ctx, codes.Unauthenticated, "unauthorized")
+	}
+
+	if req.GetPgpKey() == "" {
+		return nil, fieldError(ctx, "pgp_key", "validation.required")
+	}
+
+	card, details, err := s.cardService.CreateCard(ctx, userID, req.GetPgpKey())
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err, "Не удалось создать карту")
+	}
+
+	return &bankv1.CreateCardResponse{
+		Card:       cardMessage(card),
+		CardNumber: details["number"],
+		Expire:     details["expire"],
+		Cvv:        details["cvv"],
+	}, nil
+}
+
+func (s *CardServer) GetCardDetails(ctx context.Context, req *bankv1.GetCardDetailsRequest) (*bankv1.CardDetails, error) {
+	userID, err := middleware.GetUserID(ctx)
+	if err != nil {
+		return nil, statusError(ctx, codes.Unauthenticated, "unauthorized")
+	}
+
+	if req.GetPgpKey() == "" {
+		return nil, fieldError(ctx, "pgp_key", "validation.required")
+	}
+
+	details, err := s.cardService.GetCardDetails(ctx, req.GetCardId(), userID, req.GetPgpKey())
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err, "Не удалось получить данные карты")
+	}
+
+	return &bankv1.CardDetails{
+		Id:           req.GetCardId(),
+		MaskedNumber: details["number"],
+		Expire:       details["expire"],
+	}, nil
+}
+
+func cardMessage(card *models.Card) *bankv1.Card {
+	return &bankv1.Card{
+		Id:        card.ID,
+		CreatedAt: timestamppb.New(card.CreatedAt),
+	}
+}
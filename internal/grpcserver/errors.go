@@ -0,0 +1,100 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/apierror"
+	"github.com/therealadik/bank-api/internal/i18n"
+	"github.com/therealadik/bank-api/internal/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain — домен в google.rpc.ErrorInfo; Reason — код из каталога i18n,
+// тот же, что в поле code ответов REST API.
+const errorDomain = "bank-api"
+
+// statusError возвращает ошибку gRPC с сообщением из каталога на языке
+// запроса и кодом каталога в ErrorInfo.
+func statusError(ctx context.Context, c codes.Code, key string) error {
+	st := status.New(c, i18n.T(i18n.FromContext(ctx), key))
+	withInfo, err := st.WithDetails(&errdetails.ErrorInfo{Reason: key, Domain: errorDomain})
+	if err != nil {
+		return st.Err()
+	}
+	return withInfo.Err()
+}
+
+// fieldError отвечает InvalidArgument с нарушением в поле запроса, как
+// validation_failed в REST API.
+func fieldError(ctx context.Context, field, key string) error {
+	locale := i18n.FromContext(ctx)
+	st := status.New(codes.InvalidArgument, i18n.T(locale, "validation_failed"))
+	withDetails, err := st.WithDetails(
+		&errdetails.ErrorInfo{Reason: "validation_failed", Domain: errorDomain},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: i18n.T(locale, key)},
+		}},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// toStatus переводит ошибку сервиса в ошибку gRPC так же, как writeError
+// в REST API: известные ошибки получают свой код и сообщение из каталога,
+// остальные логируются и возвращаются как Internal.
+func toStatus(ctx context.Context, logger *logrus.Logger, err error, fallback string) error {
+	locale := i18n.FromContext(ctx)
+
+	m, ok := apierror.Lookup(err)
+	if !ok {
+		logger.WithContext(ctx).Errorf("%s: %v", fallback, err)
+		detail := fallback
+		if locale != i18n.RU {
+			detail = i18n.T(locale, "internal_error")
+		}
+		return status.New(codes.Internal, detail).Err()
+	}
+
+	if m.Status >= http.StatusInternalServerError {
+		logger.WithContext(ctx).Errorf("%s: %v", fallback, err)
+	} else {
+		logger.WithContext(ctx).Warnf("%s: %v", fallback, err)
+	}
+
+	info := &errdetails.ErrorInfo{Reason: m.Code, Domain: errorDomain}
+	message := i18n.T(locale, m.Code)
+	details := []protoadapt.MessageV1{info}
+
+	var challenge *service.ChallengeError
+	if errors.As(err, &challenge) {
+		info.Metadata = map[string]string{"challenge_id": strconv.FormatInt(challenge.ChallengeID, 10)}
+	}
+
+	var attempts *service.AttemptsError
+	if errors.As(err, &attempts) {
+		key := "too_many_attempts_retry"
+		if attempts.Locked {
+			key = "too_many_attempts_locked"
+		}
+		message = i18n.T(locale, key, attempts.RetryAfter.Round(time.Second))
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(attempts.RetryAfter)})
+	}
+
+	st := status.New(m.GRPC, message)
+	withDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
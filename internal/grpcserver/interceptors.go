@@ -0,0 +1,85 @@
+package grpcserver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/i18n"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// publicMethodPrefix — методы сервиса рефлексии доступны без токена, чтобы
+// grpcurl и подобные инструменты могли получить описание API.
+const publicMethodPrefix = "/grpc.reflection."
+
+// authInterceptor проверяет JWT из метаданных authorization так же, как
+// JWTMiddleware в REST API, и кладет пользователя в контекст по тому же
+// ключу, поэтому middleware.GetUserID работает и в обработчиках gRPC.
+type authInterceptor struct {
+	authService service.AuthService
+	logger      *logrus.Logger
+}
+
+func (a *authInterceptor) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authenticate(withLocale(ctx), info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authInterceptor) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(withLocale(ss.Context()), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+func (a *authInterceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if strings.HasPrefix(method, publicMethodPrefix) {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || values[0] == "" {
+		return nil, statusError(ctx, codes.Unauthenticated, "authorization_required")
+	}
+
+	const bearerPrefix = "Bearer "
+	if !strings.HasPrefix(values[0], bearerPrefix) {
+		return nil, statusError(ctx, codes.Unauthenticated, "malformed_token")
+	}
+
+	userID, err := a.authService.ParseToken(ctx, strings.TrimPrefix(values[0], bearerPrefix))
+	if err != nil {
+		a.logger.WithContext(ctx).WithError(err).Warn("Ошибка проверки токена gRPC")
+		return nil, statusError(ctx, codes.Unauthenticated, "invalid_access_token")
+	}
+
+	return context.WithValue(ctx, middleware.UserIDKey, userID), nil
+}
+
+// withLocale выбирает язык сообщений по метаданным accept-language.
+func withLocale(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return i18n.WithLocale(ctx, i18n.Parse(strings.Join(md.Get("accept-language"), ",")))
+}
+
+// contextStream подменяет контекст потока на контекст с пользователем.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,29 @@
+// Package grpcserver реализует внутренний gRPC API из proto/bank/v1 поверх
+// тех же сервисов, что и REST API. Доменные ошибки переводятся в коды gRPC
+// по общей с REST API таблице apierror, код ошибки из каталога передается
+// в google.rpc.ErrorInfo.
+package grpcserver
+
+import (
+	"github.com/sirupsen/logrus"
+	bankv1 "github.com/therealadik/bank-api/internal/gen/bank/v1"
+	"github.com/therealadik/bank-api/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// New создает gRPC-сервер с проверкой JWT и сервисом рефлексии.
+func New(authService service.AuthService, accountServer *AccountServer, cardServer *CardServer,
+	logger *logrus.Logger) *grpc.Server {
+	auth := &authInterceptor{authService: authService, logger: logger}
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.unary),
+		grpc.ChainStreamInterceptor(auth.stream),
+	)
+	bankv1.RegisterAccountServiceServer(srv, accountServer)
+	bankv1.RegisterCardServiceServer(srv, cardServer)
+	reflection.Register(srv)
+
+	return srv
+}
@@ -2,85 +2,76 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 	"github.com/therealadik/bank-api/internal/dto"
 	"github.com/therealadik/bank-api/internal/middleware"
 	"github.com/therealadik/bank-api/internal/models/account"
-	"github.com/therealadik/bank-api/internal/service"
+	"github.com/therealadik/bank-api/internal/problem"
 )
 
 type AccountHandler struct {
-	accountService *service.AccountService
-	logger         *logrus.Logger
+	accountService     AccountService
+	beneficiaryService BeneficiaryService
+	approvalService    ApprovalService
+	logger             *logrus.Logger
 }
 
-func NewAccountHandler(accountService *service.AccountService, logger *logrus.Logger) *AccountHandler {
+func NewAccountHandler(accountService AccountService, beneficiaryService BeneficiaryService,
+	approvalService ApprovalService, logger *logrus.Logger) *AccountHandler {
 	return &AccountHandler{
-		accountService: accountService,
-		logger:         logger,
+		accountService:     accountService,
+		beneficiaryService: beneficiaryService,
+		approvalService:    approvalService,
+		logger:             logger,
 	}
 }
 
 func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.GetUserID(r.Context())
 	if err != nil {
-		h.logger.Errorf("Ошибка получения userID из контекста: %v", err)
-		http.Error(w, "Ошибка авторизации", http.StatusUnauthorized)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	var req dto.CreateAccountRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.Errorf("Ошибка декодирования запроса: %v", err)
-		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
+	if !decodeJSON(w, r, h.logger, &req) {
 		return
 	}
 
-	if req.Currency != account.RUB {
-		h.logger.Warnf("Попытка создать счет в неподдерживаемой валюте: %s", req.Currency)
-		http.Error(w, "Поддерживается только валюта RUB", http.StatusBadRequest)
-		return
+	if req.Product == "" {
+		req.Product = account.CURRENT
 	}
 
-	newAccount, err := h.accountService.CreateAccount(r.Context(), userID, req.Currency)
+	newAccount, err := h.accountService.CreateAccount(r.Context(), userID, req.Currency, req.Product)
 	if err != nil {
-		h.logger.Errorf("Ошибка создания счета: %v", err)
-		http.Error(w, "Не удалось создать счет", http.StatusInternalServerError)
+		writeError(w, r, h.logger, err, "Не удалось создать счет")
 		return
 	}
 
-	resp := dto.AccountResponse{
-		ID:        newAccount.ID,
-		UserID:    newAccount.UserID,
-		Balance:   newAccount.Balance,
-		Currency:  newAccount.Currency,
-		CreatedAt: newAccount.CreatedAt.Format("2006-01-02T15:04:05Z"),
-	}
+	resp := newAccountResponse(newAccount)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		h.logger.Errorf("Ошибка кодирования ответа: %v", err)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
 	}
 }
 
 func (h *AccountHandler) GetAccounts(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.GetUserID(r.Context())
 	if err != nil {
-		h.logger.Errorf("Ошибка получения userID из контекста: %v", err)
-		http.Error(w, "Ошибка авторизации", http.StatusUnauthorized)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	accounts, err := h.accountService.GetAccountsByUserID(r.Context(), userID)
 	if err != nil {
-		h.logger.Errorf("Ошибка получения счетов: %v", err)
-		http.Error(w, "Не удалось получить счета", http.StatusInternalServerError)
+		writeError(w, r, h.logger, err, "Не удалось получить счета")
 		return
 	}
 
@@ -89,139 +80,132 @@ func (h *AccountHandler) GetAccounts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	for _, acc := range accounts {
-		resp.Accounts = append(resp.Accounts, dto.AccountResponse{
-			ID:        acc.ID,
-			UserID:    acc.UserID,
-			Balance:   acc.Balance,
-			Currency:  acc.Currency,
-			CreatedAt: acc.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		})
+		resp.Accounts = append(resp.Accounts, newAccountResponse(acc))
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		h.logger.Errorf("Ошибка кодирования ответа: %v", err)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
 	}
 }
 
 func (h *AccountHandler) UpdateBalance(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.GetUserID(r.Context())
 	if err != nil {
-		h.logger.Errorf("Ошибка получения userID из контекста: %v", err)
-		http.Error(w, "Ошибка авторизации", http.StatusUnauthorized)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
-	vars := mux.Vars(r)
-	accountID, err := strconv.ParseInt(vars["id"], 10, 64)
-	if err != nil {
-		h.logger.Warnf("Неверный формат ID счета: %v", err)
-		http.Error(w, "Неверный ID счета", http.StatusBadRequest)
+	accountID, ok := h.accountIDFromPath(w, r)
+	if !ok {
 		return
 	}
 
 	var req dto.UpdateBalanceRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.Errorf("Ошибка декодирования запроса: %v", err)
-		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
+	if !decodeJSON(w, r, h.logger, &req) {
 		return
 	}
 
 	err = h.accountService.UpdateBalance(r.Context(), accountID, userID, req.Amount)
 	if err != nil {
-		switch {
-		case errors.Is(err, service.ErrInsufficientFunds):
-			h.logger.Warnf("Недостаточно средств для операции: %v", err)
-			http.Error(w, "Недостаточно средств", http.StatusBadRequest)
-		default:
-			h.logger.Errorf("Ошибка обновления баланса: %v", err)
-			http.Error(w, "Не удалось обновить баланс", http.StatusInternalServerError)
-		}
+		writeError(w, r, h.logger, err, "Не удалось обновить баланс")
 		return
 	}
 
 	updatedAccount, err := h.accountService.GetAccountByID(r.Context(), accountID, userID)
 	if err != nil {
-		h.logger.Errorf("Ошибка получения обновленного счета: %v", err)
-		http.Error(w, "Операция выполнена, но не удалось получить данные счета", http.StatusInternalServerError)
+		writeError(w, r, h.logger, err, "Операция выполнена, но не удалось получить данные счета")
 		return
 	}
 
-	resp := dto.AccountResponse{
-		ID:        updatedAccount.ID,
-		UserID:    updatedAccount.UserID,
-		Balance:   updatedAccount.Balance,
-		Currency:  updatedAccount.Currency,
-		CreatedAt: updatedAccount.CreatedAt.Format("2006-01-02T15:04:05Z"),
-	}
+	resp := newAccountResponse(updatedAccount)
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		h.logger.Errorf("Ошибка кодирования ответа: %v", err)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
 	}
 }
 
 func (h *AccountHandler) Transfer(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.GetUserID(r.Context())
 	if err != nil {
-		h.logger.Errorf("Ошибка получения userID из контекста: %v", err)
-		http.Error(w, "Ошибка авторизации", http.StatusUnauthorized)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	var req dto.TransferRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.Errorf("Ошибка декодирования запроса: %v", err)
-		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
+	if !decodeJSON(w, r, h.logger, &req) {
 		return
 	}
 
-	err = h.accountService.Transfer(r.Context(), req.FromAccountID, req.ToAccountID, userID, req.Amount)
+	fromID, err := h.accountService.ResolveAccountID(r.Context(), req.FromAccountID, req.FromAccountNumber)
 	if err != nil {
-		switch {
-		case errors.Is(err, service.ErrInsufficientFunds):
-			h.logger.Warnf("Недостаточно средств для перевода: %v", err)
-			http.Error(w, "Недостаточно средств", http.StatusBadRequest)
-		case errors.Is(err, service.ErrSameAccount):
-			h.logger.Warnf("Попытка перевода на тот же счет: %v", err)
-			http.Error(w, "Нельзя переводить на тот же счет", http.StatusBadRequest)
-		case errors.Is(err, service.ErrNegativeAmount):
-			h.logger.Warnf("Попытка перевода отрицательной суммы: %v", err)
-			http.Error(w, "Сумма перевода должна быть положительной", http.StatusBadRequest)
-		default:
-			h.logger.Errorf("Ошибка выполнения перевода: %v", err)
-			http.Error(w, "Не удалось выполнить перевод", http.StatusInternalServerError)
+		writeError(w, r, h.logger, err, "Не удалось найти счет")
+		return
+	}
+
+	var toID int64
+	if req.BeneficiaryID != 0 {
+		toID, err = h.beneficiaryService.ResolveForTransfer(r.Context(), req.BeneficiaryID, userID)
+		if err != nil {
+			writeError(w, r, h.logger, err, "Не удалось выполнить перевод")
+			return
+		}
+	} else {
+		toID, err = h.accountService.ResolveAccountID(r.Context(), req.ToAccountID, req.ToAccountNumber)
+		if err != nil {
+			writeError(w, r, h.logger, err, "Не удалось найти счет")
+			return
 		}
+	}
+
+	quote, payment, err := h.approvalService.SubmitTransfer(r.Context(), fromID, toID, userID, req.Amount, req.ExpectedFee)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось выполнить перевод")
 		return
 	}
 
+	if payment != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(newPendingPaymentResponse(payment, nil)); err != nil {
+			h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+		}
+		return
+	}
+
+	resp := dto.TransferResponse{
+		Status: "success",
+		Amount: quote.Amount,
+		Fee:    quote.Fee,
+		Total:  quote.Total,
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(map[string]string{"status": "success"}); err != nil {
-		h.logger.Errorf("Ошибка кодирования ответа: %v", err)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
 	}
 }
 
 func (h *AccountHandler) GetTransactions(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.GetUserID(r.Context())
 	if err != nil {
-		h.logger.Errorf("Ошибка получения userID из контекста: %v", err)
-		http.Error(w, "Ошибка авторизации", http.StatusUnauthorized)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
-	vars := mux.Vars(r)
-	accountID, err := strconv.ParseInt(vars["id"], 10, 64)
-	if err != nil {
-		h.logger.Warnf("Неверный формат ID счета: %v", err)
-		http.Error(w, "Неверный ID счета", http.StatusBadRequest)
+	accountID, ok := h.accountIDFromPath(w, r)
+	if !ok {
 		return
 	}
 
 	transactions, err := h.accountService.GetTransactionsByAccountID(r.Context(), accountID, userID)
 	if err != nil {
-		h.logger.Errorf("Ошибка получения транзакций: %v", err)
-		http.Error(w, "Не удалось получить транзакции", http.StatusInternalServerError)
+		writeError(w, r, h.logger, err, "Не удалось получить транзакции")
 		return
 	}
 
@@ -242,6 +226,38 @@ func (h *AccountHandler) GetTransactions(w http.ResponseWriter, r *http.Request)
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		h.logger.Errorf("Ошибка кодирования ответа: %v", err)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// accountIDFromPath читает из пути идентификатор счета: внутренний ID или внешний номер.
+func (h *AccountHandler) accountIDFromPath(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	vars := mux.Vars(r)
+	accountID, err := h.accountService.ResolveAccountRef(r.Context(), vars["id"])
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось найти счет")
+		return 0, false
+	}
+	return accountID, true
+}
+
+func newAccountResponse(acc *account.Account) dto.AccountResponse {
+	var accountNumber string
+	if acc.AccountNumber != nil {
+		accountNumber = *acc.AccountNumber
+	}
+
+	return dto.AccountResponse{
+		ID:             acc.ID,
+		AccountNumber:  accountNumber,
+		UserID:         acc.UserID,
+		Balance:        acc.Balance,
+		Currency:       acc.Currency,
+		Product:        acc.Product,
+		InterestRate:   acc.InterestRate,
+		DayCount:       acc.DayCount,
+		OverdraftLimit: acc.OverdraftLimit,
+		OverdraftRate:  acc.OverdraftRate,
+		CreatedAt:      acc.CreatedAt.Format("2006-01-02T15:04:05Z"),
 	}
 }
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/models/aml"
+	"github.com/therealadik/bank-api/internal/models/transaction"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+// AMLHandler — операторский API кейсов AML-мониторинга.
+type AMLHandler struct {
+	amlService AMLService
+	logger     *logrus.Logger
+}
+
+func NewAMLHandler(amlService AMLService, logger *logrus.Logger) *AMLHandler {
+	return &AMLHandler{
+		amlService: amlService,
+		logger:     logger,
+	}
+}
+
+// GetCases возвращает кейсы; ?status=OPEN оставляет только неразобранные.
+func (h *AMLHandler) GetCases(w http.ResponseWriter, r *http.Request) {
+	cases, err := h.amlService.GetCases(r.Context(), aml.CaseStatus(r.URL.Query().Get("status")))
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить кейсы")
+		return
+	}
+
+	if cases == nil {
+		cases = []*aml.Case{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(cases); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *AMLHandler) GetCase(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	c, transactions, err := h.amlService.GetCase(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось обработать кейс")
+		return
+	}
+
+	if transactions == nil {
+		transactions = []*transaction.Transaction{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(dto.AMLCaseResponse{Case: c, Transactions: transactions}); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// Escalate признает операции кейса подозрительными.
+func (h *AMLHandler) Escalate(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.amlService.Escalate)
+}
+
+// Dismiss закрывает кейс как ложное срабатывание.
+func (h *AMLHandler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.amlService.Dismiss)
+}
+
+func (h *AMLHandler) review(w http.ResponseWriter, r *http.Request,
+	review func(ctx context.Context, id int64, comment string) (*aml.Case, error)) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.AMLReviewRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	c, err := review(r.Context(), id, req.Comment)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось обработать кейс")
+		return
+	}
+
+	h.logger.WithContext(r.Context()).Infof("Кейс AML %d разобран: %s", c.ID, c.Status)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(c); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// ExportSAR выгружает сообщение о подозрительной операции файлом и переводит
+// кейс в REPORTED.
+func (h *AMLHandler) ExportSAR(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseID(w, r)
+	if !ok {
+		return
+	}
+
+	data, fileName, err := h.amlService.ExportSAR(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось обработать кейс")
+		return
+	}
+
+	h.logger.WithContext(r.Context()).Infof("Выгружено сообщение о подозрительной операции по кейсу %d", id)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", "attachment; filename="+strconv.Quote(fileName))
+	if _, err := w.Write(data); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка отправки сообщения: %v", err)
+	}
+}
+
+func (h *AMLHandler) parseID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID кейса: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_case_id")
+		return 0, false
+	}
+	return id, true
+}
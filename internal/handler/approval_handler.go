@@ -0,0 +1,331 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/i18n"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/models/approval"
+	"github.com/therealadik/bank-api/internal/problem"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+type ApprovalHandler struct {
+	approvalService ApprovalService
+	accountService  AccountService
+	logger          *logrus.Logger
+}
+
+func NewApprovalHandler(approvalService ApprovalService, accountService AccountService,
+	logger *logrus.Logger) *ApprovalHandler {
+	return &ApprovalHandler{
+		approvalService: approvalService,
+		accountService:  accountService,
+		logger:          logger,
+	}
+}
+
+func (h *ApprovalHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	userID, accountID, ok := h.readAccount(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.AddMemberRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	m, err := h.approvalService.AddMember(r.Context(), accountID, userID, req.Email, req.Role)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось добавить участника")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newMemberResponse(m)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *ApprovalHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	userID, accountID, ok := h.readAccount(w, r)
+	if !ok {
+		return
+	}
+
+	members, err := h.approvalService.GetMembers(r.Context(), accountID, userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить участников счета")
+		return
+	}
+
+	resp := dto.MemberListResponse{
+		Members: make([]dto.MemberResponse, 0, len(members)),
+	}
+
+	for _, m := range members {
+		resp.Members = append(resp.Members, newMemberResponse(m))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *ApprovalHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, accountID, ok := h.readAccount(w, r)
+	if !ok {
+		return
+	}
+
+	memberID, err := strconv.ParseInt(mux.Vars(r)["userId"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID участника: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_member_id")
+		return
+	}
+
+	if err := h.approvalService.RemoveMember(r.Context(), accountID, userID, memberID); err != nil {
+		writeError(w, r, h.logger, err, "Не удалось удалить участника")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ApprovalHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	userID, accountID, ok := h.readAccount(w, r)
+	if !ok {
+		return
+	}
+
+	bands, err := h.approvalService.GetPolicy(r.Context(), accountID, userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить политику подтверждений")
+		return
+	}
+
+	h.writePolicy(w, r, bands)
+}
+
+func (h *ApprovalHandler) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	userID, accountID, ok := h.readAccount(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.ApprovalPolicyRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	bands := make([]approval.PolicyBand, 0, len(req.Bands))
+	for _, b := range req.Bands {
+		bands = append(bands, approval.PolicyBand{
+			AmountFrom:        b.AmountFrom,
+			RequiredApprovals: b.RequiredApprovals,
+		})
+	}
+
+	saved, err := h.approvalService.SetPolicy(r.Context(), accountID, userID, bands)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось сохранить политику подтверждений")
+		return
+	}
+
+	h.writePolicy(w, r, saved)
+}
+
+// GetPendingPayments возвращает очередь платежей, ожидающих подтверждения,
+// по всем счетам пользователя.
+func (h *ApprovalHandler) GetPendingPayments(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	payments, err := h.approvalService.GetPendingPayments(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить очередь платежей")
+		return
+	}
+
+	resp := dto.PendingPaymentListResponse{
+		Payments: make([]dto.PendingPaymentResponse, 0, len(payments)),
+	}
+
+	for _, p := range payments {
+		resp.Payments = append(resp.Payments, newPendingPaymentResponse(p, nil))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *ApprovalHandler) GetPayment(w http.ResponseWriter, r *http.Request) {
+	userID, paymentID, ok := h.readPayment(w, r)
+	if !ok {
+		return
+	}
+
+	p, decisions, err := h.approvalService.GetPayment(r.Context(), paymentID, userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить платеж")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newPendingPaymentResponse(p, decisions)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *ApprovalHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, approval.APPROVE)
+}
+
+func (h *ApprovalHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, approval.REJECT)
+}
+
+func (h *ApprovalHandler) decide(w http.ResponseWriter, r *http.Request, decision approval.Decision) {
+	userID, paymentID, ok := h.readPayment(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.DecisionRequest
+	if r.ContentLength != 0 {
+		if !decodeJSON(w, r, h.logger, &req) {
+			return
+		}
+	}
+
+	var (
+		p   *approval.Payment
+		err error
+	)
+	if decision == approval.APPROVE {
+		p, err = h.approvalService.Approve(r.Context(), paymentID, userID, req.Comment)
+	} else {
+		p, err = h.approvalService.Reject(r.Context(), paymentID, userID, req.Comment)
+	}
+
+	if err != nil {
+		if errors.Is(err, service.ErrPaymentFailed) {
+			h.logger.WithContext(r.Context()).Warnf("Одобренный платеж %d не исполнен: %v", paymentID, err)
+			key := "payment_failed"
+			if errors.Is(err, service.ErrInsufficientFunds) {
+				key = "payment_failed_insufficient_funds"
+			}
+			problem.Write(w, problem.New(http.StatusConflict, "payment_failed", i18n.T(i18n.FromContext(r.Context()), key)))
+			return
+		}
+		writeError(w, r, h.logger, err, "Не удалось обработать решение")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newPendingPaymentResponse(p, nil)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *ApprovalHandler) readAccount(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return 0, 0, false
+	}
+
+	accountID, err := h.accountService.ResolveAccountRef(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось найти счет")
+		return 0, 0, false
+	}
+
+	return userID, accountID, true
+}
+
+func (h *ApprovalHandler) readPayment(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return 0, 0, false
+	}
+
+	paymentID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID платежа: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_payment_id")
+		return 0, 0, false
+	}
+
+	return userID, paymentID, true
+}
+
+func (h *ApprovalHandler) writePolicy(w http.ResponseWriter, r *http.Request, bands []*approval.PolicyBand) {
+	resp := dto.ApprovalPolicyResponse{
+		Bands: make([]dto.PolicyBand, 0, len(bands)),
+	}
+
+	for _, b := range bands {
+		resp.Bands = append(resp.Bands, dto.PolicyBand{
+			AmountFrom:        b.AmountFrom,
+			RequiredApprovals: b.RequiredApprovals,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func newMemberResponse(m *approval.Member) dto.MemberResponse {
+	return dto.MemberResponse{
+		UserID:    m.UserID,
+		Role:      m.Role,
+		CreatedAt: m.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func newPendingPaymentResponse(p *approval.Payment, decisions []*approval.PaymentDecision) dto.PendingPaymentResponse {
+	resp := dto.PendingPaymentResponse{
+		ID:                p.ID,
+		FromAccountID:     p.AccountID,
+		ToAccountID:       p.ToAccountID,
+		InitiatorID:       p.InitiatorID,
+		Amount:            p.Amount,
+		RequiredApprovals: p.RequiredApprovals,
+		Status:            p.Status,
+		CreatedAt:         p.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:         p.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	for _, d := range decisions {
+		resp.Decisions = append(resp.Decisions, dto.DecisionResponse{
+			UserID:    d.UserID,
+			Decision:  d.Decision,
+			Comment:   d.Comment,
+			CreatedAt: d.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return resp
+}
@@ -2,11 +2,12 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 
 	"github.com/sirupsen/logrus"
 	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/i18n"
+	"github.com/therealadik/bank-api/internal/problem"
 	"github.com/therealadik/bank-api/internal/service"
 )
 
@@ -29,41 +30,34 @@ func NewAuthHandler(authService service.AuthService, logger *logrus.Logger) *Aut
 // @Accept json
 // @Produce json
 // @Param request body dto.RegisterRequest true "Данные для регистрации"
-// @Success 201 {string} string "Пользователь успешно зарегистрирован"
-// @Failure 400 {string} string "Ошибка валидации данных"
-// @Failure 409 {string} string "Пользователь с таким email или username уже существует"
-// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Success 201 {object} dto.RegisterResponse "Пользователь успешно зарегистрирован"
+// @Failure 400 {object} problem.Problem "Неверный формат запроса"
+// @Failure 409 {object} problem.Problem "Пользователь с таким email уже существует"
+// @Failure 422 {object} problem.Problem "Ошибка валидации данных"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
 // @Router /register [post]
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req dto.RegisterRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.WithError(err).Warn("Ошибка декодирования запроса регистрации")
-		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
+	if !decodeJSON(w, r, h.logger, &req) {
 		return
 	}
 
 	userID, err := h.authService.Register(r.Context(), req)
 	if err != nil {
-		h.logger.WithError(err).Warn("Ошибка при регистрации пользователя")
-
-		if errors.Is(err, service.ErrUserExists) {
-			http.Error(w, "Пользователь с таким email или username уже существует", http.StatusConflict)
-			return
-		}
-
-		http.Error(w, "Ошибка при регистрации пользователя", http.StatusInternalServerError)
+		writeError(w, r, h.logger, err, "Ошибка при регистрации пользователя")
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	response := map[string]interface{}{
-		"message": "Пользователь успешно зарегистрирован",
-		"user_id": userID,
+	response := dto.RegisterResponse{
+		Message: i18n.T(i18n.FromContext(r.Context()), "user_registered"),
+		UserID:  userID,
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		h.logger.WithError(err).Error("Ошибка при формировании ответа")
-		http.Error(w, "Ошибка сервера", http.StatusInternalServerError)
+		h.logger.WithContext(r.Context()).WithError(err).Error("Ошибка при формировании ответа")
+		problem.Error(w, r, http.StatusInternalServerError, "internal_error")
 		return
 	}
 }
@@ -76,33 +70,22 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param request body dto.LoginRequest true "Данные для входа"
 // @Success 200 {object} dto.AuthResponse "JWT токен"
-// @Failure 400 {string} string "Ошибка валидации данных"
-// @Failure 401 {string} string "Неверные учетные данные"
-// @Failure 500 {string} string "Внутренняя ошибка сервера"
+// @Failure 400 {object} problem.Problem "Неверный формат запроса"
+// @Failure 401 {object} problem.Problem "Неверные учетные данные"
+// @Failure 403 {object} problem.Problem "Адрес не подтвержден, учетная запись на проверке или заблокирована"
+// @Failure 422 {object} problem.Problem "Ошибка валидации данных"
+// @Failure 429 {object} problem.Problem "Слишком много неудачных попыток"
+// @Failure 500 {object} problem.Problem "Внутренняя ошибка сервера"
 // @Router /login [post]
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req dto.LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.WithError(err).Warn("Ошибка декодирования запроса авторизации")
-		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
-		return
-	}
-
-	if req.Email == "" || req.Password == "" {
-		http.Error(w, "Email и пароль обязательны", http.StatusBadRequest)
+	if !decodeJSON(w, r, h.logger, &req) {
 		return
 	}
 
-	token, err := h.authService.Login(r.Context(), req)
+	token, err := h.authService.Login(r.Context(), req, clientIP(r))
 	if err != nil {
-		h.logger.WithError(err).Warn("Ошибка при авторизации пользователя")
-
-		if errors.Is(err, service.ErrInvalidCredentials) {
-			http.Error(w, "Неверный email или пароль", http.StatusUnauthorized)
-			return
-		}
-
-		http.Error(w, "Ошибка авторизации", http.StatusInternalServerError)
+		writeError(w, r, h.logger, err, "Ошибка авторизации")
 		return
 	}
 
@@ -111,8 +94,98 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	response := dto.AuthResponse{Token: token}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		h.logger.WithError(err).Error("Ошибка при формировании ответа авторизации")
-		http.Error(w, "Ошибка сервера", http.StatusInternalServerError)
+		h.logger.WithContext(r.Context()).WithError(err).Error("Ошибка при формировании ответа авторизации")
+		problem.Error(w, r, http.StatusInternalServerError, "internal_error")
+		return
+	}
+}
+
+// ResendVerification повторно отправляет письмо для подтверждения адреса.
+// Ответ одинаков для зарегистрированных и незарегистрированных адресов.
+// @Summary Повторная отправка письма подтверждения
+// @Tags auth
+// @Accept json
+// @Param request body dto.EmailRequest true "Адрес электронной почты"
+// @Success 202 {string} string "Если адрес зарегистрирован и не подтвержден, письмо отправлено"
+// @Router /email/verify/resend [post]
+func (h *AuthHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var req dto.EmailRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	if err := h.authService.RequestEmailVerification(r.Context(), req.Email); err != nil {
+		writeError(w, r, h.logger, err, "Не удалось отправить письмо")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// VerifyEmail подтверждает адрес по токену из письма.
+// @Summary Подтверждение адреса электронной почты
+// @Tags auth
+// @Accept json
+// @Param request body dto.VerifyEmailRequest true "Токен из письма"
+// @Success 204 "Адрес подтвержден"
+// @Failure 400 {object} problem.Problem "Ссылка недействительна или устарела"
+// @Router /email/verify [post]
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req dto.VerifyEmailRequest
+	if !decodeJSON(w, r, h.logger, &req) {
 		return
 	}
+
+	if err := h.authService.VerifyEmail(r.Context(), req.Token); err != nil {
+		writeError(w, r, h.logger, err, "Не удалось подтвердить адрес")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ForgotPassword отправляет ссылку для сброса пароля. Ответ одинаков для
+// зарегистрированных и незарегистрированных адресов.
+// @Summary Запрос сброса пароля
+// @Tags auth
+// @Accept json
+// @Param request body dto.EmailRequest true "Адрес электронной почты"
+// @Success 202 {string} string "Если адрес зарегистрирован, письмо отправлено"
+// @Router /password/forgot [post]
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.EmailRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	if err := h.authService.ForgotPassword(r.Context(), req.Email); err != nil {
+		writeError(w, r, h.logger, err, "Не удалось отправить письмо")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ResetPassword задает новый пароль по токену из письма и завершает все
+// сессии пользователя.
+// @Summary Сброс пароля
+// @Tags auth
+// @Accept json
+// @Param request body dto.ResetPasswordRequest true "Токен из письма и новый пароль"
+// @Success 204 "Пароль изменен"
+// @Failure 400 {object} problem.Problem "Ссылка недействительна или устарела"
+// @Failure 422 {object} problem.Problem "Пароль слишком короткий"
+// @Router /password/reset [post]
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.ResetPasswordRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	if err := h.authService.ResetPassword(r.Context(), req.Token, req.Password); err != nil {
+		writeError(w, r, h.logger, err, "Не удалось изменить пароль")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
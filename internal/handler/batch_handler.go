@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/models/batch"
+	"github.com/therealadik/bank-api/internal/problem"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+const maxBatchUploadSize = 5 << 20
+
+type BatchHandler struct {
+	batchService   BatchService
+	accountService AccountService
+	logger         *logrus.Logger
+}
+
+func NewBatchHandler(batchService BatchService, accountService AccountService,
+	logger *logrus.Logger) *BatchHandler {
+	return &BatchHandler{
+		batchService:   batchService,
+		accountService: accountService,
+		logger:         logger,
+	}
+}
+
+// CreateBatch принимает пакет в JSON или CSV (Content-Type: text/csv). Для CSV
+// счет списания и режим передаются параметрами from_account и mode, а файл
+// содержит заголовок с колонками account, amount и необязательной reference.
+func (h *BatchHandler) CreateBatch(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBatchUploadSize)
+
+	var (
+		fromID int64
+		mode   batch.Mode
+		rows   []service.BatchRow
+	)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "text/csv" {
+		fromID, err = h.accountService.ResolveAccountRef(r.Context(), r.URL.Query().Get("from_account"))
+		if err != nil {
+			writeError(w, r, h.logger, err, "Не удалось найти счет")
+			return
+		}
+
+		mode = batch.Mode(strings.ToUpper(r.URL.Query().Get("mode")))
+		rows, err = parseBatchCSV(r.Body)
+		if err != nil {
+			h.logger.WithContext(r.Context()).Warnf("Ошибка разбора CSV пакета: %v", err)
+			problem.Error(w, r, http.StatusBadRequest, "invalid_csv", err)
+			return
+		}
+	} else {
+		var req dto.CreateBatchRequest
+		if !decodeJSON(w, r, h.logger, &req) {
+			return
+		}
+
+		fromID, err = h.accountService.ResolveAccountID(r.Context(), req.FromAccountID, req.FromAccountNumber)
+		if err != nil {
+			writeError(w, r, h.logger, err, "Не удалось найти счет")
+			return
+		}
+
+		mode = req.Mode
+		rows = make([]service.BatchRow, 0, len(req.Items))
+		for _, it := range req.Items {
+			rows = append(rows, service.BatchRow{
+				Account:   it.Account,
+				Amount:    it.Amount.String(),
+				Reference: it.Reference,
+			})
+		}
+	}
+
+	b, items, err := h.batchService.CreateBatch(r.Context(), userID, fromID, mode, rows)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось создать пакет платежей")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newBatchResponse(b, items)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *BatchHandler) GetBatches(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	batches, err := h.batchService.GetBatches(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить пакеты платежей")
+		return
+	}
+
+	resp := dto.BatchListResponse{
+		Batches: make([]dto.BatchResponse, 0, len(batches)),
+	}
+
+	for _, b := range batches {
+		resp.Batches = append(resp.Batches, newBatchResponse(b, nil))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// GetBatch возвращает пакет с построчным отчетом об исполнении.
+func (h *BatchHandler) GetBatch(w http.ResponseWriter, r *http.Request) {
+	userID, batchID, ok := h.readIDs(w, r)
+	if !ok {
+		return
+	}
+
+	b, items, err := h.batchService.GetBatch(r.Context(), batchID, userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить пакет платежей")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newBatchResponse(b, items)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *BatchHandler) ExecuteBatch(w http.ResponseWriter, r *http.Request) {
+	userID, batchID, ok := h.readIDs(w, r)
+	if !ok {
+		return
+	}
+
+	b, err := h.batchService.Execute(r.Context(), batchID, userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось запустить пакет платежей")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(newBatchResponse(b, nil)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *BatchHandler) readIDs(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return 0, 0, false
+	}
+
+	batchID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID пакета: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_batch_id")
+		return 0, 0, false
+	}
+
+	return userID, batchID, true
+}
+
+// parseBatchCSV читает CSV с заголовком. Разделитель определяется по
+// заголовку: поддерживаются запятая и точка с запятой.
+func parseBatchCSV(body io.Reader) ([]service.BatchRow, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	header, _, _ := strings.Cut(string(data), "\n")
+	reader := csv.NewReader(strings.NewReader(string(data)))
+	if strings.Contains(header, ";") && !strings.Contains(header, ",") {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("файл пуст")
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	accountCol, ok := columns["account"]
+	if !ok {
+		return nil, errors.New("нет колонки account")
+	}
+
+	amountCol, ok := columns["amount"]
+	if !ok {
+		return nil, errors.New("нет колонки amount")
+	}
+
+	referenceCol, hasReference := columns["reference"]
+
+	field := func(record []string, i int) string {
+		if i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	rows := make([]service.BatchRow, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := service.BatchRow{
+			Account: field(record, accountCol),
+			Amount:  field(record, amountCol),
+		}
+		if hasReference {
+			row.Reference = field(record, referenceCol)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func newBatchResponse(b *batch.Batch, items []*batch.Item) dto.BatchResponse {
+	resp := dto.BatchResponse{
+		ID:            b.ID,
+		FromAccountID: b.AccountID,
+		Mode:          b.Mode,
+		Status:        b.Status,
+		ItemCount:     b.ItemCount,
+		ValidCount:    b.ValidCount,
+		Amount:        b.Total,
+		Fee:           b.TotalFee,
+		Total:         b.Total.Add(b.TotalFee),
+		CreatedAt:     b.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	for _, it := range items {
+		resp.Items = append(resp.Items, dto.BatchItemResponse{
+			RowNumber:   it.RowNumber,
+			Account:     it.AccountRef,
+			ToAccountID: it.ToAccountID,
+			Amount:      it.Amount,
+			Fee:         it.Fee,
+			Reference:   it.Reference,
+			Status:      it.Status,
+			Error:       it.Error,
+		})
+	}
+
+	return resp
+}
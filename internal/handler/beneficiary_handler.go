@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/models/beneficiary"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+type BeneficiaryHandler struct {
+	beneficiaryService BeneficiaryService
+	logger             *logrus.Logger
+}
+
+func NewBeneficiaryHandler(beneficiaryService BeneficiaryService, logger *logrus.Logger) *BeneficiaryHandler {
+	return &BeneficiaryHandler{
+		beneficiaryService: beneficiaryService,
+		logger:             logger,
+	}
+}
+
+func (h *BeneficiaryHandler) CreateBeneficiary(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.CreateBeneficiaryRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	b, err := h.beneficiaryService.AddBeneficiary(r.Context(), userID, req.Nickname, req.AccountID, req.AccountNumber)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось добавить получателя")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newBeneficiaryResponse(b)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *BeneficiaryHandler) GetBeneficiaries(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	beneficiaries, err := h.beneficiaryService.GetBeneficiaries(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить список получателей")
+		return
+	}
+
+	resp := dto.BeneficiaryListResponse{
+		Beneficiaries: make([]dto.BeneficiaryResponse, 0, len(beneficiaries)),
+	}
+
+	for _, b := range beneficiaries {
+		resp.Beneficiaries = append(resp.Beneficiaries, newBeneficiaryResponse(b))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *BeneficiaryHandler) UpdateBeneficiary(w http.ResponseWriter, r *http.Request) {
+	userID, beneficiaryID, ok := h.readIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateBeneficiaryRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	b, err := h.beneficiaryService.RenameBeneficiary(r.Context(), beneficiaryID, userID, req.Nickname)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось изменить получателя")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newBeneficiaryResponse(b)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *BeneficiaryHandler) DeleteBeneficiary(w http.ResponseWriter, r *http.Request) {
+	userID, beneficiaryID, ok := h.readIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.beneficiaryService.DeleteBeneficiary(r.Context(), beneficiaryID, userID); err != nil {
+		writeError(w, r, h.logger, err, "Не удалось удалить получателя")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SendVerificationCode отправляет на email пользователя код подтверждения получателя.
+func (h *BeneficiaryHandler) SendVerificationCode(w http.ResponseWriter, r *http.Request) {
+	userID, beneficiaryID, ok := h.readIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.beneficiaryService.SendVerificationCode(r.Context(), beneficiaryID, userID); err != nil {
+		writeError(w, r, h.logger, err, "Не удалось отправить код подтверждения")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// VerifyBeneficiary снимает период охлаждения после ввода пароля и кода из письма.
+func (h *BeneficiaryHandler) VerifyBeneficiary(w http.ResponseWriter, r *http.Request) {
+	userID, beneficiaryID, ok := h.readIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.VerifyBeneficiaryRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	b, err := h.beneficiaryService.VerifyBeneficiary(r.Context(), beneficiaryID, userID, req.Password, req.Code)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось подтвердить получателя")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newBeneficiaryResponse(b)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *BeneficiaryHandler) readIDs(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return 0, 0, false
+	}
+
+	vars := mux.Vars(r)
+	beneficiaryID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID получателя: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_beneficiary_id")
+		return 0, 0, false
+	}
+
+	return userID, beneficiaryID, true
+}
+
+func newBeneficiaryResponse(b *beneficiary.Beneficiary) dto.BeneficiaryResponse {
+	return dto.BeneficiaryResponse{
+		ID:            b.ID,
+		Nickname:      b.Nickname,
+		AccountID:     b.AccountID,
+		AccountNumber: b.AccountNumber,
+		Status:        b.EffectiveStatus(time.Now()),
+		AvailableAt:   b.AvailableAt.UTC().Format("2006-01-02T15:04:05Z"),
+		CreatedAt:     b.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
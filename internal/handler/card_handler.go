@@ -7,18 +7,20 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/i18n"
 	"github.com/therealadik/bank-api/internal/middleware"
-	"github.com/therealadik/bank-api/internal/service"
+	"github.com/therealadik/bank-api/internal/problem"
 )
 
 type CardHandler struct {
-	cardService *service.CardService
+	cardService CardService
 	logger      *logrus.Logger
 }
 
-func NewCardHandler(cardService *service.CardService, logger *logrus.Logger) *CardHandler {
+func NewCardHandler(cardService CardService, logger *logrus.Logger) *CardHandler {
 	return &CardHandler{
 		cardService: cardService,
 		logger:      logger,
@@ -28,28 +30,19 @@ func NewCardHandler(cardService *service.CardService, logger *logrus.Logger) *Ca
 func (h *CardHandler) CreateCard(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.GetUserID(r.Context())
 	if err != nil {
-		h.logger.Errorf("Ошибка получения userID из контекста: %v", err)
-		http.Error(w, "Ошибка авторизации", http.StatusUnauthorized)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	var req dto.CreateCardRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.Errorf("Ошибка декодирования запроса: %v", err)
-		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
-		return
-	}
-
-	if req.PGPKey == "" {
-		h.logger.Warn("Отсутствует PGP ключ")
-		http.Error(w, "PGP ключ обязателен", http.StatusBadRequest)
+	if !decodeJSON(w, r, h.logger, &req) {
 		return
 	}
 
 	card, cardDetails, err := h.cardService.CreateCard(r.Context(), userID, req.PGPKey)
 	if err != nil {
-		h.logger.Errorf("Ошибка создания карты: %v", err)
-		http.Error(w, "Не удалось создать карту", http.StatusInternalServerError)
+		writeError(w, r, h.logger, err, "Не удалось создать карту")
 		return
 	}
 
@@ -65,22 +58,21 @@ func (h *CardHandler) CreateCard(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		h.logger.Errorf("Ошибка кодирования ответа: %v", err)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
 	}
 }
 
 func (h *CardHandler) GetCards(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.GetUserID(r.Context())
 	if err != nil {
-		h.logger.Errorf("Ошибка получения userID из контекста: %v", err)
-		http.Error(w, "Ошибка авторизации", http.StatusUnauthorized)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	cards, err := h.cardService.GetUserCards(r.Context(), userID)
 	if err != nil {
-		h.logger.Errorf("Ошибка получения списка карт: %v", err)
-		http.Error(w, "Не удалось получить список карт", http.StatusInternalServerError)
+		writeError(w, r, h.logger, err, "Не удалось получить список карт")
 		return
 	}
 
@@ -98,37 +90,39 @@ func (h *CardHandler) GetCards(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		h.logger.Errorf("Ошибка кодирования ответа: %v", err)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
 	}
 }
 
 func (h *CardHandler) GetCardDetails(w http.ResponseWriter, r *http.Request) {
 	userID, err := middleware.GetUserID(r.Context())
 	if err != nil {
-		h.logger.Errorf("Ошибка получения userID из контекста: %v", err)
-		http.Error(w, "Ошибка авторизации", http.StatusUnauthorized)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	vars := mux.Vars(r)
 	cardID, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		h.logger.Warnf("Неверный формат ID карты: %v", err)
-		http.Error(w, "Неверный ID карты", http.StatusBadRequest)
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID карты: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_card_id")
 		return
 	}
 
 	pgpKey := r.URL.Query().Get("pgp_key")
 	if pgpKey == "" {
-		h.logger.Warn("Отсутствует PGP ключ в запросе")
-		http.Error(w, "PGP ключ обязателен", http.StatusBadRequest)
+		writeValidationErrors(w, r, problem.FieldError{
+			Field:   "pgp_key",
+			Code:    "required",
+			Message: i18n.T(i18n.FromContext(r.Context()), "validation.required"),
+		})
 		return
 	}
 
 	cardDetails, err := h.cardService.GetCardDetails(r.Context(), cardID, userID, pgpKey)
 	if err != nil {
-		h.logger.Errorf("Ошибка получения данных карты: %v", err)
-		http.Error(w, "Не удалось получить данные карты", http.StatusInternalServerError)
+		writeError(w, r, h.logger, err, "Не удалось получить данные карты")
 		return
 	}
 
@@ -140,34 +134,20 @@ func (h *CardHandler) GetCardDetails(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		h.logger.Errorf("Ошибка кодирования ответа: %v", err)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
 	}
 }
 
 func (h *CardHandler) ProcessPayment(w http.ResponseWriter, r *http.Request) {
 	var req dto.CardPaymentRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.Errorf("Ошибка декодирования запроса: %v", err)
-		http.Error(w, "Неверный формат запроса", http.StatusBadRequest)
-		return
-	}
-
-	if req.CardID == 0 || req.CVV == "" || req.Amount == "" || req.PGPKey == "" {
-		h.logger.Warn("Отсутствуют обязательные поля")
-		http.Error(w, "Все поля обязательны", http.StatusBadRequest)
+	if !decodeJSON(w, r, h.logger, &req) {
 		return
 	}
 
-	isValid, err := h.cardService.VerifyCardPayment(r.Context(), req.CardID, req.CVV, req.PGPKey)
+	amount, _ := decimal.NewFromString(req.Amount)
+	quote, err := h.cardService.ProcessPayment(r.Context(), req.CardID, req.CVV, req.PGPKey, amount, req.ExpectedFee)
 	if err != nil {
-		h.logger.Errorf("Ошибка проверки данных карты: %v", err)
-		http.Error(w, "Ошибка проверки данных карты", http.StatusBadRequest)
-		return
-	}
-
-	if !isValid {
-		h.logger.Warn("Неверные данные карты")
-		http.Error(w, "Неверные данные карты", http.StatusBadRequest)
+		writeError(w, r, h.logger, err, "Ошибка проверки данных карты")
 		return
 	}
 
@@ -175,11 +155,13 @@ func (h *CardHandler) ProcessPayment(w http.ResponseWriter, r *http.Request) {
 	resp := dto.CardPaymentResponse{
 		Success:     true,
 		PaymentID:   paymentID,
-		Description: "Платеж успешно обработан",
+		Description: i18n.T(i18n.FromContext(r.Context()), "card_payment_processed"),
+		Fee:         quote.Fee,
+		Total:       quote.Total,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		h.logger.Errorf("Ошибка кодирования ответа: %v", err)
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
 	}
 }
@@ -0,0 +1,648 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/openapi"
+	"github.com/therealadik/bank-api/internal/problem"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// operatorToken — служебный токен оператора в контрактном тесте.
+const operatorToken = "operator-token"
+
+// contractRouter собирает роутер со всеми операциями спецификации, как в
+// main, на заглушках сервисов. Каждая заглушка возвращает ошибку err;
+// overdraftPending переводит установку овердрафта в заявку оператору.
+func contractRouter(t *testing.T, err error, overdraftPending bool) *mux.Router {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	accounts := &fakeAccountService{err: err}
+	approvals := NewApprovalHandler(&fakeApprovalService{err: err}, accounts, logger)
+	account := NewAccountHandler(accounts, &fakeBeneficiaryService{err: err}, &fakeApprovalService{err: err}, logger)
+	auth := NewAuthHandler(&fakeAuthService{err: err}, logger)
+	aml := NewAMLHandler(&fakeAMLService{err: err}, logger)
+	batches := NewBatchHandler(&fakeBatchService{err: err}, accounts, logger)
+	beneficiaries := NewBeneficiaryHandler(&fakeBeneficiaryService{err: err}, logger)
+	cards := NewCardHandler(&fakeCardService{err: err}, logger)
+	deposits := NewDepositHandler(&fakeDepositService{err: err}, accounts, logger)
+	fees := NewFeeHandler(&fakeFeeService{err: err}, logger)
+	kyc := NewKYCHandler(&fakeKYCService{err: err}, 1<<20, logger)
+	lockouts := NewLockoutHandler(&fakeLockoutService{err: err}, logger)
+	notifications := NewNotificationHandler(&fakeNotificationService{err: err}, logger)
+	overdraft := NewOverdraftHandler(&fakeOverdraftService{err: err, pending: overdraftPending}, accounts, logger)
+	p2p := NewP2PHandler(&fakeP2PService{err: err}, accounts, logger)
+	risk := NewRiskHandler(&fakeRiskService{err: err}, logger)
+	screening := NewScreeningHandler(&fakeScreeningService{err: err}, logger)
+	stream := NewStreamHandler(&fakeStreamService{err: err}, logger)
+	users := NewUserHandler(&fakeUserService{err: err}, logger)
+	webhooks := NewWebhookHandler(&fakeWebhookService{err: err}, logger)
+
+	handlers := map[string]http.HandlerFunc{
+		"Register":           auth.Register,
+		"Login":              auth.Login,
+		"VerifyEmail":        auth.VerifyEmail,
+		"ResendVerification": auth.ResendVerification,
+		"ForgotPassword":     auth.ForgotPassword,
+		"ResetPassword":      auth.ResetPassword,
+
+		"ListScreeningHits":        screening.GetHits,
+		"GetScreeningHit":          screening.GetHit,
+		"ClearScreeningHit":        screening.Clear,
+		"ConfirmScreeningHit":      screening.Confirm,
+		"ListPendingKYC":           kyc.GetPending,
+		"GetKYCCustomer":           kyc.GetCustomer,
+		"ListKYCCustomerDocuments": kyc.GetCustomerDocuments,
+		"DownloadKYCDocument":      kyc.DownloadDocument,
+		"VerifyKYC":                kyc.Verify,
+		"RejectKYC":                kyc.Reject,
+		"ListAMLCases":             aml.GetCases,
+		"GetAMLCase":               aml.GetCase,
+		"EscalateAMLCase":          aml.Escalate,
+		"DismissAMLCase":           aml.Dismiss,
+		"ExportSAR":                aml.ExportSAR,
+		"ListLockouts":             lockouts.GetActive,
+		"ReleaseLockout":           lockouts.Release,
+		"ListOverdraftRequests":    overdraft.GetRequests,
+		"ApproveOverdraftRequest":  overdraft.Approve,
+		"RejectOverdraftRequest":   overdraft.Reject,
+
+		"GetMe":             users.GetMe,
+		"UpdateContacts":    users.UpdateContacts,
+		"SendPhoneCode":     users.SendPhoneCode,
+		"ConfirmPhone":      users.ConfirmPhone,
+		"GetProfile":        kyc.GetProfile,
+		"UpdateProfile":     kyc.UpdateProfile,
+		"ListKYCDocuments":  kyc.GetDocuments,
+		"UploadKYCDocument": kyc.UploadDocument,
+		"SubmitKYC":         kyc.Submit,
+
+		"CreateAccount":       account.CreateAccount,
+		"ListAccounts":        account.GetAccounts,
+		"UpdateBalance":       account.UpdateBalance,
+		"SetOverdraft":        overdraft.SetOverdraft,
+		"AddMember":           approvals.AddMember,
+		"ListMembers":         approvals.GetMembers,
+		"RemoveMember":        approvals.RemoveMember,
+		"GetApprovalPolicy":   approvals.GetPolicy,
+		"SetApprovalPolicy":   approvals.SetPolicy,
+		"ListPendingPayments": approvals.GetPendingPayments,
+		"GetPendingPayment":   approvals.GetPayment,
+		"ApprovePayment":      approvals.Approve,
+		"RejectPayment":       approvals.Reject,
+		"ListTransactions":    account.GetTransactions,
+		"Transfer":            account.Transfer,
+
+		"CreateBeneficiary":   beneficiaries.CreateBeneficiary,
+		"ListBeneficiaries":   beneficiaries.GetBeneficiaries,
+		"UpdateBeneficiary":   beneficiaries.UpdateBeneficiary,
+		"DeleteBeneficiary":   beneficiaries.DeleteBeneficiary,
+		"SendBeneficiaryCode": beneficiaries.SendVerificationCode,
+		"VerifyBeneficiary":   beneficiaries.VerifyBeneficiary,
+
+		"CreateBatch":  batches.CreateBatch,
+		"ListBatches":  batches.GetBatches,
+		"GetBatch":     batches.GetBatch,
+		"ExecuteBatch": batches.ExecuteBatch,
+
+		"Stream": stream.Stream,
+
+		"ListNotifications":          notifications.GetNotifications,
+		"MarkAllNotificationsRead":   notifications.MarkAllRead,
+		"GetNotificationSettings":    notifications.GetSettings,
+		"UpdateNotificationSettings": notifications.UpdateSettings,
+		"MarkNotificationRead":       notifications.MarkRead,
+
+		"CreateWebhook":         webhooks.CreateWebhook,
+		"ListWebhooks":          webhooks.GetWebhooks,
+		"DeleteWebhook":         webhooks.DeleteWebhook,
+		"ListWebhookDeliveries": webhooks.GetDeliveries,
+		"GetWebhookDelivery":    webhooks.GetDelivery,
+		"RedeliverWebhook":      webhooks.Redeliver,
+
+		"ConfirmChallenge": risk.ConfirmChallenge,
+
+		"PrepareP2PTransfer": p2p.Prepare,
+		"ConfirmP2PTransfer": p2p.Confirm,
+
+		"OpenDeposit":  deposits.OpenDeposit,
+		"ListDeposits": deposits.GetDeposits,
+		"CloseDeposit": deposits.CloseDeposit,
+
+		"ListFeeRules": fees.GetRules,
+		"QuoteFee":     fees.Quote,
+
+		"CreateCard":         cards.CreateCard,
+		"ListCards":          cards.GetCards,
+		"GetCardDetails":     cards.GetCardDetails,
+		"ProcessCardPayment": cards.ProcessPayment,
+	}
+
+	// Публичные маршруты регистрируются раньше подроутеров, как в main:
+	// подроутер с пустым префиксом перехватил бы их своей аутентификацией.
+	r := mux.NewRouter()
+	register := func(router *mux.Router, auth openapi.Auth, prefix string) {
+		for _, op := range openapi.Operations {
+			if op.Auth != auth {
+				continue
+			}
+			f, ok := handlers[op.ID]
+			if !ok {
+				t.Fatalf("нет обработчика операции %s", op.ID)
+			}
+			router.HandleFunc(strings.TrimPrefix(op.Path, prefix), f).Methods(op.Method)
+			delete(handlers, op.ID)
+		}
+	}
+	register(r, openapi.Public, "")
+
+	operator := r.PathPrefix("/operator").Subrouter()
+	operator.Use(middleware.NewOperatorMiddleware(operatorToken, logger).Middleware)
+	register(operator, openapi.OperatorToken, "/operator")
+
+	// Токен пользователя принимается всегда: ошибка err относится к
+	// сервисам, а не к аутентификации.
+	api := r.PathPrefix("").Subrouter()
+	api.Use(middleware.NewJWTMiddleware(&fakeAuthService{}, logger).Middleware)
+	register(api, openapi.Bearer, "")
+
+	for id := range handlers {
+		t.Fatalf("операция %s не описана в спецификации", id)
+	}
+	if err := openapi.Verify(r, ""); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// contractCase — запрос к операции и ожидаемый ответ. Параметры пути
+// подставляются значением 1.
+type contractCase struct {
+	name  string
+	op    string
+	query string
+	// contentType — тип тела запроса; по умолчанию application/json.
+	contentType string
+	body        string
+	err         error
+	// anonymous отправляет запрос без заголовка Authorization.
+	anonymous        bool
+	overdraftPending bool
+	want             int
+	// wantCode — код ошибки в ответе problem+json.
+	wantCode string
+}
+
+func TestContract(t *testing.T) {
+	doc := openapi.Build()
+
+	const (
+		register      = `{"email":"ivan@example.com","password":"secret1","full_name":"Иван Петров"}`
+		login         = `{"email":"ivan@example.com","password":"secret1"}`
+		email         = `{"email":"ivan@example.com"}`
+		token         = `{"token":"abc"}`
+		resetPassword = `{"token":"abc","password":"secret1"}`
+		comment       = `{"comment":"проверено"}`
+		transfer      = `{"from_account_id":1,"to_account_id":2,"amount":"10"}`
+		profile       = `{"full_name":"Иван Петров","date_of_birth":"1990-01-01","citizenship":"RU","address":"Москва"}`
+		cardPayment   = `{"card_id":1,"amount":"10","cvv":"123","pgp_key":"key"}`
+	)
+
+	upload, uploadType := kycUpload(t)
+
+	tests := []contractCase{
+		{name: "регистрация", op: "Register", body: register, want: http.StatusCreated},
+		{name: "email уже занят", op: "Register", body: register, err: service.ErrUserExists,
+			want: http.StatusConflict, wantCode: "user_exists"},
+		{name: "регистрация без имени", op: "Register", body: `{"email":"ivan@example.com","password":"secret1"}`,
+			want: http.StatusUnprocessableEntity, wantCode: "validation_failed"},
+		{name: "тело не JSON", op: "Register", body: `{`, want: http.StatusBadRequest, wantCode: "malformed_request"},
+		{name: "вход", op: "Login", body: login, want: http.StatusOK},
+		{name: "неверный пароль", op: "Login", body: login, err: service.ErrInvalidCredentials,
+			want: http.StatusUnauthorized, wantCode: "invalid_credentials"},
+		{name: "адрес не подтвержден", op: "Login", body: login, err: service.ErrEmailNotVerified,
+			want: http.StatusForbidden, wantCode: "email_not_verified"},
+		{name: "пауза после неудач", op: "Login", body: login, err: &service.AttemptsError{RetryAfter: 5 * time.Second},
+			want: http.StatusTooManyRequests},
+		{name: "подтверждение адреса", op: "VerifyEmail", body: token, want: http.StatusNoContent},
+		{name: "устаревшая ссылка", op: "VerifyEmail", body: token, err: service.ErrInvalidToken,
+			want: http.StatusBadRequest, wantCode: "invalid_token"},
+		{name: "повторное письмо", op: "ResendVerification", body: email, want: http.StatusAccepted},
+		{name: "сброс пароля", op: "ForgotPassword", body: email, want: http.StatusAccepted},
+		{name: "новый пароль", op: "ResetPassword", body: resetPassword, want: http.StatusNoContent},
+		{name: "короткий пароль", op: "ResetPassword", body: `{"token":"abc","password":"123"}`,
+			want: http.StatusUnprocessableEntity, wantCode: "validation_failed"},
+
+		{name: "совпадения", op: "ListScreeningHits", query: "status=PENDING", want: http.StatusOK},
+		{name: "совпадения без токена оператора", op: "ListScreeningHits", anonymous: true,
+			want: http.StatusUnauthorized, wantCode: "operator_authorization_required"},
+		{name: "совпадение", op: "GetScreeningHit", want: http.StatusOK},
+		{name: "совпадение не найдено", op: "GetScreeningHit", err: service.ErrHitNotFound,
+			want: http.StatusNotFound, wantCode: "hit_not_found"},
+		{name: "ложное совпадение", op: "ClearScreeningHit", body: comment, want: http.StatusOK},
+		{name: "подтвержденное совпадение", op: "ConfirmScreeningHit", body: comment, want: http.StatusOK},
+		{name: "анкеты на проверке", op: "ListPendingKYC", want: http.StatusOK},
+		{name: "анкета клиента", op: "GetKYCCustomer", want: http.StatusOK},
+		{name: "документы клиента", op: "ListKYCCustomerDocuments", want: http.StatusOK},
+		{name: "файл документа", op: "DownloadKYCDocument", want: http.StatusOK},
+		{name: "документ не найден", op: "DownloadKYCDocument", err: service.ErrDocumentNotFound,
+			want: http.StatusNotFound, wantCode: "document_not_found"},
+		{name: "личность подтверждена", op: "VerifyKYC", want: http.StatusOK},
+		{name: "анкета не на проверке", op: "VerifyKYC", err: service.ErrKYCNotPending,
+			want: http.StatusConflict, wantCode: "kyc_not_pending"},
+		{name: "анкета отклонена", op: "RejectKYC", body: `{"reason":"нечитаемый скан"}`, want: http.StatusOK},
+		{name: "кейсы", op: "ListAMLCases", want: http.StatusOK},
+		{name: "кейс", op: "GetAMLCase", want: http.StatusOK},
+		{name: "кейс передан на расследование", op: "EscalateAMLCase", body: comment, want: http.StatusOK},
+		{name: "кейс закрыт", op: "DismissAMLCase", body: comment, want: http.StatusOK},
+		{name: "кейс уже закрыт", op: "DismissAMLCase", body: comment, err: service.ErrAMLCaseNotOpen,
+			want: http.StatusConflict, wantCode: "aml_case_not_open"},
+		{name: "сообщение о подозрительной операции", op: "ExportSAR", want: http.StatusOK},
+		{name: "блокировки", op: "ListLockouts", want: http.StatusOK},
+		{name: "блокировка снята", op: "ReleaseLockout", want: http.StatusOK},
+		{name: "заявки на овердрафт", op: "ListOverdraftRequests", want: http.StatusOK},
+		{name: "заявка одобрена", op: "ApproveOverdraftRequest", body: comment, want: http.StatusOK},
+		{name: "заявка отклонена", op: "RejectOverdraftRequest", body: comment, want: http.StatusOK},
+
+		{name: "текущий пользователь", op: "GetMe", want: http.StatusOK},
+		{name: "без токена", op: "GetMe", anonymous: true, want: http.StatusUnauthorized},
+		{name: "контакты", op: "UpdateContacts", body: `{"phone":"+79990000000"}`, want: http.StatusOK},
+		{name: "номер занят", op: "UpdateContacts", body: `{"phone":"+79990000000"}`, err: service.ErrPhoneTaken,
+			want: http.StatusConflict, wantCode: "phone_taken"},
+		{name: "код на телефон", op: "SendPhoneCode", want: http.StatusAccepted},
+		{name: "номер подтвержден", op: "ConfirmPhone", body: `{"code":"123456"}`, want: http.StatusOK},
+		{name: "анкета", op: "GetProfile", want: http.StatusOK},
+		{name: "анкета заполнена", op: "UpdateProfile", body: profile, want: http.StatusOK},
+		{name: "документы", op: "ListKYCDocuments", want: http.StatusOK},
+		{name: "документ загружен", op: "UploadKYCDocument", contentType: uploadType, body: upload,
+			want: http.StatusCreated},
+		{name: "документ без файла", op: "UploadKYCDocument", body: `{}`,
+			want: http.StatusBadRequest, wantCode: "multipart_required"},
+		{name: "анкета отправлена", op: "SubmitKYC", want: http.StatusOK},
+		{name: "анкета не заполнена", op: "SubmitKYC", err: service.ErrKYCProfileIncomplete,
+			want: http.StatusUnprocessableEntity, wantCode: "kyc_profile_incomplete"},
+
+		{name: "счет открыт", op: "CreateAccount", body: `{"currency":"RUB"}`, want: http.StatusCreated},
+		{name: "валюта не из списка", op: "CreateAccount", body: `{"currency":"XXX"}`,
+			want: http.StatusUnprocessableEntity, wantCode: "validation_failed"},
+		{name: "счета", op: "ListAccounts", want: http.StatusOK},
+		{name: "пополнение", op: "UpdateBalance", body: `{"amount":"100"}`, want: http.StatusOK},
+		{name: "счет не найден", op: "UpdateBalance", body: `{"amount":"100"}`, err: service.ErrAccountNotFound,
+			want: http.StatusNotFound, wantCode: "account_not_found"},
+		{name: "лимит овердрафта", op: "SetOverdraft", body: `{"limit":"1000"}`, want: http.StatusOK},
+		{name: "заявка на овердрафт", op: "SetOverdraft", body: `{"limit":"100000"}`, overdraftPending: true,
+			want: http.StatusAccepted},
+		{name: "участник добавлен", op: "AddMember", body: `{"email":"ivan@example.com","role":"APPROVER"}`,
+			want: http.StatusCreated},
+		{name: "участники", op: "ListMembers", want: http.StatusOK},
+		{name: "участник удален", op: "RemoveMember", want: http.StatusNoContent},
+		{name: "не владелец счета", op: "RemoveMember", err: service.ErrNotAccountOwner,
+			want: http.StatusForbidden, wantCode: "not_account_owner"},
+		{name: "политика", op: "GetApprovalPolicy", want: http.StatusOK},
+		{name: "политика задана", op: "SetApprovalPolicy",
+			body: `{"bands":[{"amount_from":"0","required_approvals":1}]}`, want: http.StatusOK},
+		{name: "платежи на подтверждении", op: "ListPendingPayments", want: http.StatusOK},
+		{name: "платеж", op: "GetPendingPayment", want: http.StatusOK},
+		{name: "платеж одобрен", op: "ApprovePayment", body: comment, want: http.StatusOK},
+		{name: "платеж одобрен без комментария", op: "ApprovePayment", want: http.StatusOK},
+		{name: "платеж отклонен", op: "RejectPayment", body: comment, want: http.StatusOK},
+		{name: "операции по счету", op: "ListTransactions", want: http.StatusOK},
+		{name: "перевод", op: "Transfer", body: transfer, want: http.StatusOK},
+		{name: "недостаточно средств", op: "Transfer", body: transfer, err: service.ErrInsufficientFunds,
+			want: http.StatusUnprocessableEntity, wantCode: "insufficient_funds"},
+		{name: "отрицательная сумма", op: "Transfer", body: `{"from_account_id":1,"to_account_id":2,"amount":"-1"}`,
+			want: http.StatusUnprocessableEntity, wantCode: "validation_failed"},
+
+		{name: "получатель добавлен", op: "CreateBeneficiary", body: `{"nickname":"Мама","account_id":2}`,
+			want: http.StatusCreated},
+		{name: "получатель уже есть", op: "CreateBeneficiary", body: `{"nickname":"Мама","account_id":2}`,
+			err: service.ErrBeneficiaryExists, want: http.StatusConflict, wantCode: "beneficiary_exists"},
+		{name: "получатели", op: "ListBeneficiaries", want: http.StatusOK},
+		{name: "получатель переименован", op: "UpdateBeneficiary", body: `{"nickname":"Папа"}`, want: http.StatusOK},
+		{name: "получатель удален", op: "DeleteBeneficiary", want: http.StatusNoContent},
+		{name: "код получателя", op: "SendBeneficiaryCode", want: http.StatusAccepted},
+		{name: "получатель подтвержден", op: "VerifyBeneficiary", body: `{"password":"secret1","code":"123456"}`,
+			want: http.StatusOK},
+
+		{name: "пакет в JSON", op: "CreateBatch",
+			body: `{"from_account_id":1,"mode":"BEST_EFFORT","items":[{"account":"2","amount":"10"}]}`,
+			want: http.StatusCreated},
+		{name: "пакет в CSV", op: "CreateBatch", query: "from_account=1&mode=BEST_EFFORT", contentType: "text/csv",
+			body: "account,amount,reference\n2,10,аренда\n", want: http.StatusCreated},
+		{name: "ошибки в строках пакета", op: "CreateBatch", query: "from_account=1&mode=BEST_EFFORT",
+			contentType: "text/csv", body: "account,amount\n2,10\n", err: service.ErrBatchInvalidRows,
+			want: http.StatusUnprocessableEntity, wantCode: "batch_invalid_rows"},
+		{name: "пакеты", op: "ListBatches", want: http.StatusOK},
+		{name: "пакет", op: "GetBatch", want: http.StatusOK},
+		{name: "пакет исполняется", op: "ExecuteBatch", want: http.StatusAccepted},
+		{name: "пакет уже исполнен", op: "ExecuteBatch", err: service.ErrBatchNotDraft,
+			want: http.StatusConflict, wantCode: "batch_not_draft"},
+
+		{name: "поток событий", op: "Stream", want: http.StatusOK},
+
+		{name: "уведомления", op: "ListNotifications", query: "unread=true", want: http.StatusOK},
+		{name: "все прочитаны", op: "MarkAllNotificationsRead", want: http.StatusOK},
+		{name: "настройки уведомлений", op: "GetNotificationSettings", want: http.StatusOK},
+		{name: "настройки изменены", op: "UpdateNotificationSettings",
+			body: `{"locale":"en","preferences":[{"kind":"REGISTRATION","channel":"EMAIL","enabled":false}]}`,
+			want: http.StatusOK},
+		{name: "язык не поддерживается", op: "UpdateNotificationSettings", body: `{"locale":"de"}`,
+			err: service.ErrUnsupportedLocale, want: http.StatusUnprocessableEntity, wantCode: "unsupported_locale"},
+		{name: "уведомление прочитано", op: "MarkNotificationRead", want: http.StatusNoContent},
+
+		{name: "подписка создана", op: "CreateWebhook",
+			body: `{"url":"https://example.com/hook","event_types":["transfer.completed"]}`, want: http.StatusCreated},
+		{name: "адрес запрещен", op: "CreateWebhook",
+			body: `{"url":"https://127.0.0.1/hook","event_types":["transfer.completed"]}`,
+			err:  service.ErrWebhookHostForbidden, want: http.StatusUnprocessableEntity, wantCode: "webhook_host_forbidden"},
+		{name: "подписки", op: "ListWebhooks", want: http.StatusOK},
+		{name: "подписка удалена", op: "DeleteWebhook", want: http.StatusNoContent},
+		{name: "доставки", op: "ListWebhookDeliveries", query: "status=DEAD", want: http.StatusOK},
+		{name: "доставка", op: "GetWebhookDelivery", want: http.StatusOK},
+		{name: "повторная доставка", op: "RedeliverWebhook", want: http.StatusAccepted},
+
+		{name: "операция подтверждена", op: "ConfirmChallenge", body: `{"password":"secret1"}`,
+			want: http.StatusNoContent},
+		{name: "проверка не найдена", op: "ConfirmChallenge", body: `{"password":"secret1"}`,
+			err: service.ErrChallengeNotFound, want: http.StatusNotFound, wantCode: "challenge_not_found"},
+
+		{name: "перевод по телефону", op: "PrepareP2PTransfer",
+			body: `{"from_account_id":1,"recipient":"+79990000000","amount":"10"}`, want: http.StatusCreated},
+		{name: "получатель по телефону не найден", op: "PrepareP2PTransfer",
+			body: `{"from_account_id":1,"recipient":"+79990000000","amount":"10"}`, err: service.ErrRecipientNotFound,
+			want: http.StatusNotFound, wantCode: "recipient_not_found"},
+		{name: "перевод по телефону подтвержден", op: "ConfirmP2PTransfer", want: http.StatusOK},
+
+		{name: "вклад открыт", op: "OpenDeposit", body: `{"source_account_id":1,"amount":"1000","term_months":6}`,
+			want: http.StatusCreated},
+		{name: "вклады", op: "ListDeposits", want: http.StatusOK},
+		{name: "вклад закрыт", op: "CloseDeposit", want: http.StatusOK},
+		{name: "вклад уже закрыт", op: "CloseDeposit", err: service.ErrDepositNotActive,
+			want: http.StatusConflict, wantCode: "deposit_closed"},
+
+		{name: "тарифы", op: "ListFeeRules", want: http.StatusOK},
+		{name: "комиссия", op: "QuoteFee", query: "operation=TRANSFER&amount=100", want: http.StatusOK},
+		{name: "неизвестная операция", op: "QuoteFee", query: "operation=LOAN&amount=100", err: service.ErrUnknownOperation,
+			want: http.StatusBadRequest, wantCode: "unknown_operation"},
+
+		{name: "карта выпущена", op: "CreateCard", body: `{"pgp_key":"key"}`, want: http.StatusCreated},
+		{name: "карты", op: "ListCards", want: http.StatusOK},
+		{name: "реквизиты карты", op: "GetCardDetails", query: "pgp_key=key", want: http.StatusOK},
+		{name: "оплата картой", op: "ProcessCardPayment", body: cardPayment, want: http.StatusOK},
+		{name: "неверный CVV", op: "ProcessCardPayment", body: cardPayment, err: service.ErrInvalidCVV,
+			want: http.StatusUnprocessableEntity, wantCode: "invalid_cvv"},
+	}
+
+	covered := make(map[string]bool)
+	for _, tt := range tests {
+		covered[tt.op] = true
+		t.Run(tt.op+"/"+tt.name, func(t *testing.T) {
+			checkContract(t, doc, tt)
+		})
+	}
+
+	for _, op := range openapi.Operations {
+		if !covered[op.ID] {
+			t.Errorf("нет проверки операции %s", op.ID)
+		}
+	}
+}
+
+// kycUpload возвращает тело multipart-запроса с документом и его тип
+// содержимого.
+func kycUpload(t *testing.T) (string, string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.WriteField("type", "PASSPORT"); err != nil {
+		t.Fatal(err)
+	}
+	f, err := w.CreateFormFile("file", "passport.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("%PDF-1.7")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String(), w.FormDataContentType()
+}
+
+var pathParam = regexp.MustCompile(`\{[^}]+\}`)
+
+// checkContract выполняет запрос через настоящий сервер: поток событий
+// требует от ResponseWriter управления таймаутом записи.
+func checkContract(t *testing.T, doc *openapi.Document, tt contractCase) {
+	t.Helper()
+
+	op, spec := findOperation(t, doc, tt.op)
+	srv := httptest.NewServer(contractRouter(t, tt.err, tt.overdraftPending))
+	defer srv.Close()
+
+	url := srv.URL + pathParam.ReplaceAllString(op.Path, "1")
+	if tt.query != "" {
+		url += "?" + tt.query
+	}
+	req, err := http.NewRequest(op.Method, url, strings.NewReader(tt.body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tt.body != "" {
+		contentType := tt.contentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	if !tt.anonymous {
+		switch op.Auth {
+		case openapi.Bearer:
+			req.Header.Set("Authorization", "Bearer token")
+		case openapi.OperatorToken:
+			req.Header.Set("Authorization", "Bearer "+operatorToken)
+		}
+	}
+
+	res, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.StatusCode != tt.want {
+		t.Fatalf("статус %d, ожидался %d: %s", res.StatusCode, tt.want, raw)
+	}
+
+	// Успешный ответ описан в операции явно, ошибка — ответом
+	// default в формате problem+json.
+	resp, ok := spec.Responses[strconv.Itoa(res.StatusCode)]
+	if !ok {
+		if res.StatusCode < 400 {
+			t.Fatalf("статус %d не описан в спецификации %s", res.StatusCode, tt.op)
+		}
+		resp = spec.Responses["default"]
+	}
+
+	mediaType, _, _ := strings.Cut(res.Header.Get("Content-Type"), ";")
+	if len(resp.Content) == 0 {
+		if len(raw) != 0 {
+			t.Fatalf("ответ без тела по спецификации, получено: %s", raw)
+		}
+		return
+	}
+	content, ok := resp.Content[mediaType]
+	if !ok {
+		t.Fatalf("тип содержимого %q не описан для статуса %d", mediaType, res.StatusCode)
+	}
+	// Схемы файлов и потока событий не описывают структуру тела.
+	if mediaType != "application/json" && mediaType != problem.ContentType {
+		return
+	}
+
+	var body any
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatalf("ответ не JSON: %v: %s", err, raw)
+	}
+	for _, e := range validateSchema(doc, content.Schema, body, "") {
+		t.Error(e)
+	}
+
+	if mediaType == problem.ContentType {
+		p := body.(map[string]any)
+		if p["status"] != float64(res.StatusCode) {
+			t.Errorf("status в теле %v, статус ответа %d", p["status"], res.StatusCode)
+		}
+		if tt.wantCode != "" && p["code"] != tt.wantCode {
+			t.Errorf("код ошибки %v, ожидался %s", p["code"], tt.wantCode)
+		}
+	}
+}
+
+func findOperation(t *testing.T, doc *openapi.Document, id string) (openapi.Operation, *openapi.OperationObject) {
+	t.Helper()
+
+	for _, op := range openapi.Operations {
+		if op.ID == id {
+			return op, doc.Paths[op.Path][strings.ToLower(op.Method)]
+		}
+	}
+	t.Fatalf("операция %s не найдена", id)
+	return openapi.Operation{}, nil
+}
+
+// validateSchema сверяет значение JSON с подмножеством схем, которое строит
+// пакет openapi, и возвращает найденные расхождения.
+func validateSchema(doc *openapi.Document, s *openapi.Schema, v any, path string) []string {
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+		ref, ok := doc.Components.Schemas[name]
+		if !ok {
+			return []string{fmt.Sprintf("%s: схема %s не найдена", path, s.Ref)}
+		}
+		return validateSchema(doc, ref, v, path)
+	}
+
+	if v == nil {
+		if s.Nullable || s.Type == "" {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: null для ненулевого поля", path)}
+	}
+
+	if len(s.OneOf) > 0 {
+		for _, alt := range s.OneOf {
+			if len(validateSchema(doc, alt, v, path)) == 0 {
+				return nil
+			}
+		}
+		return []string{fmt.Sprintf("%s: значение не подходит ни под один вариант", path)}
+	}
+
+	var errs []string
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: ожидался объект, получено %T", path, v)}
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				errs = append(errs, fmt.Sprintf("%s.%s: нет обязательного поля", path, name))
+			}
+		}
+		for name, value := range obj {
+			prop, ok := s.Properties[name]
+			if !ok {
+				prop = s.AdditionalProperties
+			}
+			if prop == nil {
+				errs = append(errs, fmt.Sprintf("%s.%s: поле не описано в схеме", path, name))
+				continue
+			}
+			errs = append(errs, validateSchema(doc, prop, value, path+"."+name)...)
+		}
+	case "array":
+		items, ok := v.([]any)
+		if !ok {
+			return []string{fmt.Sprintf("%s: ожидался массив, получено %T", path, v)}
+		}
+		for i, item := range items {
+			errs = append(errs, validateSchema(doc, s.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return []string{fmt.Sprintf("%s: ожидалась строка, получено %T", path, v)}
+		}
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+			errs = append(errs, fmt.Sprintf("%s: %q не из %v", path, str, s.Enum))
+		}
+		if s.Pattern != "" && !regexp.MustCompile(s.Pattern).MatchString(str) {
+			errs = append(errs, fmt.Sprintf("%s: %q не соответствует %s", path, str, s.Pattern))
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %q не дата и время", path, str))
+			}
+		}
+	case "integer":
+		n, ok := v.(float64)
+		if !ok || n != float64(int64(n)) {
+			return []string{fmt.Sprintf("%s: ожидалось целое число, получено %v", path, v)}
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			return []string{fmt.Sprintf("%s: ожидалось число, получено %T", path, v)}
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return []string{fmt.Sprintf("%s: ожидалось логическое значение, получено %T", path, v)}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/models/deposit"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+type DepositHandler struct {
+	depositService DepositService
+	accountService AccountService
+	logger         *logrus.Logger
+}
+
+func NewDepositHandler(depositService DepositService, accountService AccountService, logger *logrus.Logger) *DepositHandler {
+	return &DepositHandler{
+		depositService: depositService,
+		accountService: accountService,
+		logger:         logger,
+	}
+}
+
+func (h *DepositHandler) OpenDeposit(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.OpenDepositRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	sourceID, err := h.accountService.ResolveAccountID(r.Context(), req.SourceAccountID, req.SourceAccountNumber)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось найти счет")
+		return
+	}
+
+	d, err := h.depositService.OpenDeposit(r.Context(), userID, sourceID, req.Amount, req.TermMonths, req.AutoRollover)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось открыть вклад")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newDepositResponse(d)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *DepositHandler) GetDeposits(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	deposits, err := h.depositService.GetUserDeposits(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить вклады")
+		return
+	}
+
+	resp := dto.DepositListResponse{
+		Deposits: make([]dto.DepositResponse, 0, len(deposits)),
+	}
+
+	for _, d := range deposits {
+		resp.Deposits = append(resp.Deposits, newDepositResponse(d))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *DepositHandler) CloseDeposit(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	depositID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID вклада: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_deposit_id")
+		return
+	}
+
+	d, err := h.depositService.CloseEarly(r.Context(), depositID, userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось закрыть вклад")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newDepositResponse(d)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func newDepositResponse(d *deposit.TermDeposit) dto.DepositResponse {
+	return dto.DepositResponse{
+		ID:              d.ID,
+		AccountID:       d.AccountID,
+		SourceAccountID: d.SourceAccountID,
+		Principal:       d.Principal,
+		InterestRate:    d.InterestRate,
+		PenaltyRate:     d.PenaltyRate,
+		DayCount:        d.DayCount,
+		TermMonths:      d.TermMonths,
+		StartDate:       d.StartDate.Format("2006-01-02"),
+		MaturityDate:    d.MaturityDate.Format("2006-01-02"),
+		AutoRollover:    d.AutoRollover,
+		Status:          d.Status,
+		CreatedAt:       d.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
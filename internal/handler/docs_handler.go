@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/openapi"
+)
+
+// swaggerUIPage — страница Swagger UI; сам интерфейс загружается с CDN.
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="ru">
+<head>
+  <meta charset="utf-8">
+  <title>Bank API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "/api/openapi.json", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`
+
+type DocsHandler struct {
+	spec   []byte
+	logger *logrus.Logger
+}
+
+// NewDocsHandler кодирует спецификацию один раз при запуске.
+func NewDocsHandler(doc *openapi.Document, logger *logrus.Logger) (*DocsHandler, error) {
+	spec, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("ошибка кодирования спецификации OpenAPI: %w", err)
+	}
+
+	return &DocsHandler{
+		spec:   spec,
+		logger: logger,
+	}, nil
+}
+
+func (h *DocsHandler) Spec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(h.spec); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка отправки спецификации: %v", err)
+	}
+}
+
+func (h *DocsHandler) SwaggerUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := w.Write([]byte(swaggerUIPage)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка отправки страницы документации: %v", err)
+	}
+}
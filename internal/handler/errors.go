@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/apierror"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/i18n"
+	"github.com/therealadik/bank-api/internal/problem"
+	"github.com/therealadik/bank-api/internal/service"
+	"github.com/therealadik/bank-api/internal/validation"
+)
+
+// writeError отвечает на ошибку сервиса на языке запроса. Известные ошибки
+// получают свой статус, код и сообщение из каталога; остальные логируются
+// и возвращаются как 500. Сообщение fallback пишется в лог и отдается
+// клиенту, если он запросил русский язык.
+func writeError(w http.ResponseWriter, r *http.Request, logger *logrus.Logger, err error, fallback string) {
+	locale := i18n.FromContext(r.Context())
+
+	var held *service.HeldTransferError
+	if errors.As(err, &held) {
+		writeHeldTransfer(w, r, logger, held)
+		return
+	}
+
+	if m, ok := apierror.Lookup(err); ok {
+		if m.Status >= http.StatusInternalServerError {
+			logger.WithContext(r.Context()).Errorf("%s: %v", fallback, err)
+		} else {
+			logger.WithContext(r.Context()).Warnf("%s: %v", fallback, err)
+		}
+
+		p := problem.New(m.Status, m.Code, i18n.T(locale, m.Code))
+
+		var challenge *service.ChallengeError
+		if errors.As(err, &challenge) {
+			p.With("challenge_id", challenge.ChallengeID)
+		}
+
+		var attempts *service.AttemptsError
+		if errors.As(err, &attempts) {
+			key := "too_many_attempts_retry"
+			if attempts.Locked {
+				key = "too_many_attempts_locked"
+			}
+			p.Detail = i18n.T(locale, key, attempts.RetryAfter.Round(time.Second))
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(attempts.RetryAfter.Seconds()))))
+		}
+
+		problem.Write(w, p)
+		return
+	}
+
+	logger.WithContext(r.Context()).Errorf("%s: %v", fallback, err)
+	detail := fallback
+	if locale != i18n.RU {
+		detail = i18n.T(locale, "internal_error")
+	}
+	problem.Write(w, problem.New(http.StatusInternalServerError, "internal_error", detail))
+}
+
+// writeHeldTransfer отвечает 202 на перевод, задержанный до проверки
+// получателя по санкционным спискам.
+func writeHeldTransfer(w http.ResponseWriter, r *http.Request, logger *logrus.Logger, held *service.HeldTransferError) {
+	logger.WithContext(r.Context()).Warnf("Перевод задержан до проверки получателя: %v", held)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	resp := dto.HeldTransferResponse{
+		Status:     string(held.Transfer.Status),
+		TransferID: held.Transfer.ID,
+		Amount:     held.Transfer.Amount,
+		Message:    i18n.T(i18n.FromContext(r.Context()), "transfer_held"),
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// decodeJSON читает тело запроса в dst и проверяет его по тегам validate.
+// При ошибке отправляет ответ и возвращает false.
+func decodeJSON(w http.ResponseWriter, r *http.Request, logger *logrus.Logger, dst any) bool {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		logger.WithContext(r.Context()).Warnf("Ошибка декодирования запроса %s: %v", r.URL.Path, err)
+		problem.Error(w, r, http.StatusBadRequest, "malformed_request")
+		return false
+	}
+
+	if errs := validation.Struct(dst, i18n.FromContext(r.Context())); len(errs) > 0 {
+		writeValidationErrors(w, r, errs...)
+		return false
+	}
+	return true
+}
+
+// writeValidationErrors отвечает 422 с ошибками по полям.
+func writeValidationErrors(w http.ResponseWriter, r *http.Request, errs ...problem.FieldError) {
+	p := problem.New(http.StatusUnprocessableEntity, "validation_failed",
+		i18n.T(i18n.FromContext(r.Context()), "validation_failed"))
+	p.Errors = errs
+	problem.Write(w, p)
+}
@@ -0,0 +1,487 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/models"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/aml"
+	"github.com/therealadik/bank-api/internal/models/approval"
+	"github.com/therealadik/bank-api/internal/models/batch"
+	"github.com/therealadik/bank-api/internal/models/beneficiary"
+	"github.com/therealadik/bank-api/internal/models/deposit"
+	"github.com/therealadik/bank-api/internal/models/fee"
+	"github.com/therealadik/bank-api/internal/models/kyc"
+	"github.com/therealadik/bank-api/internal/models/lockout"
+	"github.com/therealadik/bank-api/internal/models/notification"
+	"github.com/therealadik/bank-api/internal/models/overdraft"
+	"github.com/therealadik/bank-api/internal/models/p2p"
+	"github.com/therealadik/bank-api/internal/models/screening"
+	"github.com/therealadik/bank-api/internal/models/transaction"
+	"github.com/therealadik/bank-api/internal/models/webhook"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// Заглушки сервисов для контрактного теста. Каждая возвращает ошибку err из
+// любого метода, а без нее — успешный результат с нулевыми значениями, кроме
+// полей, которые настоящий сервис заполняет всегда.
+
+type fakeAuthService struct {
+	err error
+}
+
+func (s *fakeAuthService) Register(context.Context, dto.RegisterRequest) (int64, error) {
+	return 42, s.err
+}
+
+func (s *fakeAuthService) Login(context.Context, dto.LoginRequest, string) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	return "token", nil
+}
+
+func (s *fakeAuthService) ParseToken(context.Context, string) (int64, error) {
+	return 42, s.err
+}
+
+func (s *fakeAuthService) RequestEmailVerification(context.Context, string) error {
+	return s.err
+}
+
+func (s *fakeAuthService) VerifyEmail(context.Context, string) error {
+	return s.err
+}
+
+func (s *fakeAuthService) ForgotPassword(context.Context, string) error {
+	return s.err
+}
+
+func (s *fakeAuthService) ResetPassword(context.Context, string, string) error {
+	return s.err
+}
+
+type fakeAccountService struct {
+	err error
+}
+
+func (s *fakeAccountService) CreateAccount(context.Context, int64, account.Currency, account.Product) (*account.Account, error) {
+	return &account.Account{}, s.err
+}
+
+func (s *fakeAccountService) GetAccountByID(context.Context, int64, int64) (*account.Account, error) {
+	return &account.Account{}, s.err
+}
+
+func (s *fakeAccountService) GetAccountsByUserID(context.Context, int64) ([]*account.Account, error) {
+	return []*account.Account{{}}, s.err
+}
+
+func (s *fakeAccountService) GetTransactionsByAccountID(context.Context, int64, int64) ([]*transaction.Transaction, error) {
+	return []*transaction.Transaction{{}}, s.err
+}
+
+func (s *fakeAccountService) ResolveAccountID(context.Context, int64, string) (int64, error) {
+	return 1, s.err
+}
+
+func (s *fakeAccountService) ResolveAccountRef(context.Context, string) (int64, error) {
+	return 1, s.err
+}
+
+func (s *fakeAccountService) UpdateBalance(context.Context, int64, int64, decimal.Decimal) error {
+	return s.err
+}
+
+type fakeAMLService struct {
+	err error
+}
+
+func (s *fakeAMLService) GetCases(context.Context, aml.CaseStatus) ([]*aml.Case, error) {
+	return []*aml.Case{{}}, s.err
+}
+
+func (s *fakeAMLService) GetCase(context.Context, int64) (*aml.Case, []*transaction.Transaction, error) {
+	return &aml.Case{}, []*transaction.Transaction{{}}, s.err
+}
+
+func (s *fakeAMLService) Escalate(context.Context, int64, string) (*aml.Case, error) {
+	return &aml.Case{}, s.err
+}
+
+func (s *fakeAMLService) Dismiss(context.Context, int64, string) (*aml.Case, error) {
+	return &aml.Case{}, s.err
+}
+
+func (s *fakeAMLService) ExportSAR(context.Context, int64) ([]byte, string, error) {
+	return []byte(`{}`), "sar.json", s.err
+}
+
+type fakeApprovalService struct {
+	err error
+}
+
+func (s *fakeApprovalService) SubmitTransfer(context.Context, int64, int64, int64, decimal.Decimal,
+	decimal.NullDecimal) (*fee.Quote, *approval.Payment, error) {
+	return &fee.Quote{}, nil, s.err
+}
+
+func (s *fakeApprovalService) AddMember(context.Context, int64, int64, string, approval.Role) (*approval.Member, error) {
+	return &approval.Member{}, s.err
+}
+
+func (s *fakeApprovalService) GetMembers(context.Context, int64, int64) ([]*approval.Member, error) {
+	return []*approval.Member{{}}, s.err
+}
+
+func (s *fakeApprovalService) RemoveMember(context.Context, int64, int64, int64) error {
+	return s.err
+}
+
+func (s *fakeApprovalService) GetPolicy(context.Context, int64, int64) ([]*approval.PolicyBand, error) {
+	return []*approval.PolicyBand{{}}, s.err
+}
+
+func (s *fakeApprovalService) SetPolicy(context.Context, int64, int64, []approval.PolicyBand) ([]*approval.PolicyBand, error) {
+	return []*approval.PolicyBand{{}}, s.err
+}
+
+func (s *fakeApprovalService) GetPendingPayments(context.Context, int64) ([]*approval.Payment, error) {
+	return []*approval.Payment{{}}, s.err
+}
+
+func (s *fakeApprovalService) GetPayment(context.Context, int64, int64) (*approval.Payment, []*approval.PaymentDecision, error) {
+	return &approval.Payment{}, []*approval.PaymentDecision{{}}, s.err
+}
+
+func (s *fakeApprovalService) Approve(context.Context, int64, int64, string) (*approval.Payment, error) {
+	return &approval.Payment{}, s.err
+}
+
+func (s *fakeApprovalService) Reject(context.Context, int64, int64, string) (*approval.Payment, error) {
+	return &approval.Payment{}, s.err
+}
+
+type fakeBatchService struct {
+	err error
+}
+
+func (s *fakeBatchService) CreateBatch(context.Context, int64, int64, batch.Mode,
+	[]service.BatchRow) (*batch.Batch, []*batch.Item, error) {
+	return &batch.Batch{}, []*batch.Item{{}}, s.err
+}
+
+func (s *fakeBatchService) GetBatches(context.Context, int64) ([]*batch.Batch, error) {
+	return []*batch.Batch{{}}, s.err
+}
+
+func (s *fakeBatchService) GetBatch(context.Context, int64, int64) (*batch.Batch, []*batch.Item, error) {
+	return &batch.Batch{}, []*batch.Item{{}}, s.err
+}
+
+func (s *fakeBatchService) Execute(context.Context, int64, int64) (*batch.Batch, error) {
+	return &batch.Batch{}, s.err
+}
+
+type fakeBeneficiaryService struct {
+	err error
+}
+
+func (s *fakeBeneficiaryService) AddBeneficiary(context.Context, int64, string, int64,
+	string) (*beneficiary.Beneficiary, error) {
+	return &beneficiary.Beneficiary{}, s.err
+}
+
+func (s *fakeBeneficiaryService) GetBeneficiaries(context.Context, int64) ([]*beneficiary.Beneficiary, error) {
+	return []*beneficiary.Beneficiary{{}}, s.err
+}
+
+func (s *fakeBeneficiaryService) RenameBeneficiary(context.Context, int64, int64, string) (*beneficiary.Beneficiary, error) {
+	return &beneficiary.Beneficiary{}, s.err
+}
+
+func (s *fakeBeneficiaryService) DeleteBeneficiary(context.Context, int64, int64) error {
+	return s.err
+}
+
+func (s *fakeBeneficiaryService) SendVerificationCode(context.Context, int64, int64) error {
+	return s.err
+}
+
+func (s *fakeBeneficiaryService) VerifyBeneficiary(context.Context, int64, int64, string,
+	string) (*beneficiary.Beneficiary, error) {
+	return &beneficiary.Beneficiary{}, s.err
+}
+
+func (s *fakeBeneficiaryService) ResolveForTransfer(context.Context, int64, int64) (int64, error) {
+	return 2, s.err
+}
+
+type fakeCardService struct {
+	err error
+}
+
+func (s *fakeCardService) CreateCard(context.Context, int64, string) (*models.Card, map[string]string, error) {
+	return &models.Card{}, map[string]string{}, s.err
+}
+
+func (s *fakeCardService) GetUserCards(context.Context, int64) ([]*models.Card, error) {
+	return []*models.Card{{}}, s.err
+}
+
+func (s *fakeCardService) GetCardDetails(context.Context, int64, int64, string) (map[string]string, error) {
+	return map[string]string{}, s.err
+}
+
+func (s *fakeCardService) ProcessPayment(context.Context, int64, string, string, decimal.Decimal,
+	decimal.NullDecimal) (*fee.Quote, error) {
+	return &fee.Quote{}, s.err
+}
+
+type fakeDepositService struct {
+	err error
+}
+
+func (s *fakeDepositService) OpenDeposit(context.Context, int64, int64, decimal.Decimal, int,
+	bool) (*deposit.TermDeposit, error) {
+	return &deposit.TermDeposit{}, s.err
+}
+
+func (s *fakeDepositService) GetUserDeposits(context.Context, int64) ([]*deposit.TermDeposit, error) {
+	return []*deposit.TermDeposit{{}}, s.err
+}
+
+func (s *fakeDepositService) CloseEarly(context.Context, int64, int64) (*deposit.TermDeposit, error) {
+	return &deposit.TermDeposit{}, s.err
+}
+
+type fakeFeeService struct {
+	err error
+}
+
+func (s *fakeFeeService) GetRules(context.Context) ([]*fee.Rule, error) {
+	return []*fee.Rule{{}}, s.err
+}
+
+func (s *fakeFeeService) Quote(context.Context, int64, fee.Operation, decimal.Decimal) (*fee.Quote, error) {
+	return &fee.Quote{}, s.err
+}
+
+type fakeKYCService struct {
+	err error
+}
+
+func (s *fakeKYCService) GetProfile(context.Context, int64) (*kyc.Profile, error) {
+	return &kyc.Profile{}, s.err
+}
+
+func (s *fakeKYCService) UpdateProfile(context.Context, int64, string, string, string, string) (*kyc.Profile, error) {
+	return &kyc.Profile{}, s.err
+}
+
+func (s *fakeKYCService) GetDocuments(context.Context, int64) ([]*kyc.Document, error) {
+	return []*kyc.Document{{}}, s.err
+}
+
+func (s *fakeKYCService) UploadDocument(context.Context, int64, kyc.DocumentType, string, io.Reader) (*kyc.Document, error) {
+	return &kyc.Document{}, s.err
+}
+
+func (s *fakeKYCService) OpenDocument(context.Context, int64, int64) (*kyc.Document, io.ReadCloser, error) {
+	const content = "%PDF-1.7"
+	d := &kyc.Document{ContentType: "application/pdf", Size: int64(len(content))}
+	return d, io.NopCloser(strings.NewReader(content)), s.err
+}
+
+func (s *fakeKYCService) Submit(context.Context, int64) (*kyc.Profile, error) {
+	return &kyc.Profile{}, s.err
+}
+
+func (s *fakeKYCService) GetPending(context.Context) ([]*kyc.Profile, error) {
+	return []*kyc.Profile{{}}, s.err
+}
+
+func (s *fakeKYCService) Verify(context.Context, int64) (*kyc.Profile, error) {
+	return &kyc.Profile{}, s.err
+}
+
+func (s *fakeKYCService) Reject(context.Context, int64, string) (*kyc.Profile, error) {
+	return &kyc.Profile{}, s.err
+}
+
+type fakeLockoutService struct {
+	err error
+}
+
+func (s *fakeLockoutService) GetActive(context.Context, lockout.Scope) ([]*lockout.Lockout, error) {
+	return []*lockout.Lockout{{}}, s.err
+}
+
+func (s *fakeLockoutService) Release(context.Context, int64) (*lockout.Lockout, error) {
+	return &lockout.Lockout{}, s.err
+}
+
+type fakeNotificationService struct {
+	err error
+}
+
+func (s *fakeNotificationService) GetInbox(context.Context, int64, bool) ([]*notification.Notification, int, error) {
+	return []*notification.Notification{{}}, 1, s.err
+}
+
+func (s *fakeNotificationService) MarkRead(context.Context, int64, int64) error {
+	return s.err
+}
+
+func (s *fakeNotificationService) MarkAllRead(context.Context, int64) (int, error) {
+	return 1, s.err
+}
+
+func (s *fakeNotificationService) GetSettings(context.Context, int64) (*notification.Settings, error) {
+	return &notification.Settings{}, s.err
+}
+
+func (s *fakeNotificationService) UpdateSettings(context.Context, int64, notification.Locale,
+	[]notification.Preference) (*notification.Settings, error) {
+	return &notification.Settings{}, s.err
+}
+
+// fakeOverdraftService возвращает заявку вместо измененного счета, если
+// задан pending.
+type fakeOverdraftService struct {
+	err     error
+	pending bool
+}
+
+func (s *fakeOverdraftService) SetLimit(context.Context, int64, int64, decimal.Decimal) (*account.Account, *overdraft.Request, error) {
+	if s.pending {
+		return nil, &overdraft.Request{}, s.err
+	}
+	return &account.Account{}, nil, s.err
+}
+
+func (s *fakeOverdraftService) GetRequests(context.Context, overdraft.Status) ([]*overdraft.Request, error) {
+	return []*overdraft.Request{{}}, s.err
+}
+
+func (s *fakeOverdraftService) Approve(context.Context, int64, string) (*overdraft.Request, error) {
+	return &overdraft.Request{}, s.err
+}
+
+func (s *fakeOverdraftService) Reject(context.Context, int64, string) (*overdraft.Request, error) {
+	return &overdraft.Request{}, s.err
+}
+
+type fakeP2PService struct {
+	err error
+}
+
+func (s *fakeP2PService) Prepare(context.Context, int64, int64, string, decimal.Decimal) (*p2p.Transfer, string, error) {
+	return &p2p.Transfer{}, "Иван П.", s.err
+}
+
+func (s *fakeP2PService) Confirm(context.Context, int64, int64) (*p2p.Transfer, error) {
+	return &p2p.Transfer{}, s.err
+}
+
+type fakeRiskService struct {
+	err error
+}
+
+func (s *fakeRiskService) ConfirmChallenge(context.Context, int64, int64, string) error {
+	return s.err
+}
+
+type fakeScreeningService struct {
+	err error
+}
+
+func (s *fakeScreeningService) GetHits(context.Context, screening.HitStatus) ([]*screening.Hit, error) {
+	return []*screening.Hit{{}}, s.err
+}
+
+func (s *fakeScreeningService) GetHit(context.Context, int64) (*screening.Hit, error) {
+	return &screening.Hit{}, s.err
+}
+
+func (s *fakeScreeningService) Clear(context.Context, int64, string) (*screening.Hit, error) {
+	return &screening.Hit{}, s.err
+}
+
+func (s *fakeScreeningService) Confirm(context.Context, int64, string) (*screening.Hit, error) {
+	return &screening.Hit{}, s.err
+}
+
+// fakeStreamService отдает подписку с закрытым каналом, поэтому поток
+// завершается сразу после снимка балансов.
+type fakeStreamService struct {
+	err error
+}
+
+func (s *fakeStreamService) Subscribe(int64) *service.StreamSubscription {
+	ch := make(chan service.StreamMessage)
+	close(ch)
+	return &service.StreamSubscription{C: ch}
+}
+
+func (s *fakeStreamService) Unsubscribe(*service.StreamSubscription) {}
+
+func (s *fakeStreamService) Balances(context.Context, int64) ([]service.AccountBalance, error) {
+	return []service.AccountBalance{{}}, s.err
+}
+
+func (s *fakeStreamService) Replay(context.Context, int64, int64) ([]service.StreamMessage, error) {
+	return nil, s.err
+}
+
+type fakeUserService struct {
+	err error
+}
+
+func (s *fakeUserService) GetUser(context.Context, int64) (*models.User, error) {
+	return &models.User{}, s.err
+}
+
+func (s *fakeUserService) UpdateContacts(context.Context, int64, *string, *bool) (*models.User, error) {
+	return &models.User{}, s.err
+}
+
+func (s *fakeUserService) SendPhoneCode(context.Context, int64) error {
+	return s.err
+}
+
+func (s *fakeUserService) ConfirmPhone(context.Context, int64, string) (*models.User, error) {
+	return &models.User{}, s.err
+}
+
+type fakeWebhookService struct {
+	err error
+}
+
+func (s *fakeWebhookService) CreateSubscription(context.Context, int64, string, []string) (*webhook.Subscription, error) {
+	return &webhook.Subscription{EventTypes: []string{"transfer.completed"}}, s.err
+}
+
+func (s *fakeWebhookService) GetSubscriptions(context.Context, int64) ([]*webhook.Subscription, error) {
+	return []*webhook.Subscription{{EventTypes: []string{"transfer.completed"}}}, s.err
+}
+
+func (s *fakeWebhookService) DeleteSubscription(context.Context, int64, int64) error {
+	return s.err
+}
+
+func (s *fakeWebhookService) GetDeliveries(context.Context, int64, int64, webhook.Status) ([]*webhook.Delivery, error) {
+	return []*webhook.Delivery{{}}, s.err
+}
+
+func (s *fakeWebhookService) GetDelivery(context.Context, int64, int64) (*webhook.Delivery, []*webhook.Attempt, error) {
+	return &webhook.Delivery{}, []*webhook.Attempt{{}}, s.err
+}
+
+func (s *fakeWebhookService) Redeliver(context.Context, int64, int64) (*webhook.Delivery, error) {
+	return &webhook.Delivery{}, s.err
+}
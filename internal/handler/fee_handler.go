@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/models/fee"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+type FeeHandler struct {
+	feeService FeeService
+	logger     *logrus.Logger
+}
+
+func NewFeeHandler(feeService FeeService, logger *logrus.Logger) *FeeHandler {
+	return &FeeHandler{
+		feeService: feeService,
+		logger:     logger,
+	}
+}
+
+func (h *FeeHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.feeService.GetRules(r.Context())
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить тарифы")
+		return
+	}
+
+	resp := dto.FeeRuleListResponse{
+		Rules: make([]dto.FeeRuleResponse, 0, len(rules)),
+	}
+
+	for _, rule := range rules {
+		resp.Rules = append(resp.Rules, dto.FeeRuleResponse{
+			ID:         rule.ID,
+			Operation:  rule.Operation,
+			VolumeFrom: rule.VolumeFrom,
+			Fixed:      rule.Fixed,
+			Percent:    rule.Percent,
+			MinAmount:  rule.MinAmount,
+			MaxAmount:  rule.MaxAmount,
+			Active:     rule.Active,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// Quote рассчитывает комиссию за операцию до ее подтверждения.
+// Параметры запроса: operation и amount.
+func (h *FeeHandler) Quote(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	operation := fee.Operation(r.URL.Query().Get("operation"))
+	amount, err := decimal.NewFromString(r.URL.Query().Get("amount"))
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат суммы: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_amount_format")
+		return
+	}
+
+	quote, err := h.feeService.Quote(r.Context(), userID, operation, amount)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось рассчитать комиссию")
+		return
+	}
+
+	resp := dto.FeeQuoteResponse{
+		Operation: quote.Operation,
+		Amount:    quote.Amount,
+		Fee:       quote.Fee,
+		Total:     quote.Total,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
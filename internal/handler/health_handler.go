@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+type HealthHandler struct {
+	healthService *service.HealthService
+	logger        *logrus.Logger
+}
+
+func NewHealthHandler(healthService *service.HealthService, logger *logrus.Logger) *HealthHandler {
+	return &HealthHandler{
+		healthService: healthService,
+		logger:        logger,
+	}
+}
+
+// Liveness отвечает, пока процесс обслуживает запросы. Зависимости не
+// проверяются: при недоступной БД под не нужно перезапускать.
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	h.writeJSON(w, r, http.StatusOK, dto.HealthResponse{Status: "ok"})
+}
+
+// Readiness проверяет соединение с БД и версию схемы. Ответ 503 снимает под
+// с балансировки до восстановления.
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	ready := h.healthService.Readiness(r.Context())
+
+	resp := dto.ReadinessResponse{
+		Status:           "ok",
+		Database:         "ok",
+		MigrationVersion: ready.MigrationVersion,
+		ExpectedVersion:  ready.ExpectedVersion,
+		Dirty:            ready.Dirty,
+	}
+	if ready.Database != nil {
+		h.logger.WithContext(r.Context()).Warnf("Проверка готовности: БД недоступна: %v", ready.Database)
+		resp.Database = "unavailable"
+	}
+
+	status := http.StatusOK
+	if !ready.Ready {
+		resp.Status = "unavailable"
+		status = http.StatusServiceUnavailable
+	}
+	h.writeJSON(w, r, status, resp)
+}
+
+func (h *HealthHandler) writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
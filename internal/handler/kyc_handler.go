@@ -0,0 +1,331 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/models/kyc"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+// multipartMemory — сколько multipart-запроса держится в памяти; остальное
+// пишется во временные файлы.
+const multipartMemory = 1 << 20
+
+type KYCHandler struct {
+	kycService      KYCService
+	maxDocumentSize int64
+	logger          *logrus.Logger
+}
+
+func NewKYCHandler(kycService KYCService, maxDocumentSize int64, logger *logrus.Logger) *KYCHandler {
+	return &KYCHandler{
+		kycService:      kycService,
+		maxDocumentSize: maxDocumentSize,
+		logger:          logger,
+	}
+}
+
+func (h *KYCHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	p, err := h.kycService.GetProfile(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить анкету")
+		return
+	}
+
+	h.writeProfile(w, r, p)
+}
+
+// UpdateProfile сохраняет анкету клиента, пока она не отправлена на проверку.
+func (h *KYCHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.UpdateProfileRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	p, err := h.kycService.UpdateProfile(r.Context(), userID, req.FullName, req.DateOfBirth, req.Citizenship, req.Address)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось сохранить анкету")
+		return
+	}
+
+	h.writeProfile(w, r, p)
+}
+
+func (h *KYCHandler) GetDocuments(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	h.writeDocuments(w, r, userID)
+}
+
+// UploadDocument принимает документ в multipart/form-data: поле type —
+// тип документа, поле file — файл JPEG, PNG или PDF.
+func (h *KYCHandler) UploadDocument(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxDocumentSize+multipartMemory)
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			problem.Error(w, r, http.StatusRequestEntityTooLarge, "document_too_large")
+			return
+		}
+		h.logger.WithContext(r.Context()).Warnf("Ошибка разбора multipart-запроса: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "multipart_required")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		problem.Error(w, r, http.StatusBadRequest, "document_file_required")
+		return
+	}
+	defer file.Close()
+
+	d, err := h.kycService.UploadDocument(r.Context(), userID, kyc.DocumentType(r.FormValue("type")), header.Filename, file)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось загрузить документ")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newKYCDocumentResponse(d)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// Submit отправляет анкету на проверку.
+func (h *KYCHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	p, err := h.kycService.Submit(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось отправить анкету")
+		return
+	}
+
+	h.writeProfile(w, r, p)
+}
+
+// GetPending возвращает оператору анкеты, ожидающие проверки.
+func (h *KYCHandler) GetPending(w http.ResponseWriter, r *http.Request) {
+	profiles, err := h.kycService.GetPending(r.Context())
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить анкеты")
+		return
+	}
+
+	resp := make([]dto.ProfileResponse, 0, len(profiles))
+	for _, p := range profiles {
+		resp = append(resp, newProfileResponse(p))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *KYCHandler) GetCustomer(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	p, err := h.kycService.GetProfile(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить анкету")
+		return
+	}
+
+	h.writeProfile(w, r, p)
+}
+
+func (h *KYCHandler) GetCustomerDocuments(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	h.writeDocuments(w, r, userID)
+}
+
+// DownloadDocument отдает оператору содержимое документа клиента.
+func (h *KYCHandler) DownloadDocument(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	docID, err := strconv.ParseInt(mux.Vars(r)["docId"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID документа: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_document_id")
+		return
+	}
+
+	d, content, err := h.kycService.OpenDocument(r.Context(), userID, docID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить документ")
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", d.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(d.Size, 10))
+	w.Header().Set("Content-Disposition", "attachment; filename="+strconv.Quote(d.FileName))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if _, err := io.Copy(w, content); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка отправки документа %d: %v", d.ID, err)
+	}
+}
+
+func (h *KYCHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	p, err := h.kycService.Verify(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось сохранить решение")
+		return
+	}
+
+	h.logger.WithContext(r.Context()).Infof("Личность клиента %d подтверждена", userID)
+	h.writeProfile(w, r, p)
+}
+
+func (h *KYCHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.parseUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.RejectKYCRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	p, err := h.kycService.Reject(r.Context(), userID, req.Reason)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось сохранить решение")
+		return
+	}
+
+	h.logger.WithContext(r.Context()).Infof("Анкета клиента %d отклонена", userID)
+	h.writeProfile(w, r, p)
+}
+
+func (h *KYCHandler) parseUserID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	userID, err := strconv.ParseInt(mux.Vars(r)["userId"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID клиента: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_customer_id")
+		return 0, false
+	}
+	return userID, true
+}
+
+func (h *KYCHandler) writeProfile(w http.ResponseWriter, r *http.Request, p *kyc.Profile) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newProfileResponse(p)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *KYCHandler) writeDocuments(w http.ResponseWriter, r *http.Request, userID int64) {
+	documents, err := h.kycService.GetDocuments(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить документы")
+		return
+	}
+
+	resp := make([]dto.KYCDocumentResponse, 0, len(documents))
+	for _, d := range documents {
+		resp = append(resp, newKYCDocumentResponse(d))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func newProfileResponse(p *kyc.Profile) dto.ProfileResponse {
+	resp := dto.ProfileResponse{
+		UserID:       p.UserID,
+		FullName:     p.FullName,
+		Phone:        p.Phone,
+		Citizenship:  p.Citizenship,
+		Address:      p.Address,
+		KYCStatus:    p.Status,
+		RejectReason: p.RejectReason,
+		SubmittedAt:  formatOptionalTime(p.SubmittedAt),
+		ReviewedAt:   formatOptionalTime(p.ReviewedAt),
+	}
+	if p.DateOfBirth != nil {
+		dob := p.DateOfBirth.Format("2006-01-02")
+		resp.DateOfBirth = &dob
+	}
+	return resp
+}
+
+func newKYCDocumentResponse(d *kyc.Document) dto.KYCDocumentResponse {
+	return dto.KYCDocumentResponse{
+		ID:          d.ID,
+		Type:        d.Type,
+		FileName:    d.FileName,
+		ContentType: d.ContentType,
+		Size:        d.Size,
+		SHA256:      d.SHA256,
+		CreatedAt:   d.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.UTC().Format("2006-01-02T15:04:05Z")
+	return &s
+}
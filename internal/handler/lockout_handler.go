@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/models/lockout"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+// LockoutHandler — операторский API блокировок после перебора паролей и CVV.
+type LockoutHandler struct {
+	lockoutService LockoutService
+	logger         *logrus.Logger
+}
+
+func NewLockoutHandler(lockoutService LockoutService, logger *logrus.Logger) *LockoutHandler {
+	return &LockoutHandler{
+		lockoutService: lockoutService,
+		logger:         logger,
+	}
+}
+
+// GetActive возвращает действующие блокировки; ?scope=ACCOUNT оставляет
+// только блокировки учетных записей.
+func (h *LockoutHandler) GetActive(w http.ResponseWriter, r *http.Request) {
+	lockouts, err := h.lockoutService.GetActive(r.Context(), lockout.Scope(r.URL.Query().Get("scope")))
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить блокировки")
+		return
+	}
+
+	if lockouts == nil {
+		lockouts = []*lockout.Lockout{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(lockouts); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// Release досрочно снимает блокировку.
+func (h *LockoutHandler) Release(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		problem.Error(w, r, http.StatusBadRequest, "invalid_lockout_id")
+		return
+	}
+
+	l, err := h.lockoutService.Release(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось снять блокировку")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(l); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// clientIP возвращает адрес клиента из соединения. Заголовки прокси не
+// учитываются: их может подставить сам клиент.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
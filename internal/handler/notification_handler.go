@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/models/notification"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+type NotificationHandler struct {
+	notificationService NotificationService
+	logger              *logrus.Logger
+}
+
+func NewNotificationHandler(notificationService NotificationService, logger *logrus.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// GetNotifications возвращает входящие уведомления; ?unread=true оставляет
+// только непрочитанные.
+func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	notifications, unread, err := h.notificationService.GetInbox(r.Context(), userID, unreadOnly)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить уведомления")
+		return
+	}
+
+	resp := dto.NotificationListResponse{
+		Notifications: make([]dto.NotificationResponse, 0, len(notifications)),
+		Unread:        unread,
+	}
+
+	for _, n := range notifications {
+		resp.Notifications = append(resp.Notifications, dto.NotificationResponse{
+			ID:          n.ID,
+			Kind:        n.Kind,
+			Subject:     n.Subject,
+			Body:        n.Body,
+			Read:        n.ReadAt != nil,
+			EmailStatus: n.EmailStatus,
+			CreatedAt:   n.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	if err := h.notificationService.MarkRead(r.Context(), id, userID); err != nil {
+		writeError(w, r, h.logger, err, "Не удалось отметить уведомление")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	marked, err := h.notificationService.MarkAllRead(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось отметить уведомления")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(dto.MarkAllReadResponse{Marked: marked}); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *NotificationHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	settings, err := h.notificationService.GetSettings(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить настройки уведомлений")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newNotificationSettingsResponse(settings)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *NotificationHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.UpdateNotificationSettingsRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	prefs := make([]notification.Preference, 0, len(req.Preferences))
+	for _, p := range req.Preferences {
+		prefs = append(prefs, notification.Preference{Kind: p.Kind, Channel: p.Channel, Enabled: p.Enabled})
+	}
+
+	settings, err := h.notificationService.UpdateSettings(r.Context(), userID, req.Locale, prefs)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось сохранить настройки уведомлений")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newNotificationSettingsResponse(settings)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func newNotificationSettingsResponse(settings *notification.Settings) dto.NotificationSettingsResponse {
+	resp := dto.NotificationSettingsResponse{
+		Locale:      settings.Locale,
+		Preferences: make([]dto.NotificationPreference, 0, len(notification.Kinds)*len(notification.Channels)),
+	}
+
+	for _, kind := range notification.Kinds {
+		for _, channel := range notification.Channels {
+			resp.Preferences = append(resp.Preferences, dto.NotificationPreference{
+				Kind:    kind,
+				Channel: channel,
+				Enabled: settings.Enabled(kind, channel),
+			})
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/models/overdraft"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+// OverdraftHandler — установка лимита овердрафта клиентом и операторский
+// API заявок на его увеличение.
+type OverdraftHandler struct {
+	overdraftService OverdraftService
+	accountService   AccountService
+	logger           *logrus.Logger
+}
+
+func NewOverdraftHandler(overdraftService OverdraftService, accountService AccountService,
+	logger *logrus.Logger) *OverdraftHandler {
+	return &OverdraftHandler{
+		overdraftService: overdraftService,
+		accountService:   accountService,
+		logger:           logger,
+	}
+}
+
+// SetOverdraft отвечает 200 со счетом, если лимит применен сразу, и 202
+// с заявкой, если увеличение лимита ждет решения оператора.
+func (h *OverdraftHandler) SetOverdraft(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	accountID, err := h.accountService.ResolveAccountRef(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось найти счет")
+		return
+	}
+
+	var req dto.OverdraftRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	acc, request, err := h.overdraftService.SetLimit(r.Context(), accountID, userID, req.Limit)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось установить лимит овердрафта")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if request != nil {
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(request); err != nil {
+			h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+		}
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newAccountResponse(acc)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// GetRequests возвращает заявки; ?status=PENDING оставляет только
+// ожидающие решения.
+func (h *OverdraftHandler) GetRequests(w http.ResponseWriter, r *http.Request) {
+	status := overdraft.Status(r.URL.Query().Get("status"))
+
+	requests, err := h.overdraftService.GetRequests(r.Context(), status)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить заявки на овердрафт")
+		return
+	}
+
+	if requests == nil {
+		requests = []*overdraft.Request{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(requests); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// Approve одобряет заявку и устанавливает лимит.
+func (h *OverdraftHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, h.overdraftService.Approve)
+}
+
+// Reject отклоняет заявку; лимит остается прежним.
+func (h *OverdraftHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, h.overdraftService.Reject)
+}
+
+func (h *OverdraftHandler) resolve(w http.ResponseWriter, r *http.Request,
+	resolve func(ctx context.Context, id int64, comment string) (*overdraft.Request, error)) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	var req dto.ResolveOverdraftRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	request, err := resolve(r.Context(), id, req.Comment)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось сохранить решение")
+		return
+	}
+
+	h.logger.WithContext(r.Context()).Infof("Заявка на овердрафт %d рассмотрена: %s", request.ID, request.Status)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(request); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/models/p2p"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+type P2PHandler struct {
+	p2pService     P2PService
+	accountService AccountService
+	logger         *logrus.Logger
+}
+
+func NewP2PHandler(p2pService P2PService, accountService AccountService, logger *logrus.Logger) *P2PHandler {
+	return &P2PHandler{
+		p2pService:     p2pService,
+		accountService: accountService,
+		logger:         logger,
+	}
+}
+
+// Prepare создает P2P-перевод по email или телефону получателя и возвращает
+// маскированное имя получателя и комиссию для подтверждения.
+func (h *P2PHandler) Prepare(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.P2PTransferRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	fromID, err := h.accountService.ResolveAccountID(r.Context(), req.FromAccountID, req.FromAccountNumber)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось найти счет")
+		return
+	}
+
+	t, recipientName, err := h.p2pService.Prepare(r.Context(), userID, fromID, req.Recipient, req.Amount)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось подготовить перевод")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newP2PTransferResponse(t, recipientName)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *P2PHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	transferID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID перевода: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_transfer_id")
+		return
+	}
+
+	t, err := h.p2pService.Confirm(r.Context(), transferID, userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось выполнить перевод")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newP2PTransferResponse(t, "")); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func newP2PTransferResponse(t *p2p.Transfer, recipientName string) dto.P2PTransferResponse {
+	return dto.P2PTransferResponse{
+		ID:            t.ID,
+		FromAccountID: t.FromAccountID,
+		RecipientName: recipientName,
+		Amount:        t.Amount,
+		Fee:           t.Fee,
+		Total:         t.Amount.Add(t.Fee),
+		Status:        t.Status,
+		ExpiresAt:     t.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+type RiskHandler struct {
+	riskService RiskService
+	logger      *logrus.Logger
+}
+
+func NewRiskHandler(riskService RiskService, logger *logrus.Logger) *RiskHandler {
+	return &RiskHandler{
+		riskService: riskService,
+		logger:      logger,
+	}
+}
+
+// ConfirmChallenge подтверждает проверку паролем; после этого операцию можно
+// повторить с теми же параметрами.
+func (h *RiskHandler) ConfirmChallenge(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	var req dto.ConfirmChallengeRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	if err := h.riskService.ConfirmChallenge(r.Context(), id, userID, req.Password); err != nil {
+		writeError(w, r, h.logger, err, "Не удалось подтвердить операцию")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
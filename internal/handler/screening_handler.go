@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/models/screening"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+// ScreeningHandler — операторский API очереди совпадений с санкционными
+// списками.
+type ScreeningHandler struct {
+	screeningService ScreeningService
+	logger           *logrus.Logger
+}
+
+func NewScreeningHandler(screeningService ScreeningService, logger *logrus.Logger) *ScreeningHandler {
+	return &ScreeningHandler{
+		screeningService: screeningService,
+		logger:           logger,
+	}
+}
+
+// GetHits возвращает совпадения; ?status=PENDING оставляет только
+// ожидающие разбора.
+func (h *ScreeningHandler) GetHits(w http.ResponseWriter, r *http.Request) {
+	status := screening.HitStatus(r.URL.Query().Get("status"))
+
+	hits, err := h.screeningService.GetHits(r.Context(), status)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить совпадения")
+		return
+	}
+
+	if hits == nil {
+		hits = []*screening.Hit{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(hits); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *ScreeningHandler) GetHit(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	hit, err := h.screeningService.GetHit(r.Context(), id)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить совпадение")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(hit); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// Clear признает совпадение ложным.
+func (h *ScreeningHandler) Clear(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, h.screeningService.Clear)
+}
+
+// Confirm подтверждает совпадение со списком.
+func (h *ScreeningHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	h.resolve(w, r, h.screeningService.Confirm)
+}
+
+func (h *ScreeningHandler) resolve(w http.ResponseWriter, r *http.Request,
+	resolve func(ctx context.Context, id int64, comment string) (*screening.Hit, error)) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_id")
+		return
+	}
+
+	var req dto.ResolveHitRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	hit, err := resolve(r.Context(), id, req.Comment)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось сохранить решение")
+		return
+	}
+
+	h.logger.WithContext(r.Context()).Infof("Совпадение %d разобрано: %s", hit.ID, hit.Status)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(hit); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"context"
+	"io"
+
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/aml"
+	"github.com/therealadik/bank-api/internal/models/approval"
+	"github.com/therealadik/bank-api/internal/models/batch"
+	"github.com/therealadik/bank-api/internal/models/beneficiary"
+	"github.com/therealadik/bank-api/internal/models/deposit"
+	"github.com/therealadik/bank-api/internal/models/fee"
+	"github.com/therealadik/bank-api/internal/models/kyc"
+	"github.com/therealadik/bank-api/internal/models/lockout"
+	"github.com/therealadik/bank-api/internal/models/notification"
+	"github.com/therealadik/bank-api/internal/models/overdraft"
+	"github.com/therealadik/bank-api/internal/models/p2p"
+	"github.com/therealadik/bank-api/internal/models/screening"
+	"github.com/therealadik/bank-api/internal/models/transaction"
+	"github.com/therealadik/bank-api/internal/models/webhook"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// Обработчики зависят от интерфейсов с нужными им методами сервисов, а не от
+// типов пакета service: так контрактный тест подставляет вместо сервисов
+// заглушки. В main передаются сервисы из пакета service.
+
+type AccountService interface {
+	CreateAccount(ctx context.Context, userID int64, currency account.Currency, product account.Product) (*account.Account, error)
+	GetAccountByID(ctx context.Context, id, userID int64) (*account.Account, error)
+	GetAccountsByUserID(ctx context.Context, userID int64) ([]*account.Account, error)
+	GetTransactionsByAccountID(ctx context.Context, accountID, userID int64) ([]*transaction.Transaction, error)
+	ResolveAccountID(ctx context.Context, id int64, number string) (int64, error)
+	ResolveAccountRef(ctx context.Context, ref string) (int64, error)
+	UpdateBalance(ctx context.Context, id, userID int64, amount decimal.Decimal) error
+}
+
+type AMLService interface {
+	GetCases(ctx context.Context, status aml.CaseStatus) ([]*aml.Case, error)
+	GetCase(ctx context.Context, id int64) (*aml.Case, []*transaction.Transaction, error)
+	Escalate(ctx context.Context, id int64, comment string) (*aml.Case, error)
+	Dismiss(ctx context.Context, id int64, comment string) (*aml.Case, error)
+	ExportSAR(ctx context.Context, id int64) ([]byte, string, error)
+}
+
+type ApprovalService interface {
+	SubmitTransfer(ctx context.Context, fromID, toID, userID int64, amount decimal.Decimal,
+		expectedFee decimal.NullDecimal) (*fee.Quote, *approval.Payment, error)
+	AddMember(ctx context.Context, accountID, ownerID int64, email string, role approval.Role) (*approval.Member, error)
+	GetMembers(ctx context.Context, accountID, userID int64) ([]*approval.Member, error)
+	RemoveMember(ctx context.Context, accountID, ownerID, memberID int64) error
+	GetPolicy(ctx context.Context, accountID, userID int64) ([]*approval.PolicyBand, error)
+	SetPolicy(ctx context.Context, accountID, ownerID int64, bands []approval.PolicyBand) ([]*approval.PolicyBand, error)
+	GetPendingPayments(ctx context.Context, userID int64) ([]*approval.Payment, error)
+	GetPayment(ctx context.Context, id, userID int64) (*approval.Payment, []*approval.PaymentDecision, error)
+	Approve(ctx context.Context, id, userID int64, comment string) (*approval.Payment, error)
+	Reject(ctx context.Context, id, userID int64, comment string) (*approval.Payment, error)
+}
+
+type BatchService interface {
+	CreateBatch(ctx context.Context, userID, fromID int64, mode batch.Mode,
+		rows []service.BatchRow) (*batch.Batch, []*batch.Item, error)
+	GetBatches(ctx context.Context, userID int64) ([]*batch.Batch, error)
+	GetBatch(ctx context.Context, id, userID int64) (*batch.Batch, []*batch.Item, error)
+	Execute(ctx context.Context, id, userID int64) (*batch.Batch, error)
+}
+
+type BeneficiaryService interface {
+	AddBeneficiary(ctx context.Context, userID int64, nickname string, accountID int64,
+		accountNumber string) (*beneficiary.Beneficiary, error)
+	GetBeneficiaries(ctx context.Context, userID int64) ([]*beneficiary.Beneficiary, error)
+	RenameBeneficiary(ctx context.Context, id, userID int64, nickname string) (*beneficiary.Beneficiary, error)
+	DeleteBeneficiary(ctx context.Context, id, userID int64) error
+	SendVerificationCode(ctx context.Context, id, userID int64) error
+	VerifyBeneficiary(ctx context.Context, id, userID int64, password, code string) (*beneficiary.Beneficiary, error)
+	ResolveForTransfer(ctx context.Context, id, userID int64) (int64, error)
+}
+
+type CardService interface {
+	CreateCard(ctx context.Context, userID int64, pgpKey string) (*models.Card, map[string]string, error)
+	GetUserCards(ctx context.Context, userID int64) ([]*models.Card, error)
+	GetCardDetails(ctx context.Context, cardID, userID int64, pgpKey string) (map[string]string, error)
+	ProcessPayment(ctx context.Context, cardID int64, cvv, pgpKey string, amount decimal.Decimal,
+		expectedFee decimal.NullDecimal) (*fee.Quote, error)
+}
+
+type DepositService interface {
+	OpenDeposit(ctx context.Context, userID, sourceAccountID int64, amount decimal.Decimal, termMonths int,
+		autoRollover bool) (*deposit.TermDeposit, error)
+	GetUserDeposits(ctx context.Context, userID int64) ([]*deposit.TermDeposit, error)
+	CloseEarly(ctx context.Context, id, userID int64) (*deposit.TermDeposit, error)
+}
+
+type FeeService interface {
+	GetRules(ctx context.Context) ([]*fee.Rule, error)
+	Quote(ctx context.Context, userID int64, operation fee.Operation, amount decimal.Decimal) (*fee.Quote, error)
+}
+
+type KYCService interface {
+	GetProfile(ctx context.Context, userID int64) (*kyc.Profile, error)
+	UpdateProfile(ctx context.Context, userID int64, fullName, dateOfBirth, citizenship, address string) (*kyc.Profile, error)
+	GetDocuments(ctx context.Context, userID int64) ([]*kyc.Document, error)
+	UploadDocument(ctx context.Context, userID int64, docType kyc.DocumentType, fileName string,
+		r io.Reader) (*kyc.Document, error)
+	OpenDocument(ctx context.Context, userID, id int64) (*kyc.Document, io.ReadCloser, error)
+	Submit(ctx context.Context, userID int64) (*kyc.Profile, error)
+	GetPending(ctx context.Context) ([]*kyc.Profile, error)
+	Verify(ctx context.Context, userID int64) (*kyc.Profile, error)
+	Reject(ctx context.Context, userID int64, reason string) (*kyc.Profile, error)
+}
+
+type LockoutService interface {
+	GetActive(ctx context.Context, scope lockout.Scope) ([]*lockout.Lockout, error)
+	Release(ctx context.Context, id int64) (*lockout.Lockout, error)
+}
+
+type NotificationService interface {
+	GetInbox(ctx context.Context, userID int64, unreadOnly bool) ([]*notification.Notification, int, error)
+	MarkRead(ctx context.Context, id, userID int64) error
+	MarkAllRead(ctx context.Context, userID int64) (int, error)
+	GetSettings(ctx context.Context, userID int64) (*notification.Settings, error)
+	UpdateSettings(ctx context.Context, userID int64, locale notification.Locale,
+		prefs []notification.Preference) (*notification.Settings, error)
+}
+
+type OverdraftService interface {
+	SetLimit(ctx context.Context, accountID, userID int64, limit decimal.Decimal) (*account.Account, *overdraft.Request, error)
+	GetRequests(ctx context.Context, status overdraft.Status) ([]*overdraft.Request, error)
+	Approve(ctx context.Context, id int64, comment string) (*overdraft.Request, error)
+	Reject(ctx context.Context, id int64, comment string) (*overdraft.Request, error)
+}
+
+type P2PService interface {
+	Prepare(ctx context.Context, senderID, fromAccountID int64, recipient string,
+		amount decimal.Decimal) (*p2p.Transfer, string, error)
+	Confirm(ctx context.Context, id, senderID int64) (*p2p.Transfer, error)
+}
+
+type RiskService interface {
+	ConfirmChallenge(ctx context.Context, id, userID int64, password string) error
+}
+
+type ScreeningService interface {
+	GetHits(ctx context.Context, status screening.HitStatus) ([]*screening.Hit, error)
+	GetHit(ctx context.Context, id int64) (*screening.Hit, error)
+	Clear(ctx context.Context, id int64, comment string) (*screening.Hit, error)
+	Confirm(ctx context.Context, id int64, comment string) (*screening.Hit, error)
+}
+
+type StreamService interface {
+	Subscribe(userID int64) *service.StreamSubscription
+	Unsubscribe(sub *service.StreamSubscription)
+	Balances(ctx context.Context, userID int64) ([]service.AccountBalance, error)
+	Replay(ctx context.Context, userID, afterID int64) ([]service.StreamMessage, error)
+}
+
+type UserService interface {
+	GetUser(ctx context.Context, userID int64) (*models.User, error)
+	UpdateContacts(ctx context.Context, userID int64, phone *string, discoverable *bool) (*models.User, error)
+	SendPhoneCode(ctx context.Context, userID int64) error
+	ConfirmPhone(ctx context.Context, userID int64, code string) (*models.User, error)
+}
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, userID int64, rawURL string, eventTypes []string) (*webhook.Subscription, error)
+	GetSubscriptions(ctx context.Context, userID int64) ([]*webhook.Subscription, error)
+	DeleteSubscription(ctx context.Context, id, userID int64) error
+	GetDeliveries(ctx context.Context, subscriptionID, userID int64, status webhook.Status) ([]*webhook.Delivery, error)
+	GetDelivery(ctx context.Context, id, userID int64) (*webhook.Delivery, []*webhook.Attempt, error)
+	Redeliver(ctx context.Context, id, userID int64) (*webhook.Delivery, error)
+}
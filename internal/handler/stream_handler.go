@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/problem"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+const streamHeartbeatInterval = 15 * time.Second
+
+type StreamHandler struct {
+	streamService StreamService
+	logger        *logrus.Logger
+}
+
+func NewStreamHandler(streamService StreamService, logger *logrus.Logger) *StreamHandler {
+	return &StreamHandler{
+		streamService: streamService,
+		logger:        logger,
+	}
+}
+
+// Stream отдает поток Server-Sent Events с событиями по счетам и картам
+// пользователя. Сначала отправляется снимок балансов, затем — события после
+// Last-Event-ID (заголовок или параметр last_event_id), затем новые события.
+func (h *StreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	var afterID int64
+	if lastEventID != "" {
+		afterID, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || afterID < 0 {
+			problem.Error(w, r, http.StatusBadRequest, "invalid_last_event_id")
+			return
+		}
+	}
+
+	rc := http.NewResponseController(w)
+	// Поток живет дольше WriteTimeout сервера.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Потоковая передача не поддерживается: %v", err)
+		problem.Error(w, r, http.StatusInternalServerError, "streaming_unsupported")
+		return
+	}
+
+	// Подписка оформляется до чтения истории, чтобы не потерять события
+	// между повтором и живым потоком.
+	sub := h.streamService.Subscribe(userID)
+	defer h.streamService.Unsubscribe(sub)
+
+	balances, err := h.streamService.Balances(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось открыть поток событий")
+		return
+	}
+
+	var replay []service.StreamMessage
+	if afterID > 0 {
+		replay, err = h.streamService.Replay(r.Context(), userID, afterID)
+		if err != nil {
+			writeError(w, r, h.logger, err, "Не удалось открыть поток событий")
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeSSE(w, "", "balances", balances); err != nil {
+		return
+	}
+
+	sent := make(map[int64]bool, len(replay))
+	for _, msg := range replay {
+		if err := writeSSE(w, strconv.FormatInt(msg.Event.ID, 10), string(msg.Event.Type), msg); err != nil {
+			return
+		}
+		sent[msg.Event.ID] = true
+	}
+
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case msg, ok := <-sub.C:
+			if !ok {
+				// Клиент не успевал читать; он переподключится с Last-Event-ID.
+				return
+			}
+			if sent[msg.Event.ID] {
+				continue
+			}
+			if err := writeSSE(w, strconv.FormatInt(msg.Event.ID, 10), string(msg.Event.Type), msg); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, id, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
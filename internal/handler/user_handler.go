@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/models"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+type UserHandler struct {
+	userService UserService
+	logger      *logrus.Logger
+}
+
+func NewUserHandler(userService UserService, logger *logrus.Logger) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+		logger:      logger,
+	}
+}
+
+func (h *UserHandler) GetMe(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	user, err := h.userService.GetUser(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить данные пользователя")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newUserResponse(user)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// UpdateContacts меняет телефон пользователя и его видимость в справочнике P2P-переводов.
+func (h *UserHandler) UpdateContacts(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.UpdateContactsRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	user, err := h.userService.UpdateContacts(r.Context(), userID, req.Phone, req.Discoverable)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось обновить контакты")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newUserResponse(user)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+// SendPhoneCode повторно отправляет код подтверждения на номер пользователя.
+func (h *UserHandler) SendPhoneCode(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := h.userService.SendPhoneCode(r.Context(), userID); err != nil {
+		writeError(w, r, h.logger, err, "Не удалось отправить код подтверждения")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ConfirmPhone подтверждает номер кодом из SMS; после этого по номеру
+// можно найти пользователя для P2P-перевода.
+func (h *UserHandler) ConfirmPhone(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.ConfirmCodeRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	user, err := h.userService.ConfirmPhone(r.Context(), userID, req.Code)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось подтвердить номер")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newUserResponse(user)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func newUserResponse(user *models.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		FullName:      user.FullName,
+		Phone:         user.Phone,
+		PhoneVerified: user.PhoneVerifiedAt != nil,
+		Discoverable:  user.Discoverable,
+		KYCStatus:     user.KYCStatus,
+		CreatedAt:     user.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/middleware"
+	"github.com/therealadik/bank-api/internal/models/webhook"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+type WebhookHandler struct {
+	webhookService WebhookService
+	logger         *logrus.Logger
+}
+
+func NewWebhookHandler(webhookService WebhookService, logger *logrus.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		logger:         logger,
+	}
+}
+
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req dto.CreateWebhookRequest
+	if !decodeJSON(w, r, h.logger, &req) {
+		return
+	}
+
+	sub, err := h.webhookService.CreateSubscription(r.Context(), userID, req.URL, req.EventTypes)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось создать подписку")
+		return
+	}
+
+	resp := newWebhookResponse(sub)
+	resp.Secret = sub.Secret
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	subs, err := h.webhookService.GetSubscriptions(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить подписки")
+		return
+	}
+
+	resp := dto.WebhookListResponse{
+		Webhooks: make([]dto.WebhookResponse, 0, len(subs)),
+	}
+
+	for _, sub := range subs {
+		resp.Webhooks = append(resp.Webhooks, newWebhookResponse(sub))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.readIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(r.Context(), id, userID); err != nil {
+		writeError(w, r, h.logger, err, "Не удалось удалить подписку")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDeliveries возвращает журнал доставок подписки; ?status=DEAD показывает
+// очередь недоставленных событий.
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.readIDs(w, r)
+	if !ok {
+		return
+	}
+
+	status := webhook.Status(strings.ToUpper(r.URL.Query().Get("status")))
+	deliveries, err := h.webhookService.GetDeliveries(r.Context(), id, userID, status)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить журнал доставок")
+		return
+	}
+
+	resp := dto.WebhookDeliveryListResponse{
+		Deliveries: make([]dto.WebhookDeliveryResponse, 0, len(deliveries)),
+	}
+
+	for _, d := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, newWebhookDeliveryResponse(d, nil, false))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *WebhookHandler) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.readIDs(w, r)
+	if !ok {
+		return
+	}
+
+	d, attempts, err := h.webhookService.GetDelivery(r.Context(), id, userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось получить доставку")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newWebhookDeliveryResponse(d, attempts, true)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	userID, id, ok := h.readIDs(w, r)
+	if !ok {
+		return
+	}
+
+	d, err := h.webhookService.Redeliver(r.Context(), id, userID)
+	if err != nil {
+		writeError(w, r, h.logger, err, "Не удалось поставить доставку в очередь")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(newWebhookDeliveryResponse(d, nil, false)); err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка кодирования ответа: %v", err)
+	}
+}
+
+func (h *WebhookHandler) readIDs(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	userID, err := middleware.GetUserID(r.Context())
+	if err != nil {
+		h.logger.WithContext(r.Context()).Errorf("Ошибка получения userID из контекста: %v", err)
+		problem.Error(w, r, http.StatusUnauthorized, "unauthorized")
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		h.logger.WithContext(r.Context()).Warnf("Неверный формат ID: %v", err)
+		problem.Error(w, r, http.StatusBadRequest, "invalid_id")
+		return 0, 0, false
+	}
+
+	return userID, id, true
+}
+
+func newWebhookResponse(sub *webhook.Subscription) dto.WebhookResponse {
+	return dto.WebhookResponse{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		EventTypes: sub.EventTypes,
+		Active:     sub.Active,
+		CreatedAt:  sub.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func newWebhookDeliveryResponse(d *webhook.Delivery, attempts []*webhook.Attempt, withPayload bool) dto.WebhookDeliveryResponse {
+	resp := dto.WebhookDeliveryResponse{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if d.Status == webhook.PENDING {
+		resp.NextAttemptAt = d.NextAttemptAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+
+	if withPayload {
+		resp.Payload = d.Payload
+	}
+
+	for _, a := range attempts {
+		resp.AttemptLog = append(resp.AttemptLog, dto.WebhookAttemptResponse{
+			StatusCode: a.StatusCode,
+			Error:      a.Error,
+			DurationMs: a.DurationMs,
+			CreatedAt:  a.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,35 @@
+// Package httpx содержит общие обертки net/http для middleware.
+package httpx
+
+import "net/http"
+
+// StatusRecorder запоминает статус ответа для middleware метрик и
+// трассировки. Если обработчик не вызвал WriteHeader, статус — 200.
+type StatusRecorder struct {
+	http.ResponseWriter
+	Status      int
+	wroteHeader bool
+}
+
+func NewStatusRecorder(w http.ResponseWriter) *StatusRecorder {
+	return &StatusRecorder{ResponseWriter: w, Status: http.StatusOK}
+}
+
+func (r *StatusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.Status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *StatusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap открывает исходный ResponseWriter для http.ResponseController,
+// через который поток событий управляет сбросом буфера и таймаутами.
+func (r *StatusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
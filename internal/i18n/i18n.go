@@ -0,0 +1,95 @@
+// Package i18n выбирает язык ответа по заголовку Accept-Language и
+// переводит сообщения API по их коду. Каталог сообщений — в messages.go;
+// если перевода на выбранный язык нет, используется русский.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type Locale string
+
+const (
+	RU Locale = "ru"
+	EN Locale = "en"
+)
+
+// Default — язык, на котором написаны все сообщения каталога.
+const Default = RU
+
+var Locales = []Locale{RU, EN}
+
+// Supported сообщает, есть ли в каталоге сообщения на языке l.
+func Supported(l Locale) bool {
+	return slices.Contains(Locales, l)
+}
+
+// Parse выбирает язык по значению Accept-Language с учетом весов q,
+// например "en-US,en;q=0.9,ru;q=0.8". Региональные варианты сводятся
+// к основному языку. Если подходящего языка нет, возвращается Default.
+func Parse(header string) Locale {
+	best, bestQ := Default, 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= bestQ {
+			continue
+		}
+
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		l := Locale(primary)
+		if primary == "*" {
+			l = Default
+		}
+		if Supported(l) {
+			best, bestQ = l, q
+		}
+	}
+	return best
+}
+
+type contextKey struct{}
+
+// WithLocale сохраняет язык ответа в контексте запроса.
+func WithLocale(ctx context.Context, l Locale) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext возвращает язык ответа; если он не выбран — Default.
+func FromContext(ctx context.Context) Locale {
+	if l, ok := ctx.Value(contextKey{}).(Locale); ok {
+		return l
+	}
+	return Default
+}
+
+// T возвращает сообщение с кодом key на языке l. Аргументы подставляются
+// через fmt.Sprintf. Если перевода нет, берется русское сообщение, если нет
+// и его — возвращается сам код.
+func T(l Locale, key string, args ...any) string {
+	byLocale, ok := messages[key]
+	if !ok {
+		return key
+	}
+
+	msg, ok := byLocale[l]
+	if !ok {
+		msg = byLocale[Default]
+	}
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
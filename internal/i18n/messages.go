@@ -0,0 +1,758 @@
+package i18n
+
+// messages — каталог сообщений API: код → язык → текст. Коды ошибок
+// совпадают с полем code в ответах application/problem+json. Тексты могут
+// содержать глаголы fmt; аргументы передаются в T.
+var messages = map[string]map[Locale]string{
+	// Общие ошибки запроса.
+	"internal_error": {
+		RU: "Внутренняя ошибка сервера",
+		EN: "Internal server error",
+	},
+	"not_found": {
+		RU: "Ресурс не найден",
+		EN: "Resource not found",
+	},
+	"method_not_allowed": {
+		RU: "Метод не поддерживается",
+		EN: "Method not allowed",
+	},
+	"malformed_request": {
+		RU: "Неверный формат запроса",
+		EN: "Malformed request",
+	},
+	"validation_failed": {
+		RU: "Запрос содержит ошибки",
+		EN: "The request contains errors",
+	},
+	"unauthorized": {
+		RU: "Ошибка авторизации",
+		EN: "Authorization failed",
+	},
+	"authorization_required": {
+		RU: "Требуется авторизация",
+		EN: "Authorization required",
+	},
+	"malformed_token": {
+		RU: "Неверный формат токена",
+		EN: "Malformed token",
+	},
+	"invalid_access_token": {
+		RU: "Неверный или просроченный токен",
+		EN: "Invalid or expired token",
+	},
+	"operator_authorization_required": {
+		RU: "Требуется авторизация оператора",
+		EN: "Operator authorization required",
+	},
+	"invalid_operator_token": {
+		RU: "Неверный токен оператора",
+		EN: "Invalid operator token",
+	},
+	"invalid_id": {
+		RU: "Неверный ID",
+		EN: "Invalid ID",
+	},
+	"invalid_card_id": {
+		RU: "Неверный ID карты",
+		EN: "Invalid card ID",
+	},
+	"invalid_deposit_id": {
+		RU: "Неверный ID вклада",
+		EN: "Invalid deposit ID",
+	},
+	"invalid_transfer_id": {
+		RU: "Неверный ID перевода",
+		EN: "Invalid transfer ID",
+	},
+	"invalid_beneficiary_id": {
+		RU: "Неверный ID получателя",
+		EN: "Invalid beneficiary ID",
+	},
+	"invalid_member_id": {
+		RU: "Неверный ID участника",
+		EN: "Invalid member ID",
+	},
+	"invalid_payment_id": {
+		RU: "Неверный ID платежа",
+		EN: "Invalid payment ID",
+	},
+	"invalid_batch_id": {
+		RU: "Неверный ID пакета",
+		EN: "Invalid batch ID",
+	},
+	"invalid_customer_id": {
+		RU: "Неверный ID клиента",
+		EN: "Invalid customer ID",
+	},
+	"invalid_document_id": {
+		RU: "Неверный ID документа",
+		EN: "Invalid document ID",
+	},
+	"invalid_case_id": {
+		RU: "Неверный ID кейса",
+		EN: "Invalid case ID",
+	},
+	"invalid_lockout_id": {
+		RU: "Неверный ID блокировки",
+		EN: "Invalid lockout ID",
+	},
+	"invalid_amount_format": {
+		RU: "Неверный формат суммы",
+		EN: "Invalid amount format",
+	},
+	"invalid_csv": {
+		RU: "Неверный формат CSV: %v",
+		EN: "Invalid CSV: %v",
+	},
+	"multipart_required": {
+		RU: "Ожидается multipart/form-data с полями type и file",
+		EN: "Expected multipart/form-data with type and file fields",
+	},
+	"document_file_required": {
+		RU: "Файл документа обязателен",
+		EN: "The document file is required",
+	},
+	"invalid_last_event_id": {
+		RU: "Неверный Last-Event-ID",
+		EN: "Invalid Last-Event-ID",
+	},
+	"streaming_unsupported": {
+		RU: "Потоковая передача не поддерживается",
+		EN: "Streaming is not supported",
+	},
+	"stream_lagging": {
+		RU: "Клиент не успевает читать поток, переподключитесь с последним полученным событием",
+		EN: "The client is not keeping up with the stream, reconnect from the last received event",
+	},
+
+	// Ответы об успехе.
+	"user_registered": {
+		RU: "Пользователь успешно зарегистрирован, подтвердите адрес по ссылке из письма",
+		EN: "Registration complete, please confirm your email using the link we sent",
+	},
+	"card_payment_processed": {
+		RU: "Платеж успешно обработан",
+		EN: "Payment processed successfully",
+	},
+	"transfer_held": {
+		RU: "Перевод задержан до проверки получателя и будет исполнен после нее",
+		EN: "The transfer is on hold pending recipient screening and will be executed afterwards",
+	},
+
+	// Счета и переводы.
+	"insufficient_funds": {
+		RU: "недостаточно средств",
+		EN: "insufficient funds",
+	},
+	"same_account": {
+		RU: "нельзя переводить деньги на тот же счет",
+		EN: "cannot transfer money to the same account",
+	},
+	"invalid_amount": {
+		RU: "сумма не может быть отрицательной",
+		EN: "amount cannot be negative",
+	},
+	"zero_amount": {
+		RU: "сумма должна быть отлична от нуля",
+		EN: "amount must not be zero",
+	},
+	"unknown_product": {
+		RU: "неизвестный тип счета",
+		EN: "unknown account type",
+	},
+	"deposit_product": {
+		RU: "срочный вклад открывается через /api/deposits",
+		EN: "term deposits are opened via /api/deposits",
+	},
+	"account_locked": {
+		RU: "средства срочного вклада заблокированы до даты погашения",
+		EN: "term deposit funds are locked until maturity",
+	},
+	"unsupported_currency": {
+		RU: "нет курса для конвертации валюты",
+		EN: "no exchange rate for the currency",
+	},
+	"currency_mismatch": {
+		RU: "валюта счета получателя отличается от валюты счета списания",
+		EN: "the recipient account currency differs from the debit account currency",
+	},
+	"overdraft_not_available": {
+		RU: "овердрафт доступен только для текущих счетов",
+		EN: "overdraft is only available for current accounts",
+	},
+	"overdraft_limit_exceeded": {
+		RU: "запрошенный лимит овердрафта превышает допустимый",
+		EN: "the requested overdraft limit exceeds the maximum",
+	},
+	"overdraft_in_use": {
+		RU: "задолженность по овердрафту превышает новый лимит",
+		EN: "the overdraft balance exceeds the new limit",
+	},
+	"overdraft_request_pending": {
+		RU: "по счету уже есть заявка на овердрафт",
+		EN: "an overdraft request for this account is already pending",
+	},
+	"overdraft_request_not_found": {
+		RU: "заявка на овердрафт не найдена",
+		EN: "overdraft request not found",
+	},
+	"overdraft_request_not_pending": {
+		RU: "заявка на овердрафт уже рассмотрена",
+		EN: "the overdraft request has already been reviewed",
+	},
+	"unknown_overdraft_request_status": {
+		RU: "неизвестный статус заявки на овердрафт",
+		EN: "unknown overdraft request status",
+	},
+	"invalid_account_ref": {
+		RU: "неверный идентификатор счета",
+		EN: "invalid account identifier",
+	},
+	"invalid_account_number": {
+		RU: "неверный номер счета",
+		EN: "invalid account number",
+	},
+	"account_not_found": {
+		RU: "счет не найден",
+		EN: "account not found",
+	},
+	"no_payment_account": {
+		RU: "у владельца карты нет текущего счета для списания",
+		EN: "the card holder has no current account to debit",
+	},
+	"card_not_found": {
+		RU: "карта не найдена",
+		EN: "card not found",
+	},
+	"invalid_cvv": {
+		RU: "неверный CVV код",
+		EN: "invalid CVV code",
+	},
+	"card_expired": {
+		RU: "карта просрочена",
+		EN: "card has expired",
+	},
+	"recipient_not_found": {
+		RU: "получатель не найден",
+		EN: "recipient not found",
+	},
+	"self_transfer": {
+		RU: "для перевода между своими счетами используйте /api/transfer",
+		EN: "use /api/transfer for transfers between your own accounts",
+	},
+	"p2p_transfer_not_pending": {
+		RU: "перевод не найден, уже выполнен или срок подтверждения истек",
+		EN: "transfer not found, already completed or the confirmation period has expired",
+	},
+	"unknown_operation": {
+		RU: "неизвестный тип операции",
+		EN: "unknown operation type",
+	},
+	"fee_changed": {
+		RU: "комиссия изменилась, подтвердите операцию повторно",
+		EN: "the fee has changed, please confirm the operation again",
+	},
+
+	// Вклады.
+	"invalid_term": {
+		RU: "срок вклада должен быть от 1 до 60 месяцев",
+		EN: "deposit term must be between 1 and 60 months",
+	},
+	"deposit_not_found": {
+		RU: "вклад не найден",
+		EN: "deposit not found",
+	},
+	"deposit_closed": {
+		RU: "вклад уже закрыт",
+		EN: "deposit is already closed",
+	},
+	"invalid_source_account": {
+		RU: "вклад можно пополнить только с текущего счета",
+		EN: "a deposit can only be funded from a current account",
+	},
+
+	// Получатели.
+	"beneficiary_not_found": {
+		RU: "получатель не найден в списке",
+		EN: "beneficiary not found",
+	},
+	"beneficiary_exists": {
+		RU: "получатель с этим счетом уже добавлен",
+		EN: "a beneficiary with this account already exists",
+	},
+	"beneficiary_cooling_off": {
+		RU: "новый получатель станет доступен после периода ожидания или подтверждения кодом",
+		EN: "the new beneficiary becomes available after the cooling-off period or code confirmation",
+	},
+	"beneficiary_required": {
+		RU: "переводы на чужие счета выполняются через сохраненного получателя",
+		EN: "transfers to other customers' accounts require a saved beneficiary",
+	},
+	"beneficiary_already_verified": {
+		RU: "получатель уже подтвержден",
+		EN: "the beneficiary is already verified",
+	},
+	"invalid_nickname": {
+		RU: "название получателя должно содержать от 1 до 100 символов",
+		EN: "beneficiary nickname must be 1 to 100 characters long",
+	},
+	"own_account_beneficiary": {
+		RU: "нельзя добавить собственный счет в список получателей",
+		EN: "cannot add your own account as a beneficiary",
+	},
+
+	// Совместные счета и подтверждения.
+	"approval_required": {
+		RU: "перевод требует подтверждения по политике счета",
+		EN: "the transfer requires approval under the account policy",
+	},
+	"not_account_owner": {
+		RU: "управлять участниками и политикой может только владелец счета",
+		EN: "only the account owner can manage members and the policy",
+	},
+	"not_initiator": {
+		RU: "нет прав на создание платежей по счету",
+		EN: "not allowed to initiate payments from this account",
+	},
+	"not_approver": {
+		RU: "нет прав на подтверждение платежей по счету",
+		EN: "not allowed to approve payments from this account",
+	},
+	"self_approval": {
+		RU: "инициатор не может подтверждать собственный платеж",
+		EN: "the initiator cannot approve their own payment",
+	},
+	"already_decided": {
+		RU: "решение по платежу уже принято",
+		EN: "a decision on this payment has already been made",
+	},
+	"payment_not_found": {
+		RU: "платеж не найден",
+		EN: "payment not found",
+	},
+	"payment_not_pending": {
+		RU: "платеж уже обработан",
+		EN: "payment has already been processed",
+	},
+	"payment_failed": {
+		RU: "платеж одобрен, но не исполнен",
+		EN: "payment approved but not executed",
+	},
+	"payment_failed_insufficient_funds": {
+		RU: "платеж одобрен, но не исполнен: недостаточно средств",
+		EN: "payment approved but not executed: insufficient funds",
+	},
+	"invalid_role": {
+		RU: "неизвестная роль участника",
+		EN: "unknown member role",
+	},
+	"self_member": {
+		RU: "владелец счета не может быть добавлен участником",
+		EN: "the account owner cannot be added as a member",
+	},
+	"member_not_found": {
+		RU: "участник счета не найден",
+		EN: "account member not found",
+	},
+	"member_user_unknown": {
+		RU: "пользователь с таким email не найден",
+		EN: "no user with this email",
+	},
+	"invalid_policy": {
+		RU: "неверная политика подтверждений",
+		EN: "invalid approval policy",
+	},
+
+	// Пакетные платежи.
+	"batch_not_found": {
+		RU: "пакет платежей не найден",
+		EN: "payment batch not found",
+	},
+	"batch_empty": {
+		RU: "пакет не содержит строк для исполнения",
+		EN: "the batch has no rows to execute",
+	},
+	"batch_too_large": {
+		RU: "превышено максимальное число строк в пакете",
+		EN: "the batch exceeds the maximum number of rows",
+	},
+	"batch_invalid_rows": {
+		RU: "в режиме «все или ничего» пакет не должен содержать ошибочных строк",
+		EN: "an all-or-nothing batch must not contain invalid rows",
+	},
+	"batch_row_held": {
+		RU: "в режиме «все или ничего» получатель строки требует проверки по санкционным спискам",
+		EN: "in an all-or-nothing batch, a row recipient requires sanctions review",
+	},
+	"batch_not_draft": {
+		RU: "пакет уже отправлен на исполнение",
+		EN: "the batch has already been submitted",
+	},
+	"invalid_batch_mode": {
+		RU: "неизвестный режим исполнения пакета",
+		EN: "unknown batch execution mode",
+	},
+
+	// Пользователи и вход.
+	"invalid_credentials": {
+		RU: "неверные учетные данные",
+		EN: "invalid credentials",
+	},
+	"user_exists": {
+		RU: "пользователь уже существует",
+		EN: "user already exists",
+	},
+	"full_name_required": {
+		RU: "укажите полное имя",
+		EN: "full name is required",
+	},
+	"user_held": {
+		RU: "учетная запись проходит проверку",
+		EN: "the account is under review",
+	},
+	"user_blocked": {
+		RU: "учетная запись заблокирована",
+		EN: "the account is blocked",
+	},
+	"email_not_verified": {
+		RU: "адрес электронной почты не подтвержден",
+		EN: "email address is not verified",
+	},
+	"invalid_token": {
+		RU: "ссылка недействительна или устарела",
+		EN: "the link is invalid or has expired",
+	},
+	"weak_password": {
+		RU: "пароль должен быть не короче 6 символов",
+		EN: "password must be at least 6 characters long",
+	},
+	"invalid_phone": {
+		RU: "неверный формат номера телефона",
+		EN: "invalid phone number format",
+	},
+	"phone_taken": {
+		RU: "номер телефона уже используется",
+		EN: "phone number is already in use",
+	},
+	"no_phone": {
+		RU: "номер телефона не указан",
+		EN: "no phone number is set",
+	},
+	"phone_already_verified": {
+		RU: "номер телефона уже подтвержден",
+		EN: "the phone number is already confirmed",
+	},
+	"invalid_code": {
+		RU: "неверный или устаревший код подтверждения",
+		EN: "invalid or expired confirmation code",
+	},
+	"user_not_found": {
+		RU: "пользователь не найден",
+		EN: "user not found",
+	},
+	"too_many_attempts": {
+		RU: "слишком много неудачных попыток",
+		EN: "too many failed attempts",
+	},
+	"too_many_attempts_retry": {
+		RU: "слишком много неудачных попыток: повторите через %s",
+		EN: "too many failed attempts: retry in %s",
+	},
+	"too_many_attempts_locked": {
+		RU: "слишком много неудачных попыток: временная блокировка еще %s",
+		EN: "too many failed attempts: temporarily locked for %s",
+	},
+	"lockout_not_found": {
+		RU: "активная блокировка не найдена",
+		EN: "active lockout not found",
+	},
+	"unknown_lockout_scope": {
+		RU: "неизвестный вид блокировки",
+		EN: "unknown lockout scope",
+	},
+
+	// Антифрод, санкционные списки, KYC и AML.
+	"risk_blocked": {
+		RU: "операция отклонена системой безопасности",
+		EN: "the operation was declined by the security system",
+	},
+	"risk_challenge": {
+		RU: "операция требует дополнительного подтверждения",
+		EN: "the operation requires additional confirmation",
+	},
+	"challenge_not_found": {
+		RU: "проверка не найдена или уже подтверждена",
+		EN: "challenge not found or already confirmed",
+	},
+	"counterparty_blocked": {
+		RU: "переводы этому получателю запрещены",
+		EN: "transfers to this recipient are prohibited",
+	},
+	"hit_not_found": {
+		RU: "совпадение не найдено",
+		EN: "screening hit not found",
+	},
+	"hit_not_pending": {
+		RU: "совпадение уже разобрано",
+		EN: "screening hit has already been resolved",
+	},
+	"unknown_hit_status": {
+		RU: "неизвестный статус совпадения",
+		EN: "unknown screening hit status",
+	},
+	"kyc_required": {
+		RU: "операция доступна после подтверждения личности",
+		EN: "the operation is available after identity verification",
+	},
+	"kyc_profile_locked": {
+		RU: "анкета на проверке или уже подтверждена",
+		EN: "the profile is under review or already verified",
+	},
+	"kyc_profile_incomplete": {
+		RU: "заполните анкету: имя, дата рождения, гражданство и адрес",
+		EN: "complete the profile: name, date of birth, citizenship and address",
+	},
+	"kyc_documents_missing": {
+		RU: "загрузите документ, удостоверяющий личность",
+		EN: "upload an identity document",
+	},
+	"kyc_not_pending": {
+		RU: "анкета не ожидает проверки",
+		EN: "the profile is not awaiting review",
+	},
+	"kyc_reject_reason_required": {
+		RU: "укажите причину отказа",
+		EN: "a rejection reason is required",
+	},
+	"invalid_date_of_birth": {
+		RU: "неверная дата рождения",
+		EN: "invalid date of birth",
+	},
+	"invalid_citizenship": {
+		RU: "гражданство указывается двухбуквенным кодом ISO 3166",
+		EN: "citizenship must be a two-letter ISO 3166 code",
+	},
+	"unknown_document_type": {
+		RU: "неизвестный тип документа",
+		EN: "unknown document type",
+	},
+	"document_too_large": {
+		RU: "файл документа слишком большой",
+		EN: "the document file is too large",
+	},
+	"document_format": {
+		RU: "документ должен быть в формате JPEG, PNG или PDF",
+		EN: "the document must be a JPEG, PNG or PDF file",
+	},
+	"document_not_found": {
+		RU: "документ не найден",
+		EN: "document not found",
+	},
+	"aml_case_not_found": {
+		RU: "кейс не найден",
+		EN: "case not found",
+	},
+	"aml_case_not_open": {
+		RU: "кейс уже разобран",
+		EN: "the case has already been resolved",
+	},
+	"aml_case_not_escalated": {
+		RU: "сообщение формируется только по эскалированному кейсу",
+		EN: "a report can only be produced for an escalated case",
+	},
+	"unknown_aml_case_status": {
+		RU: "неизвестный статус кейса",
+		EN: "unknown case status",
+	},
+
+	// Уведомления и webhook.
+	"unsupported_locale": {
+		RU: "язык уведомлений не поддерживается",
+		EN: "notification language is not supported",
+	},
+	"unknown_notification_kind": {
+		RU: "неизвестный вид уведомления",
+		EN: "unknown notification kind",
+	},
+	"unknown_channel": {
+		RU: "неизвестный канал уведомлений",
+		EN: "unknown notification channel",
+	},
+	"notification_not_found": {
+		RU: "уведомление не найдено",
+		EN: "notification not found",
+	},
+	"invalid_webhook_url": {
+		RU: "адрес webhook должен быть абсолютным HTTPS URL",
+		EN: "webhook URL must be an absolute HTTPS URL",
+	},
+	"webhook_host_forbidden": {
+		RU: "адрес webhook указывает на внутреннюю сеть",
+		EN: "webhook URL points to an internal network",
+	},
+	"unknown_event_type": {
+		RU: "неизвестный тип события",
+		EN: "unknown event type",
+	},
+	"subscription_not_found": {
+		RU: "подписка не найдена",
+		EN: "subscription not found",
+	},
+	"delivery_not_found": {
+		RU: "доставка не найдена",
+		EN: "delivery not found",
+	},
+	"invalid_status_filter": {
+		RU: "неизвестный статус доставки",
+		EN: "unknown delivery status",
+	},
+
+	// Ошибки в полях запроса.
+	"validation.required": {
+		RU: "обязательное поле",
+		EN: "required field",
+	},
+	"validation.email": {
+		RU: "неверный формат email",
+		EN: "invalid email format",
+	},
+	"validation.digits": {
+		RU: "допускаются только цифры",
+		EN: "only digits are allowed",
+	},
+	"validation.decimal": {
+		RU: "неверный формат числа",
+		EN: "invalid number format",
+	},
+	"validation.positive": {
+		RU: "значение должно быть больше нуля",
+		EN: "value must be greater than zero",
+	},
+	"validation.oneof": {
+		RU: "допустимые значения: %s",
+		EN: "allowed values: %s",
+	},
+	"validation.min.chars": {
+		RU: "не менее %d символов",
+		EN: "at least %d characters",
+	},
+	"validation.max.chars": {
+		RU: "не более %d символов",
+		EN: "at most %d characters",
+	},
+	"validation.len.chars": {
+		RU: "ровно %d символов",
+		EN: "exactly %d characters",
+	},
+	"validation.min.items": {
+		RU: "не менее %d элементов",
+		EN: "at least %d items",
+	},
+	"validation.max.items": {
+		RU: "не более %d элементов",
+		EN: "at most %d items",
+	},
+	"validation.len.items": {
+		RU: "ровно %d элементов",
+		EN: "exactly %d items",
+	},
+	"validation.min.value": {
+		RU: "значение должно быть не меньше %s",
+		EN: "value must be at least %s",
+	},
+	"validation.max.value": {
+		RU: "значение должно быть не больше %s",
+		EN: "value must be at most %s",
+	},
+	"validation.len.value": {
+		RU: "значение должно быть равно %s",
+		EN: "value must be equal to %s",
+	},
+
+	// Письма со ссылками подтверждения адреса и сброса пароля.
+	"mail.verify_email.subject": {
+		RU: "Подтверждение адреса электронной почты",
+		EN: "Confirm your email address",
+	},
+	"mail.verify_email.body": {
+		RU: "Чтобы подтвердить адрес, перейдите по ссылке:\n%s\n\nСсылка действует до %s.",
+		EN: "To confirm your email address, follow the link:\n%s\n\nThe link is valid until %s.",
+	},
+	"mail.reset_password.subject": {
+		RU: "Сброс пароля",
+		EN: "Password reset",
+	},
+	"mail.reset_password.body": {
+		RU: "Чтобы задать новый пароль, перейдите по ссылке:\n%s\n\nСсылка действует до %s. " +
+			"Если вы не запрашивали сброс, просто проигнорируйте это письмо.",
+		EN: "To set a new password, follow the link:\n%s\n\nThe link is valid until %s. " +
+			"If you did not request a reset, just ignore this email.",
+	},
+
+	// Одноразовые коды подтверждения, ключ — otp.<назначение>.
+	"otp.phone_verify.subject": {
+		RU: "Подтверждение номера телефона",
+		EN: "Confirm your phone number",
+	},
+	"otp.phone_verify.body": {
+		RU: "Код подтверждения номера: %s. Код действует %d мин. Никому его не сообщайте.",
+		EN: "Your phone confirmation code: %s. It is valid for %d min. Do not share it with anyone.",
+	},
+	"otp.beneficiary_verify.subject": {
+		RU: "Подтверждение нового получателя",
+		EN: "Confirm a new payee",
+	},
+	"otp.beneficiary_verify.body": {
+		RU: "Код для подтверждения нового получателя платежей: %s. Код действует %d мин.\n\n" +
+			"Если вы не добавляли получателя, никому не сообщайте код и смените пароль.",
+		EN: "Your code to confirm a new payee: %s. It is valid for %d min.\n\n" +
+			"If you did not add a payee, do not share the code with anyone and change your password.",
+	},
+
+	// Форматы дат в письмах и уведомлениях.
+	"format.datetime": {
+		RU: "02.01.2006 15:04 MST",
+		EN: "2006-01-02 15:04 MST",
+	},
+
+	// Шаблоны уведомлений (text/template), ключ — notification.<вид>.
+	"notification.REGISTRATION.subject": {
+		RU: "Добро пожаловать в банк",
+		EN: "Welcome to the bank",
+	},
+	"notification.REGISTRATION.body": {
+		RU: "Здравствуйте!\n\nВы зарегистрировались в банке с адресом {{.Email}}.\n" +
+			"Если это были не вы, свяжитесь со службой поддержки.",
+		EN: "Hello!\n\nYou have registered with the bank using {{.Email}}.\n" +
+			"If this wasn't you, please contact support.",
+	},
+	"notification.CARD_ISSUED.subject": {
+		RU: "Выпущена новая карта",
+		EN: "A new card has been issued",
+	},
+	"notification.CARD_ISSUED.body": {
+		RU: "Карта №{{.CardID}} выпущена и готова к использованию.",
+		EN: "Card #{{.CardID}} has been issued and is ready to use.",
+	},
+	"notification.LARGE_TRANSFER.subject": {
+		RU: "Крупный перевод со счета {{.AccountNumber}}",
+		EN: "Large transfer from account {{.AccountNumber}}",
+	},
+	"notification.LARGE_TRANSFER.body": {
+		RU: "Со счета {{.AccountNumber}} переведено {{.Amount}} {{.Currency}}, комиссия {{.Fee}} {{.Currency}}.\n" +
+			"Если вы не совершали этот перевод, срочно свяжитесь с банком.",
+		EN: "{{.Amount}} {{.Currency}} has been transferred from account {{.AccountNumber}}, fee {{.Fee}} {{.Currency}}.\n" +
+			"If you did not make this transfer, contact the bank immediately.",
+	},
+	"notification.OVERDRAFT_LIMIT_BREACHED.subject": {
+		RU: "Превышен лимит овердрафта по счету {{.AccountNumber}}",
+		EN: "Overdraft limit exceeded on account {{.AccountNumber}}",
+	},
+	"notification.OVERDRAFT_LIMIT_BREACHED.body": {
+		RU: "После начисления процентов баланс счета {{.AccountNumber}} составил {{.Balance}} {{.Currency}} " +
+			"при лимите овердрафта {{.OverdraftLimit}} {{.Currency}}.\nПополните счет, чтобы погасить превышение.",
+		EN: "After interest was charged, the balance of account {{.AccountNumber}} is {{.Balance}} {{.Currency}} " +
+			"with an overdraft limit of {{.OverdraftLimit}} {{.Currency}}.\nPlease top up the account to cover the excess.",
+	},
+}
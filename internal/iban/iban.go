@@ -0,0 +1,183 @@
+// Package iban формирует и проверяет номера счетов с контрольными цифрами
+// по ISO 7064 MOD 97-10: как в полном формате IBAN, так и в национальном
+// цифровом формате, где две последние цифры являются контрольными.
+package iban
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	minLength     = 15
+	maxLength     = 34
+	accountDigits = 12
+)
+
+var (
+	ErrInvalidFormat   = errors.New("неверный формат номера счета")
+	ErrInvalidChecksum = errors.New("неверные контрольные цифры номера счета")
+
+	ErrInvalidCountryCode = errors.New("код страны должен состоять из двух латинских букв")
+	ErrInvalidBankCode    = errors.New("неверный код банка")
+)
+
+// Generator выпускает новые номера счетов. Если CountryCode пуст,
+// номер формируется в национальном формате: код банка, 12 случайных цифр
+// и 2 контрольные цифры.
+type Generator struct {
+	CountryCode string
+	BankCode    string
+}
+
+func (g Generator) Generate() (string, error) {
+	accountPart, err := randomDigits(accountDigits)
+	if err != nil {
+		return "", err
+	}
+
+	bban := g.BankCode + accountPart
+	if g.CountryCode == "" {
+		return bban + checkDigits(bban), nil
+	}
+
+	return FormatIBAN(g.CountryCode, bban)
+}
+
+// Validate проверяет параметры выпуска, чтобы выпущенные номера проходили
+// проверку Validate. В национальном формате код банка состоит только из
+// цифр, в IBAN допускаются и латинские буквы в верхнем регистре.
+func (g Generator) Validate() error {
+	if g.CountryCode != "" && (len(g.CountryCode) != 2 || !isLetters(g.CountryCode)) {
+		return ErrInvalidCountryCode
+	}
+
+	if g.BankCode == "" || !isAlphanumeric(g.BankCode) {
+		return ErrInvalidBankCode
+	}
+
+	length := len(g.BankCode) + accountDigits + 2
+	if g.CountryCode == "" {
+		if !isDigits(g.BankCode) {
+			return ErrInvalidBankCode
+		}
+	} else {
+		length += 2
+	}
+
+	if length > maxLength {
+		return ErrInvalidBankCode
+	}
+	return nil
+}
+
+// FormatIBAN собирает IBAN из кода страны и BBAN, вычисляя контрольные цифры.
+func FormatIBAN(countryCode, bban string) (string, error) {
+	countryCode = strings.ToUpper(countryCode)
+	if len(countryCode) != 2 || !isLetters(countryCode) {
+		return "", ErrInvalidFormat
+	}
+
+	digits := checkDigits(bban + countryCode)
+	return countryCode + digits + bban, nil
+}
+
+// Normalize удаляет пробелы и приводит номер к верхнему регистру.
+func Normalize(number string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(number), ""))
+}
+
+// Validate проверяет длину, алфавит и контрольные цифры номера.
+// Номер, начинающийся с двух букв, проверяется как IBAN.
+func Validate(number string) error {
+	number = Normalize(number)
+	if len(number) < minLength || len(number) > maxLength || !isAlphanumeric(number) {
+		return ErrInvalidFormat
+	}
+
+	if isLetters(number[:2]) {
+		if !isDigits(number[2:4]) {
+			return ErrInvalidFormat
+		}
+		number = number[4:] + number[:4]
+	} else if !isDigits(number) {
+		return ErrInvalidFormat
+	}
+
+	remainder, err := mod97(number)
+	if err != nil {
+		return err
+	}
+	if remainder != 1 {
+		return ErrInvalidChecksum
+	}
+	return nil
+}
+
+// LooksLikeNumber отличает номер счета от внутреннего числового ID по длине.
+func LooksLikeNumber(value string) bool {
+	return len(Normalize(value)) >= minLength
+}
+
+func checkDigits(value string) string {
+	remainder, _ := mod97(value + "00")
+	return fmt.Sprintf("%02d", 98-remainder)
+}
+
+// mod97 вычисляет остаток от деления на 97, заменяя буквы числами A=10 … Z=35.
+func mod97(value string) (int, error) {
+	remainder := 0
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		default:
+			return 0, ErrInvalidFormat
+		}
+	}
+	return remainder, nil
+}
+
+func randomDigits(n int) (string, error) {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		d, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(byte('0' + d.Int64()))
+	}
+	return b.String(), nil
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package iban
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		want   error
+	}{
+		{"IBAN Великобритании", "GB82WEST12345698765432", nil},
+		{"IBAN Германии", "DE89370400440532013000", nil},
+		{"IBAN с пробелами и в нижнем регистре", "gb82 west 1234 5698 7654 32", nil},
+		{"национальный формат", "0452512345678901203", nil},
+		{"IBAN с неверными контрольными цифрами", "GB83WEST12345698765432", ErrInvalidChecksum},
+		{"переставленные цифры", "GB82WEST12345698765423", ErrInvalidChecksum},
+		{"национальный с неверными контрольными цифрами", "0452512345678901204", ErrInvalidChecksum},
+		{"слишком короткий", "04525123456", ErrInvalidFormat},
+		{"слишком длинный", "GB82" + strings.Repeat("1", 31), ErrInvalidFormat},
+		{"недопустимый символ", "GB82WEST1234569876543-", ErrInvalidFormat},
+		{"буквы вместо контрольных цифр", "GBXXWEST12345698765432", ErrInvalidFormat},
+		{"буквы в национальном формате", "04525ABC456789012031", ErrInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Validate(tt.number); !errors.Is(err, tt.want) {
+				t.Errorf("Validate(%q) = %v, ожидалось %v", tt.number, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatIBAN(t *testing.T) {
+	tests := []struct {
+		country, bban string
+		want          string
+		wantErr       error
+	}{
+		{"GB", "WEST12345698765432", "GB82WEST12345698765432", nil},
+		{"de", "370400440532013000", "DE89370400440532013000", nil},
+		{"RU", "04525123456789012", "RU7404525123456789012", nil},
+		{"G", "04525123456789012", "", ErrInvalidFormat},
+		{"R1", "04525123456789012", "", ErrInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country+tt.bban, func(t *testing.T) {
+			got, err := FormatIBAN(tt.country, tt.bban)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ошибка %v, ожидалось %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FormatIBAN(%q, %q) = %q, ожидалось %q", tt.country, tt.bban, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeneratorValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		gen  Generator
+		want error
+	}{
+		{"национальный формат", Generator{BankCode: "04525"}, nil},
+		{"IBAN с цифровым кодом банка", Generator{CountryCode: "RU", BankCode: "04525"}, nil},
+		{"IBAN с буквенным кодом банка", Generator{CountryCode: "GB", BankCode: "WEST123456"}, nil},
+		{"пустой код банка", Generator{}, ErrInvalidBankCode},
+		{"буквы в национальном формате", Generator{BankCode: "WEST"}, ErrInvalidBankCode},
+		{"нижний регистр", Generator{CountryCode: "GB", BankCode: "west"}, ErrInvalidBankCode},
+		{"недопустимый символ", Generator{BankCode: "045-25"}, ErrInvalidBankCode},
+		{"номер длиннее 34 символов", Generator{CountryCode: "RU", BankCode: strings.Repeat("1", 19)}, ErrInvalidBankCode},
+		{"предельная длина IBAN", Generator{CountryCode: "RU", BankCode: strings.Repeat("1", 18)}, nil},
+		{"код страны из трех букв", Generator{CountryCode: "RUS", BankCode: "04525"}, ErrInvalidCountryCode},
+		{"код страны в нижнем регистре", Generator{CountryCode: "ru", BankCode: "04525"}, ErrInvalidCountryCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.gen.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, ожидалось %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// Любой номер, выпущенный по допустимым параметрам, проходит проверку.
+func TestGenerateRoundTrip(t *testing.T) {
+	for _, gen := range []Generator{
+		{BankCode: "04525"},
+		{CountryCode: "RU", BankCode: "04525"},
+		{CountryCode: "GB", BankCode: "WEST123456"},
+	} {
+		for i := 0; i < 100; i++ {
+			number, err := gen.Generate()
+			if err != nil {
+				t.Fatalf("Generate(%+v): %v", gen, err)
+			}
+			if err := Validate(number); err != nil {
+				t.Fatalf("Validate(%q) для %+v: %v", number, gen, err)
+			}
+			if !LooksLikeNumber(number) {
+				t.Fatalf("LooksLikeNumber(%q) = false", number)
+			}
+		}
+	}
+}
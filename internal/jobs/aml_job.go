@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// AMLJob периодически прогоняет сценарии AML-мониторинга по операциям.
+type AMLJob struct {
+	amlService *service.AMLService
+	interval   time.Duration
+	logger     *logrus.Logger
+}
+
+func NewAMLJob(amlService *service.AMLService, interval time.Duration, logger *logrus.Logger) *AMLJob {
+	return &AMLJob{
+		amlService: amlService,
+		interval:   interval,
+		logger:     logger,
+	}
+}
+
+func (j *AMLJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *AMLJob) RunOnce(ctx context.Context) {
+	created, err := j.amlService.RunScenarios(ctx)
+	if err != nil {
+		j.logger.Errorf("Ошибка AML-мониторинга: %v", err)
+	}
+	if created > 0 {
+		j.logger.Infof("Заведено кейсов AML: %d", created)
+	}
+}
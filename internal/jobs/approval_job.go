@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// ApprovalJob доисполняет одобренные платежи, исполнение которых прервалось.
+type ApprovalJob struct {
+	approvalService *service.ApprovalService
+	interval        time.Duration
+	logger          *logrus.Logger
+}
+
+func NewApprovalJob(approvalService *service.ApprovalService, interval time.Duration, logger *logrus.Logger) *ApprovalJob {
+	return &ApprovalJob{
+		approvalService: approvalService,
+		interval:        interval,
+		logger:          logger,
+	}
+}
+
+func (j *ApprovalJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *ApprovalJob) RunOnce(ctx context.Context) {
+	processed, err := j.approvalService.ResumeStalePayments(ctx)
+	if err != nil {
+		j.logger.Errorf("Ошибка исполнения прерванных платежей: %v", err)
+	}
+	if processed > 0 {
+		j.logger.Infof("Исполнено прерванных платежей: %d", processed)
+	}
+}
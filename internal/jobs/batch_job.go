@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// BatchJob исполняет пакеты платежей, поставленные в очередь, и продолжает
+// пакеты, обработка которых была прервана.
+type BatchJob struct {
+	batchService *service.BatchService
+	interval     time.Duration
+	logger       *logrus.Logger
+}
+
+func NewBatchJob(batchService *service.BatchService, interval time.Duration, logger *logrus.Logger) *BatchJob {
+	return &BatchJob{
+		batchService: batchService,
+		interval:     interval,
+		logger:       logger,
+	}
+}
+
+func (j *BatchJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *BatchJob) RunOnce(ctx context.Context) {
+	processed, err := j.batchService.ProcessQueued(ctx)
+	if err != nil {
+		j.logger.Errorf("Ошибка исполнения пакетов платежей: %v", err)
+	}
+	if processed > 0 {
+		j.logger.Infof("Исполнено пакетов платежей: %d", processed)
+	}
+}
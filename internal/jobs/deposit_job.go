@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// DepositJob погашает и пролонгирует срочные вклады с наступившей датой погашения.
+type DepositJob struct {
+	depositService *service.DepositService
+	interval       time.Duration
+	logger         *logrus.Logger
+}
+
+func NewDepositJob(depositService *service.DepositService, interval time.Duration, logger *logrus.Logger) *DepositJob {
+	return &DepositJob{
+		depositService: depositService,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+func (j *DepositJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx, time.Now().UTC())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *DepositJob) RunOnce(ctx context.Context, now time.Time) {
+	processed, err := j.depositService.ProcessMatured(ctx, now)
+	if err != nil {
+		j.logger.Errorf("Ошибка погашения вкладов: %v", err)
+	}
+	if processed > 0 {
+		j.logger.Infof("Обработано вкладов с наступившей датой погашения: %d", processed)
+	}
+}
@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// FeeJob списывает ежемесячную плату за обслуживание счетов.
+type FeeJob struct {
+	feeService *service.FeeService
+	interval   time.Duration
+	logger     *logrus.Logger
+}
+
+func NewFeeJob(feeService *service.FeeService, interval time.Duration, logger *logrus.Logger) *FeeJob {
+	return &FeeJob{
+		feeService: feeService,
+		interval:   interval,
+		logger:     logger,
+	}
+}
+
+func (j *FeeJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx, time.Now().UTC())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *FeeJob) RunOnce(ctx context.Context, now time.Time) {
+	charged, skipped, err := j.feeService.ChargeMaintenance(ctx, now)
+	if err != nil {
+		j.logger.Errorf("Ошибка списания платы за обслуживание: %v", err)
+	}
+	if charged > 0 {
+		j.logger.Infof("Списана плата за обслуживание по %d счетам", charged)
+	}
+	if skipped > 0 {
+		j.logger.Warnf("Не хватает средств для списания платы за обслуживание по %d счетам", skipped)
+	}
+}
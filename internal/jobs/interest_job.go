@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// InterestJob периодически начисляет дневные проценты, списывает проценты по овердрафту
+// и раз в месяц капитализирует начисления.
+// Обе операции идемпотентны, поэтому интервал запуска может быть меньше суток.
+type InterestJob struct {
+	interestService *service.InterestService
+	interval        time.Duration
+	logger          *logrus.Logger
+}
+
+func NewInterestJob(interestService *service.InterestService, interval time.Duration, logger *logrus.Logger) *InterestJob {
+	return &InterestJob{
+		interestService: interestService,
+		interval:        interval,
+		logger:          logger,
+	}
+}
+
+func (j *InterestJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx, time.Now().UTC())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *InterestJob) RunOnce(ctx context.Context, now time.Time) {
+	// Начисляем за последний полностью завершившийся день
+	day := now.AddDate(0, 0, -1)
+
+	accrued, err := j.interestService.AccrueDaily(ctx, day)
+	if err != nil {
+		j.logger.Errorf("Ошибка начисления процентов: %v", err)
+		return
+	}
+	if accrued > 0 {
+		j.logger.Infof("Начислены проценты за %s по %d счетам", day.Format("2006-01-02"), accrued)
+	}
+
+	charged, err := j.interestService.ChargeOverdraft(ctx, day)
+	if err != nil {
+		j.logger.Errorf("Ошибка списания процентов по овердрафту: %v", err)
+	}
+	if charged > 0 {
+		j.logger.Infof("Списаны проценты по овердрафту за %s по %d счетам", day.Format("2006-01-02"), charged)
+	}
+
+	total, err := j.interestService.Capitalize(ctx, now)
+	if err != nil {
+		j.logger.Errorf("Ошибка капитализации процентов: %v", err)
+		return
+	}
+	if total.IsPositive() {
+		j.logger.Infof("Капитализировано процентов на сумму %s", total.String())
+	}
+}
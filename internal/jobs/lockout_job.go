@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// LockoutJob удаляет неудачные попытки, которые уже не влияют на паузы
+// и блокировки.
+type LockoutJob struct {
+	lockoutService *service.LockoutService
+	interval       time.Duration
+	logger         *logrus.Logger
+}
+
+func NewLockoutJob(lockoutService *service.LockoutService, interval time.Duration, logger *logrus.Logger) *LockoutJob {
+	return &LockoutJob{
+		lockoutService: lockoutService,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+func (j *LockoutJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *LockoutJob) RunOnce(ctx context.Context) {
+	pruned, err := j.lockoutService.PruneAttempts(ctx)
+	if err != nil {
+		j.logger.Errorf("Ошибка очистки попыток входа: %v", err)
+	}
+	if pruned > 0 {
+		j.logger.Infof("Удалено устаревших попыток входа: %d", pruned)
+	}
+}
@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// NotificationJob отправляет письма-уведомления, время отправки которых наступило.
+type NotificationJob struct {
+	notificationService *service.NotificationService
+	interval            time.Duration
+	logger              *logrus.Logger
+}
+
+func NewNotificationJob(notificationService *service.NotificationService, interval time.Duration, logger *logrus.Logger) *NotificationJob {
+	return &NotificationJob{
+		notificationService: notificationService,
+		interval:            interval,
+		logger:              logger,
+	}
+}
+
+func (j *NotificationJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *NotificationJob) RunOnce(ctx context.Context) {
+	sent, err := j.notificationService.SendDueEmails(ctx)
+	if err != nil {
+		j.logger.Errorf("Ошибка отправки писем: %v", err)
+	}
+	if sent > 0 {
+		j.logger.Debugf("Отправлено писем: %d", sent)
+	}
+}
@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// OutboxJob периодически публикует доменные события из outbox.
+type OutboxJob struct {
+	outboxService *service.OutboxService
+	interval      time.Duration
+	logger        *logrus.Logger
+}
+
+func NewOutboxJob(outboxService *service.OutboxService, interval time.Duration, logger *logrus.Logger) *OutboxJob {
+	return &OutboxJob{
+		outboxService: outboxService,
+		interval:      interval,
+		logger:        logger,
+	}
+}
+
+func (j *OutboxJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *OutboxJob) RunOnce(ctx context.Context) {
+	published, err := j.outboxService.Relay(ctx)
+	if err != nil {
+		j.logger.Errorf("Ошибка публикации событий: %v", err)
+	}
+	if published > 0 {
+		j.logger.Debugf("Опубликовано событий: %d", published)
+	}
+}
@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// RiskJob перечитывает файл порогов антифрода при его изменении.
+type RiskJob struct {
+	riskService *service.RiskService
+	interval    time.Duration
+	logger      *logrus.Logger
+}
+
+func NewRiskJob(riskService *service.RiskService, interval time.Duration, logger *logrus.Logger) *RiskJob {
+	return &RiskJob{
+		riskService: riskService,
+		interval:    interval,
+		logger:      logger,
+	}
+}
+
+func (j *RiskJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *RiskJob) RunOnce(_ context.Context) {
+	reloaded, err := j.riskService.ReloadThresholds()
+	if err != nil {
+		j.logger.Errorf("Ошибка загрузки порогов антифрода: %v", err)
+		return
+	}
+	if reloaded {
+		j.logger.Info("Пороги антифрода обновлены")
+	}
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// ScreeningJob перечитывает санкционный список при изменении файла и
+// исполняет переводы, освобожденные оператором.
+type ScreeningJob struct {
+	screeningService *service.ScreeningService
+	accountService   *service.AccountService
+	reloadInterval   time.Duration
+	interval         time.Duration
+	logger           *logrus.Logger
+	lastReload       time.Time
+}
+
+func NewScreeningJob(screeningService *service.ScreeningService, accountService *service.AccountService,
+	reloadInterval, interval time.Duration, logger *logrus.Logger) *ScreeningJob {
+	return &ScreeningJob{
+		screeningService: screeningService,
+		accountService:   accountService,
+		reloadInterval:   reloadInterval,
+		interval:         interval,
+		logger:           logger,
+	}
+}
+
+func (j *ScreeningJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *ScreeningJob) RunOnce(ctx context.Context) {
+	if time.Since(j.lastReload) >= j.reloadInterval {
+		j.lastReload = time.Now()
+		reloaded, size, err := j.screeningService.ReloadList()
+		if err != nil {
+			j.logger.Errorf("Ошибка загрузки санкционного списка: %v", err)
+		} else if reloaded {
+			j.logger.Infof("Санкционный список загружен: %d записей", size)
+		}
+	}
+
+	processed, err := j.accountService.ProcessReleasedTransfers(ctx)
+	if err != nil {
+		j.logger.Errorf("Ошибка исполнения освобожденных переводов: %v", err)
+	}
+	if processed > 0 {
+		j.logger.Infof("Обработано освобожденных переводов: %d", processed)
+	}
+}
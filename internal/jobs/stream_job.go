@@ -0,0 +1,41 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// StreamJob держит подписку LISTEN/NOTIFY для рассылки событий в реальном
+// времени и переподключается после обрыва соединения.
+type StreamJob struct {
+	streamService *service.StreamService
+	retryDelay    time.Duration
+	logger        *logrus.Logger
+}
+
+func NewStreamJob(streamService *service.StreamService, retryDelay time.Duration, logger *logrus.Logger) *StreamJob {
+	return &StreamJob{
+		streamService: streamService,
+		retryDelay:    retryDelay,
+		logger:        logger,
+	}
+}
+
+func (j *StreamJob) Run(ctx context.Context) {
+	for {
+		err := j.streamService.Listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		j.logger.Errorf("Подписка на события прервана: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(j.retryDelay):
+		}
+	}
+}
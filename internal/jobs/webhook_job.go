@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/service"
+)
+
+// WebhookJob отправляет webhook-доставки, время попытки которых наступило.
+type WebhookJob struct {
+	webhookService *service.WebhookService
+	interval       time.Duration
+	logger         *logrus.Logger
+}
+
+func NewWebhookJob(webhookService *service.WebhookService, interval time.Duration, logger *logrus.Logger) *WebhookJob {
+	return &WebhookJob{
+		webhookService: webhookService,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+func (j *WebhookJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *WebhookJob) RunOnce(ctx context.Context) {
+	delivered, err := j.webhookService.DeliverDue(ctx)
+	if err != nil {
+		j.logger.Errorf("Ошибка доставки webhook: %v", err)
+	}
+	if delivered > 0 {
+		j.logger.Debugf("Доставлено webhook: %d", delivered)
+	}
+}
@@ -0,0 +1,157 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/therealadik/bank-api/internal/config"
+)
+
+// smtpStub — минимальный SMTP-сервер для одного сеанса: без STARTTLS и
+// аутентификации, отвечает rcptReply на RCPT и запоминает конверт и письмо.
+type smtpStub struct {
+	ln        net.Listener
+	rcptReply string
+
+	from, to string
+	data     string
+	done     chan struct{}
+}
+
+func newSMTPStub(t *testing.T, rcptReply string) *smtpStub {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ошибка запуска заглушки SMTP: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	s := &smtpStub{ln: ln, rcptReply: rcptReply, done: make(chan struct{})}
+	go s.serve()
+	return s
+}
+
+func (s *smtpStub) config() config.SMTPConfig {
+	host, port, _ := net.SplitHostPort(s.ln.Addr().String())
+	return config.SMTPConfig{Host: host, Port: port, From: "Банк <no-reply@bank.local>", Timeout: 5 * time.Second}
+}
+
+func (s *smtpStub) serve() {
+	defer close(s.done)
+
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 stub ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 stub")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			s.from = line[len("MAIL FROM:"):]
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			s.to = line[len("RCPT TO:"):]
+			reply(s.rcptReply)
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.data = data.String()
+			reply("250 OK")
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	msg := Message{
+		To:      "Иван <ivan@example.com>",
+		Subject: "Код подтверждения",
+		Body:    "Ваш код: 123456.\nНикому его не сообщайте.",
+	}
+
+	tests := []struct {
+		name      string
+		rcptReply string
+		msg       Message
+		wantErr   string
+	}{
+		{name: "письмо доставлено", rcptReply: "250 OK", msg: msg},
+		{name: "получатель отклонен", rcptReply: "550 No such user", msg: msg, wantErr: "RCPT"},
+		{name: "неверный адрес получателя", rcptReply: "250 OK", msg: Message{To: "не адрес", Subject: "-", Body: "-"},
+			wantErr: "адрес получателя"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newSMTPStub(t, tt.rcptReply)
+
+			err := NewSMTPMailer(stub.config()).Send(context.Background(), tt.msg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ошибка %v, ожидалась ошибка с %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+			<-stub.done
+
+			if stub.from != "<no-reply@bank.local>" || stub.to != "<ivan@example.com>" {
+				t.Errorf("конверт %s → %s", stub.from, stub.to)
+			}
+
+			sent, err := mail.ReadMessage(strings.NewReader(stub.data))
+			if err != nil {
+				t.Fatalf("ошибка разбора письма: %v", err)
+			}
+			subject, err := new(mime.WordDecoder).DecodeHeader(sent.Header.Get("Subject"))
+			if err != nil || subject != tt.msg.Subject {
+				t.Errorf("тема %q (%v), ожидалась %q", subject, err, tt.msg.Subject)
+			}
+			body, err := io.ReadAll(quotedprintable.NewReader(sent.Body))
+			// Клиент SMTP завершает данные переводом строки.
+			got := strings.TrimSuffix(strings.ReplaceAll(string(body), "\r\n", "\n"), "\n")
+			if err != nil || got != tt.msg.Body {
+				t.Errorf("тело %q (%v), ожидалось %q", got, err, tt.msg.Body)
+			}
+		})
+	}
+}
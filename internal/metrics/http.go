@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/therealadik/bank-api/internal/httpx"
+)
+
+// Middleware замеряет время обработки запроса. Маршрут берется из шаблона
+// mux, например /accounts/{id}/transactions, чтобы число рядов не зависело
+// от идентификаторов в пути. Подключается через Router.Use и видит только
+// запросы, для которых нашелся маршрут.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := "unknown"
+		if current := mux.CurrentRoute(r); current != nil {
+			if tpl, err := current.GetPathTemplate(); err == nil {
+				route = tpl
+			}
+		}
+
+		rec := httpx.NewStatusRecorder(w)
+		start := time.Now()
+		next.ServeHTTP(rec, r)
+
+		HTTPRequestDuration.WithLabelValues(r.Method, route, strconv.Itoa(rec.Status)).
+			Observe(time.Since(start).Seconds())
+	})
+}
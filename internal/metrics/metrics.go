@@ -0,0 +1,69 @@
+// Package metrics описывает метрики Prometheus сервиса: задержки HTTP по
+// маршрутам, состояние пула соединений с БД и бизнес-счетчики. Счетчики —
+// переменные пакета, их увеличивают сервисы; NewRegistry собирает все
+// метрики для эндпоинта /metrics.
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "bank"
+
+var (
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Время обработки HTTP-запросов по маршрутам и статусам ответа.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	TransfersTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfers_total",
+		Help:      "Выполненные переводы между счетами по валюте.",
+	}, []string{"currency"})
+
+	TransferAmountTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfer_amount_total",
+		Help:      "Сумма выполненных переводов без комиссии по валюте.",
+	}, []string{"currency"})
+
+	CardPaymentsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "card_payments_total",
+		Help:      "Проведенные платежи по картам.",
+	})
+
+	CardPaymentsFailedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "card_payments_failed_total",
+		Help:      "Отклоненные платежи по картам по причине.",
+	}, []string{"reason"})
+
+	CardsIssuedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cards_issued_total",
+		Help:      "Выпущенные карты.",
+	})
+)
+
+// NewRegistry возвращает реестр с метриками сервиса, пула pool и рантайма Go.
+func NewRegistry(pool *pgxpool.Pool) *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		newPoolCollector(pool),
+		HTTPRequestDuration,
+		TransfersTotal,
+		TransferAmountTotal,
+		CardPaymentsTotal,
+		CardPaymentsFailedTotal,
+		CardsIssuedTotal,
+	)
+	return reg
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector снимает pgxpool.Stat при каждом сборе метрик.
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	newConnsCount        *prometheus.Desc
+	lifetimeDestroyCount *prometheus.Desc
+	idleDestroyCount     *prometheus.Desc
+}
+
+func newPoolCollector(pool *pgxpool.Pool) *poolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &poolCollector{
+		pool:                 pool,
+		acquiredConns:        desc("acquired_conns", "Соединения, занятые запросами."),
+		idleConns:            desc("idle_conns", "Свободные соединения."),
+		constructingConns:    desc("constructing_conns", "Соединения в процессе установки."),
+		totalConns:           desc("total_conns", "Все соединения пула."),
+		maxConns:             desc("max_conns", "Максимальный размер пула."),
+		acquireCount:         desc("acquire_total", "Успешные получения соединения из пула."),
+		acquireDuration:      desc("acquire_duration_seconds_total", "Суммарное время ожидания соединения."),
+		emptyAcquireCount:    desc("empty_acquire_total", "Получения соединения, которым пришлось ждать."),
+		canceledAcquireCount: desc("canceled_acquire_total", "Ожидания соединения, отмененные контекстом."),
+		newConnsCount:        desc("new_conns_total", "Открытые пулом соединения."),
+		lifetimeDestroyCount: desc("max_lifetime_destroy_total", "Соединения, закрытые по MaxConnLifetime."),
+		idleDestroyCount:     desc("max_idle_destroy_total", "Соединения, закрытые по MaxConnIdleTime."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	gauge := func(d *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, v)
+	}
+	counter := func(d *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, v)
+	}
+
+	gauge(c.acquiredConns, float64(stat.AcquiredConns()))
+	gauge(c.idleConns, float64(stat.IdleConns()))
+	gauge(c.constructingConns, float64(stat.ConstructingConns()))
+	gauge(c.totalConns, float64(stat.TotalConns()))
+	gauge(c.maxConns, float64(stat.MaxConns()))
+	counter(c.acquireCount, float64(stat.AcquireCount()))
+	counter(c.acquireDuration, stat.AcquireDuration().Seconds())
+	counter(c.emptyAcquireCount, float64(stat.EmptyAcquireCount()))
+	counter(c.canceledAcquireCount, float64(stat.CanceledAcquireCount()))
+	counter(c.newConnsCount, float64(stat.NewConnsCount()))
+	counter(c.lifetimeDestroyCount, float64(stat.MaxLifetimeDestroyCount()))
+	counter(c.idleDestroyCount, float64(stat.MaxIdleDestroyCount()))
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/problem"
 	"github.com/therealadik/bank-api/internal/service"
 )
 
@@ -29,22 +30,22 @@ func (m *JWTMiddleware) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			http.Error(w, "Требуется авторизация", http.StatusUnauthorized)
+			problem.Error(w, r, http.StatusUnauthorized, "authorization_required")
 			return
 		}
 
 		const bearerPrefix = "Bearer "
 		if !strings.HasPrefix(authHeader, bearerPrefix) {
-			http.Error(w, "Неверный формат токена", http.StatusUnauthorized)
+			problem.Error(w, r, http.StatusUnauthorized, "malformed_token")
 			return
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, bearerPrefix)
 
-		userID, err := m.authService.ParseToken(tokenString)
+		userID, err := m.authService.ParseToken(r.Context(), tokenString)
 		if err != nil {
-			m.logger.WithError(err).Warn("Ошибка проверки токена")
-			http.Error(w, "Неверный или просроченный токен", http.StatusUnauthorized)
+			m.logger.WithContext(r.Context()).WithError(err).Warn("Ошибка проверки токена")
+			problem.Error(w, r, http.StatusUnauthorized, "invalid_access_token")
 			return
 		}
 
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/therealadik/bank-api/internal/i18n"
+)
+
+// Locale выбирает язык ответа по заголовку Accept-Language и сохраняет его
+// в контексте запроса. Оборачивает весь роутер, чтобы ответы 404 и 405
+// тоже переводились.
+func Locale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := i18n.Parse(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", string(locale))
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), locale)))
+	})
+}
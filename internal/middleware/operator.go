@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+// OperatorMiddleware пропускает запросы операторского API со статическим
+// токеном OPERATOR_TOKEN. Если токен не задан, операторский API закрыт.
+type OperatorMiddleware struct {
+	token  string
+	logger *logrus.Logger
+}
+
+func NewOperatorMiddleware(token string, logger *logrus.Logger) *OperatorMiddleware {
+	return &OperatorMiddleware{
+		token:  token,
+		logger: logger,
+	}
+}
+
+func (m *OperatorMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const bearerPrefix = "Bearer "
+		authHeader := r.Header.Get("Authorization")
+		if m.token == "" || !strings.HasPrefix(authHeader, bearerPrefix) {
+			problem.Error(w, r, http.StatusUnauthorized, "operator_authorization_required")
+			return
+		}
+
+		token := strings.TrimPrefix(authHeader, bearerPrefix)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(m.token)) != 1 {
+			m.logger.WithContext(r.Context()).Warnf("Неверный токен оператора с адреса %s", r.RemoteAddr)
+			problem.Error(w, r, http.StatusUnauthorized, "invalid_operator_token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
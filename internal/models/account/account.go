@@ -6,9 +6,20 @@ import (
 )
 
 type Account struct {
-	ID        int64           `db:"id"       json:"id"`
-	UserID    int64           `db:"user_id"  json:"user_id"`
-	Balance   decimal.Decimal `db:"balance"  json:"balance"`
-	Currency  Currency        `db:"currency" json:"currency"`
-	CreatedAt time.Time       `db:"created_at" json:"created_at"`
+	ID             int64           `db:"id"       json:"id"`
+	UserID         int64           `db:"user_id"  json:"user_id"`
+	AccountNumber  *string         `db:"account_number" json:"account_number"`
+	Balance        decimal.Decimal `db:"balance"  json:"balance"`
+	Currency       Currency        `db:"currency" json:"currency"`
+	Product        Product         `db:"product"  json:"product"`
+	InterestRate   decimal.Decimal `db:"interest_rate" json:"interest_rate"`
+	DayCount       DayCount        `db:"day_count" json:"day_count"`
+	OverdraftLimit decimal.Decimal `db:"overdraft_limit" json:"overdraft_limit"`
+	OverdraftRate  decimal.Decimal `db:"overdraft_rate"  json:"overdraft_rate"`
+	CreatedAt      time.Time       `db:"created_at" json:"created_at"`
+}
+
+// Available возвращает сумму, доступную для списания с учетом овердрафта.
+func (a *Account) Available() decimal.Decimal {
+	return a.Balance.Add(a.OverdraftLimit)
 }
@@ -0,0 +1,16 @@
+package account
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type InterestAccrual struct {
+	ID          int64           `db:"id"           json:"id"`
+	AccountID   int64           `db:"account_id"   json:"account_id"`
+	AccrualDate time.Time       `db:"accrual_date" json:"accrual_date"`
+	Amount      decimal.Decimal `db:"amount"       json:"amount"`
+	Capitalized bool            `db:"capitalized"  json:"capitalized"`
+	CarryOver   bool            `db:"carry_over"   json:"carry_over"`
+	CreatedAt   time.Time       `db:"created_at"   json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package account
+
+type Product string
+
+const (
+	CURRENT Product = "CURRENT"
+	SAVINGS Product = "SAVINGS"
+	DEPOSIT Product = "DEPOSIT"
+)
+
+type DayCount string
+
+const (
+	ACT365    DayCount = "ACT/365"
+	THIRTY360 DayCount = "30/360"
+)
+
+// Valid сообщает, поддерживается ли конвенция расчета дней.
+func (d DayCount) Valid() bool {
+	return d == ACT365 || d == THIRTY360
+}
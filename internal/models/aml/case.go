@@ -0,0 +1,33 @@
+package aml
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Case — кейс AML-мониторинга со связанными операциями.
+type Case struct {
+	ID               int64           `db:"id"                json:"id"`
+	Scenario         Scenario        `db:"scenario"          json:"scenario"`
+	AccountID        int64           `db:"account_id"        json:"account_id"`
+	UserID           int64           `db:"user_id"           json:"user_id"`
+	Summary          string          `db:"summary"           json:"summary"`
+	TotalAmount      decimal.Decimal `db:"total_amount"      json:"total_amount"`
+	TransactionCount int             `db:"transaction_count" json:"transaction_count"`
+	Status           CaseStatus      `db:"status"            json:"status"`
+	ReviewComment    string          `db:"review_comment"    json:"review_comment"`
+	CreatedAt        time.Time       `db:"created_at"        json:"created_at"`
+	UpdatedAt        time.Time       `db:"updated_at"        json:"updated_at"`
+	ReportedAt       *time.Time      `db:"reported_at"       json:"reported_at"`
+}
+
+// Detection — срабатывание сценария по счету до заведения кейса.
+type Detection struct {
+	Scenario       Scenario
+	AccountID      int64
+	UserID         int64
+	TransactionIDs []int64
+	Total          decimal.Decimal
+	Count          int
+}
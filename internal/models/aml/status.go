@@ -0,0 +1,25 @@
+package aml
+
+// Scenario — сценарий мониторинга, по которому заведен кейс.
+type Scenario string
+
+const (
+	// STRUCTURING — дробление: серия операций на суммы чуть ниже порога
+	// обязательного контроля.
+	STRUCTURING Scenario = "STRUCTURING"
+	// RAPID_MOVEMENT — транзит: поступившие средства быстро уходят со счета.
+	RAPID_MOVEMENT Scenario = "RAPID_MOVEMENT"
+	// DORMANT_REACTIVATION — крупные операции по счету после долгого простоя.
+	DORMANT_REACTIVATION Scenario = "DORMANT_REACTIVATION"
+)
+
+// CaseStatus — состояние разбора кейса: OPEN → DISMISSED | ESCALATED,
+// ESCALATED → REPORTED после выгрузки сообщения о подозрительной операции.
+type CaseStatus string
+
+const (
+	OPEN      CaseStatus = "OPEN"
+	DISMISSED CaseStatus = "DISMISSED"
+	ESCALATED CaseStatus = "ESCALATED"
+	REPORTED  CaseStatus = "REPORTED"
+)
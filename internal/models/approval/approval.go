@@ -0,0 +1,42 @@
+package approval
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type Member struct {
+	AccountID int64     `db:"account_id" json:"account_id"`
+	UserID    int64     `db:"user_id"    json:"user_id"`
+	Role      Role      `db:"role"       json:"role"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// PolicyBand задает число подтверждений для переводов от AmountFrom
+// до следующей границы.
+type PolicyBand struct {
+	ID                int64           `db:"id"                 json:"id"`
+	AccountID         int64           `db:"account_id"         json:"account_id"`
+	AmountFrom        decimal.Decimal `db:"amount_from"        json:"amount_from"`
+	RequiredApprovals int             `db:"required_approvals" json:"required_approvals"`
+}
+
+type Payment struct {
+	ID                int64           `db:"id"                 json:"id"`
+	AccountID         int64           `db:"account_id"         json:"account_id"`
+	ToAccountID       int64           `db:"to_account_id"      json:"to_account_id"`
+	InitiatorID       int64           `db:"initiator_id"       json:"initiator_id"`
+	Amount            decimal.Decimal `db:"amount"             json:"amount"`
+	RequiredApprovals int             `db:"required_approvals" json:"required_approvals"`
+	Status            Status          `db:"status"             json:"status"`
+	CreatedAt         time.Time       `db:"created_at"         json:"created_at"`
+	UpdatedAt         time.Time       `db:"updated_at"         json:"updated_at"`
+}
+
+type PaymentDecision struct {
+	PaymentID int64     `db:"payment_id" json:"payment_id"`
+	UserID    int64     `db:"user_id"    json:"user_id"`
+	Decision  Decision  `db:"decision"   json:"decision"`
+	Comment   string    `db:"comment"    json:"comment"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
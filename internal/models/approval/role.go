@@ -0,0 +1,9 @@
+package approval
+
+type Role string
+
+const (
+	INITIATOR Role = "INITIATOR"
+	APPROVER  Role = "APPROVER"
+	VIEWER    Role = "VIEWER"
+)
@@ -0,0 +1,18 @@
+package approval
+
+type Status string
+
+const (
+	PENDING    Status = "PENDING"
+	PROCESSING Status = "PROCESSING"
+	EXECUTED   Status = "EXECUTED"
+	REJECTED   Status = "REJECTED"
+	FAILED     Status = "FAILED"
+)
+
+type Decision string
+
+const (
+	APPROVE Decision = "APPROVE"
+	REJECT  Decision = "REJECT"
+)
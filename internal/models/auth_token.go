@@ -0,0 +1,15 @@
+package models
+
+// TokenPurpose — назначение одноразового токена из письма или кода
+// подтверждения.
+type TokenPurpose string
+
+const (
+	EMAIL_VERIFY   TokenPurpose = "EMAIL_VERIFY"
+	PASSWORD_RESET TokenPurpose = "PASSWORD_RESET"
+	// PHONE_VERIFY — код из SMS, подтверждающий номер телефона.
+	PHONE_VERIFY TokenPurpose = "PHONE_VERIFY"
+	// BENEFICIARY_VERIFY — код из письма, снимающий период охлаждения
+	// нового получателя.
+	BENEFICIARY_VERIFY TokenPurpose = "BENEFICIARY_VERIFY"
+)
@@ -0,0 +1,36 @@
+package batch
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type Batch struct {
+	ID         int64           `db:"id"          json:"id"`
+	UserID     int64           `db:"user_id"     json:"user_id"`
+	AccountID  int64           `db:"account_id"  json:"account_id"`
+	Mode       Mode            `db:"mode"        json:"mode"`
+	Status     Status          `db:"status"      json:"status"`
+	ItemCount  int             `db:"item_count"  json:"item_count"`
+	ValidCount int             `db:"valid_count" json:"valid_count"`
+	Total      decimal.Decimal `db:"total"       json:"total"`
+	TotalFee   decimal.Decimal `db:"total_fee"   json:"total_fee"`
+	CreatedAt  time.Time       `db:"created_at"  json:"created_at"`
+	UpdatedAt  time.Time       `db:"updated_at"  json:"updated_at"`
+}
+
+// Item — строка пакета. Строки со статусом INVALID не исполняются, Error
+// содержит причину отказа.
+type Item struct {
+	ID          int64           `db:"id"            json:"id"`
+	BatchID     int64           `db:"batch_id"      json:"batch_id"`
+	RowNumber   int             `db:"row_number"    json:"row_number"`
+	AccountRef  string          `db:"account_ref"   json:"account_ref"`
+	ToAccountID *int64          `db:"to_account_id" json:"to_account_id"`
+	Amount      decimal.Decimal `db:"amount"        json:"amount"`
+	Fee         decimal.Decimal `db:"fee"           json:"fee"`
+	Reference   string          `db:"reference"     json:"reference"`
+	Status      ItemStatus      `db:"status"        json:"status"`
+	Error       string          `db:"error"         json:"error"`
+	ProcessedAt *time.Time      `db:"processed_at"  json:"processed_at"`
+}
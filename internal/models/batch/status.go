@@ -0,0 +1,31 @@
+package batch
+
+type Mode string
+
+const (
+	ALL_OR_NOTHING Mode = "ALL_OR_NOTHING"
+	BEST_EFFORT    Mode = "BEST_EFFORT"
+)
+
+type Status string
+
+const (
+	DRAFT      Status = "DRAFT"
+	QUEUED     Status = "QUEUED"
+	PROCESSING Status = "PROCESSING"
+	COMPLETED  Status = "COMPLETED"
+	PARTIAL    Status = "PARTIAL"
+	FAILED     Status = "FAILED"
+)
+
+type ItemStatus string
+
+const (
+	ITEM_PENDING   ItemStatus = "PENDING"
+	ITEM_INVALID   ItemStatus = "INVALID"
+	ITEM_COMPLETED ItemStatus = "COMPLETED"
+	ITEM_FAILED    ItemStatus = "FAILED"
+	// ITEM_HELD — получатель совпал с санкционным списком: строка исключена
+	// из пакета, а перевод исполнится отдельно после решения оператора.
+	ITEM_HELD ItemStatus = "HELD"
+)
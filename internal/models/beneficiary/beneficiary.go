@@ -0,0 +1,24 @@
+package beneficiary
+
+import "time"
+
+type Beneficiary struct {
+	ID            int64      `db:"id"             json:"id"`
+	UserID        int64      `db:"user_id"        json:"user_id"`
+	Nickname      string     `db:"nickname"       json:"nickname"`
+	AccountID     int64      `db:"account_id"     json:"account_id"`
+	AccountNumber string     `db:"account_number" json:"account_number"`
+	Status        Status     `db:"status"         json:"status"`
+	AvailableAt   time.Time  `db:"available_at"   json:"available_at"`
+	VerifiedAt    *time.Time `db:"verified_at"    json:"verified_at,omitempty"`
+	CreatedAt     time.Time  `db:"created_at"     json:"created_at"`
+}
+
+// EffectiveStatus учитывает истечение периода охлаждения: получатель
+// становится подтвержденным без дополнительных действий после AvailableAt.
+func (b *Beneficiary) EffectiveStatus(now time.Time) Status {
+	if b.Status == PENDING && !now.Before(b.AvailableAt) {
+		return VERIFIED
+	}
+	return b.Status
+}
@@ -0,0 +1,8 @@
+package beneficiary
+
+type Status string
+
+const (
+	PENDING  Status = "PENDING"
+	VERIFIED Status = "VERIFIED"
+)
@@ -0,0 +1,24 @@
+package deposit
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"time"
+)
+
+type TermDeposit struct {
+	ID              int64            `db:"id"                json:"id"`
+	UserID          int64            `db:"user_id"           json:"user_id"`
+	AccountID       int64            `db:"account_id"        json:"account_id"`
+	SourceAccountID int64            `db:"source_account_id" json:"source_account_id"`
+	Principal       decimal.Decimal  `db:"principal"         json:"principal"`
+	InterestRate    decimal.Decimal  `db:"interest_rate"     json:"interest_rate"`
+	PenaltyRate     decimal.Decimal  `db:"penalty_rate"      json:"penalty_rate"`
+	DayCount        account.DayCount `db:"day_count"         json:"day_count"`
+	TermMonths      int              `db:"term_months"       json:"term_months"`
+	StartDate       time.Time        `db:"start_date"        json:"start_date"`
+	MaturityDate    time.Time        `db:"maturity_date"     json:"maturity_date"`
+	AutoRollover    bool             `db:"auto_rollover"     json:"auto_rollover"`
+	Status          Status           `db:"status"            json:"status"`
+	CreatedAt       time.Time        `db:"created_at"        json:"created_at"`
+}
@@ -0,0 +1,11 @@
+package deposit
+
+type Status string
+
+const (
+	PENDING      Status = "PENDING"
+	ACTIVE       Status = "ACTIVE"
+	MATURED      Status = "MATURED"
+	CLOSED_EARLY Status = "CLOSED_EARLY"
+	CANCELLED    Status = "CANCELLED"
+)
@@ -0,0 +1,41 @@
+package fee
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// Rule описывает тариф для операции. Несколько правил одной операции
+// образуют шкалу: применяется правило с наибольшим VolumeFrom, не превышающим
+// оборот пользователя по этой операции за текущий месяц.
+type Rule struct {
+	ID         int64               `db:"id"          json:"id"`
+	Operation  Operation           `db:"operation"   json:"operation"`
+	VolumeFrom decimal.Decimal     `db:"volume_from" json:"volume_from"`
+	Fixed      decimal.Decimal     `db:"fixed"       json:"fixed"`
+	Percent    decimal.Decimal     `db:"percent"     json:"percent"`
+	MinAmount  decimal.Decimal     `db:"min_amount"  json:"min_amount"`
+	MaxAmount  decimal.NullDecimal `db:"max_amount"  json:"max_amount"`
+	Active     bool                `db:"active"      json:"active"`
+	CreatedAt  time.Time           `db:"created_at"  json:"created_at"`
+}
+
+// Quote — рассчитанная комиссия за операцию до ее подтверждения.
+type Quote struct {
+	Operation Operation       `json:"operation"`
+	Amount    decimal.Decimal `json:"amount"`
+	Fee       decimal.Decimal `json:"fee"`
+	Total     decimal.Decimal `json:"total"`
+	RuleID    int64           `json:"rule_id,omitempty"`
+}
+
+type Charge struct {
+	ID        int64           `db:"id"         json:"id"`
+	UserID    int64           `db:"user_id"    json:"user_id"`
+	AccountID int64           `db:"account_id" json:"account_id"`
+	Operation Operation       `db:"operation"  json:"operation"`
+	Amount    decimal.Decimal `db:"amount"     json:"amount"`
+	Fee       decimal.Decimal `db:"fee"        json:"fee"`
+	Period    *time.Time      `db:"period"     json:"period,omitempty"`
+	CreatedAt time.Time       `db:"created_at" json:"created_at"`
+}
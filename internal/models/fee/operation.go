@@ -0,0 +1,10 @@
+package fee
+
+type Operation string
+
+const (
+	TRANSFER      Operation = "TRANSFER"
+	CARD_PAYMENT  Operation = "CARD_PAYMENT"
+	FX_CONVERSION Operation = "FX_CONVERSION"
+	MAINTENANCE   Operation = "MAINTENANCE"
+)
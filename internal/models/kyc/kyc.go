@@ -0,0 +1,32 @@
+package kyc
+
+import "time"
+
+// Profile — анкета клиента. Имя и телефон хранятся в users и
+// подставляются при чтении.
+type Profile struct {
+	UserID       int64      `db:"user_id"       json:"user_id"`
+	FullName     *string    `db:"full_name"     json:"full_name"`
+	Phone        *string    `db:"phone"         json:"phone"`
+	DateOfBirth  *time.Time `db:"date_of_birth" json:"date_of_birth"`
+	Citizenship  string     `db:"citizenship"   json:"citizenship"`
+	Address      string     `db:"address"       json:"address"`
+	Status       Status     `db:"kyc_status"    json:"status"`
+	RejectReason string     `db:"reject_reason" json:"reject_reason"`
+	SubmittedAt  *time.Time `db:"submitted_at"  json:"submitted_at"`
+	ReviewedAt   *time.Time `db:"reviewed_at"   json:"reviewed_at"`
+}
+
+// Document — загруженный документ. Содержимое лежит в хранилище файлов
+// под ключом StorageKey.
+type Document struct {
+	ID          int64        `db:"id"           json:"id"`
+	UserID      int64        `db:"user_id"      json:"user_id"`
+	Type        DocumentType `db:"doc_type"     json:"type"`
+	FileName    string       `db:"file_name"    json:"file_name"`
+	ContentType string       `db:"content_type" json:"content_type"`
+	Size        int64        `db:"size"         json:"size"`
+	SHA256      string       `db:"sha256"       json:"sha256"`
+	StorageKey  string       `db:"storage_key"  json:"-"`
+	CreatedAt   time.Time    `db:"created_at"   json:"created_at"`
+}
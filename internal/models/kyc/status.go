@@ -0,0 +1,29 @@
+package kyc
+
+// Status — состояние проверки личности клиента. Допустимые переходы:
+// UNVERIFIED → PENDING → VERIFIED | REJECTED, REJECTED → PENDING.
+type Status string
+
+const (
+	UNVERIFIED Status = "UNVERIFIED"
+	// PENDING — анкета и документы отправлены и ожидают проверки оператором.
+	PENDING  Status = "PENDING"
+	VERIFIED Status = "VERIFIED"
+	REJECTED Status = "REJECTED"
+)
+
+type DocumentType string
+
+const (
+	PASSPORT         DocumentType = "PASSPORT"
+	ID_CARD          DocumentType = "ID_CARD"
+	DRIVER_LICENSE   DocumentType = "DRIVER_LICENSE"
+	PROOF_OF_ADDRESS DocumentType = "PROOF_OF_ADDRESS"
+	SELFIE           DocumentType = "SELFIE"
+)
+
+var DocumentTypes = []DocumentType{PASSPORT, ID_CARD, DRIVER_LICENSE, PROOF_OF_ADDRESS, SELFIE}
+
+// IdentityDocuments — документы, хотя бы один из которых нужен для отправки
+// анкеты на проверку.
+var IdentityDocuments = []DocumentType{PASSPORT, ID_CARD, DRIVER_LICENSE}
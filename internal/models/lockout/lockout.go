@@ -0,0 +1,62 @@
+package lockout
+
+import "time"
+
+// Scope — что ограничивается: учетная запись (по email), адрес клиента или
+// карта (по ID).
+type Scope string
+
+const (
+	ACCOUNT Scope = "ACCOUNT"
+	IP      Scope = "IP"
+	CARD    Scope = "CARD"
+)
+
+// Policy — ограничения для одного вида субъектов. Первые FreeAttempts
+// неудачных попыток за окно Window проходят без паузы, дальше пауза перед
+// следующей попыткой удваивается от BaseDelay до MaxDelay; без MaxDelay пауза
+// не растет. После LockAfter неудач субъект блокируется на LockDuration.
+type Policy struct {
+	Window       time.Duration
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	LockAfter    int
+	LockDuration time.Duration
+}
+
+// Delay возвращает паузу, которую нужно выждать после последней неудачи,
+// если за окно их было failures.
+func (p Policy) Delay(failures int) time.Duration {
+	if failures < p.FreeAttempts || p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// Attempt — попытка, допущенная к проверке. Пока проверка идет, попытка
+// считается неудачной, поэтому параллельные запросы не обходят паузу.
+type Attempt struct {
+	ID      int64
+	Scope   Scope
+	Subject string
+}
+
+// Lockout — временная блокировка субъекта после серии неудач.
+type Lockout struct {
+	ID          int64      `db:"id"           json:"id"`
+	Scope       Scope      `db:"scope"        json:"scope"`
+	Subject     string     `db:"subject"      json:"subject"`
+	Failures    int        `db:"failures"     json:"failures"`
+	LockedUntil time.Time  `db:"locked_until" json:"locked_until"`
+	CreatedAt   time.Time  `db:"created_at"   json:"created_at"`
+	ReleasedAt  *time.Time `db:"released_at"  json:"released_at"`
+}
@@ -0,0 +1,37 @@
+package lockout
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPolicyDelay(t *testing.T) {
+	p := Policy{FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+
+	tests := []struct {
+		name     string
+		policy   Policy
+		failures int
+		want     time.Duration
+	}{
+		{"нет неудач", p, 0, 0},
+		{"последняя бесплатная попытка", p, 2, 0},
+		{"первая пауза", p, 3, time.Second},
+		{"пауза удваивается", p, 4, 2 * time.Second},
+		{"пауза удваивается дальше", p, 7, 16 * time.Second},
+		{"пауза ограничена сверху", p, 8, 30 * time.Second},
+		{"много неудач не переполняют паузу", p, 1000, 30 * time.Second},
+		{"без бесплатных попыток", Policy{BaseDelay: time.Second, MaxDelay: time.Minute}, 0, time.Second},
+		{"без базовой паузы", Policy{FreeAttempts: 1, MaxDelay: time.Minute}, 5, 0},
+		{"без предела пауза не растет", Policy{FreeAttempts: 1, BaseDelay: 5 * time.Second}, 10, 5 * time.Second},
+		{"базовая пауза больше предела", Policy{BaseDelay: time.Minute, MaxDelay: 10 * time.Second}, 1, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Delay(tt.failures); got != tt.want {
+				t.Errorf("Delay(%d) = %s, ожидалось %s", tt.failures, got, tt.want)
+			}
+		})
+	}
+}
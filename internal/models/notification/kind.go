@@ -0,0 +1,32 @@
+package notification
+
+import "github.com/therealadik/bank-api/internal/i18n"
+
+type Kind string
+
+const (
+	REGISTRATION   Kind = "REGISTRATION"
+	CARD_ISSUED    Kind = "CARD_ISSUED"
+	LARGE_TRANSFER Kind = "LARGE_TRANSFER"
+	// OVERDRAFT_LIMIT_BREACHED — баланс вышел за одобренный лимит овердрафта.
+	OVERDRAFT_LIMIT_BREACHED Kind = "OVERDRAFT_LIMIT_BREACHED"
+)
+
+var Kinds = []Kind{REGISTRATION, CARD_ISSUED, LARGE_TRANSFER, OVERDRAFT_LIMIT_BREACHED}
+
+type Channel string
+
+const (
+	EMAIL  Channel = "EMAIL"
+	IN_APP Channel = "IN_APP"
+)
+
+var Channels = []Channel{EMAIL, IN_APP}
+
+// Locale — язык уведомлений; набор языков общий с сообщениями API.
+type Locale = i18n.Locale
+
+const (
+	RU = i18n.RU
+	EN = i18n.EN
+)
@@ -0,0 +1,55 @@
+package notification
+
+import "time"
+
+type Notification struct {
+	ID        int64      `db:"id"         json:"id"`
+	UserID    int64      `db:"user_id"    json:"user_id"`
+	EventID   int64      `db:"event_id"   json:"event_id"`
+	Kind      Kind       `db:"kind"       json:"kind"`
+	Subject   string     `db:"subject"    json:"subject"`
+	Body      string     `db:"body"       json:"body"`
+	InApp     bool       `db:"in_app"     json:"in_app"`
+	ReadAt    *time.Time `db:"read_at"    json:"read_at"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+	// EmailStatus — состояние письма, если уведомление отправлялось по почте.
+	EmailStatus *EmailStatus `db:"email_status" json:"email_status"`
+}
+
+type Preference struct {
+	Kind    Kind    `db:"kind"    json:"kind"`
+	Channel Channel `db:"channel" json:"channel"`
+	Enabled bool    `db:"enabled" json:"enabled"`
+}
+
+// Settings — язык уведомлений и явно заданные предпочтения каналов.
+// Канал, для которого предпочтение не задано, включен.
+type Settings struct {
+	UserID      int64        `db:"user_id"     json:"user_id"`
+	Locale      Locale       `db:"locale"      json:"locale"`
+	Preferences []Preference `db:"preferences" json:"preferences"`
+}
+
+// Enabled сообщает, включен ли канал для вида уведомления.
+func (s *Settings) Enabled(kind Kind, channel Channel) bool {
+	for _, p := range s.Preferences {
+		if p.Kind == kind && p.Channel == channel {
+			return p.Enabled
+		}
+	}
+	return true
+}
+
+type EmailDelivery struct {
+	ID             int64       `db:"id"              json:"id"`
+	NotificationID int64       `db:"notification_id" json:"notification_id"`
+	Recipient      string      `db:"recipient"       json:"recipient"`
+	Subject        string      `db:"subject"         json:"subject"`
+	Body           string      `db:"body"            json:"body"`
+	Status         EmailStatus `db:"status"          json:"status"`
+	Attempts       int         `db:"attempts"        json:"attempts"`
+	NextAttemptAt  time.Time   `db:"next_attempt_at" json:"next_attempt_at"`
+	LastError      string      `db:"last_error"      json:"last_error"`
+	CreatedAt      time.Time   `db:"created_at"      json:"created_at"`
+	SentAt         *time.Time  `db:"sent_at"         json:"sent_at"`
+}
@@ -0,0 +1,11 @@
+package notification
+
+// EmailStatus — состояние отправки письма.
+type EmailStatus string
+
+const (
+	EMAIL_PENDING EmailStatus = "PENDING"
+	EMAIL_SENT    EmailStatus = "SENT"
+	// EMAIL_FAILED — письмо не удалось отправить за отведенное число попыток.
+	EMAIL_FAILED EmailStatus = "FAILED"
+)
@@ -0,0 +1,20 @@
+package overdraft
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Request — заявка клиента на увеличение лимита овердрафта, ожидающая
+// решения оператора.
+type Request struct {
+	ID             int64           `db:"id"              json:"id"`
+	AccountID      int64           `db:"account_id"      json:"account_id"`
+	UserID         int64           `db:"user_id"         json:"user_id"`
+	RequestedLimit decimal.Decimal `db:"requested_limit" json:"requested_limit"`
+	Status         Status          `db:"status"          json:"status"`
+	ReviewComment  string          `db:"review_comment"  json:"review_comment"`
+	CreatedAt      time.Time       `db:"created_at"      json:"created_at"`
+	ReviewedAt     *time.Time      `db:"reviewed_at"     json:"reviewed_at"`
+}
@@ -0,0 +1,9 @@
+package overdraft
+
+type Status string
+
+const (
+	PENDING  Status = "PENDING"
+	APPROVED Status = "APPROVED"
+	REJECTED Status = "REJECTED"
+)
@@ -0,0 +1,13 @@
+package p2p
+
+type Status string
+
+const (
+	PENDING    Status = "PENDING"
+	PROCESSING Status = "PROCESSING"
+	COMPLETED  Status = "COMPLETED"
+	FAILED     Status = "FAILED"
+	// HELD — получатель требует проверки по санкционным спискам; перевод
+	// исполнится после разбора совпадения оператором.
+	HELD Status = "HELD"
+)
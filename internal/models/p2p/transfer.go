@@ -0,0 +1,19 @@
+package p2p
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+type Transfer struct {
+	ID            int64           `db:"id"              json:"id"`
+	SenderID      int64           `db:"sender_id"       json:"sender_id"`
+	FromAccountID int64           `db:"from_account_id" json:"from_account_id"`
+	RecipientID   int64           `db:"recipient_id"    json:"-"`
+	ToAccountID   int64           `db:"to_account_id"   json:"-"`
+	Amount        decimal.Decimal `db:"amount"          json:"amount"`
+	Fee           decimal.Decimal `db:"fee"             json:"fee"`
+	Status        Status          `db:"status"          json:"status"`
+	ExpiresAt     time.Time       `db:"expires_at"      json:"expires_at"`
+	CreatedAt     time.Time       `db:"created_at"      json:"created_at"`
+}
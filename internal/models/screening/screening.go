@@ -0,0 +1,39 @@
+package screening
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Hit — потенциальное совпадение в очереди на разбор оператором.
+type Hit struct {
+	ID             int64           `db:"id"               json:"id"`
+	SubjectType    SubjectType     `db:"subject_type"     json:"subject_type"`
+	UserID         int64           `db:"user_id"          json:"user_id"`
+	ScreenedName   string          `db:"screened_name"    json:"screened_name"`
+	EntryID        string          `db:"entry_id"         json:"entry_id"`
+	EntryName      string          `db:"entry_name"       json:"entry_name"`
+	ListSource     string          `db:"list_source"      json:"list_source"`
+	Score          decimal.Decimal `db:"score"            json:"score"`
+	Matches        json.RawMessage `db:"matches"          json:"matches"`
+	HeldTransferID *int64          `db:"held_transfer_id" json:"held_transfer_id"`
+	Status         HitStatus       `db:"status"           json:"status"`
+	ReviewComment  string          `db:"review_comment"   json:"review_comment"`
+	CreatedAt      time.Time       `db:"created_at"       json:"created_at"`
+	ReviewedAt     *time.Time      `db:"reviewed_at"      json:"reviewed_at"`
+}
+
+// HeldTransfer — перевод, задержанный до разбора совпадения по получателю.
+type HeldTransfer struct {
+	ID            int64           `db:"id"              json:"id"`
+	UserID        int64           `db:"user_id"         json:"user_id"`
+	FromAccountID int64           `db:"from_account_id" json:"from_account_id"`
+	ToAccountID   int64           `db:"to_account_id"   json:"to_account_id"`
+	Amount        decimal.Decimal `db:"amount"          json:"amount"`
+	Status        TransferStatus  `db:"status"          json:"status"`
+	FailureReason string          `db:"failure_reason"  json:"failure_reason"`
+	CreatedAt     time.Time       `db:"created_at"      json:"created_at"`
+	UpdatedAt     time.Time       `db:"updated_at"      json:"updated_at"`
+}
@@ -0,0 +1,40 @@
+package screening
+
+type SubjectType string
+
+const (
+	// USER — проверка нового пользователя при регистрации.
+	USER SubjectType = "USER"
+	// TRANSFER — проверка получателя исходящего перевода.
+	TRANSFER SubjectType = "TRANSFER"
+)
+
+type HitStatus string
+
+const (
+	PENDING HitStatus = "PENDING"
+	// CLEARED — оператор признал совпадение ложным.
+	CLEARED HitStatus = "CLEARED"
+	// CONFIRMED — оператор подтвердил совпадение со списком.
+	CONFIRMED HitStatus = "CONFIRMED"
+)
+
+type TransferStatus string
+
+const (
+	HELD       TransferStatus = "HELD"
+	RELEASED   TransferStatus = "RELEASED"
+	PROCESSING TransferStatus = "PROCESSING"
+	EXECUTED   TransferStatus = "EXECUTED"
+	REJECTED   TransferStatus = "REJECTED"
+	FAILED     TransferStatus = "FAILED"
+)
+
+// UserStatus — результат проверки пользователя по санкционным спискам.
+type UserStatus string
+
+const (
+	USER_CLEAR   UserStatus = "CLEAR"
+	USER_HELD    UserStatus = "HELD"
+	USER_BLOCKED UserStatus = "BLOCKED"
+)
@@ -6,4 +6,8 @@ const (
 	DEPOSIT    Type = "DEPOSIT"
 	WITHDRAWAL Type = "WITHDRAWAL"
 	TRANSFER   Type = "TRANSFER"
+	FEE        Type = "FEE"
 )
+
+// CreditTypes — операции, увеличивающие баланс счета; остальные его уменьшают.
+var CreditTypes = []Type{DEPOSIT}
@@ -1,10 +1,23 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/therealadik/bank-api/internal/models/kyc"
+	"github.com/therealadik/bank-api/internal/models/screening"
+)
 
 type User struct {
-	ID        int64     `db:"id" json:"id"`
-	Email     string    `db:"email" json:"email"`
-	Password  string    `db:"password_hash" json:"-"`
-	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	ID              int64                `db:"id" json:"id"`
+	Email           string               `db:"email" json:"email"`
+	Password        string               `db:"password_hash" json:"-"`
+	FullName        *string              `db:"full_name" json:"full_name,omitempty"`
+	Phone           *string              `db:"phone" json:"phone,omitempty"`
+	PhoneVerifiedAt *time.Time           `db:"phone_verified_at" json:"phone_verified_at"`
+	Discoverable    bool                 `db:"discoverable" json:"discoverable"`
+	ScreeningStatus screening.UserStatus `db:"screening_status" json:"-"`
+	KYCStatus       kyc.Status           `db:"kyc_status" json:"kyc_status"`
+	EmailVerifiedAt *time.Time           `db:"email_verified_at" json:"email_verified_at"`
+	TokenVersion    int                  `db:"token_version" json:"-"`
+	CreatedAt       time.Time            `db:"created_at" json:"created_at"`
 }
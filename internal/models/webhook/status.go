@@ -0,0 +1,10 @@
+package webhook
+
+type Status string
+
+const (
+	PENDING   Status = "PENDING"
+	DELIVERED Status = "DELIVERED"
+	// DEAD — доставка исчерпала попытки и находится в очереди недоставленных.
+	DEAD Status = "DEAD"
+)
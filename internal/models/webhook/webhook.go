@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Subscription struct {
+	ID         int64     `db:"id"          json:"id"`
+	UserID     int64     `db:"user_id"     json:"user_id"`
+	URL        string    `db:"url"         json:"url"`
+	Secret     string    `db:"secret"      json:"-"`
+	EventTypes []string  `db:"event_types" json:"event_types"`
+	Active     bool      `db:"active"      json:"active"`
+	CreatedAt  time.Time `db:"created_at"  json:"created_at"`
+}
+
+type Delivery struct {
+	ID             int64           `db:"id"               json:"id"`
+	SubscriptionID int64           `db:"subscription_id"  json:"subscription_id"`
+	EventID        int64           `db:"event_id"         json:"event_id"`
+	EventType      string          `db:"event_type"       json:"event_type"`
+	Payload        json.RawMessage `db:"payload"          json:"payload"`
+	Status         Status          `db:"status"           json:"status"`
+	Attempts       int             `db:"attempts"         json:"attempts"`
+	NextAttemptAt  time.Time       `db:"next_attempt_at"  json:"next_attempt_at"`
+	LastStatusCode *int            `db:"last_status_code" json:"last_status_code"`
+	LastError      string          `db:"last_error"       json:"last_error"`
+	CreatedAt      time.Time       `db:"created_at"       json:"created_at"`
+	DeliveredAt    *time.Time      `db:"delivered_at"     json:"delivered_at"`
+}
+
+type Attempt struct {
+	ID         int64     `db:"id"          json:"id"`
+	DeliveryID int64     `db:"delivery_id" json:"delivery_id"`
+	StatusCode *int      `db:"status_code" json:"status_code"`
+	Error      string    `db:"error"       json:"error"`
+	DurationMs int       `db:"duration_ms" json:"duration_ms"`
+	CreatedAt  time.Time `db:"created_at"  json:"created_at"`
+}
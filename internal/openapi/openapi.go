@@ -0,0 +1,215 @@
+// Package openapi описывает API спецификацией OpenAPI 3.0. Спецификация
+// собирается из таблицы Operations и типов DTO, поэтому схемы тел всегда
+// совпадают с тем, что кодируют обработчики. Verify сверяет таблицу
+// с маршрутами роутера при запуске сервера; по той же таблице cmd/genclient
+// генерирует Go-клиент.
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+const Version = "1.0.0"
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Build собирает документ по таблице Operations.
+func Build() *Document {
+	reg := newSchemaRegistry()
+	problemRef := reg.of(problem.Problem{})
+	reg.schemas["Problem"].AdditionalProperties = &Schema{Description: "Члены расширения, например challenge_id"}
+
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "Bank API",
+			Description: "Ошибки возвращаются в формате RFC 7807 (application/problem+json). Язык сообщений выбирается заголовком Accept-Language.",
+			Version:     Version,
+		},
+		Servers: []Server{{URL: "/api"}},
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearerAuth": {
+					Type:         "http",
+					Scheme:       "bearer",
+					BearerFormat: "JWT",
+					Description:  "Токен из /login",
+				},
+				"operatorAuth": {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: "Служебный токен оператора",
+				},
+			},
+		},
+	}
+
+	ids := make(map[string]bool)
+	tags := make(map[string]bool)
+	for _, op := range Operations {
+		if ids[op.ID] {
+			panic(fmt.Sprintf("openapi: повторяется идентификатор операции %s", op.ID))
+		}
+		ids[op.ID] = true
+
+		if !tags[op.Tag] {
+			tags[op.Tag] = true
+			doc.Tags = append(doc.Tags, Tag{Name: op.Tag})
+		}
+
+		item, ok := doc.Paths[op.Path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[op.Path] = item
+		}
+		item[strings.ToLower(op.Method)] = buildOperation(reg, op, problemRef)
+	}
+
+	doc.Components.Schemas = reg.schemas
+	return doc
+}
+
+func buildOperation(reg *schemaRegistry, op Operation, problemRef *Schema) *OperationObject {
+	o := &OperationObject{
+		OperationID: op.ID,
+		Tags:        []string{op.Tag},
+		Summary:     op.Summary,
+		Responses:   make(map[string]ResponseObject),
+		Security:    []map[string][]string{},
+	}
+
+	switch op.Auth {
+	case Bearer:
+		o.Security = append(o.Security, map[string][]string{"bearerAuth": {}})
+	case OperatorToken:
+		o.Security = append(o.Security, map[string][]string{"operatorAuth": {}})
+	}
+
+	for _, p := range PathParams(op) {
+		o.Parameters = append(o.Parameters, buildParameter(reg, p, "path"))
+	}
+	for _, p := range op.Query {
+		o.Parameters = append(o.Parameters, buildParameter(reg, p, "query"))
+	}
+
+	if len(op.Body) > 0 {
+		o.RequestBody = &RequestBody{Required: !op.BodyOptional, Content: buildContent(reg, op.Body)}
+	}
+
+	for _, resp := range op.Responses {
+		o.Responses[fmt.Sprint(resp.Status)] = ResponseObject{
+			Description: resp.Description,
+			Headers:     resp.Headers,
+			Content:     buildContent(reg, resp.Content),
+		}
+	}
+	o.Responses["default"] = ResponseObject{
+		Description: "Ошибка",
+		Content:     map[string]MediaType{problem.ContentType: {Schema: problemRef}},
+	}
+	return o
+}
+
+// PathParams возвращает параметры пути операции в порядке их следования
+// в шаблоне.
+func PathParams(op Operation) []Param {
+	var params []Param
+	for _, m := range pathParamPattern.FindAllStringSubmatch(op.Path, -1) {
+		p := Param{Name: m[1], Required: true, Value: int64(0)}
+		for _, override := range op.PathParams {
+			if override.Name == p.Name {
+				p = override
+			}
+		}
+		params = append(params, p)
+	}
+	return params
+}
+
+func buildParameter(reg *schemaRegistry, p Param, in string) ParameterObject {
+	value := p.Value
+	if value == nil {
+		value = ""
+	}
+	return ParameterObject{
+		Name:        p.Name,
+		In:          in,
+		Description: p.Description,
+		Required:    p.Required,
+		Schema:      reg.of(value),
+	}
+}
+
+func buildContent(reg *schemaRegistry, contents []Content) map[string]MediaType {
+	if len(contents) == 0 {
+		return nil
+	}
+	m := make(map[string]MediaType, len(contents))
+	for _, c := range contents {
+		m[c.MediaType] = MediaType{Schema: reg.of(c.Value)}
+	}
+	return m
+}
+
+// Verify сверяет маршруты роутера с таблицей Operations: каждый маршрут
+// должен быть описан, а каждая операция — зарегистрирована. prefix
+// отрезается от шаблонов маршрутов.
+func Verify(router *mux.Router, prefix string) error {
+	registered := make(map[string]bool)
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if route.GetHandler() == nil {
+			return nil
+		}
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return fmt.Errorf("маршрут %s без метода: %w", tpl, err)
+		}
+		for _, m := range methods {
+			registered[m+" "+strings.TrimPrefix(tpl, prefix)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка обхода маршрутов: %w", err)
+	}
+
+	var missing, undocumented []string
+	documented := make(map[string]bool, len(Operations))
+	for _, op := range Operations {
+		key := op.Method + " " + op.Path
+		documented[key] = true
+		if !registered[key] {
+			missing = append(missing, key)
+		}
+	}
+	for key := range registered {
+		if !documented[key] {
+			undocumented = append(undocumented, key)
+		}
+	}
+	if len(missing) == 0 && len(undocumented) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+	sort.Strings(undocumented)
+	var parts []string
+	if len(undocumented) > 0 {
+		parts = append(parts, "не описаны: "+strings.Join(undocumented, ", "))
+	}
+	if len(missing) > 0 {
+		parts = append(parts, "не зарегистрированы: "+strings.Join(missing, ", "))
+	}
+	return fmt.Errorf("спецификация OpenAPI расходится с маршрутами: %s", strings.Join(parts, "; "))
+}
@@ -0,0 +1,333 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/models/aml"
+	"github.com/therealadik/bank-api/internal/models/lockout"
+	"github.com/therealadik/bank-api/internal/models/overdraft"
+	"github.com/therealadik/bank-api/internal/models/screening"
+)
+
+// Auth — способ аутентификации операции.
+type Auth int
+
+const (
+	Public Auth = iota
+	// Bearer — JWT пользователя из /login.
+	Bearer
+	// OperatorToken — служебный токен оператора.
+	OperatorToken
+)
+
+// Operation описывает один маршрут API. Схемы тел строятся по типам
+// значений Body и Content; *Schema используется как есть.
+type Operation struct {
+	Method string
+	// Path — шаблон пути относительно /api в синтаксисе gorilla/mux.
+	Path    string
+	ID      string
+	Tag     string
+	Summary string
+	Auth    Auth
+	// PathParams переопределяет параметры пути; по умолчанию параметр —
+	// целочисленный идентификатор.
+	PathParams []Param
+	Query      []Param
+	// Body — варианты тела запроса по типам содержимого.
+	Body         []Content
+	BodyOptional bool
+	Responses    []Response
+}
+
+type Param struct {
+	Name        string
+	Description string
+	Required    bool
+	// Value — значение, по типу которого строится схема; nil — строка.
+	Value any
+}
+
+type Content struct {
+	MediaType string
+	Value     any
+}
+
+type Response struct {
+	Status      int
+	Description string
+	Content     []Content
+	Headers     map[string]Header
+}
+
+// oneOf — тело ответа, принимающее одну из нескольких форм.
+type oneOf []any
+
+const (
+	mediaJSON      = "application/json"
+	mediaCSV       = "text/csv"
+	mediaMultipart = "multipart/form-data"
+	mediaSSE       = "text/event-stream"
+)
+
+func jsonBody(v any) []Content {
+	return []Content{{MediaType: mediaJSON, Value: v}}
+}
+
+func reply(status int, description string, v any) Response {
+	resp := Response{Status: status, Description: description}
+	if v != nil {
+		resp.Content = jsonBody(v)
+	}
+	return resp
+}
+
+// accountRef — счет в пути задается идентификатором или номером.
+var accountRef = []Param{{Name: "id", Description: "Идентификатор или номер счета", Required: true, Value: ""}}
+
+var statusFilter = []Param{{Name: "status", Description: "Фильтр по статусу"}}
+
+// documentFile — файл документа KYC отдается с форматом, определенным при
+// загрузке.
+var documentFile = []Content{
+	{MediaType: "image/jpeg", Value: &Schema{Type: "string", Format: "binary"}},
+	{MediaType: "image/png", Value: &Schema{Type: "string", Format: "binary"}},
+	{MediaType: "application/pdf", Value: &Schema{Type: "string", Format: "binary"}},
+}
+
+var uploadForm = &Schema{
+	Type: "object",
+	Properties: map[string]*Schema{
+		"type": {Type: "string", Enum: []string{"PASSPORT", "ID_CARD", "DRIVER_LICENSE", "PROOF_OF_ADDRESS", "SELFIE"}},
+		"file": {Type: "string", Format: "binary"},
+	},
+	Required:      []string{"type", "file"},
+	PropertyOrder: []string{"type", "file"},
+}
+
+// Operations — все маршруты API. Порядок задает порядок методов в
+// сгенерированном клиенте; соответствие маршрутам роутера проверяет Verify.
+var Operations = []Operation{
+	// Регистрация и вход.
+	{Method: http.MethodPost, Path: "/register", ID: "Register", Tag: "auth", Summary: "Регистрация пользователя",
+		Body: jsonBody(dto.RegisterRequest{}), Responses: []Response{reply(http.StatusCreated, "Пользователь зарегистрирован", dto.RegisterResponse{})}},
+	{Method: http.MethodPost, Path: "/login", ID: "Login", Tag: "auth", Summary: "Вход по email и паролю",
+		Body: jsonBody(dto.LoginRequest{}), Responses: []Response{reply(http.StatusOK, "JWT токен", dto.AuthResponse{})}},
+	{Method: http.MethodPost, Path: "/email/verify", ID: "VerifyEmail", Tag: "auth", Summary: "Подтверждение email по токену из письма",
+		Body: jsonBody(dto.VerifyEmailRequest{}), Responses: []Response{reply(http.StatusNoContent, "Адрес подтвержден", nil)}},
+	{Method: http.MethodPost, Path: "/email/verify/resend", ID: "ResendVerification", Tag: "auth", Summary: "Повторная отправка письма для подтверждения email",
+		Body: jsonBody(dto.EmailRequest{}), Responses: []Response{reply(http.StatusAccepted, "Если адрес зарегистрирован и не подтвержден, письмо отправлено", nil)}},
+	{Method: http.MethodPost, Path: "/password/forgot", ID: "ForgotPassword", Tag: "auth", Summary: "Запрос на сброс пароля",
+		Body: jsonBody(dto.EmailRequest{}), Responses: []Response{reply(http.StatusAccepted, "Если адрес зарегистрирован, письмо отправлено", nil)}},
+	{Method: http.MethodPost, Path: "/password/reset", ID: "ResetPassword", Tag: "auth", Summary: "Сброс пароля по токену из письма",
+		Body: jsonBody(dto.ResetPasswordRequest{}), Responses: []Response{reply(http.StatusNoContent, "Пароль изменен", nil)}},
+
+	// Операторские маршруты.
+	{Method: http.MethodGet, Path: "/operator/screening/hits", ID: "ListScreeningHits", Tag: "operator", Summary: "Совпадения по санкционным спискам", Auth: OperatorToken,
+		Query: statusFilter, Responses: []Response{reply(http.StatusOK, "Совпадения", []screening.Hit{})}},
+	{Method: http.MethodGet, Path: "/operator/screening/hits/{id}", ID: "GetScreeningHit", Tag: "operator", Summary: "Совпадение по санкционным спискам", Auth: OperatorToken,
+		Responses: []Response{reply(http.StatusOK, "Совпадение", screening.Hit{})}},
+	{Method: http.MethodPost, Path: "/operator/screening/hits/{id}/clear", ID: "ClearScreeningHit", Tag: "operator", Summary: "Признать совпадение ложным", Auth: OperatorToken,
+		Body: jsonBody(dto.ResolveHitRequest{}), Responses: []Response{reply(http.StatusOK, "Совпадение закрыто", screening.Hit{})}},
+	{Method: http.MethodPost, Path: "/operator/screening/hits/{id}/confirm", ID: "ConfirmScreeningHit", Tag: "operator", Summary: "Подтвердить совпадение", Auth: OperatorToken,
+		Body: jsonBody(dto.ResolveHitRequest{}), Responses: []Response{reply(http.StatusOK, "Совпадение подтверждено", screening.Hit{})}},
+	{Method: http.MethodGet, Path: "/operator/kyc", ID: "ListPendingKYC", Tag: "operator", Summary: "Анкеты, ожидающие проверки", Auth: OperatorToken,
+		Responses: []Response{reply(http.StatusOK, "Анкеты", []dto.ProfileResponse{})}},
+	{Method: http.MethodGet, Path: "/operator/kyc/{userId}", ID: "GetKYCCustomer", Tag: "operator", Summary: "Анкета клиента", Auth: OperatorToken,
+		Responses: []Response{reply(http.StatusOK, "Анкета", dto.ProfileResponse{})}},
+	{Method: http.MethodGet, Path: "/operator/kyc/{userId}/documents", ID: "ListKYCCustomerDocuments", Tag: "operator", Summary: "Документы клиента", Auth: OperatorToken,
+		Responses: []Response{reply(http.StatusOK, "Документы", []dto.KYCDocumentResponse{})}},
+	{Method: http.MethodGet, Path: "/operator/kyc/{userId}/documents/{docId}", ID: "DownloadKYCDocument", Tag: "operator", Summary: "Скачать документ клиента", Auth: OperatorToken,
+		Responses: []Response{{Status: http.StatusOK, Description: "Файл документа", Content: documentFile}}},
+	{Method: http.MethodPost, Path: "/operator/kyc/{userId}/verify", ID: "VerifyKYC", Tag: "operator", Summary: "Подтвердить личность клиента", Auth: OperatorToken,
+		Responses: []Response{reply(http.StatusOK, "Анкета", dto.ProfileResponse{})}},
+	{Method: http.MethodPost, Path: "/operator/kyc/{userId}/reject", ID: "RejectKYC", Tag: "operator", Summary: "Отклонить анкету клиента", Auth: OperatorToken,
+		Body: jsonBody(dto.RejectKYCRequest{}), Responses: []Response{reply(http.StatusOK, "Анкета", dto.ProfileResponse{})}},
+	{Method: http.MethodGet, Path: "/operator/aml/cases", ID: "ListAMLCases", Tag: "operator", Summary: "Кейсы мониторинга операций", Auth: OperatorToken,
+		Query: statusFilter, Responses: []Response{reply(http.StatusOK, "Кейсы", []aml.Case{})}},
+	{Method: http.MethodGet, Path: "/operator/aml/cases/{id}", ID: "GetAMLCase", Tag: "operator", Summary: "Кейс с операциями", Auth: OperatorToken,
+		Responses: []Response{reply(http.StatusOK, "Кейс", dto.AMLCaseResponse{})}},
+	{Method: http.MethodPost, Path: "/operator/aml/cases/{id}/escalate", ID: "EscalateAMLCase", Tag: "operator", Summary: "Передать кейс на расследование", Auth: OperatorToken,
+		Body: jsonBody(dto.AMLReviewRequest{}), Responses: []Response{reply(http.StatusOK, "Кейс", aml.Case{})}},
+	{Method: http.MethodPost, Path: "/operator/aml/cases/{id}/dismiss", ID: "DismissAMLCase", Tag: "operator", Summary: "Закрыть кейс без последствий", Auth: OperatorToken,
+		Body: jsonBody(dto.AMLReviewRequest{}), Responses: []Response{reply(http.StatusOK, "Кейс", aml.Case{})}},
+	{Method: http.MethodPost, Path: "/operator/aml/cases/{id}/sar", ID: "ExportSAR", Tag: "operator", Summary: "Выгрузить сообщение о подозрительной операции", Auth: OperatorToken,
+		Responses: []Response{{Status: http.StatusOK, Description: "Файл сообщения", Content: jsonBody(&Schema{Type: "object", Description: "Сообщение о подозрительной операции"}),
+			Headers: map[string]Header{"Content-Disposition": {Schema: &Schema{Type: "string"}}}}}},
+	{Method: http.MethodGet, Path: "/operator/lockouts", ID: "ListLockouts", Tag: "operator", Summary: "Действующие блокировки после неудачных попыток", Auth: OperatorToken,
+		Query: []Param{{Name: "scope", Description: "Фильтр по виду блокировки"}}, Responses: []Response{reply(http.StatusOK, "Блокировки", []lockout.Lockout{})}},
+	{Method: http.MethodPost, Path: "/operator/lockouts/{id}/release", ID: "ReleaseLockout", Tag: "operator", Summary: "Снять блокировку", Auth: OperatorToken,
+		Responses: []Response{reply(http.StatusOK, "Блокировка", lockout.Lockout{})}},
+	{Method: http.MethodGet, Path: "/operator/overdraft-requests", ID: "ListOverdraftRequests", Tag: "operator", Summary: "Заявки на увеличение овердрафта", Auth: OperatorToken,
+		Query: statusFilter, Responses: []Response{reply(http.StatusOK, "Заявки", []overdraft.Request{})}},
+	{Method: http.MethodPost, Path: "/operator/overdraft-requests/{id}/approve", ID: "ApproveOverdraftRequest", Tag: "operator", Summary: "Одобрить заявку на овердрафт", Auth: OperatorToken,
+		Body: jsonBody(dto.ResolveOverdraftRequest{}), Responses: []Response{reply(http.StatusOK, "Заявка", overdraft.Request{})}},
+	{Method: http.MethodPost, Path: "/operator/overdraft-requests/{id}/reject", ID: "RejectOverdraftRequest", Tag: "operator", Summary: "Отклонить заявку на овердрафт", Auth: OperatorToken,
+		Body: jsonBody(dto.ResolveOverdraftRequest{}), Responses: []Response{reply(http.StatusOK, "Заявка", overdraft.Request{})}},
+
+	// Пользователь и KYC.
+	{Method: http.MethodGet, Path: "/users/me", ID: "GetMe", Tag: "users", Summary: "Текущий пользователь", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Пользователь", dto.UserResponse{})}},
+	{Method: http.MethodPatch, Path: "/users/me", ID: "UpdateContacts", Tag: "users", Summary: "Изменить контакты", Auth: Bearer,
+		Body: jsonBody(dto.UpdateContactsRequest{}), Responses: []Response{reply(http.StatusOK, "Пользователь; на новый номер отправлен код подтверждения", dto.UserResponse{})}},
+	{Method: http.MethodPost, Path: "/users/me/phone/code", ID: "SendPhoneCode", Tag: "users", Summary: "Повторно отправить код подтверждения номера", Auth: Bearer,
+		Responses: []Response{reply(http.StatusAccepted, "Код отправлен", nil)}},
+	{Method: http.MethodPost, Path: "/users/me/phone/confirm", ID: "ConfirmPhone", Tag: "users", Summary: "Подтвердить номер кодом из SMS", Auth: Bearer,
+		Body: jsonBody(dto.ConfirmCodeRequest{}), Responses: []Response{reply(http.StatusOK, "Пользователь", dto.UserResponse{})}},
+	{Method: http.MethodGet, Path: "/users/me/profile", ID: "GetProfile", Tag: "kyc", Summary: "Анкета клиента", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Анкета", dto.ProfileResponse{})}},
+	{Method: http.MethodPut, Path: "/users/me/profile", ID: "UpdateProfile", Tag: "kyc", Summary: "Заполнить анкету", Auth: Bearer,
+		Body: jsonBody(dto.UpdateProfileRequest{}), Responses: []Response{reply(http.StatusOK, "Анкета", dto.ProfileResponse{})}},
+	{Method: http.MethodGet, Path: "/kyc/documents", ID: "ListKYCDocuments", Tag: "kyc", Summary: "Загруженные документы", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Документы", []dto.KYCDocumentResponse{})}},
+	{Method: http.MethodPost, Path: "/kyc/documents", ID: "UploadKYCDocument", Tag: "kyc", Summary: "Загрузить документ", Auth: Bearer,
+		Body: []Content{{MediaType: mediaMultipart, Value: uploadForm}}, Responses: []Response{reply(http.StatusCreated, "Документ загружен", dto.KYCDocumentResponse{})}},
+	{Method: http.MethodPost, Path: "/kyc/submit", ID: "SubmitKYC", Tag: "kyc", Summary: "Отправить анкету на проверку", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Анкета", dto.ProfileResponse{})}},
+
+	// Счета и переводы.
+	{Method: http.MethodPost, Path: "/accounts", ID: "CreateAccount", Tag: "accounts", Summary: "Открыть счет", Auth: Bearer,
+		Body: jsonBody(dto.CreateAccountRequest{}), Responses: []Response{reply(http.StatusCreated, "Счет открыт", dto.AccountResponse{})}},
+	{Method: http.MethodGet, Path: "/accounts", ID: "ListAccounts", Tag: "accounts", Summary: "Счета пользователя", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Счета", dto.AccountsListResponse{})}},
+	{Method: http.MethodPatch, Path: "/accounts/{id}/balance", ID: "UpdateBalance", Tag: "accounts", Summary: "Пополнить или списать средства", Auth: Bearer,
+		PathParams: accountRef, Body: jsonBody(dto.UpdateBalanceRequest{}), Responses: []Response{reply(http.StatusOK, "Счет", dto.AccountResponse{})}},
+	{Method: http.MethodPut, Path: "/accounts/{id}/overdraft", ID: "SetOverdraft", Tag: "accounts", Summary: "Установить лимит овердрафта", Auth: Bearer,
+		PathParams: accountRef, Body: jsonBody(dto.OverdraftRequest{}), Responses: []Response{
+			reply(http.StatusOK, "Лимит применен", dto.AccountResponse{}),
+			reply(http.StatusAccepted, "Увеличение лимита ждет решения оператора", overdraft.Request{}),
+		}},
+	{Method: http.MethodPost, Path: "/accounts/{id}/members", ID: "AddMember", Tag: "approvals", Summary: "Добавить участника счета", Auth: Bearer,
+		PathParams: accountRef, Body: jsonBody(dto.AddMemberRequest{}), Responses: []Response{reply(http.StatusCreated, "Участник добавлен", dto.MemberResponse{})}},
+	{Method: http.MethodGet, Path: "/accounts/{id}/members", ID: "ListMembers", Tag: "approvals", Summary: "Участники счета", Auth: Bearer,
+		PathParams: accountRef, Responses: []Response{reply(http.StatusOK, "Участники", dto.MemberListResponse{})}},
+	{Method: http.MethodDelete, Path: "/accounts/{id}/members/{userId}", ID: "RemoveMember", Tag: "approvals", Summary: "Удалить участника счета", Auth: Bearer,
+		PathParams: accountRef, Responses: []Response{reply(http.StatusNoContent, "Участник удален", nil)}},
+	{Method: http.MethodGet, Path: "/accounts/{id}/approval-policy", ID: "GetApprovalPolicy", Tag: "approvals", Summary: "Политика подтверждения платежей", Auth: Bearer,
+		PathParams: accountRef, Responses: []Response{reply(http.StatusOK, "Политика", dto.ApprovalPolicyResponse{})}},
+	{Method: http.MethodPut, Path: "/accounts/{id}/approval-policy", ID: "SetApprovalPolicy", Tag: "approvals", Summary: "Задать политику подтверждения платежей", Auth: Bearer,
+		PathParams: accountRef, Body: jsonBody(dto.ApprovalPolicyRequest{}), Responses: []Response{reply(http.StatusOK, "Политика", dto.ApprovalPolicyResponse{})}},
+	{Method: http.MethodGet, Path: "/approvals", ID: "ListPendingPayments", Tag: "approvals", Summary: "Платежи, ожидающие подтверждения", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Платежи", dto.PendingPaymentListResponse{})}},
+	{Method: http.MethodGet, Path: "/approvals/{id}", ID: "GetPendingPayment", Tag: "approvals", Summary: "Платеж с решениями участников", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Платеж", dto.PendingPaymentResponse{})}},
+	{Method: http.MethodPost, Path: "/approvals/{id}/approve", ID: "ApprovePayment", Tag: "approvals", Summary: "Одобрить платеж", Auth: Bearer,
+		Body: jsonBody(dto.DecisionRequest{}), BodyOptional: true, Responses: []Response{reply(http.StatusOK, "Платеж", dto.PendingPaymentResponse{})}},
+	{Method: http.MethodPost, Path: "/approvals/{id}/reject", ID: "RejectPayment", Tag: "approvals", Summary: "Отклонить платеж", Auth: Bearer,
+		Body: jsonBody(dto.DecisionRequest{}), BodyOptional: true, Responses: []Response{reply(http.StatusOK, "Платеж", dto.PendingPaymentResponse{})}},
+	{Method: http.MethodGet, Path: "/accounts/{id}/transactions", ID: "ListTransactions", Tag: "accounts", Summary: "История операций по счету", Auth: Bearer,
+		PathParams: accountRef, Responses: []Response{reply(http.StatusOK, "Операции", dto.TransactionListResponse{})}},
+	{Method: http.MethodPost, Path: "/transfer", ID: "Transfer", Tag: "accounts", Summary: "Перевод между счетами", Auth: Bearer,
+		Body: jsonBody(dto.TransferRequest{}), Responses: []Response{
+			reply(http.StatusOK, "Перевод выполнен", dto.TransferResponse{}),
+			reply(http.StatusAccepted, "Платеж ждет подтверждения участников счета или перевод задержан до проверки получателя",
+				oneOf{dto.PendingPaymentResponse{}, dto.HeldTransferResponse{}}),
+		}},
+
+	// Получатели.
+	{Method: http.MethodPost, Path: "/beneficiaries", ID: "CreateBeneficiary", Tag: "beneficiaries", Summary: "Добавить получателя", Auth: Bearer,
+		Body: jsonBody(dto.CreateBeneficiaryRequest{}), Responses: []Response{reply(http.StatusCreated, "Получатель добавлен", dto.BeneficiaryResponse{})}},
+	{Method: http.MethodGet, Path: "/beneficiaries", ID: "ListBeneficiaries", Tag: "beneficiaries", Summary: "Сохраненные получатели", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Получатели", dto.BeneficiaryListResponse{})}},
+	{Method: http.MethodPatch, Path: "/beneficiaries/{id}", ID: "UpdateBeneficiary", Tag: "beneficiaries", Summary: "Изменить получателя", Auth: Bearer,
+		Body: jsonBody(dto.UpdateBeneficiaryRequest{}), Responses: []Response{reply(http.StatusOK, "Получатель", dto.BeneficiaryResponse{})}},
+	{Method: http.MethodDelete, Path: "/beneficiaries/{id}", ID: "DeleteBeneficiary", Tag: "beneficiaries", Summary: "Удалить получателя", Auth: Bearer,
+		Responses: []Response{reply(http.StatusNoContent, "Получатель удален", nil)}},
+	{Method: http.MethodPost, Path: "/beneficiaries/{id}/verify/code", ID: "SendBeneficiaryCode", Tag: "beneficiaries", Summary: "Отправить код подтверждения получателя на email", Auth: Bearer,
+		Responses: []Response{reply(http.StatusAccepted, "Код отправлен", nil)}},
+	{Method: http.MethodPost, Path: "/beneficiaries/{id}/verify", ID: "VerifyBeneficiary", Tag: "beneficiaries", Summary: "Подтвердить получателя паролем и кодом из письма", Auth: Bearer,
+		Body: jsonBody(dto.VerifyBeneficiaryRequest{}), Responses: []Response{reply(http.StatusOK, "Получатель", dto.BeneficiaryResponse{})}},
+
+	// Пакетные платежи.
+	{Method: http.MethodPost, Path: "/batches", ID: "CreateBatch", Tag: "batches", Summary: "Загрузить пакет платежей в JSON или CSV", Auth: Bearer,
+		Query: []Param{
+			{Name: "from_account", Description: "Счет списания для CSV: идентификатор или номер"},
+			{Name: "mode", Description: "Режим исполнения для CSV"},
+		},
+		Body: []Content{
+			{MediaType: mediaJSON, Value: dto.CreateBatchRequest{}},
+			{MediaType: mediaCSV, Value: &Schema{Type: "string", Description: "CSV с заголовком: account, amount и необязательная reference"}},
+		},
+		Responses: []Response{reply(http.StatusCreated, "Пакет создан", dto.BatchResponse{})}},
+	{Method: http.MethodGet, Path: "/batches", ID: "ListBatches", Tag: "batches", Summary: "Пакеты платежей", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Пакеты", dto.BatchListResponse{})}},
+	{Method: http.MethodGet, Path: "/batches/{id}", ID: "GetBatch", Tag: "batches", Summary: "Пакет со строками", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Пакет", dto.BatchResponse{})}},
+	{Method: http.MethodPost, Path: "/batches/{id}/execute", ID: "ExecuteBatch", Tag: "batches", Summary: "Исполнить пакет", Auth: Bearer,
+		Responses: []Response{reply(http.StatusAccepted, "Пакет принят к исполнению", dto.BatchResponse{})}},
+
+	// Поток событий.
+	{Method: http.MethodGet, Path: "/stream", ID: "Stream", Tag: "stream", Summary: "Поток событий (Server-Sent Events)", Auth: Bearer,
+		Query:     []Param{{Name: "last_event_id", Description: "Идентификатор последнего полученного события, если нельзя передать заголовок Last-Event-ID"}},
+		Responses: []Response{{Status: http.StatusOK, Description: "Поток событий", Content: []Content{{MediaType: mediaSSE, Value: &Schema{Type: "string"}}}}}},
+
+	// Уведомления.
+	{Method: http.MethodGet, Path: "/notifications", ID: "ListNotifications", Tag: "notifications", Summary: "Уведомления", Auth: Bearer,
+		Query: []Param{{Name: "unread", Description: "Только непрочитанные", Value: false}}, Responses: []Response{reply(http.StatusOK, "Уведомления", dto.NotificationListResponse{})}},
+	{Method: http.MethodPost, Path: "/notifications/read-all", ID: "MarkAllNotificationsRead", Tag: "notifications", Summary: "Отметить все уведомления прочитанными", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Число отмеченных", dto.MarkAllReadResponse{})}},
+	{Method: http.MethodGet, Path: "/notifications/settings", ID: "GetNotificationSettings", Tag: "notifications", Summary: "Настройки уведомлений", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Настройки", dto.NotificationSettingsResponse{})}},
+	{Method: http.MethodPut, Path: "/notifications/settings", ID: "UpdateNotificationSettings", Tag: "notifications", Summary: "Изменить настройки уведомлений", Auth: Bearer,
+		Body: jsonBody(dto.UpdateNotificationSettingsRequest{}), Responses: []Response{reply(http.StatusOK, "Настройки", dto.NotificationSettingsResponse{})}},
+	{Method: http.MethodPost, Path: "/notifications/{id}/read", ID: "MarkNotificationRead", Tag: "notifications", Summary: "Отметить уведомление прочитанным", Auth: Bearer,
+		Responses: []Response{reply(http.StatusNoContent, "Уведомление прочитано", nil)}},
+
+	// Webhook.
+	{Method: http.MethodPost, Path: "/webhooks", ID: "CreateWebhook", Tag: "webhooks", Summary: "Подписаться на события", Auth: Bearer,
+		Body: jsonBody(dto.CreateWebhookRequest{}), Responses: []Response{reply(http.StatusCreated, "Подписка создана; секрет возвращается один раз", dto.WebhookResponse{})}},
+	{Method: http.MethodGet, Path: "/webhooks", ID: "ListWebhooks", Tag: "webhooks", Summary: "Подписки на события", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Подписки", dto.WebhookListResponse{})}},
+	{Method: http.MethodDelete, Path: "/webhooks/{id}", ID: "DeleteWebhook", Tag: "webhooks", Summary: "Удалить подписку", Auth: Bearer,
+		Responses: []Response{reply(http.StatusNoContent, "Подписка удалена", nil)}},
+	{Method: http.MethodGet, Path: "/webhooks/{id}/deliveries", ID: "ListWebhookDeliveries", Tag: "webhooks", Summary: "Доставки по подписке", Auth: Bearer,
+		Query: statusFilter, Responses: []Response{reply(http.StatusOK, "Доставки", dto.WebhookDeliveryListResponse{})}},
+	{Method: http.MethodGet, Path: "/webhook-deliveries/{id}", ID: "GetWebhookDelivery", Tag: "webhooks", Summary: "Доставка с попытками", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Доставка", dto.WebhookDeliveryResponse{})}},
+	{Method: http.MethodPost, Path: "/webhook-deliveries/{id}/redeliver", ID: "RedeliverWebhook", Tag: "webhooks", Summary: "Повторить доставку", Auth: Bearer,
+		Responses: []Response{reply(http.StatusAccepted, "Доставка поставлена в очередь", dto.WebhookDeliveryResponse{})}},
+
+	// Антифрод.
+	{Method: http.MethodPost, Path: "/risk/challenges/{id}/confirm", ID: "ConfirmChallenge", Tag: "risk", Summary: "Подтвердить операцию паролем", Auth: Bearer,
+		Body: jsonBody(dto.ConfirmChallengeRequest{}), Responses: []Response{reply(http.StatusNoContent, "Операция подтверждена, ее можно повторить", nil)}},
+
+	// Переводы по номеру телефона или email.
+	{Method: http.MethodPost, Path: "/p2p/transfers", ID: "PrepareP2PTransfer", Tag: "p2p", Summary: "Подготовить перевод по телефону или email", Auth: Bearer,
+		Body: jsonBody(dto.P2PTransferRequest{}), Responses: []Response{reply(http.StatusCreated, "Перевод подготовлен", dto.P2PTransferResponse{})}},
+	{Method: http.MethodPost, Path: "/p2p/transfers/{id}/confirm", ID: "ConfirmP2PTransfer", Tag: "p2p", Summary: "Подтвердить перевод", Auth: Bearer,
+		Responses: []Response{
+			reply(http.StatusOK, "Перевод выполнен", dto.P2PTransferResponse{}),
+			reply(http.StatusAccepted, "Перевод задержан до проверки получателя", dto.HeldTransferResponse{}),
+		}},
+
+	// Вклады.
+	{Method: http.MethodPost, Path: "/deposits", ID: "OpenDeposit", Tag: "deposits", Summary: "Открыть вклад", Auth: Bearer,
+		Body: jsonBody(dto.OpenDepositRequest{}), Responses: []Response{reply(http.StatusCreated, "Вклад открыт", dto.DepositResponse{})}},
+	{Method: http.MethodGet, Path: "/deposits", ID: "ListDeposits", Tag: "deposits", Summary: "Вклады", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Вклады", dto.DepositListResponse{})}},
+	{Method: http.MethodPost, Path: "/deposits/{id}/close", ID: "CloseDeposit", Tag: "deposits", Summary: "Закрыть вклад", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Вклад закрыт", dto.DepositResponse{})}},
+
+	// Комиссии.
+	{Method: http.MethodGet, Path: "/fees", ID: "ListFeeRules", Tag: "fees", Summary: "Тарифы", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Тарифы", dto.FeeRuleListResponse{})}},
+	{Method: http.MethodGet, Path: "/fees/quote", ID: "QuoteFee", Tag: "fees", Summary: "Рассчитать комиссию", Auth: Bearer,
+		Query: []Param{
+			{Name: "operation", Description: "Вид операции", Required: true},
+			{Name: "amount", Description: "Сумма операции", Required: true, Value: decimal.Decimal{}},
+		},
+		Responses: []Response{reply(http.StatusOK, "Комиссия", dto.FeeQuoteResponse{})}},
+
+	// Карты.
+	{Method: http.MethodPost, Path: "/cards", ID: "CreateCard", Tag: "cards", Summary: "Выпустить карту", Auth: Bearer,
+		Body: jsonBody(dto.CreateCardRequest{}), Responses: []Response{reply(http.StatusCreated, "Карта выпущена", dto.CreateCardResponse{})}},
+	{Method: http.MethodGet, Path: "/cards", ID: "ListCards", Tag: "cards", Summary: "Карты", Auth: Bearer,
+		Responses: []Response{reply(http.StatusOK, "Карты", dto.CardListResponse{})}},
+	{Method: http.MethodGet, Path: "/cards/{id}", ID: "GetCardDetails", Tag: "cards", Summary: "Реквизиты карты, зашифрованные ключом PGP клиента", Auth: Bearer,
+		Query:     []Param{{Name: "pgp_key", Description: "Открытый ключ PGP в ASCII armor", Required: true}},
+		Responses: []Response{reply(http.StatusOK, "Реквизиты карты", dto.CardDetailsResponse{})}},
+	{Method: http.MethodPost, Path: "/payments", ID: "ProcessCardPayment", Tag: "cards", Summary: "Оплата картой", Auth: Bearer,
+		Body: jsonBody(dto.CardPaymentRequest{}), Responses: []Response{reply(http.StatusOK, "Платеж проведен", dto.CardPaymentResponse{})}},
+}
@@ -0,0 +1,220 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	decimalType     = reflect.TypeOf(decimal.Decimal{})
+	nullDecimalType = reflect.TypeOf(decimal.NullDecimal{})
+	timeType        = reflect.TypeOf(time.Time{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaRegistry строит схемы по типам Go. Именованные структуры попадают
+// в components.schemas и подставляются ссылками.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// of возвращает схему значения v; *Schema возвращается как есть, oneOf —
+// как выбор из схем вариантов.
+func (r *schemaRegistry) of(v any) *Schema {
+	switch v := v.(type) {
+	case *Schema:
+		return v
+	case oneOf:
+		s := &Schema{}
+		for _, alt := range v {
+			s.OneOf = append(s.OneOf, r.of(alt))
+		}
+		return s
+	}
+	return r.schema(reflect.TypeOf(v))
+}
+
+func (r *schemaRegistry) schema(t reflect.Type) *Schema {
+	switch t {
+	case decimalType:
+		return &Schema{Type: "string", Format: "decimal", Pattern: `^-?\d+(\.\d+)?$`}
+	case nullDecimalType:
+		return &Schema{Type: "string", Format: "decimal", Pattern: `^-?\d+(\.\d+)?$`, Nullable: true}
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{Description: "Произвольный JSON"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := r.schema(t.Elem())
+		if s.Ref != "" {
+			// В OpenAPI 3.0 nullable рядом с $ref не действует.
+			return s
+		}
+		s.Nullable = true
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schema(t.Elem())}
+	case reflect.Interface:
+		return &Schema{Description: "Произвольный JSON"}
+	case reflect.Struct:
+		return r.ref(t)
+	}
+	panic(fmt.Sprintf("openapi: тип %s не поддерживается", t))
+}
+
+// ref регистрирует структуру в components и возвращает ссылку на нее. При
+// совпадении имен типов из разных пакетов к имени добавляется пакет.
+func (r *schemaRegistry) ref(t reflect.Type) *Schema {
+	name, ok := r.names[t]
+	if !ok {
+		name = t.Name()
+		if name == "" {
+			panic(fmt.Sprintf("openapi: анонимная структура %s", t))
+		}
+		if _, taken := r.schemas[name]; taken {
+			pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+			name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+		}
+
+		r.names[t] = name
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		r.schemas[name] = s
+		r.fields(t, s)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (r *schemaRegistry) fields(t reflect.Type, s *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Tag.Get("json") == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				r.fields(ft, s)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := r.schema(f.Type)
+		if applyValidate(prop, f.Tag.Get("validate")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+		s.PropertyOrder = append(s.PropertyOrder, name)
+	}
+}
+
+// applyValidate переносит правила тега validate в ограничения схемы и
+// сообщает, обязательно ли поле.
+func applyValidate(s *Schema, tag string) bool {
+	if tag == "" || s.Ref != "" {
+		return strings.HasPrefix(tag, "required")
+	}
+
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		rule, param, _ := strings.Cut(rule, "=")
+		switch rule {
+		case "required":
+			required = true
+		case "email":
+			s.Format = "email"
+		case "digits":
+			s.Pattern = `^[0-9]+$`
+		case "decimal":
+			s.Format = "decimal"
+			s.Pattern = `^-?\d+(\.\d+)?$`
+		case "oneof":
+			s.Enum = strings.Fields(param)
+		case "positive":
+			if s.Type == "integer" || s.Type == "number" {
+				zero := 0.0
+				s.Minimum, s.ExclusiveMinimum = &zero, true
+			} else {
+				s.Description = "Больше нуля"
+			}
+		case "min", "max", "len":
+			applyBound(s, rule, param)
+		}
+	}
+	return required
+}
+
+func applyBound(s *Schema, rule, param string) {
+	switch s.Type {
+	case "string", "array":
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return
+		}
+		lo, hi := &s.MinLength, &s.MaxLength
+		if s.Type == "array" {
+			lo, hi = &s.MinItems, &s.MaxItems
+		}
+		if rule == "min" || rule == "len" {
+			*lo = &n
+		}
+		if rule == "max" || rule == "len" {
+			*hi = &n
+		}
+	case "integer", "number":
+		v, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+		if rule == "min" || rule == "len" {
+			s.Minimum = &v
+		}
+		if rule == "max" || rule == "len" {
+			s.Maximum = &v
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Package problem формирует ответы об ошибках в формате RFC 7807
+// (application/problem+json). Поле code — стабильный машиночитаемый код
+// ошибки, detail — сообщение для человека.
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/therealadik/bank-api/internal/i18n"
+)
+
+const ContentType = "application/problem+json"
+
+// FieldError — ошибка в конкретном поле запроса. Field — путь к полю в JSON,
+// например items[2].amount.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type Problem struct {
+	Type   string       `json:"type"`
+	Title  string       `json:"title"`
+	Status int          `json:"status"`
+	Detail string       `json:"detail,omitempty"`
+	Code   string       `json:"code"`
+	Errors []FieldError `json:"errors,omitempty"`
+	// Extensions — дополнительные члены ответа, например challenge_id.
+	Extensions map[string]any `json:"-"`
+}
+
+// New создает ответ с типом /problems/<code>.
+func New(status int, code, detail string) *Problem {
+	return &Problem{
+		Type:   "/problems/" + code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// With добавляет член расширения и возвращает тот же ответ.
+func (p *Problem) With(key string, value any) *Problem {
+	if p.Extensions == nil {
+		p.Extensions = make(map[string]any)
+	}
+	p.Extensions[key] = value
+	return p
+}
+
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	type plain Problem
+	if len(p.Extensions) == 0 {
+		return json.Marshal((*plain)(p))
+	}
+
+	base, err := json.Marshal((*plain)(p))
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string]any, len(p.Extensions)+7)
+	for k, v := range p.Extensions {
+		members[k] = v
+	}
+	// Стандартные члены имеют приоритет над расширениями с тем же именем.
+	if err := json.Unmarshal(base, &members); err != nil {
+		return nil, err
+	}
+	return json.Marshal(members)
+}
+
+// Write отправляет ответ с кодом p.Status.
+func Write(w http.ResponseWriter, p *Problem) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// Error отвечает ошибкой с кодом code; detail берется из каталога сообщений
+// на языке запроса.
+func Error(w http.ResponseWriter, r *http.Request, status int, code string, args ...any) {
+	Write(w, New(status, code, i18n.T(i18n.FromContext(r.Context()), code, args...)))
+}
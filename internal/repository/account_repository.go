@@ -2,12 +2,24 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/events"
 	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/fee"
+	"github.com/therealadik/bank-api/internal/models/transaction"
+	"github.com/therealadik/bank-api/internal/tracing"
 )
 
+const accountColumns = `id, user_id, account_number, balance, currency, product, interest_rate, day_count,
+		overdraft_limit, overdraft_rate, created_at`
+
+var ErrLimitExceeded = errors.New("операция превышает доступный остаток с учетом овердрафта")
+
 type AccountRepository struct {
 	db *pgxpool.Pool
 }
@@ -16,15 +28,12 @@ func NewAccountRepository(db *pgxpool.Pool) *AccountRepository {
 	return &AccountRepository{db: db}
 }
 
-func (r *AccountRepository) CreateAccount(ctx context.Context, userID int64, currency account.Currency) (*account.Account, error) {
-	query := `
-		INSERT INTO accounts (user_id, currency)
-		VALUES ($1, $2)
-		RETURNING id, user_id, balance, currency, created_at
-	`
+func scanAccount(row pgx.Row) (*account.Account, error) {
 	var acc account.Account
-	err := r.db.QueryRow(ctx, query, userID, currency).Scan(
-		&acc.ID, &acc.UserID, &acc.Balance, &acc.Currency, &acc.CreatedAt,
+	err := row.Scan(
+		&acc.ID, &acc.UserID, &acc.AccountNumber, &acc.Balance, &acc.Currency,
+		&acc.Product, &acc.InterestRate, &acc.DayCount,
+		&acc.OverdraftLimit, &acc.OverdraftRate, &acc.CreatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -32,30 +41,186 @@ func (r *AccountRepository) CreateAccount(ctx context.Context, userID int64, cur
 	return &acc, nil
 }
 
+func (r *AccountRepository) CreateAccount(ctx context.Context, userID int64, accountNumber string, currency account.Currency,
+	product account.Product, interestRate decimal.Decimal, dayCount account.DayCount) (*account.Account, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	acc, err := insertAccount(ctx, tx, userID, accountNumber, currency, product, interestRate, dayCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+// insertAccount открывает счет и ставит в outbox событие AccountCreated
+// в рамках транзакции tx.
+func insertAccount(ctx context.Context, tx pgx.Tx, userID int64, accountNumber string, currency account.Currency,
+	product account.Product, interestRate decimal.Decimal, dayCount account.DayCount) (*account.Account, error) {
+	query := `
+		INSERT INTO accounts (user_id, account_number, currency, product, interest_rate, day_count)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + accountColumns
+
+	acc, err := scanAccount(tx.QueryRow(ctx, query, userID, accountNumber, currency, product, interestRate, dayCount))
+	if err != nil {
+		return nil, err
+	}
+
+	err = enqueueEvent(ctx, tx, events.AggregateAccount, acc.ID, events.ACCOUNT_CREATED, events.AccountCreated{
+		AccountID:     acc.ID,
+		UserID:        acc.UserID,
+		AccountNumber: accountNumber,
+		Currency:      string(acc.Currency),
+		Product:       string(acc.Product),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
 func (r *AccountRepository) GetAccountByID(ctx context.Context, id int64) (*account.Account, error) {
 	query := `
-		SELECT id, user_id, balance, currency, created_at
+		SELECT ` + accountColumns + `
 		FROM accounts
 		WHERE id = $1
 	`
-	var acc account.Account
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&acc.ID, &acc.UserID, &acc.Balance, &acc.Currency, &acc.CreatedAt,
-	)
-	if err != nil {
-		return nil, err
-	}
-	return &acc, nil
+	return scanAccount(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *AccountRepository) GetAccountByNumber(ctx context.Context, number string) (*account.Account, error) {
+	query := `
+		SELECT ` + accountColumns + `
+		FROM accounts
+		WHERE account_number = $1
+	`
+	return scanAccount(r.db.QueryRow(ctx, query, number))
+}
+
+func (r *AccountRepository) GetAccountsWithoutNumber(ctx context.Context) ([]*account.Account, error) {
+	query := `
+		SELECT ` + accountColumns + `
+		FROM accounts
+		WHERE account_number IS NULL
+		ORDER BY id
+	`
+	return r.queryAccounts(ctx, query)
+}
+
+func (r *AccountRepository) SetAccountNumber(ctx context.Context, id int64, number string) error {
+	query := `
+		UPDATE accounts
+		SET account_number = $1
+		WHERE id = $2 AND account_number IS NULL
+	`
+	_, err := r.db.Exec(ctx, query, number, id)
+	return err
 }
 
 func (r *AccountRepository) GetAccountsByUserID(ctx context.Context, userID int64) ([]*account.Account, error) {
 	query := `
-		SELECT id, user_id, balance, currency, created_at
+		SELECT ` + accountColumns + `
 		FROM accounts
 		WHERE user_id = $1
 		ORDER BY id
 	`
-	rows, err := r.db.Query(ctx, query, userID)
+	return r.queryAccounts(ctx, query, userID)
+}
+
+func (r *AccountRepository) GetAccountsByIDs(ctx context.Context, ids []int64) ([]*account.Account, error) {
+	query := `
+		SELECT ` + accountColumns + `
+		FROM accounts
+		WHERE id = ANY ($1)
+		ORDER BY id
+	`
+	return r.queryAccounts(ctx, query, ids)
+}
+
+// GetPrimaryAccount возвращает самый старый текущий счет пользователя в указанной валюте.
+func (r *AccountRepository) GetPrimaryAccount(ctx context.Context, userID int64, currency account.Currency) (*account.Account, error) {
+	query := `
+		SELECT ` + accountColumns + `
+		FROM accounts
+		WHERE user_id = $1 AND currency = $2 AND product = $3
+		ORDER BY id
+		LIMIT 1
+	`
+	return scanAccount(r.db.QueryRow(ctx, query, userID, currency, account.CURRENT))
+}
+
+func (r *AccountRepository) GetAccountsByProduct(ctx context.Context, product account.Product) ([]*account.Account, error) {
+	query := `
+		SELECT ` + accountColumns + `
+		FROM accounts
+		WHERE product = $1
+		ORDER BY id
+	`
+	return r.queryAccounts(ctx, query, product)
+}
+
+// balanceAtColumns — колонки счета, в которых баланс заменен остатком на момент $1:
+// из текущего баланса вычитаются операции, проведенные позже. $2 — типы
+// операций, увеличивающих баланс.
+const balanceAtColumns = `a.id, a.user_id, a.account_number,
+		a.balance - COALESCE((
+			SELECT SUM(CASE WHEN t.type = ANY ($2) THEN t.amount ELSE -t.amount END)
+			FROM transactions t
+			WHERE t.account_id = a.id AND t.created_at >= $1
+		), 0) AS balance,
+		a.currency, a.product, a.interest_rate, a.day_count, a.overdraft_limit, a.overdraft_rate, a.created_at`
+
+// GetInterestBearingAccounts возвращает процентные счета с положительным
+// остатком на момент at; Balance содержит этот остаток, а не текущий баланс.
+func (r *AccountRepository) GetInterestBearingAccounts(ctx context.Context, at time.Time) ([]*account.Account, error) {
+	query := `
+		SELECT ` + accountColumns + `
+		FROM (
+			SELECT ` + balanceAtColumns + `
+			FROM accounts a
+			WHERE a.interest_rate > 0 AND a.product <> $3
+		) s
+		WHERE balance > 0
+		ORDER BY id
+	`
+	// Проценты по срочным вкладам выплачиваются при погашении, а не капитализируются
+	return r.queryAccounts(ctx, query, at, creditTypes(), account.DEPOSIT)
+}
+
+// GetOverdrawnAccounts возвращает счета с отрицательным остатком на момент at;
+// Balance содержит этот остаток, а не текущий баланс.
+func (r *AccountRepository) GetOverdrawnAccounts(ctx context.Context, at time.Time) ([]*account.Account, error) {
+	query := `
+		SELECT ` + accountColumns + `
+		FROM (
+			SELECT ` + balanceAtColumns + `
+			FROM accounts a
+		) s
+		WHERE balance < 0
+		ORDER BY id
+	`
+	return r.queryAccounts(ctx, query, at, creditTypes())
+}
+
+// creditTypes переводит transaction.CreditTypes в массив для параметра запроса.
+func creditTypes() []string {
+	types := make([]string, 0, len(transaction.CreditTypes))
+	for _, t := range transaction.CreditTypes {
+		types = append(types, string(t))
+	}
+	return types
+}
+
+func (r *AccountRepository) queryAccounts(ctx context.Context, query string, args ...any) ([]*account.Account, error) {
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -63,11 +228,11 @@ func (r *AccountRepository) GetAccountsByUserID(ctx context.Context, userID int6
 
 	var accounts []*account.Account
 	for rows.Next() {
-		var acc account.Account
-		if err := rows.Scan(&acc.ID, &acc.UserID, &acc.Balance, &acc.Currency, &acc.CreatedAt); err != nil {
+		acc, err := scanAccount(rows)
+		if err != nil {
 			return nil, err
 		}
-		accounts = append(accounts, &acc)
+		accounts = append(accounts, acc)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -76,32 +241,103 @@ func (r *AccountRepository) GetAccountsByUserID(ctx context.Context, userID int6
 	return accounts, nil
 }
 
+// UpdateBalance изменяет баланс счета на amount. Списание не может опустить
+// баланс ниже разрешенного овердрафта, иначе возвращается ErrLimitExceeded.
+// UpdateBalance изменяет баланс на amount и записывает операцию DEPOSIT или
+// WITHDRAWAL в той же транзакции: по операциям восстанавливается баланс на
+// конец дня для начисления процентов.
 func (r *AccountRepository) UpdateBalance(ctx context.Context, id int64, amount decimal.Decimal) error {
 	query := `
 		UPDATE accounts
 		SET balance = balance + $1
-		WHERE id = $2
+		WHERE id = $2 AND ($1 >= 0 OR balance + overdraft_limit + $1 >= 0)
 	`
-	_, err := r.db.Exec(ctx, query, amount, id)
-	return err
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, query, amount, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLimitExceeded
+	}
+
+	txType := transaction.WITHDRAWAL
+	if amount.IsPositive() {
+		txType = transaction.DEPOSIT
+	}
+	if err = insertTransaction(ctx, tx, id, amount.Abs(), txType); err != nil {
+		return err
+	}
+
+	err = enqueueEvent(ctx, tx, events.AggregateAccount, id, events.BALANCE_UPDATED, events.BalanceUpdated{
+		AccountID: id,
+		Amount:    amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *AccountRepository) SetOverdraft(ctx context.Context, id int64, limit, rate decimal.Decimal) (*account.Account, error) {
+	query := `
+		UPDATE accounts
+		SET overdraft_limit = $1, overdraft_rate = $2
+		WHERE id = $3
+		RETURNING ` + accountColumns
+	return scanAccount(r.db.QueryRow(ctx, query, limit, rate, id))
 }
 
-func (r *AccountRepository) TransferBetweenAccounts(ctx context.Context, fromID, toID int64, amount decimal.Decimal) error {
+// TransferBetweenAccounts списывает amount со счета fromID и зачисляет credit
+// на toID; суммы различаются при конвертации валют. Если передан расчет
+// комиссии q, комиссия списывается со счета отправителя в той же транзакции.
+func (r *AccountRepository) TransferBetweenAccounts(ctx context.Context, fromID, toID, userID int64, amount, credit decimal.Decimal,
+	q *fee.Quote) (err error) {
+	ctx, span := tracing.Start(ctx, "AccountRepository.TransferBetweenAccounts")
+	defer func() { tracing.End(span, err) }()
+
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback(ctx)
 
+	if err = transferFunds(ctx, tx, fromID, toID, userID, amount, credit, q); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// transferFunds проводит перевод в транзакции tx: меняет остатки, пишет
+// операции списания и зачисления, комиссию и событие TRANSFER_COMPLETED.
+// Если счета получателя нет, возвращается pgx.ErrNoRows.
+func transferFunds(ctx context.Context, tx pgx.Tx, fromID, toID, userID int64, amount, credit decimal.Decimal,
+	q *fee.Quote) error {
+	debit := amount
+	if q != nil {
+		debit = debit.Add(q.Fee)
+	}
+
 	updateFromQuery := `
 		UPDATE accounts
 		SET balance = balance - $1
-		WHERE id = $2 AND balance >= $1
+		WHERE id = $2 AND balance + overdraft_limit >= $1
 		RETURNING balance
 	`
 	var newBalance decimal.Decimal
-	err = tx.QueryRow(ctx, updateFromQuery, amount, fromID).Scan(&newBalance)
+	err := tx.QueryRow(ctx, updateFromQuery, debit, fromID).Scan(&newBalance)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrLimitExceeded
+		}
 		return err
 	}
 
@@ -110,7 +346,75 @@ func (r *AccountRepository) TransferBetweenAccounts(ctx context.Context, fromID,
 		SET balance = balance + $1
 		WHERE id = $2
 	`
-	_, err = tx.Exec(ctx, updateToQuery, amount, toID)
+	tag, err := tx.Exec(ctx, updateToQuery, credit, toID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	if err = insertTransaction(ctx, tx, fromID, amount, transaction.WITHDRAWAL); err != nil {
+		return err
+	}
+	if err = insertTransaction(ctx, tx, toID, credit, transaction.DEPOSIT); err != nil {
+		return err
+	}
+
+	transferFee := decimal.Zero
+	if q != nil {
+		if err = recordFee(ctx, tx, userID, fromID, q); err != nil {
+			return err
+		}
+		transferFee = q.Fee
+	}
+
+	return enqueueEvent(ctx, tx, events.AggregateAccount, fromID, events.TRANSFER_COMPLETED, events.TransferCompleted{
+		FromAccountID: fromID,
+		ToAccountID:   toID,
+		Amount:        amount,
+		Fee:           transferFee,
+	})
+}
+
+// ChargeAccount списывает со счета сумму платежа и комиссию по расчету q одной транзакцией.
+func (r *AccountRepository) ChargeAccount(ctx context.Context, accountID, userID int64, q *fee.Quote) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	debitQuery := `
+		UPDATE accounts
+		SET balance = balance - $1
+		WHERE id = $2 AND balance + overdraft_limit >= $1
+	`
+	tag, err := tx.Exec(ctx, debitQuery, q.Total, accountID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLimitExceeded
+	}
+
+	txQuery := `
+		INSERT INTO transactions (account_id, amount, type, status)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err = tx.Exec(ctx, txQuery, accountID, q.Amount, transaction.WITHDRAWAL, transaction.COMPLETED); err != nil {
+		return err
+	}
+
+	if err = recordFee(ctx, tx, userID, accountID, q); err != nil {
+		return err
+	}
+
+	err = enqueueEvent(ctx, tx, events.AggregateAccount, accountID, events.PAYMENT_AUTHORIZED, events.PaymentAuthorized{
+		AccountID: accountID,
+		Amount:    q.Amount,
+		Fee:       q.Fee,
+	})
 	if err != nil {
 		return err
 	}
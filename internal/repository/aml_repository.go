@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models/aml"
+	"github.com/therealadik/bank-api/internal/models/transaction"
+)
+
+const amlCaseColumns = `id, scenario, account_id, user_id, summary, total_amount, transaction_count, status,
+	review_comment, created_at, updated_at, reported_at`
+
+// AMLRepository ищет подозрительные операции по сценариям AML. Зачисления
+// процентов (transactions.interest) ни в одном сценарии не учитываются.
+type AMLRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAMLRepository(db *pgxpool.Pool) *AMLRepository {
+	return &AMLRepository{db: db}
+}
+
+func scanAMLCase(row pgx.Row) (*aml.Case, error) {
+	var c aml.Case
+	err := row.Scan(&c.ID, &c.Scenario, &c.AccountID, &c.UserID, &c.Summary, &c.TotalAmount, &c.TransactionCount,
+		&c.Status, &c.ReviewComment, &c.CreatedAt, &c.UpdatedAt, &c.ReportedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *AMLRepository) queryDetections(ctx context.Context, scenario aml.Scenario, query string,
+	args ...any) ([]*aml.Detection, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var detections []*aml.Detection
+	for rows.Next() {
+		d := &aml.Detection{Scenario: scenario}
+		if err := rows.Scan(&d.AccountID, &d.UserID, &d.TransactionIDs, &d.Total, &d.Count); err != nil {
+			return nil, err
+		}
+		detections = append(detections, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return detections, nil
+}
+
+// FindStructuring ищет счета с серией операций на суммы из [low, threshold)
+// за окно. Операции, уже попавшие в кейс дробления, не учитываются.
+func (r *AMLRepository) FindStructuring(ctx context.Context, low, threshold decimal.Decimal, window time.Duration,
+	minCount int) ([]*aml.Detection, error) {
+	query := `
+		SELECT a.id, a.user_id, array_agg(t.id ORDER BY t.id), sum(t.amount), count(*)
+		FROM transactions t
+		JOIN accounts a ON a.id = t.account_id
+		WHERE t.created_at >= now() - $1 * interval '1 millisecond'
+		  AND t.status = $2
+		  AND t.type IN ($3, $4)
+		  AND NOT t.interest
+		  AND t.amount >= $5 AND t.amount < $6
+		  AND NOT EXISTS (SELECT 1 FROM aml_case_transactions l WHERE l.transaction_id = t.id AND l.scenario = $7)
+		GROUP BY a.id, a.user_id
+		HAVING count(*) >= $8
+	`
+	return r.queryDetections(ctx, aml.STRUCTURING, query, window.Milliseconds(), transaction.COMPLETED,
+		transaction.DEPOSIT, transaction.WITHDRAWAL, low, threshold, aml.STRUCTURING, minCount)
+}
+
+// FindRapidMovement ищет счета, на которые за окно поступило не меньше
+// minAmount и с которых после первого поступления ушло не меньше ratio от
+// поступившего.
+func (r *AMLRepository) FindRapidMovement(ctx context.Context, window time.Duration, minAmount,
+	ratio decimal.Decimal) ([]*aml.Detection, error) {
+	query := `
+		WITH recent AS (
+			SELECT t.id, t.account_id, t.amount, t.type, t.created_at
+			FROM transactions t
+			WHERE t.created_at >= now() - $1 * interval '1 millisecond'
+			  AND t.status = $2
+			  AND t.type IN ($3, $4)
+			  AND NOT t.interest
+			  AND NOT EXISTS (SELECT 1 FROM aml_case_transactions l WHERE l.transaction_id = t.id AND l.scenario = $5)
+		), flows AS (
+			SELECT account_id,
+			       COALESCE(sum(amount) FILTER (WHERE type = $3), 0) AS inflow,
+			       COALESCE(sum(amount) FILTER (WHERE type = $4), 0) AS outflow,
+			       min(created_at) FILTER (WHERE type = $3)          AS first_in,
+			       max(created_at) FILTER (WHERE type = $4)          AS last_out,
+			       array_agg(id ORDER BY id)                         AS ids,
+			       count(*)                                          AS cnt
+			FROM recent
+			GROUP BY account_id
+		)
+		SELECT f.account_id, a.user_id, f.ids, f.inflow + f.outflow, f.cnt
+		FROM flows f
+		JOIN accounts a ON a.id = f.account_id
+		WHERE f.inflow >= $6 AND f.outflow >= f.inflow * $7 AND f.last_out > f.first_in
+	`
+	return r.queryDetections(ctx, aml.RAPID_MOVEMENT, query, window.Milliseconds(), transaction.COMPLETED,
+		transaction.DEPOSIT, transaction.WITHDRAWAL, aml.RAPID_MOVEMENT, minAmount, ratio)
+}
+
+// FindDormantReactivation ищет счета, по которым за окно прошло операций
+// не меньше чем на minAmount, а до первой из них счет простаивал не меньше
+// dormant.
+func (r *AMLRepository) FindDormantReactivation(ctx context.Context, dormant, window time.Duration,
+	minAmount decimal.Decimal) ([]*aml.Detection, error) {
+	query := `
+		WITH recent AS (
+			SELECT t.account_id, array_agg(t.id ORDER BY t.id) AS ids, sum(t.amount) AS total, count(*) AS cnt,
+			       min(t.created_at) AS first_at
+			FROM transactions t
+			WHERE t.created_at >= now() - $1 * interval '1 millisecond'
+			  AND t.status = $2
+			  AND NOT t.interest
+			  AND NOT EXISTS (SELECT 1 FROM aml_case_transactions l WHERE l.transaction_id = t.id AND l.scenario = $3)
+			GROUP BY t.account_id
+		)
+		SELECT r.account_id, a.user_id, r.ids, r.total, r.cnt
+		FROM recent r
+		JOIN accounts a ON a.id = r.account_id
+		WHERE r.total >= $4
+		  AND a.created_at < r.first_at - $5 * interval '1 millisecond'
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM transactions p
+			WHERE p.account_id = r.account_id
+			  AND p.created_at < r.first_at
+			  AND p.created_at >= r.first_at - $5 * interval '1 millisecond'
+			  AND NOT p.interest
+		  )
+	`
+	return r.queryDetections(ctx, aml.DORMANT_REACTIVATION, query, window.Milliseconds(), transaction.COMPLETED,
+		aml.DORMANT_REACTIVATION, minAmount, dormant.Milliseconds())
+}
+
+// CreateCase заводит кейс и связывает с ним операции. Если другой экземпляр
+// уже завел кейс по этим операциям, кейс не создается и возвращается nil.
+func (r *AMLRepository) CreateCase(ctx context.Context, d *aml.Detection, summary string) (*aml.Case, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO aml_cases (scenario, account_id, user_id, summary, total_amount, transaction_count)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + amlCaseColumns
+	c, err := scanAMLCase(tx.QueryRow(ctx, query, d.Scenario, d.AccountID, d.UserID, summary, d.Total, d.Count))
+	if err != nil {
+		return nil, err
+	}
+
+	linkQuery := `
+		INSERT INTO aml_case_transactions (case_id, transaction_id, scenario)
+		SELECT $1, unnest($2::bigint[]), $3
+		ON CONFLICT (scenario, transaction_id) DO NOTHING
+	`
+	tag, err := tx.Exec(ctx, linkQuery, c.ID, d.TransactionIDs, d.Scenario)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() < int64(len(d.TransactionIDs)) {
+		return nil, nil
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (r *AMLRepository) GetCases(ctx context.Context, status aml.CaseStatus, limit int) ([]*aml.Case, error) {
+	query := `
+		SELECT ` + amlCaseColumns + `
+		FROM aml_cases
+		WHERE $1 = '' OR status = $1
+		ORDER BY id
+		LIMIT $2
+	`
+	rows, err := r.db.Query(ctx, query, string(status), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cases []*aml.Case
+	for rows.Next() {
+		c, err := scanAMLCase(rows)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return cases, nil
+}
+
+func (r *AMLRepository) GetCase(ctx context.Context, id int64) (*aml.Case, error) {
+	query := `
+		SELECT ` + amlCaseColumns + `
+		FROM aml_cases
+		WHERE id = $1
+	`
+	return scanAMLCase(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *AMLRepository) GetCaseTransactions(ctx context.Context, caseID int64) ([]*transaction.Transaction, error) {
+	query := `
+		SELECT t.id, t.account_id, t.amount, t.type, t.status, t.created_at
+		FROM aml_case_transactions l
+		JOIN transactions t ON t.id = l.transaction_id
+		WHERE l.case_id = $1
+		ORDER BY t.created_at, t.id
+	`
+	rows, err := r.db.Query(ctx, query, caseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []*transaction.Transaction
+	for rows.Next() {
+		var t transaction.Transaction
+		if err := rows.Scan(&t.ID, &t.AccountID, &t.Amount, &t.Type, &t.Status, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, &t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// UpdateStatus переводит кейс из статуса from в to. Возвращает pgx.ErrNoRows,
+// если кейс не найден или находится в другом статусе. Комментарий
+// сохраняется, только если передан.
+func (r *AMLRepository) UpdateStatus(ctx context.Context, id int64, from, to aml.CaseStatus,
+	comment string) (*aml.Case, error) {
+	query := `
+		UPDATE aml_cases
+		SET status = $1,
+		    review_comment = CASE WHEN $2 = '' THEN review_comment ELSE $2 END,
+		    reported_at = CASE WHEN $1 = $5 THEN now() ELSE reported_at END,
+		    updated_at = now()
+		WHERE id = $3 AND status = $4
+		RETURNING ` + amlCaseColumns
+	return scanAMLCase(r.db.QueryRow(ctx, query, to, comment, id, from, aml.REPORTED))
+}
@@ -0,0 +1,404 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models/approval"
+	"github.com/therealadik/bank-api/internal/models/fee"
+)
+
+var (
+	ErrPaymentNotPending    = errors.New("платеж уже обработан")
+	ErrPaymentNotProcessing = errors.New("платеж не ожидает исполнения")
+)
+
+const pendingPaymentColumns = `id, account_id, to_account_id, initiator_id, amount, required_approvals, status, created_at, updated_at`
+
+type ApprovalRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewApprovalRepository(db *pgxpool.Pool) *ApprovalRepository {
+	return &ApprovalRepository{db: db}
+}
+
+func scanPendingPayment(row pgx.Row) (*approval.Payment, error) {
+	var p approval.Payment
+	err := row.Scan(&p.ID, &p.AccountID, &p.ToAccountID, &p.InitiatorID, &p.Amount,
+		&p.RequiredApprovals, &p.Status, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *ApprovalRepository) AddMember(ctx context.Context, accountID, userID int64, role approval.Role) (*approval.Member, error) {
+	query := `
+		INSERT INTO account_members (account_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (account_id, user_id) DO UPDATE SET role = EXCLUDED.role
+		RETURNING account_id, user_id, role, created_at
+	`
+	var m approval.Member
+	err := r.db.QueryRow(ctx, query, accountID, userID, role).Scan(&m.AccountID, &m.UserID, &m.Role, &m.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *ApprovalRepository) RemoveMember(ctx context.Context, accountID, userID int64) (bool, error) {
+	query := `
+		DELETE FROM account_members
+		WHERE account_id = $1 AND user_id = $2
+	`
+	tag, err := r.db.Exec(ctx, query, accountID, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *ApprovalRepository) GetMember(ctx context.Context, accountID, userID int64) (*approval.Member, error) {
+	query := `
+		SELECT account_id, user_id, role, created_at
+		FROM account_members
+		WHERE account_id = $1 AND user_id = $2
+	`
+	var m approval.Member
+	err := r.db.QueryRow(ctx, query, accountID, userID).Scan(&m.AccountID, &m.UserID, &m.Role, &m.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *ApprovalRepository) GetMembers(ctx context.Context, accountID int64) ([]*approval.Member, error) {
+	query := `
+		SELECT account_id, user_id, role, created_at
+		FROM account_members
+		WHERE account_id = $1
+		ORDER BY created_at
+	`
+	rows, err := r.db.Query(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*approval.Member
+	for rows.Next() {
+		var m approval.Member
+		if err := rows.Scan(&m.AccountID, &m.UserID, &m.Role, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, &m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *ApprovalRepository) GetPolicy(ctx context.Context, accountID int64) ([]*approval.PolicyBand, error) {
+	query := `
+		SELECT id, account_id, amount_from, required_approvals
+		FROM approval_policies
+		WHERE account_id = $1
+		ORDER BY amount_from
+	`
+	rows, err := r.db.Query(ctx, query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bands []*approval.PolicyBand
+	for rows.Next() {
+		var b approval.PolicyBand
+		if err := rows.Scan(&b.ID, &b.AccountID, &b.AmountFrom, &b.RequiredApprovals); err != nil {
+			return nil, err
+		}
+		bands = append(bands, &b)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return bands, nil
+}
+
+// ReplacePolicy атомарно заменяет все диапазоны политики счета.
+func (r *ApprovalRepository) ReplacePolicy(ctx context.Context, accountID int64, bands []approval.PolicyBand) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `DELETE FROM approval_policies WHERE account_id = $1`, accountID)
+	if err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO approval_policies (account_id, amount_from, required_approvals)
+		VALUES ($1, $2, $3)
+	`
+	for _, b := range bands {
+		if _, err = tx.Exec(ctx, insertQuery, accountID, b.AmountFrom, b.RequiredApprovals); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RequiredApprovals возвращает число подтверждений для суммы по диапазону
+// с наибольшей нижней границей, не превышающей сумму. Без политики — 0.
+func (r *ApprovalRepository) RequiredApprovals(ctx context.Context, accountID int64, amount decimal.Decimal) (int, error) {
+	query := `
+		SELECT required_approvals
+		FROM approval_policies
+		WHERE account_id = $1 AND amount_from <= $2
+		ORDER BY amount_from DESC
+		LIMIT 1
+	`
+	var required int
+	err := r.db.QueryRow(ctx, query, accountID, amount).Scan(&required)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return required, nil
+}
+
+func (r *ApprovalRepository) CreatePayment(ctx context.Context, p *approval.Payment) (*approval.Payment, error) {
+	query := `
+		INSERT INTO pending_payments (account_id, to_account_id, initiator_id, amount, required_approvals, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + pendingPaymentColumns
+	return scanPendingPayment(r.db.QueryRow(ctx, query,
+		p.AccountID, p.ToAccountID, p.InitiatorID, p.Amount, p.RequiredApprovals, p.Status))
+}
+
+func (r *ApprovalRepository) GetPayment(ctx context.Context, id int64) (*approval.Payment, error) {
+	query := `
+		SELECT ` + pendingPaymentColumns + `
+		FROM pending_payments
+		WHERE id = $1
+	`
+	return scanPendingPayment(r.db.QueryRow(ctx, query, id))
+}
+
+// GetPaymentsForUser возвращает платежи по счетам, которыми пользователь
+// владеет или в которых состоит участником.
+func (r *ApprovalRepository) GetPaymentsForUser(ctx context.Context, userID int64, status approval.Status) ([]*approval.Payment, error) {
+	query := `
+		SELECT ` + pendingPaymentColumns + `
+		FROM pending_payments
+		WHERE status = $2 AND account_id IN (
+			SELECT id FROM accounts WHERE user_id = $1
+			UNION
+			SELECT account_id FROM account_members WHERE user_id = $1
+		)
+		ORDER BY created_at
+	`
+	rows, err := r.db.Query(ctx, query, userID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []*approval.Payment
+	for rows.Next() {
+		p, err := scanPendingPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+func (r *ApprovalRepository) GetDecisions(ctx context.Context, paymentID int64) ([]*approval.PaymentDecision, error) {
+	query := `
+		SELECT payment_id, user_id, decision, comment, created_at
+		FROM payment_decisions
+		WHERE payment_id = $1
+		ORDER BY created_at
+	`
+	rows, err := r.db.Query(ctx, query, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var decisions []*approval.PaymentDecision
+	for rows.Next() {
+		var d approval.PaymentDecision
+		if err := rows.Scan(&d.PaymentID, &d.UserID, &d.Decision, &d.Comment, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, &d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return decisions, nil
+}
+
+// RecordDecision сохраняет решение по платежу в статусе PENDING и возвращает
+// платеж с новым статусом. Отклонение сразу переводит платеж в REJECTED,
+// а набранный кворум — в PROCESSING; исполнение перевода остается за
+// вызывающим кодом.
+func (r *ApprovalRepository) RecordDecision(ctx context.Context, d *approval.PaymentDecision) (*approval.Payment, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	lockQuery := `
+		SELECT ` + pendingPaymentColumns + `
+		FROM pending_payments
+		WHERE id = $1
+		FOR UPDATE
+	`
+	p, err := scanPendingPayment(tx.QueryRow(ctx, lockQuery, d.PaymentID))
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Status != approval.PENDING {
+		return nil, ErrPaymentNotPending
+	}
+
+	insertQuery := `
+		INSERT INTO payment_decisions (payment_id, user_id, decision, comment)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err = tx.Exec(ctx, insertQuery, d.PaymentID, d.UserID, d.Decision, d.Comment); err != nil {
+		return nil, err
+	}
+
+	next := approval.PENDING
+	if d.Decision == approval.REJECT {
+		next = approval.REJECTED
+	} else {
+		var approvals int
+		countQuery := `
+			SELECT count(*)
+			FROM payment_decisions
+			WHERE payment_id = $1 AND decision = $2
+		`
+		if err = tx.QueryRow(ctx, countQuery, d.PaymentID, approval.APPROVE).Scan(&approvals); err != nil {
+			return nil, err
+		}
+		if approvals >= p.RequiredApprovals {
+			next = approval.PROCESSING
+		}
+	}
+
+	if next != p.Status {
+		updateQuery := `
+			UPDATE pending_payments
+			SET status = $1, updated_at = now()
+			WHERE id = $2
+			RETURNING ` + pendingPaymentColumns
+		p, err = scanPendingPayment(tx.QueryRow(ctx, updateQuery, next, d.PaymentID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ExecutePayment переводит платеж из PROCESSING в EXECUTED и проводит перевод
+// в одной транзакции: исполненный платеж не может остаться в PROCESSING, а
+// платеж в PROCESSING — оказаться исполненным. Если платеж уже не в
+// PROCESSING, возвращает ErrPaymentNotProcessing и перевод не проводится.
+func (r *ApprovalRepository) ExecutePayment(ctx context.Context, id, userID int64, amount, credit decimal.Decimal,
+	q *fee.Quote) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE pending_payments
+		SET status = $1, updated_at = now()
+		WHERE id = $2 AND status = $3
+		RETURNING account_id, to_account_id
+	`
+	var fromID, toID int64
+	err = tx.QueryRow(ctx, query, approval.EXECUTED, id, approval.PROCESSING).Scan(&fromID, &toID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPaymentNotProcessing
+		}
+		return err
+	}
+
+	if err = transferFunds(ctx, tx, fromID, toID, userID, amount, credit, q); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// FailPayment переводит платеж из PROCESSING в FAILED. Если платеж уже не
+// в PROCESSING, возвращает ErrPaymentNotProcessing.
+func (r *ApprovalRepository) FailPayment(ctx context.Context, id int64) error {
+	query := `
+		UPDATE pending_payments
+		SET status = $1, updated_at = now()
+		WHERE id = $2 AND status = $3
+	`
+	tag, err := r.db.Exec(ctx, query, approval.FAILED, id, approval.PROCESSING)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPaymentNotProcessing
+	}
+	return nil
+}
+
+// ClaimStalePayment возвращает платеж, который дольше staleAfter остается
+// в PROCESSING, — исполнение прервалось после набора кворума. Отметка
+// updated_at сдвигается, чтобы платеж не забрали повторно до следующей
+// попытки. Возвращает pgx.ErrNoRows, если таких платежей нет.
+func (r *ApprovalRepository) ClaimStalePayment(ctx context.Context, staleAfter time.Duration) (*approval.Payment, error) {
+	query := `
+		UPDATE pending_payments
+		SET updated_at = now()
+		WHERE id = (
+			SELECT id
+			FROM pending_payments
+			WHERE status = $1 AND updated_at < now() - $2 * interval '1 millisecond'
+			ORDER BY id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + pendingPaymentColumns
+	return scanPendingPayment(r.db.QueryRow(ctx, query, approval.PROCESSING, staleAfter.Milliseconds()))
+}
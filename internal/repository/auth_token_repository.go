@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/therealadik/bank-api/internal/models"
+)
+
+var ErrTokenInvalid = errors.New("токен недействителен или истек")
+
+// AuthTokenRepository хранит одноразовые токены подтверждения email и сброса
+// пароля и коды подтверждения. В базе хранится только SHA-256 токена.
+type AuthTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuthTokenRepository(db *pgxpool.Pool) *AuthTokenRepository {
+	return &AuthTokenRepository{db: db}
+}
+
+// Create сохраняет новый токен и отзывает неиспользованные токены того же
+// назначения, чтобы действовала только последняя ссылка.
+func (r *AuthTokenRepository) Create(ctx context.Context, userID int64, purpose models.TokenPurpose, hash string,
+	expiresAt time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = revokeTokens(ctx, tx, userID, purpose); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO auth_tokens (user_id, purpose, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err = tx.Exec(ctx, query, userID, purpose, hash, expiresAt); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func revokeTokens(ctx context.Context, tx pgx.Tx, userID int64, purpose models.TokenPurpose) error {
+	_, err := tx.Exec(ctx, `UPDATE auth_tokens SET used_at = now() WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL`,
+		userID, purpose)
+	return err
+}
+
+func consumeToken(ctx context.Context, tx pgx.Tx, purpose models.TokenPurpose, hash string) (int64, error) {
+	query := `
+		UPDATE auth_tokens
+		SET used_at = now()
+		WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > now()
+		RETURNING user_id
+	`
+	var userID int64
+	if err := tx.QueryRow(ctx, query, hash, purpose).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrTokenInvalid
+		}
+		return 0, err
+	}
+	return userID, nil
+}
+
+// ConsumeCode гасит действующий код подтверждения пользователя.
+func (r *AuthTokenRepository) ConsumeCode(ctx context.Context, userID int64, purpose models.TokenPurpose, hash string) error {
+	query := `
+		UPDATE auth_tokens
+		SET used_at = now()
+		WHERE user_id = $1 AND purpose = $2 AND token_hash = $3 AND used_at IS NULL AND expires_at > now()
+	`
+	tag, err := r.db.Exec(ctx, query, userID, purpose, hash)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTokenInvalid
+	}
+	return nil
+}
+
+// VerifyEmail гасит токен подтверждения и отмечает адрес подтвержденным.
+func (r *AuthTokenRepository) VerifyEmail(ctx context.Context, hash string) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	userID, err := consumeToken(ctx, tx, models.EMAIL_VERIFY, hash)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE users SET email_verified_at = COALESCE(email_verified_at, now()) WHERE id = $1`, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return userID, nil
+}
+
+// ResetPassword гасит токен сброса, меняет пароль и отзывает все выданные
+// пользователю JWT и прочие токены сброса. Переход по ссылке из письма
+// заодно подтверждает адрес.
+func (r *AuthTokenRepository) ResetPassword(ctx context.Context, hash, passwordHash string) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	userID, err := consumeToken(ctx, tx, models.PASSWORD_RESET, hash)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		UPDATE users
+		SET password_hash = $1, token_version = token_version + 1,
+		    email_verified_at = COALESCE(email_verified_at, now())
+		WHERE id = $2
+	`
+	if _, err = tx.Exec(ctx, query, passwordHash, userID); err != nil {
+		return 0, err
+	}
+
+	if err = revokeTokens(ctx, tx, userID, models.PASSWORD_RESET); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return userID, nil
+}
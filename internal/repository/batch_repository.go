@@ -0,0 +1,341 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/therealadik/bank-api/internal/models/batch"
+	"github.com/therealadik/bank-api/internal/models/fee"
+	"github.com/therealadik/bank-api/internal/models/screening"
+)
+
+const (
+	batchColumns = `id, user_id, account_id, mode, status, item_count, valid_count, total, total_fee, created_at, updated_at`
+
+	batchItemColumns = `id, batch_id, row_number, account_ref, to_account_id, amount, fee, reference, status, error, processed_at`
+)
+
+type BatchRepository interface {
+	CreateBatch(ctx context.Context, b *batch.Batch, items []*batch.Item) (*batch.Batch, error)
+	GetBatch(ctx context.Context, id, userID int64) (*batch.Batch, error)
+	GetBatchesByUserID(ctx context.Context, userID int64) ([]*batch.Batch, error)
+	GetRunnableBatches(ctx context.Context) ([]*batch.Batch, error)
+	GetItems(ctx context.Context, batchID int64) ([]*batch.Item, error)
+	GetPendingItems(ctx context.Context, batchID int64) ([]*batch.Item, error)
+	UpdateStatus(ctx context.Context, id int64, from, to batch.Status) (bool, error)
+	UpdateFees(ctx context.Context, b *batch.Batch, items []*batch.Item) error
+	FailItems(ctx context.Context, batchID int64, reason string) error
+	FailItem(ctx context.Context, id int64, reason string) error
+	HoldItem(ctx context.Context, id int64, reason string, t *screening.HeldTransfer, h *screening.Hit) error
+	ExecuteItem(ctx context.Context, b *batch.Batch, it *batch.Item) error
+	ExecuteAll(ctx context.Context, b *batch.Batch, items []*batch.Item) error
+}
+
+type BatchRepositoryPgx struct {
+	db *pgxpool.Pool
+}
+
+func NewBatchRepository(db *pgxpool.Pool) BatchRepository {
+	return &BatchRepositoryPgx{db: db}
+}
+
+func scanBatch(row pgx.Row) (*batch.Batch, error) {
+	var b batch.Batch
+	err := row.Scan(&b.ID, &b.UserID, &b.AccountID, &b.Mode, &b.Status, &b.ItemCount, &b.ValidCount,
+		&b.Total, &b.TotalFee, &b.CreatedAt, &b.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func scanBatchItem(row pgx.Row) (*batch.Item, error) {
+	var it batch.Item
+	err := row.Scan(&it.ID, &it.BatchID, &it.RowNumber, &it.AccountRef, &it.ToAccountID, &it.Amount,
+		&it.Fee, &it.Reference, &it.Status, &it.Error, &it.ProcessedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &it, nil
+}
+
+// CreateBatch сохраняет пакет вместе со всеми строками в одной транзакции.
+func (r *BatchRepositoryPgx) CreateBatch(ctx context.Context, b *batch.Batch, items []*batch.Item) (*batch.Batch, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	batchQuery := `
+		INSERT INTO payment_batches (user_id, account_id, mode, status, item_count, valid_count, total, total_fee)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + batchColumns
+	created, err := scanBatch(tx.QueryRow(ctx, batchQuery,
+		b.UserID, b.AccountID, b.Mode, b.Status, b.ItemCount, b.ValidCount, b.Total, b.TotalFee))
+	if err != nil {
+		return nil, err
+	}
+
+	itemQuery := `
+		INSERT INTO payment_batch_items (batch_id, row_number, account_ref, to_account_id, amount, fee, reference, status, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	for _, it := range items {
+		_, err = tx.Exec(ctx, itemQuery, created.ID, it.RowNumber, it.AccountRef, it.ToAccountID,
+			it.Amount, it.Fee, it.Reference, it.Status, it.Error)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *BatchRepositoryPgx) GetBatch(ctx context.Context, id, userID int64) (*batch.Batch, error) {
+	query := `
+		SELECT ` + batchColumns + `
+		FROM payment_batches
+		WHERE id = $1 AND user_id = $2
+	`
+	return scanBatch(r.db.QueryRow(ctx, query, id, userID))
+}
+
+func (r *BatchRepositoryPgx) GetBatchesByUserID(ctx context.Context, userID int64) ([]*batch.Batch, error) {
+	query := `
+		SELECT ` + batchColumns + `
+		FROM payment_batches
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+	return r.queryBatches(ctx, query, userID)
+}
+
+// GetRunnableBatches возвращает пакеты в очереди и пакеты, обработка которых
+// была прервана, например падением процесса.
+func (r *BatchRepositoryPgx) GetRunnableBatches(ctx context.Context) ([]*batch.Batch, error) {
+	query := `
+		SELECT ` + batchColumns + `
+		FROM payment_batches
+		WHERE status IN ($1, $2)
+		ORDER BY created_at
+	`
+	return r.queryBatches(ctx, query, batch.QUEUED, batch.PROCESSING)
+}
+
+func (r *BatchRepositoryPgx) queryBatches(ctx context.Context, query string, args ...any) ([]*batch.Batch, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var batches []*batch.Batch
+	for rows.Next() {
+		b, err := scanBatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, b)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return batches, nil
+}
+
+func (r *BatchRepositoryPgx) GetItems(ctx context.Context, batchID int64) ([]*batch.Item, error) {
+	return r.queryItems(ctx, `
+		SELECT `+batchItemColumns+`
+		FROM payment_batch_items
+		WHERE batch_id = $1
+		ORDER BY row_number
+	`, batchID)
+}
+
+func (r *BatchRepositoryPgx) GetPendingItems(ctx context.Context, batchID int64) ([]*batch.Item, error) {
+	return r.queryItems(ctx, `
+		SELECT `+batchItemColumns+`
+		FROM payment_batch_items
+		WHERE batch_id = $1 AND status = $2
+		ORDER BY row_number
+	`, batchID, batch.ITEM_PENDING)
+}
+
+func (r *BatchRepositoryPgx) queryItems(ctx context.Context, query string, args ...any) ([]*batch.Item, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*batch.Item
+	for rows.Next() {
+		it, err := scanBatchItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// UpdateStatus переводит пакет из статуса from в to. Возвращает false, если
+// пакет уже находится в другом статусе.
+func (r *BatchRepositoryPgx) UpdateStatus(ctx context.Context, id int64, from, to batch.Status) (bool, error) {
+	query := `
+		UPDATE payment_batches
+		SET status = $1, updated_at = now()
+		WHERE id = $2 AND status = $3
+	`
+	tag, err := r.db.Exec(ctx, query, to, id, from)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// UpdateFees сохраняет пересчитанные комиссии строк и итог пакета.
+func (r *BatchRepositoryPgx) UpdateFees(ctx context.Context, b *batch.Batch, items []*batch.Item) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, it := range items {
+		_, err = tx.Exec(ctx, `UPDATE payment_batch_items SET fee = $1 WHERE id = $2`, it.Fee, it.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	batchQuery := `
+		UPDATE payment_batches
+		SET total_fee = $1, updated_at = now()
+		WHERE id = $2
+	`
+	if _, err = tx.Exec(ctx, batchQuery, b.TotalFee, b.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *BatchRepositoryPgx) FailItems(ctx context.Context, batchID int64, reason string) error {
+	query := `
+		UPDATE payment_batch_items
+		SET status = $1, error = $2, processed_at = now()
+		WHERE batch_id = $3 AND status = $4
+	`
+	_, err := r.db.Exec(ctx, query, batch.ITEM_FAILED, reason, batchID, batch.ITEM_PENDING)
+	return err
+}
+
+func (r *BatchRepositoryPgx) FailItem(ctx context.Context, id int64, reason string) error {
+	query := `
+		UPDATE payment_batch_items
+		SET status = $1, error = $2, processed_at = now()
+		WHERE id = $3 AND status = $4
+	`
+	_, err := r.db.Exec(ctx, query, batch.ITEM_FAILED, reason, id, batch.ITEM_PENDING)
+	return err
+}
+
+// HoldItem исключает строку из пакета и задерживает ее перевод до разбора
+// совпадения оператором. Строка и задержанный перевод сохраняются в одной
+// транзакции, поэтому повторная отправка пакета не задержит перевод дважды.
+func (r *BatchRepositoryPgx) HoldItem(ctx context.Context, id int64, reason string, t *screening.HeldTransfer,
+	h *screening.Hit) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE payment_batch_items
+		SET status = $1, error = $2, processed_at = now()
+		WHERE id = $3 AND status = $4
+	`
+	tag, err := tx.Exec(ctx, query, batch.ITEM_HELD, reason, id, batch.ITEM_PENDING)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+
+	if _, err = holdTransfer(ctx, tx, t, h); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ExecuteItem проводит одну строку пакета. Отметка строки и движение средств
+// выполняются в одной транзакции, поэтому после сбоя строка либо проведена,
+// либо остается в PENDING и будет повторена.
+func (r *BatchRepositoryPgx) ExecuteItem(ctx context.Context, b *batch.Batch, it *batch.Item) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = executeBatchItem(ctx, tx, b, it); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ExecuteAll проводит все строки в одной транзакции: либо все, либо ничего.
+func (r *BatchRepositoryPgx) ExecuteAll(ctx context.Context, b *batch.Batch, items []*batch.Item) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, it := range items {
+		if err = executeBatchItem(ctx, tx, b, it); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func executeBatchItem(ctx context.Context, tx pgx.Tx, b *batch.Batch, it *batch.Item) error {
+	claimQuery := `
+		UPDATE payment_batch_items
+		SET status = $1, processed_at = now()
+		WHERE id = $2 AND status = $3
+	`
+	tag, err := tx.Exec(ctx, claimQuery, batch.ITEM_COMPLETED, it.ID, batch.ITEM_PENDING)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+
+	q := &fee.Quote{
+		Operation: fee.TRANSFER,
+		Amount:    it.Amount,
+		Fee:       it.Fee,
+		Total:     it.Amount.Add(it.Fee),
+	}
+
+	return transferFunds(ctx, tx, b.AccountID, *it.ToAccountID, b.UserID, it.Amount, it.Amount, q)
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/therealadik/bank-api/internal/models/beneficiary"
+)
+
+const beneficiaryColumns = `id, user_id, nickname, account_id, account_number, status, available_at, verified_at, created_at`
+
+type BeneficiaryRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBeneficiaryRepository(db *pgxpool.Pool) *BeneficiaryRepository {
+	return &BeneficiaryRepository{db: db}
+}
+
+func scanBeneficiary(row pgx.Row) (*beneficiary.Beneficiary, error) {
+	var b beneficiary.Beneficiary
+	err := row.Scan(&b.ID, &b.UserID, &b.Nickname, &b.AccountID, &b.AccountNumber,
+		&b.Status, &b.AvailableAt, &b.VerifiedAt, &b.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (r *BeneficiaryRepository) CreateBeneficiary(ctx context.Context, b *beneficiary.Beneficiary) (*beneficiary.Beneficiary, error) {
+	query := `
+		INSERT INTO beneficiaries (user_id, nickname, account_id, account_number, status, available_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + beneficiaryColumns
+	return scanBeneficiary(r.db.QueryRow(ctx, query, b.UserID, b.Nickname, b.AccountID, b.AccountNumber, b.Status, b.AvailableAt))
+}
+
+func (r *BeneficiaryRepository) GetBeneficiary(ctx context.Context, id, userID int64) (*beneficiary.Beneficiary, error) {
+	query := `
+		SELECT ` + beneficiaryColumns + `
+		FROM beneficiaries
+		WHERE id = $1 AND user_id = $2
+	`
+	return scanBeneficiary(r.db.QueryRow(ctx, query, id, userID))
+}
+
+func (r *BeneficiaryRepository) GetBeneficiaryByAccount(ctx context.Context, userID, accountID int64) (*beneficiary.Beneficiary, error) {
+	query := `
+		SELECT ` + beneficiaryColumns + `
+		FROM beneficiaries
+		WHERE user_id = $1 AND account_id = $2
+	`
+	return scanBeneficiary(r.db.QueryRow(ctx, query, userID, accountID))
+}
+
+func (r *BeneficiaryRepository) GetBeneficiariesByUserID(ctx context.Context, userID int64) ([]*beneficiary.Beneficiary, error) {
+	query := `
+		SELECT ` + beneficiaryColumns + `
+		FROM beneficiaries
+		WHERE user_id = $1
+		ORDER BY nickname, id
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var beneficiaries []*beneficiary.Beneficiary
+	for rows.Next() {
+		b, err := scanBeneficiary(rows)
+		if err != nil {
+			return nil, err
+		}
+		beneficiaries = append(beneficiaries, b)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return beneficiaries, nil
+}
+
+func (r *BeneficiaryRepository) UpdateNickname(ctx context.Context, id, userID int64, nickname string) (*beneficiary.Beneficiary, error) {
+	query := `
+		UPDATE beneficiaries
+		SET nickname = $1
+		WHERE id = $2 AND user_id = $3
+		RETURNING ` + beneficiaryColumns
+	return scanBeneficiary(r.db.QueryRow(ctx, query, nickname, id, userID))
+}
+
+func (r *BeneficiaryRepository) MarkVerified(ctx context.Context, id, userID int64) (*beneficiary.Beneficiary, error) {
+	query := `
+		UPDATE beneficiaries
+		SET status = $1, verified_at = now()
+		WHERE id = $2 AND user_id = $3
+		RETURNING ` + beneficiaryColumns
+	return scanBeneficiary(r.db.QueryRow(ctx, query, beneficiary.VERIFIED, id, userID))
+}
+
+func (r *BeneficiaryRepository) DeleteBeneficiary(ctx context.Context, id, userID int64) (bool, error) {
+	query := `
+		DELETE FROM beneficiaries
+		WHERE id = $1 AND user_id = $2
+	`
+	tag, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
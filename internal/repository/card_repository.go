@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/therealadik/bank-api/internal/events"
 	"github.com/therealadik/bank-api/internal/models"
 )
 
@@ -21,14 +22,32 @@ func (r *CardRepository) CreateCard(ctx context.Context, userID int64, encrypted
 		VALUES ($1, $2, $3, $4)
 		RETURNING id, user_id, created_at
 	`
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
 	var card models.Card
-	err := r.db.QueryRow(ctx, query, userID, encryptedNumber, encryptedExpire, cvvHash).Scan(
+	err = tx.QueryRow(ctx, query, userID, encryptedNumber, encryptedExpire, cvvHash).Scan(
 		&card.ID, &card.UserID, &card.CreatedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	err = enqueueEvent(ctx, tx, events.AggregateCard, card.ID, events.CARD_ISSUED, events.CardIssued{
+		CardID: card.ID,
+		UserID: card.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
 	card.CardNumber = encryptedNumber
 	card.Expire = encryptedExpire
 	card.CVVHash = cvvHash
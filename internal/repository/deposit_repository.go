@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/events"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/deposit"
+)
+
+const depositColumns = `id, user_id, account_id, source_account_id, principal, interest_rate, penalty_rate,
+		day_count, term_months, start_date, maturity_date, auto_rollover, status, created_at`
+
+type DepositRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewDepositRepository(db *pgxpool.Pool) *DepositRepository {
+	return &DepositRepository{db: db}
+}
+
+func scanDeposit(row pgx.Row) (*deposit.TermDeposit, error) {
+	var d deposit.TermDeposit
+	err := row.Scan(
+		&d.ID, &d.UserID, &d.AccountID, &d.SourceAccountID, &d.Principal, &d.InterestRate, &d.PenaltyRate,
+		&d.DayCount, &d.TermMonths, &d.StartDate, &d.MaturityDate, &d.AutoRollover, &d.Status, &d.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// OpenDeposit открывает вклад одной транзакцией: создает счет вклада с номером
+// number, списывает тело вклада с исходного счета и сохраняет вклад в статусе
+// ACTIVE. Если на исходном счете не хватает средств, возвращается
+// ErrLimitExceeded и счет вклада не создается.
+func (r *DepositRepository) OpenDeposit(ctx context.Context, d *deposit.TermDeposit, number string,
+	currency account.Currency) (*deposit.TermDeposit, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	acc, err := insertAccount(ctx, tx, d.UserID, number, currency, account.DEPOSIT, d.InterestRate, d.DayCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = transferFunds(ctx, tx, d.SourceAccountID, acc.ID, d.UserID, d.Principal, d.Principal, nil); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO term_deposits (user_id, account_id, source_account_id, principal, interest_rate, penalty_rate,
+			day_count, term_months, start_date, maturity_date, auto_rollover, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING ` + depositColumns
+	created, err := scanDeposit(tx.QueryRow(ctx, query,
+		d.UserID, acc.ID, d.SourceAccountID, d.Principal, d.InterestRate, d.PenaltyRate,
+		d.DayCount, d.TermMonths, d.StartDate, d.MaturityDate, d.AutoRollover, deposit.ACTIVE,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *DepositRepository) GetDepositByID(ctx context.Context, id int64) (*deposit.TermDeposit, error) {
+	query := `
+		SELECT ` + depositColumns + `
+		FROM term_deposits
+		WHERE id = $1
+	`
+	return scanDeposit(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *DepositRepository) GetDepositsByUserID(ctx context.Context, userID int64) ([]*deposit.TermDeposit, error) {
+	query := `
+		SELECT ` + depositColumns + `
+		FROM term_deposits
+		WHERE user_id = $1
+		ORDER BY id
+	`
+	return r.queryDeposits(ctx, query, userID)
+}
+
+func (r *DepositRepository) GetMaturedDeposits(ctx context.Context, date time.Time) ([]*deposit.TermDeposit, error) {
+	query := `
+		SELECT ` + depositColumns + `
+		FROM term_deposits
+		WHERE status = $1 AND maturity_date <= $2
+		ORDER BY maturity_date, id
+	`
+	return r.queryDeposits(ctx, query, deposit.ACTIVE, date)
+}
+
+func (r *DepositRepository) queryDeposits(ctx context.Context, query string, args ...any) ([]*deposit.TermDeposit, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deposits []*deposit.TermDeposit
+	for rows.Next() {
+		d, err := scanDeposit(rows)
+		if err != nil {
+			return nil, err
+		}
+		deposits = append(deposits, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return deposits, nil
+}
+
+// Settle закрывает активный вклад одной транзакцией: переводит его в статус
+// to, зачисляет проценты interest на счет вклада и переводит весь остаток на
+// исходный счет. Возвращает false, если вклад уже был закрыт параллельно;
+// при ошибке вклад остается активным и будет закрыт при повторе.
+func (r *DepositRepository) Settle(ctx context.Context, d *deposit.TermDeposit, to deposit.Status,
+	interest decimal.Decimal) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	statusQuery := `
+		UPDATE term_deposits
+		SET status = $1
+		WHERE id = $2 AND status = $3
+	`
+	tag, err := tx.Exec(ctx, statusQuery, to, d.ID, deposit.ACTIVE)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if err = creditInterest(ctx, tx, d.AccountID, interest); err != nil {
+		return false, err
+	}
+
+	balanceQuery := `
+		SELECT balance
+		FROM accounts
+		WHERE id = $1
+		FOR UPDATE
+	`
+	var balance decimal.Decimal
+	if err = tx.QueryRow(ctx, balanceQuery, d.AccountID).Scan(&balance); err != nil {
+		return false, err
+	}
+
+	if balance.IsPositive() {
+		if err = transferFunds(ctx, tx, d.AccountID, d.SourceAccountID, d.UserID, balance, balance, nil); err != nil {
+			return false, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Rollover продлевает вклад на новый срок и капитализирует проценты interest
+// в тело вклада одной транзакцией. Условие по текущей дате погашения защищает
+// от повторной пролонгации при параллельном запуске задачи.
+func (r *DepositRepository) Rollover(ctx context.Context, d *deposit.TermDeposit, interest decimal.Decimal,
+	start, maturity time.Time) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE term_deposits
+		SET principal = principal + $1, start_date = $2, maturity_date = $3
+		WHERE id = $4 AND status = $5 AND maturity_date = $6
+	`
+	tag, err := tx.Exec(ctx, query, interest, start, maturity, d.ID, deposit.ACTIVE, d.MaturityDate)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if err = creditInterest(ctx, tx, d.AccountID, interest); err != nil {
+		return false, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// creditInterest зачисляет проценты по вкладу на его счет.
+func creditInterest(ctx context.Context, tx pgx.Tx, accountID int64, interest decimal.Decimal) error {
+	if !interest.IsPositive() {
+		return nil
+	}
+
+	if err := adjustBalance(ctx, tx, accountID, interest); err != nil {
+		return err
+	}
+	if err := insertInterest(ctx, tx, accountID, interest); err != nil {
+		return err
+	}
+
+	return enqueueEvent(ctx, tx, events.AggregateAccount, accountID, events.BALANCE_UPDATED, events.BalanceUpdated{
+		AccountID: accountID,
+		Amount:    interest,
+	})
+}
+
+// adjustBalance изменяет баланс счета на amount без проверки лимитов.
+func adjustBalance(ctx context.Context, tx pgx.Tx, accountID int64, amount decimal.Decimal) error {
+	query := `
+		UPDATE accounts
+		SET balance = balance + $1
+		WHERE id = $2
+	`
+	_, err := tx.Exec(ctx, query, amount, accountID)
+	return err
+}
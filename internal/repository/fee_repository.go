@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/events"
+	"github.com/therealadik/bank-api/internal/models/fee"
+	"github.com/therealadik/bank-api/internal/models/transaction"
+)
+
+const feeRuleColumns = `id, operation, volume_from, fixed, percent, min_amount, max_amount, active, created_at`
+
+type FeeRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewFeeRepository(db *pgxpool.Pool) *FeeRepository {
+	return &FeeRepository{db: db}
+}
+
+func (r *FeeRepository) GetActiveRules(ctx context.Context, operation fee.Operation) ([]*fee.Rule, error) {
+	query := `
+		SELECT ` + feeRuleColumns + `
+		FROM fee_rules
+		WHERE operation = $1 AND active
+		ORDER BY volume_from DESC
+	`
+	return r.queryRules(ctx, query, operation)
+}
+
+func (r *FeeRepository) GetAllRules(ctx context.Context) ([]*fee.Rule, error) {
+	query := `
+		SELECT ` + feeRuleColumns + `
+		FROM fee_rules
+		ORDER BY operation, volume_from
+	`
+	return r.queryRules(ctx, query)
+}
+
+func (r *FeeRepository) queryRules(ctx context.Context, query string, args ...any) ([]*fee.Rule, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []*fee.Rule
+	for rows.Next() {
+		var rule fee.Rule
+		err := rows.Scan(&rule.ID, &rule.Operation, &rule.VolumeFrom, &rule.Fixed, &rule.Percent,
+			&rule.MinAmount, &rule.MaxAmount, &rule.Active, &rule.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, &rule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// GetMonthlyVolume возвращает оборот пользователя по операции начиная с since.
+func (r *FeeRepository) GetMonthlyVolume(ctx context.Context, userID int64, operation fee.Operation, since time.Time) (decimal.Decimal, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM fee_charges
+		WHERE user_id = $1 AND operation = $2 AND created_at >= $3
+	`
+	var volume decimal.Decimal
+	err := r.db.QueryRow(ctx, query, userID, operation, since).Scan(&volume)
+	return volume, err
+}
+
+// ChargePeriodic списывает периодическую комиссию за период period.
+// Повторное списание за тот же период игнорируется и возвращает false.
+// Если комиссия не укладывается в остаток с учетом овердрафта, ничего не
+// списывается и возвращается ErrLimitExceeded: период остается неоплаченным
+// и будет списан при следующем запуске.
+func (r *FeeRepository) ChargePeriodic(ctx context.Context, userID, accountID int64, period time.Time, q *fee.Quote) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	chargeQuery := `
+		INSERT INTO fee_charges (user_id, account_id, operation, amount, fee, period)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (account_id, operation, period) WHERE period IS NOT NULL DO NOTHING
+	`
+	tag, err := tx.Exec(ctx, chargeQuery, userID, accountID, q.Operation, q.Amount, q.Fee, period)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	balanceQuery := `
+		UPDATE accounts
+		SET balance = balance - $1
+		WHERE id = $2 AND balance + overdraft_limit >= $1
+	`
+	tag, err = tx.Exec(ctx, balanceQuery, q.Fee, accountID)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, ErrLimitExceeded
+	}
+
+	if err = insertFeeTransaction(ctx, tx, accountID, q); err != nil {
+		return false, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// recordFee фиксирует операцию для расчета оборота и, если комиссия ненулевая,
+// создает отдельную транзакцию FEE. Списание комиссии с баланса выполняет вызывающий код
+// в той же транзакции БД.
+func recordFee(ctx context.Context, tx pgx.Tx, userID, accountID int64, q *fee.Quote) error {
+	chargeQuery := `
+		INSERT INTO fee_charges (user_id, account_id, operation, amount, fee)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	if _, err := tx.Exec(ctx, chargeQuery, userID, accountID, q.Operation, q.Amount, q.Fee); err != nil {
+		return err
+	}
+
+	if !q.Fee.IsPositive() {
+		return nil
+	}
+	return insertFeeTransaction(ctx, tx, accountID, q)
+}
+
+func insertFeeTransaction(ctx context.Context, tx pgx.Tx, accountID int64, q *fee.Quote) error {
+	query := `
+		INSERT INTO transactions (account_id, amount, type, status)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := tx.Exec(ctx, query, accountID, q.Fee, transaction.FEE, transaction.COMPLETED); err != nil {
+		return err
+	}
+
+	return enqueueEvent(ctx, tx, events.AggregateAccount, accountID, events.FEE_CHARGED, events.FeeCharged{
+		AccountID: accountID,
+		Operation: string(q.Operation),
+		Amount:    q.Amount,
+		Fee:       q.Fee,
+	})
+}
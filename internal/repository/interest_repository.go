@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/events"
+	"github.com/therealadik/bank-api/internal/models/transaction"
+)
+
+type InterestRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewInterestRepository(db *pgxpool.Pool) *InterestRepository {
+	return &InterestRepository{db: db}
+}
+
+// CreateAccrual сохраняет начисление за день. Повторная запись за ту же дату
+// игнорируется, поэтому возвращается false, если начисление уже существовало.
+func (r *InterestRepository) CreateAccrual(ctx context.Context, accountID int64, date time.Time, amount decimal.Decimal) (bool, error) {
+	query := `
+		INSERT INTO interest_accruals (account_id, accrual_date, amount)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (account_id, accrual_date) WHERE NOT carry_over DO NOTHING
+	`
+	tag, err := r.db.Exec(ctx, query, accountID, date, amount)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *InterestRepository) GetAccountsWithPendingAccruals(ctx context.Context, before time.Time) ([]int64, error) {
+	query := `
+		SELECT DISTINCT account_id
+		FROM interest_accruals
+		WHERE NOT capitalized AND accrual_date < $1
+		ORDER BY account_id
+	`
+	rows, err := r.db.Query(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CapitalizeAccruals переносит накопленные до даты before проценты на баланс счета
+// одной транзакцией: зачисление, запись DEPOSIT, событие BalanceUpdated и отметка
+// начислений выполняются атомарно.
+// Дробный остаток меньше копейки переносится в следующее начисление.
+func (r *InterestRepository) CapitalizeAccruals(ctx context.Context, accountID int64, before time.Time) (decimal.Decimal, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	defer tx.Rollback(ctx)
+
+	markQuery := `
+		UPDATE interest_accruals
+		SET capitalized = TRUE
+		WHERE account_id = $1 AND NOT capitalized AND accrual_date < $2
+		RETURNING amount
+	`
+	rows, err := tx.Query(ctx, markQuery, accountID, before)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	total := decimal.Zero
+	for rows.Next() {
+		var amount decimal.Decimal
+		if err := rows.Scan(&amount); err != nil {
+			rows.Close()
+			return decimal.Zero, err
+		}
+		total = total.Add(amount)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return decimal.Zero, err
+	}
+
+	payout := total.RoundDown(2)
+	remainder := total.Sub(payout)
+
+	if remainder.IsPositive() {
+		carryQuery := `
+			INSERT INTO interest_accruals (account_id, accrual_date, amount, carry_over)
+			VALUES ($1, $2, $3, TRUE)
+		`
+		if _, err = tx.Exec(ctx, carryQuery, accountID, before, remainder); err != nil {
+			return decimal.Zero, err
+		}
+	}
+
+	if payout.IsPositive() {
+		balanceQuery := `
+			UPDATE accounts
+			SET balance = balance + $1
+			WHERE id = $2
+		`
+		if _, err = tx.Exec(ctx, balanceQuery, payout, accountID); err != nil {
+			return decimal.Zero, err
+		}
+
+		if err = insertInterest(ctx, tx, accountID, payout); err != nil {
+			return decimal.Zero, err
+		}
+
+		err = enqueueEvent(ctx, tx, events.AggregateAccount, accountID, events.BALANCE_UPDATED, events.BalanceUpdated{
+			AccountID: accountID,
+			Amount:    payout,
+		})
+		if err != nil {
+			return decimal.Zero, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return decimal.Zero, err
+	}
+	return payout, nil
+}
+
+// ChargeOverdraftInterest списывает проценты за использование овердрафта за день date.
+// Вместе со списанием в outbox ставится BalanceUpdated, а если баланс выходит
+// за лимит — еще и OverdraftLimitBreached. Возвращает false, если списание
+// за эту дату уже было выполнено.
+func (r *InterestRepository) ChargeOverdraftInterest(ctx context.Context, accountID int64, date time.Time,
+	balance, amount decimal.Decimal) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	chargeQuery := `
+		INSERT INTO overdraft_charges (account_id, charge_date, balance, amount)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (account_id, charge_date) DO NOTHING
+	`
+	tag, err := tx.Exec(ctx, chargeQuery, accountID, date, balance, amount)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	balanceQuery := `
+		UPDATE accounts
+		SET balance = balance - $1
+		WHERE id = $2
+		RETURNING balance, overdraft_limit
+	`
+	var newBalance, limit decimal.Decimal
+	if err = tx.QueryRow(ctx, balanceQuery, amount, accountID).Scan(&newBalance, &limit); err != nil {
+		return false, err
+	}
+
+	txQuery := `
+		INSERT INTO transactions (account_id, amount, type, status)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err = tx.Exec(ctx, txQuery, accountID, amount, transaction.WITHDRAWAL, transaction.COMPLETED); err != nil {
+		return false, err
+	}
+
+	err = enqueueEvent(ctx, tx, events.AggregateAccount, accountID, events.BALANCE_UPDATED, events.BalanceUpdated{
+		AccountID: accountID,
+		Amount:    amount.Neg(),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if newBalance.Add(limit).IsNegative() {
+		err = enqueueEvent(ctx, tx, events.AggregateAccount, accountID, events.OVERDRAFT_LIMIT_BREACHED,
+			events.OverdraftLimitBreached{
+				AccountID:      accountID,
+				Balance:        newBalance,
+				OverdraftLimit: limit,
+			})
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
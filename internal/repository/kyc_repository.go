@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/therealadik/bank-api/internal/models/kyc"
+)
+
+var ErrProfileLocked = errors.New("анкета на проверке или уже подтверждена")
+
+const (
+	profileColumns = `u.id, u.full_name, u.phone, p.date_of_birth, COALESCE(p.citizenship, ''), COALESCE(p.address, ''),
+		u.kyc_status, COALESCE(p.reject_reason, ''), p.submitted_at, p.reviewed_at`
+
+	documentColumns = `id, user_id, doc_type, file_name, content_type, size, sha256, storage_key, created_at`
+)
+
+type KYCRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewKYCRepository(db *pgxpool.Pool) *KYCRepository {
+	return &KYCRepository{db: db}
+}
+
+func scanProfile(row pgx.Row) (*kyc.Profile, error) {
+	var p kyc.Profile
+	err := row.Scan(&p.UserID, &p.FullName, &p.Phone, &p.DateOfBirth, &p.Citizenship, &p.Address, &p.Status,
+		&p.RejectReason, &p.SubmittedAt, &p.ReviewedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func scanDocument(row pgx.Row) (*kyc.Document, error) {
+	var d kyc.Document
+	err := row.Scan(&d.ID, &d.UserID, &d.Type, &d.FileName, &d.ContentType, &d.Size, &d.SHA256, &d.StorageKey,
+		&d.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *KYCRepository) GetProfile(ctx context.Context, userID int64) (*kyc.Profile, error) {
+	query := `
+		SELECT ` + profileColumns + `
+		FROM users u
+		LEFT JOIN customer_profiles p ON p.user_id = u.id
+		WHERE u.id = $1
+	`
+	p, err := scanProfile(r.db.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// SaveProfile сохраняет анкету. Анкета на проверке или уже подтвержденная
+// не меняется: возвращается ErrProfileLocked.
+func (r *KYCRepository) SaveProfile(ctx context.Context, p *kyc.Profile) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `UPDATE users SET full_name = $1 WHERE id = $2 AND kyc_status IN ($3, $4)`,
+		p.FullName, p.UserID, kyc.UNVERIFIED, kyc.REJECTED)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProfileLocked
+	}
+
+	query := `
+		INSERT INTO customer_profiles (user_id, date_of_birth, citizenship, address)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET date_of_birth = EXCLUDED.date_of_birth, citizenship = EXCLUDED.citizenship,
+		    address = EXCLUDED.address, updated_at = now()
+	`
+	if _, err = tx.Exec(ctx, query, p.UserID, p.DateOfBirth, p.Citizenship, p.Address); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *KYCRepository) GetStatus(ctx context.Context, userID int64) (kyc.Status, error) {
+	var status kyc.Status
+	err := r.db.QueryRow(ctx, `SELECT kyc_status FROM users WHERE id = $1`, userID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrUserNotFound
+		}
+		return "", err
+	}
+	return status, nil
+}
+
+// Transition переводит анкету в статус to, если текущий статус входит
+// в from. Возвращает false, если переход недопустим. Отправка на проверку
+// фиксирует время отправки и сбрасывает причину прошлого отказа, решение
+// оператора — время проверки и причину отказа.
+func (r *KYCRepository) Transition(ctx context.Context, userID int64, from []kyc.Status, to kyc.Status,
+	reason string) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	statuses := make([]string, len(from))
+	for i, st := range from {
+		statuses[i] = string(st)
+	}
+
+	tag, err := tx.Exec(ctx, `UPDATE users SET kyc_status = $1 WHERE id = $2 AND kyc_status = ANY($3)`,
+		to, userID, statuses)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	query := `
+		INSERT INTO customer_profiles (user_id, reject_reason, reviewed_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT (user_id) DO UPDATE
+		SET reject_reason = EXCLUDED.reject_reason, reviewed_at = now(), updated_at = now()
+	`
+	if to == kyc.PENDING {
+		query = `
+			INSERT INTO customer_profiles (user_id, reject_reason, submitted_at)
+			VALUES ($1, $2, now())
+			ON CONFLICT (user_id) DO UPDATE
+			SET reject_reason = EXCLUDED.reject_reason, submitted_at = now(), reviewed_at = NULL, updated_at = now()
+		`
+	}
+	if _, err = tx.Exec(ctx, query, userID, reason); err != nil {
+		return false, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetProfilesByStatus возвращает анкеты в статусе, начиная с давно отправленных.
+func (r *KYCRepository) GetProfilesByStatus(ctx context.Context, status kyc.Status, limit int) ([]*kyc.Profile, error) {
+	query := `
+		SELECT ` + profileColumns + `
+		FROM users u
+		LEFT JOIN customer_profiles p ON p.user_id = u.id
+		WHERE u.kyc_status = $1
+		ORDER BY p.submitted_at NULLS LAST, u.id
+		LIMIT $2
+	`
+	rows, err := r.db.Query(ctx, query, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []*kyc.Profile
+	for rows.Next() {
+		p, err := scanProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func (r *KYCRepository) CreateDocument(ctx context.Context, d *kyc.Document) error {
+	query := `
+		INSERT INTO kyc_documents (user_id, doc_type, file_name, content_type, size, sha256, storage_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	return r.db.QueryRow(ctx, query, d.UserID, d.Type, d.FileName, d.ContentType, d.Size, d.SHA256, d.StorageKey).
+		Scan(&d.ID, &d.CreatedAt)
+}
+
+func (r *KYCRepository) GetDocuments(ctx context.Context, userID int64) ([]*kyc.Document, error) {
+	query := `
+		SELECT ` + documentColumns + `
+		FROM kyc_documents
+		WHERE user_id = $1
+		ORDER BY id
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var documents []*kyc.Document
+	for rows.Next() {
+		d, err := scanDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func (r *KYCRepository) GetDocument(ctx context.Context, id, userID int64) (*kyc.Document, error) {
+	query := `
+		SELECT ` + documentColumns + `
+		FROM kyc_documents
+		WHERE id = $1 AND user_id = $2
+	`
+	return scanDocument(r.db.QueryRow(ctx, query, id, userID))
+}
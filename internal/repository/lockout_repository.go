@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/therealadik/bank-api/internal/models/lockout"
+)
+
+var ErrLockoutNotFound = errors.New("активная блокировка не найдена")
+
+const lockoutColumns = `id, scope, subject, failures, locked_until, created_at, released_at`
+
+func scanLockout(row pgx.Row) (*lockout.Lockout, error) {
+	l := &lockout.Lockout{}
+	err := row.Scan(&l.ID, &l.Scope, &l.Subject, &l.Failures, &l.LockedUntil, &l.CreatedAt, &l.ReleasedAt)
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// LockoutRepository учитывает неудачные попытки входа и проверки CVV
+// и хранит блокировки.
+type LockoutRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewLockoutRepository(db *pgxpool.Pool) *LockoutRepository {
+	return &LockoutRepository{db: db}
+}
+
+// lockSubject сериализует попытки одного субъекта до конца транзакции.
+func lockSubject(ctx context.Context, tx pgx.Tx, scope lockout.Scope, subject string) error {
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2))`, scope, subject)
+	return err
+}
+
+// Begin регистрирует попытку, если субъект не заблокирован и выждал паузу
+// после последней неудачи. Иначе попытка не создается и возвращается время,
+// через которое можно повторить, и признак блокировки.
+func (r *LockoutRepository) Begin(ctx context.Context, scope lockout.Scope, subject string,
+	p lockout.Policy) (*lockout.Attempt, time.Duration, bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockSubject(ctx, tx, scope, subject); err != nil {
+		return nil, 0, false, err
+	}
+
+	query := `
+		SELECT
+			(SELECT (extract(epoch FROM max(locked_until) - now()) * 1000)::BIGINT
+			 FROM lockouts
+			 WHERE scope = $1 AND subject = $2 AND released_at IS NULL AND locked_until > now()),
+			(SELECT count(*)
+			 FROM auth_attempts
+			 WHERE scope = $1 AND subject = $2 AND created_at > now() - $3 * interval '1 millisecond'),
+			(SELECT (extract(epoch FROM now() - max(created_at)) * 1000)::BIGINT
+			 FROM auth_attempts
+			 WHERE scope = $1 AND subject = $2 AND created_at > now() - $3 * interval '1 millisecond')
+	`
+	var (
+		lockedForMs *int64
+		failures    int
+		sinceLastMs *int64
+	)
+	err = tx.QueryRow(ctx, query, scope, subject, p.Window.Milliseconds()).Scan(&lockedForMs, &failures, &sinceLastMs)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	if lockedForMs != nil {
+		return nil, time.Duration(*lockedForMs) * time.Millisecond, true, nil
+	}
+	if sinceLastMs != nil {
+		if wait := p.Delay(failures) - time.Duration(*sinceLastMs)*time.Millisecond; wait > 0 {
+			return nil, wait, false, nil
+		}
+	}
+
+	a := &lockout.Attempt{Scope: scope, Subject: subject}
+	err = tx.QueryRow(ctx, `INSERT INTO auth_attempts (scope, subject) VALUES ($1, $2) RETURNING id`,
+		scope, subject).Scan(&a.ID)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, 0, false, err
+	}
+	return a, 0, false, nil
+}
+
+// DeleteAttempt снимает попытку с учета.
+func (r *LockoutRepository) DeleteAttempt(ctx context.Context, id int64) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM auth_attempts WHERE id = $1`, id)
+	return err
+}
+
+// ClearAttempts снимает с учета все попытки субъекта.
+func (r *LockoutRepository) ClearAttempts(ctx context.Context, scope lockout.Scope, subject string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM auth_attempts WHERE scope = $1 AND subject = $2`, scope, subject)
+	return err
+}
+
+// PruneAttempts удаляет попытки вида scope старше окна: они уже не входят
+// в счетчик неудач. Возвращает число удаленных попыток.
+func (r *LockoutRepository) PruneAttempts(ctx context.Context, scope lockout.Scope, window time.Duration) (int64, error) {
+	query := `DELETE FROM auth_attempts WHERE scope = $1 AND created_at <= now() - $2 * interval '1 millisecond'`
+	tag, err := r.db.Exec(ctx, query, scope, window.Milliseconds())
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Fail оставляет попытку неудачной и блокирует субъект, если неудач за окно
+// набралось p.LockAfter. Счетчик неудач после блокировки начинается заново.
+// Возвращает созданную блокировку или nil.
+func (r *LockoutRepository) Fail(ctx context.Context, a *lockout.Attempt, p lockout.Policy) (*lockout.Lockout, error) {
+	if p.LockAfter <= 0 {
+		return nil, nil
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = lockSubject(ctx, tx, a.Scope, a.Subject); err != nil {
+		return nil, err
+	}
+
+	var failures int
+	query := `
+		SELECT count(*)
+		FROM auth_attempts
+		WHERE scope = $1 AND subject = $2 AND created_at > now() - $3 * interval '1 millisecond'
+	`
+	if err = tx.QueryRow(ctx, query, a.Scope, a.Subject, p.Window.Milliseconds()).Scan(&failures); err != nil {
+		return nil, err
+	}
+	if failures < p.LockAfter {
+		return nil, nil
+	}
+
+	query = `
+		INSERT INTO lockouts (scope, subject, failures, locked_until)
+		VALUES ($1, $2, $3, now() + $4 * interval '1 millisecond')
+		RETURNING ` + lockoutColumns
+	l, err := scanLockout(tx.QueryRow(ctx, query, a.Scope, a.Subject, failures, p.LockDuration.Milliseconds()))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM auth_attempts WHERE scope = $1 AND subject = $2`, a.Scope, a.Subject); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// GetActive возвращает действующие блокировки; пустой scope — всех видов.
+func (r *LockoutRepository) GetActive(ctx context.Context, scope lockout.Scope) ([]*lockout.Lockout, error) {
+	query := `
+		SELECT ` + lockoutColumns + `
+		FROM lockouts
+		WHERE released_at IS NULL AND locked_until > now() AND ($1 = '' OR scope = $1)
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lockouts []*lockout.Lockout
+	for rows.Next() {
+		l, err := scanLockout(rows)
+		if err != nil {
+			return nil, err
+		}
+		lockouts = append(lockouts, l)
+	}
+	return lockouts, rows.Err()
+}
+
+// Release досрочно снимает действующую блокировку и сбрасывает счетчик
+// неудач субъекта.
+func (r *LockoutRepository) Release(ctx context.Context, id int64) (*lockout.Lockout, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE lockouts
+		SET released_at = now()
+		WHERE id = $1 AND released_at IS NULL AND locked_until > now()
+		RETURNING ` + lockoutColumns
+	l, err := scanLockout(tx.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrLockoutNotFound
+		}
+		return nil, err
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM auth_attempts WHERE scope = $1 AND subject = $2`, l.Scope, l.Subject); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
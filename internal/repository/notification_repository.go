@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/therealadik/bank-api/internal/models/notification"
+)
+
+const (
+	notificationColumns = `n.id, n.user_id, n.event_id, n.kind, n.subject, n.body, n.in_app, n.read_at, n.created_at,
+		e.status`
+
+	emailDeliveryColumns = `d.id, d.notification_id, d.recipient, n.subject, n.body, d.status, d.attempts,
+		d.next_attempt_at, d.last_error, d.created_at, d.sent_at`
+)
+
+type NotificationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewNotificationRepository(db *pgxpool.Pool) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+func scanNotification(row pgx.Row) (*notification.Notification, error) {
+	var n notification.Notification
+	err := row.Scan(&n.ID, &n.UserID, &n.EventID, &n.Kind, &n.Subject, &n.Body, &n.InApp, &n.ReadAt, &n.CreatedAt,
+		&n.EmailStatus)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func scanEmailDelivery(row pgx.Row) (*notification.EmailDelivery, error) {
+	var d notification.EmailDelivery
+	err := row.Scan(&d.ID, &d.NotificationID, &d.Recipient, &d.Subject, &d.Body, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastError, &d.CreatedAt, &d.SentAt)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// GetSettings возвращает настройки уведомлений пользователя. Если язык не
+// выбран, Locale пустой.
+func (r *NotificationRepository) GetSettings(ctx context.Context, userID int64) (*notification.Settings, error) {
+	settings := &notification.Settings{UserID: userID}
+
+	err := r.db.QueryRow(ctx, `SELECT locale FROM notification_settings WHERE user_id = $1`, userID).
+		Scan(&settings.Locale)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	query := `
+		SELECT kind, channel, enabled
+		FROM notification_preferences
+		WHERE user_id = $1
+		ORDER BY kind, channel
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p notification.Preference
+		if err := rows.Scan(&p.Kind, &p.Channel, &p.Enabled); err != nil {
+			return nil, err
+		}
+		settings.Preferences = append(settings.Preferences, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SaveSettings сохраняет язык (если задан) и переданные предпочтения;
+// предпочтения, не упомянутые в запросе, не меняются.
+func (r *NotificationRepository) SaveSettings(ctx context.Context, userID int64, locale notification.Locale,
+	prefs []notification.Preference) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if locale != "" {
+		settingsQuery := `
+			INSERT INTO notification_settings (user_id, locale)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id) DO UPDATE SET locale = EXCLUDED.locale, updated_at = now()
+		`
+		if _, err = tx.Exec(ctx, settingsQuery, userID, locale); err != nil {
+			return err
+		}
+	}
+
+	prefQuery := `
+		INSERT INTO notification_preferences (user_id, kind, channel, enabled)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, kind, channel) DO UPDATE SET enabled = EXCLUDED.enabled
+	`
+	for _, p := range prefs {
+		if _, err = tx.Exec(ctx, prefQuery, userID, p.Kind, p.Channel, p.Enabled); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Create сохраняет уведомление и, если указан адрес, ставит письмо в очередь.
+// Повторная обработка того же события не создает дублей: в этом случае
+// возвращается false.
+func (r *NotificationRepository) Create(ctx context.Context, n *notification.Notification, recipient string) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO notifications (user_id, event_id, kind, subject, body, in_app)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, event_id, kind) DO NOTHING
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(ctx, query, n.UserID, n.EventID, n.Kind, n.Subject, n.Body, n.InApp).
+		Scan(&n.ID, &n.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if recipient != "" {
+		emailQuery := `
+			INSERT INTO email_deliveries (notification_id, recipient)
+			VALUES ($1, $2)
+		`
+		if _, err = tx.Exec(ctx, emailQuery, n.ID, recipient); err != nil {
+			return false, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetInbox возвращает уведомления входящих, начиная с новых.
+func (r *NotificationRepository) GetInbox(ctx context.Context, userID int64, unreadOnly bool,
+	limit int) ([]*notification.Notification, error) {
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications n
+		LEFT JOIN email_deliveries e ON e.notification_id = n.id
+		WHERE n.user_id = $1 AND n.in_app AND (NOT $2 OR n.read_at IS NULL)
+		ORDER BY n.id DESC
+		LIMIT $3
+	`
+	rows, err := r.db.Query(ctx, query, userID, unreadOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*notification.Notification
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx,
+		`SELECT count(*) FROM notifications WHERE user_id = $1 AND in_app AND read_at IS NULL`,
+		userID).Scan(&count)
+	return count, err
+}
+
+// MarkRead отмечает уведомление прочитанным. Уже прочитанное уведомление
+// сохраняет исходное время прочтения.
+func (r *NotificationRepository) MarkRead(ctx context.Context, id, userID int64) (bool, error) {
+	query := `
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, now())
+		WHERE id = $1 AND user_id = $2 AND in_app
+	`
+	tag, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID int64) (int, error) {
+	query := `
+		UPDATE notifications
+		SET read_at = now()
+		WHERE user_id = $1 AND in_app AND read_at IS NULL
+	`
+	tag, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// ClaimDueEmails выбирает письма, время отправки которых наступило, и
+// сдвигает следующую попытку на lease, чтобы другой экземпляр не отправил их
+// одновременно.
+func (r *NotificationRepository) ClaimDueEmails(ctx context.Context, limit int,
+	lease time.Duration) ([]*notification.EmailDelivery, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE email_deliveries
+			SET next_attempt_at = now() + $3 * interval '1 millisecond'
+			WHERE id IN (
+				SELECT id
+				FROM email_deliveries
+				WHERE status = $1 AND next_attempt_at <= now()
+				ORDER BY next_attempt_at, id
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *
+		)
+		SELECT ` + emailDeliveryColumns + `
+		FROM claimed d
+		JOIN notifications n ON n.id = d.notification_id
+		ORDER BY d.id
+	`
+	rows, err := r.db.Query(ctx, query, notification.EMAIL_PENDING, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*notification.EmailDelivery
+	for rows.Next() {
+		d, err := scanEmailDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *NotificationRepository) UpdateEmailDelivery(ctx context.Context, d *notification.EmailDelivery) error {
+	query := `
+		UPDATE email_deliveries
+		SET status = $1, attempts = $2, next_attempt_at = $3, last_error = $4, sent_at = $5
+		WHERE id = $6
+	`
+	_, err := r.db.Exec(ctx, query, d.Status, d.Attempts, d.NextAttemptAt, d.LastError, d.SentAt, d.ID)
+	return err
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/therealadik/bank-api/internal/events"
+)
+
+// outboxRelayLockKey — ключ advisory-блокировки, гарантирующей, что события
+// публикует только один экземпляр сервиса, и порядок внутри агрегата
+// сохраняется.
+const outboxRelayLockKey = 735001
+
+// outboxChannel — канал LISTEN/NOTIFY, в который триггер пишет ID каждого
+// нового события.
+const outboxChannel = "outbox_events"
+
+const outboxColumns = `id, aggregate_type, aggregate_id, event_type, payload, created_at`
+
+type OutboxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// enqueueEvent записывает событие в outbox в рамках транзакции изменения
+// состояния.
+func enqueueEvent(ctx context.Context, tx pgx.Tx, aggregateType string, aggregateID int64,
+	eventType events.Type, payload any) error {
+	e, err := events.New(aggregateType, aggregateID, eventType, payload)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO outbox_events (aggregate_type, aggregate_id, event_type, payload)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err = tx.Exec(ctx, query, e.AggregateType, e.AggregateID, e.Type, e.Payload)
+	return err
+}
+
+func scanOutboxEvent(row pgx.Row) (events.Event, error) {
+	var e events.Event
+	err := row.Scan(&e.ID, &e.AggregateType, &e.AggregateID, &e.Type, &e.Payload, &e.CreatedAt)
+	return e, err
+}
+
+func (r *OutboxRepository) GetUnpublished(ctx context.Context, limit int) ([]events.Event, error) {
+	query := `
+		SELECT ` + outboxColumns + `
+		FROM outbox_events
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+	`
+	return r.queryEvents(ctx, query, limit)
+}
+
+func (r *OutboxRepository) GetEvent(ctx context.Context, id int64) (events.Event, error) {
+	query := `
+		SELECT ` + outboxColumns + `
+		FROM outbox_events
+		WHERE id = $1
+	`
+	return scanOutboxEvent(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *OutboxRepository) GetEventsAfter(ctx context.Context, afterID int64, limit int) ([]events.Event, error) {
+	query := `
+		SELECT ` + outboxColumns + `
+		FROM outbox_events
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+	return r.queryEvents(ctx, query, afterID, limit)
+}
+
+// GetUserEventsAfter возвращает события по счетам и картам пользователя,
+// включая входящие переводы на его счета.
+func (r *OutboxRepository) GetUserEventsAfter(ctx context.Context, userID, afterID int64, limit int) ([]events.Event, error) {
+	query := `
+		SELECT ` + outboxColumns + `
+		FROM outbox_events
+		WHERE id > $2 AND (
+			(aggregate_type = $3 AND aggregate_id IN (SELECT id FROM accounts WHERE user_id = $1))
+			OR (aggregate_type = $4 AND aggregate_id IN (SELECT id FROM cards WHERE user_id = $1))
+			OR (event_type = $5 AND (payload ->> 'to_account_id')::bigint IN (SELECT id FROM accounts WHERE user_id = $1))
+		)
+		ORDER BY id
+		LIMIT $6
+	`
+	return r.queryEvents(ctx, query, userID, afterID, events.AggregateAccount, events.AggregateCard,
+		events.TRANSFER_COMPLETED, limit)
+}
+
+func (r *OutboxRepository) GetLastEventID(ctx context.Context) (int64, error) {
+	var id int64
+	err := r.db.QueryRow(ctx, `SELECT COALESCE(max(id), 0) FROM outbox_events`).Scan(&id)
+	return id, err
+}
+
+func (r *OutboxRepository) queryEvents(ctx context.Context, query string, args ...any) ([]events.Event, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []events.Event
+	for rows.Next() {
+		e, err := scanOutboxEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	query := `
+		UPDATE outbox_events
+		SET published_at = now()
+		WHERE id = $1
+	`
+	_, err := r.db.Exec(ctx, query, id)
+	return err
+}
+
+// WithRelayLock выполняет fn, удерживая блокировку публикации. Если
+// блокировку держит другой экземпляр, fn не вызывается и возвращается false.
+func (r *OutboxRepository) WithRelayLock(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, outboxRelayLockKey).Scan(&locked); err != nil {
+		return false, err
+	}
+	if !locked {
+		return false, nil
+	}
+	defer conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, outboxRelayLockKey)
+
+	return true, fn(ctx)
+}
+
+// Listen подписывается на уведомления о новых событиях и вызывает fn с ID
+// каждого события, пока не отменен ctx, не оборвалось соединение или fn не
+// вернула ошибку.
+func (r *OutboxRepository) Listen(ctx context.Context, fn func(id int64) error) error {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+outboxChannel); err != nil {
+		return err
+	}
+	defer conn.Exec(context.WithoutCancel(ctx), "UNLISTEN "+outboxChannel)
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		id, err := strconv.ParseInt(n.Payload, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		if err := fn(id); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models/overdraft"
+)
+
+var ErrOverdraftRequestNotPending = errors.New("заявка на овердрафт уже рассмотрена")
+
+const overdraftRequestColumns = `id, account_id, user_id, requested_limit, status, review_comment, created_at, reviewed_at`
+
+type OverdraftRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOverdraftRepository(db *pgxpool.Pool) *OverdraftRepository {
+	return &OverdraftRepository{db: db}
+}
+
+func scanOverdraftRequest(row pgx.Row) (*overdraft.Request, error) {
+	var req overdraft.Request
+	err := row.Scan(&req.ID, &req.AccountID, &req.UserID, &req.RequestedLimit, &req.Status, &req.ReviewComment,
+		&req.CreatedAt, &req.ReviewedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// CreateRequest сохраняет заявку на лимит. Вторая ожидающая заявка по тому же
+// счету нарушает уникальный индекс.
+func (r *OverdraftRepository) CreateRequest(ctx context.Context, accountID, userID int64,
+	limit decimal.Decimal) (*overdraft.Request, error) {
+	query := `
+		INSERT INTO overdraft_requests (account_id, user_id, requested_limit)
+		VALUES ($1, $2, $3)
+		RETURNING ` + overdraftRequestColumns
+	return scanOverdraftRequest(r.db.QueryRow(ctx, query, accountID, userID, limit))
+}
+
+func (r *OverdraftRepository) GetRequest(ctx context.Context, id int64) (*overdraft.Request, error) {
+	query := `
+		SELECT ` + overdraftRequestColumns + `
+		FROM overdraft_requests
+		WHERE id = $1
+	`
+	return scanOverdraftRequest(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *OverdraftRepository) GetRequests(ctx context.Context, status overdraft.Status, limit int) ([]*overdraft.Request, error) {
+	query := `
+		SELECT ` + overdraftRequestColumns + `
+		FROM overdraft_requests
+		WHERE $1 = '' OR status = $1
+		ORDER BY id
+		LIMIT $2
+	`
+	rows, err := r.db.Query(ctx, query, string(status), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []*overdraft.Request
+	for rows.Next() {
+		req, err := scanOverdraftRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// ResolveRequest фиксирует решение оператора. При одобрении лимит и ставка
+// устанавливаются на счет в той же транзакции, поэтому одобренная заявка
+// всегда означает действующий лимит.
+func (r *OverdraftRepository) ResolveRequest(ctx context.Context, id int64, status overdraft.Status, comment string,
+	rate decimal.Decimal) (*overdraft.Request, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE overdraft_requests
+		SET status = $1, review_comment = $2, reviewed_at = now()
+		WHERE id = $3 AND status = $4
+		RETURNING ` + overdraftRequestColumns
+	req, err := scanOverdraftRequest(tx.QueryRow(ctx, query, status, comment, id, overdraft.PENDING))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOverdraftRequestNotPending
+		}
+		return nil, err
+	}
+
+	if status == overdraft.APPROVED {
+		limitQuery := `
+			UPDATE accounts
+			SET overdraft_limit = $1, overdraft_rate = $2
+			WHERE id = $3
+		`
+		if _, err = tx.Exec(ctx, limitQuery, req.RequestedLimit, rate, req.AccountID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/therealadik/bank-api/internal/models/p2p"
+)
+
+const p2pColumns = `id, sender_id, from_account_id, recipient_id, to_account_id, amount, fee, status, expires_at, created_at`
+
+type P2PRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewP2PRepository(db *pgxpool.Pool) *P2PRepository {
+	return &P2PRepository{db: db}
+}
+
+func scanP2PTransfer(row pgx.Row) (*p2p.Transfer, error) {
+	var t p2p.Transfer
+	err := row.Scan(&t.ID, &t.SenderID, &t.FromAccountID, &t.RecipientID, &t.ToAccountID,
+		&t.Amount, &t.Fee, &t.Status, &t.ExpiresAt, &t.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *P2PRepository) CreateTransfer(ctx context.Context, t *p2p.Transfer) (*p2p.Transfer, error) {
+	query := `
+		INSERT INTO p2p_transfers (sender_id, from_account_id, recipient_id, to_account_id, amount, fee, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + p2pColumns
+	return scanP2PTransfer(r.db.QueryRow(ctx, query,
+		t.SenderID, t.FromAccountID, t.RecipientID, t.ToAccountID, t.Amount, t.Fee, t.Status, t.ExpiresAt))
+}
+
+// ClaimPending переводит неистекший перевод отправителя из PENDING в PROCESSING.
+// Возвращает pgx.ErrNoRows, если перевод не найден, уже обработан или истек.
+func (r *P2PRepository) ClaimPending(ctx context.Context, id, senderID int64) (*p2p.Transfer, error) {
+	query := `
+		UPDATE p2p_transfers
+		SET status = $1
+		WHERE id = $2 AND sender_id = $3 AND status = $4 AND expires_at > now()
+		RETURNING ` + p2pColumns
+	return scanP2PTransfer(r.db.QueryRow(ctx, query, p2p.PROCESSING, id, senderID, p2p.PENDING))
+}
+
+func (r *P2PRepository) UpdateStatus(ctx context.Context, id int64, status p2p.Status) error {
+	query := `
+		UPDATE p2p_transfers
+		SET status = $1
+		WHERE id = $2
+	`
+	_, err := r.db.Exec(ctx, query, status, id)
+	return err
+}
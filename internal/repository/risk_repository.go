@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/therealadik/bank-api/internal/models/transaction"
+	"github.com/therealadik/bank-api/internal/risk"
+)
+
+type RiskRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRiskRepository(db *pgxpool.Pool) *RiskRepository {
+	return &RiskRepository{db: db}
+}
+
+// CollectSignals заполняет признаки операции по истории пользователя:
+// число оцененных операций за окно скорости, число и среднюю сумму
+// проведенных списаний за период истории, новизну получателя по дате его
+// сохранения и неверные CVV по карте. Пропущенная антифродом операция могла
+// не провестись, поэтому история считается по операциям, а не по решениям.
+func (r *RiskRepository) CollectSignals(ctx context.Context, op *risk.Operation, th risk.Thresholds) error {
+	query := `
+		WITH history AS (
+			SELECT t.amount
+			FROM transactions t
+			JOIN accounts a ON a.id = t.account_id
+			WHERE a.user_id = $1
+			  AND t.type = $3
+			  AND t.status = $4
+			  AND t.created_at > now() - $5 * interval '1 millisecond'
+		)
+		SELECT
+			(SELECT count(*)
+			 FROM risk_decisions
+			 WHERE user_id = $1 AND created_at > now() - $2 * interval '1 millisecond'),
+			(SELECT count(*) FROM history),
+			(SELECT COALESCE(avg(amount), 0) FROM history),
+			(SELECT count(*)
+			 FROM card_cvv_failures
+			 WHERE card_id = $6 AND created_at > now() - $7 * interval '1 millisecond'),
+			$8::BIGINT IS NOT NULL
+				AND NOT EXISTS (SELECT 1 FROM accounts WHERE id = $8 AND user_id = $1)
+				AND NOT EXISTS (SELECT 1
+				                FROM beneficiaries
+				                WHERE user_id = $1 AND account_id = $8
+				                  AND created_at <= now() - $9 * interval '1 millisecond')
+	`
+	return r.db.QueryRow(ctx, query, op.UserID, th.VelocityWindow.Milliseconds(), transaction.WITHDRAWAL,
+		transaction.COMPLETED, th.HistoryWindow.Milliseconds(), op.CardID, th.CVVWindow.Milliseconds(),
+		op.CounterpartyID, th.NewPayeeAge.Milliseconds()).
+		Scan(&op.RecentOperations, &op.HistoryCount, &op.AverageAmount, &op.CVVFailures, &op.NewCounterparty)
+}
+
+// RecordDecision сохраняет решение. Для CHALLENGE создается ожидающая
+// проверка; challengeID ссылается на подтвержденную проверку, по которой
+// операция пропущена.
+func (r *RiskRepository) RecordDecision(ctx context.Context, op *risk.Operation, a risk.Assessment,
+	challengeID *int64) (int64, error) {
+	hits, err := json.Marshal(a.Hits)
+	if err != nil {
+		return 0, err
+	}
+
+	var status *risk.ChallengeStatus
+	if a.Decision == risk.CHALLENGE {
+		pending := risk.CHALLENGE_PENDING
+		status = &pending
+	}
+
+	query := `
+		INSERT INTO risk_decisions (user_id, operation, account_id, counterparty_account_id, card_id, amount,
+		                            score, decision, hits, challenge_status, challenge_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+	var id int64
+	err = r.db.QueryRow(ctx, query, op.UserID, op.Type, op.AccountID, op.CounterpartyID, op.CardID, op.Amount,
+		a.Score, a.Decision, hits, status, challengeID).Scan(&id)
+	return id, err
+}
+
+// UseConfirmedChallenge находит подтвержденную не старше ttl проверку той же
+// операции и помечает ее использованной. Возвращает ее ID или nil.
+func (r *RiskRepository) UseConfirmedChallenge(ctx context.Context, op *risk.Operation,
+	ttl time.Duration) (*int64, error) {
+	query := `
+		UPDATE risk_decisions
+		SET challenge_status = $1
+		WHERE id = (
+			SELECT id
+			FROM risk_decisions
+			WHERE user_id = $2 AND operation = $3 AND account_id = $4
+			  AND counterparty_account_id IS NOT DISTINCT FROM $5
+			  AND card_id IS NOT DISTINCT FROM $6
+			  AND amount = $7
+			  AND challenge_status = $8
+			  AND confirmed_at > now() - $9 * interval '1 millisecond'
+			ORDER BY id DESC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+	`
+	var id int64
+	err := r.db.QueryRow(ctx, query, risk.CHALLENGE_USED, op.UserID, op.Type, op.AccountID, op.CounterpartyID,
+		op.CardID, op.Amount, risk.CHALLENGE_CONFIRMED, ttl.Milliseconds()).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &id, nil
+}
+
+// ConfirmChallenge отмечает ожидающую проверку пользователя подтвержденной.
+func (r *RiskRepository) ConfirmChallenge(ctx context.Context, id, userID int64) (bool, error) {
+	query := `
+		UPDATE risk_decisions
+		SET challenge_status = $1, confirmed_at = now()
+		WHERE id = $2 AND user_id = $3 AND challenge_status = $4
+	`
+	tag, err := r.db.Exec(ctx, query, risk.CHALLENGE_CONFIRMED, id, userID, risk.CHALLENGE_PENDING)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *RiskRepository) RecordCVVFailure(ctx context.Context, cardID int64) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO card_cvv_failures (card_id) VALUES ($1)`, cardID)
+	return err
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SchemaRepository читает состояние соединения и версию схемы БД для
+// проверки готовности.
+type SchemaRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSchemaRepository(db *pgxpool.Pool) *SchemaRepository {
+	return &SchemaRepository{db: db}
+}
+
+func (r *SchemaRepository) Ping(ctx context.Context) error {
+	return r.db.Ping(ctx)
+}
+
+// MigrationVersion возвращает версию из таблицы golang-migrate и признак
+// незавершенной миграции.
+func (r *SchemaRepository) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := r.db.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/therealadik/bank-api/internal/models/screening"
+)
+
+var ErrHitNotPending = errors.New("совпадение уже разобрано")
+
+const (
+	hitColumns = `id, subject_type, user_id, screened_name, entry_id, entry_name, list_source, score, matches,
+		held_transfer_id, status, review_comment, created_at, reviewed_at`
+
+	heldTransferColumns = `id, user_id, from_account_id, to_account_id, amount, status, failure_reason, created_at,
+		updated_at`
+)
+
+type ScreeningRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewScreeningRepository(db *pgxpool.Pool) *ScreeningRepository {
+	return &ScreeningRepository{db: db}
+}
+
+func scanHit(row pgx.Row) (*screening.Hit, error) {
+	var h screening.Hit
+	err := row.Scan(&h.ID, &h.SubjectType, &h.UserID, &h.ScreenedName, &h.EntryID, &h.EntryName, &h.ListSource,
+		&h.Score, &h.Matches, &h.HeldTransferID, &h.Status, &h.ReviewComment, &h.CreatedAt, &h.ReviewedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func scanHeldTransfer(row pgx.Row) (*screening.HeldTransfer, error) {
+	var t screening.HeldTransfer
+	err := row.Scan(&t.ID, &t.UserID, &t.FromAccountID, &t.ToAccountID, &t.Amount, &t.Status, &t.FailureReason,
+		&t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func insertHit(ctx context.Context, tx pgx.Tx, h *screening.Hit) error {
+	query := `
+		INSERT INTO screening_hits (subject_type, user_id, screened_name, entry_id, entry_name, list_source, score,
+		                            matches, held_transfer_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, status, created_at
+	`
+	return tx.QueryRow(ctx, query, h.SubjectType, h.UserID, h.ScreenedName, h.EntryID, h.EntryName, h.ListSource,
+		h.Score, h.Matches, h.HeldTransferID).Scan(&h.ID, &h.Status, &h.CreatedAt)
+}
+
+// CreateUserHit ставит совпадение по пользователю в очередь разбора.
+func (r *ScreeningRepository) CreateUserHit(ctx context.Context, h *screening.Hit) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = insertHit(ctx, tx, h); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// HoldTransfer задерживает перевод и ставит совпадение по получателю
+// в очередь разбора в одной транзакции.
+func (r *ScreeningRepository) HoldTransfer(ctx context.Context, t *screening.HeldTransfer,
+	h *screening.Hit) (*screening.HeldTransfer, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	held, err := holdTransfer(ctx, tx, t, h)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return held, nil
+}
+
+func holdTransfer(ctx context.Context, tx pgx.Tx, t *screening.HeldTransfer,
+	h *screening.Hit) (*screening.HeldTransfer, error) {
+	query := `
+		INSERT INTO held_transfers (user_id, from_account_id, to_account_id, amount)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + heldTransferColumns
+	held, err := scanHeldTransfer(tx.QueryRow(ctx, query, t.UserID, t.FromAccountID, t.ToAccountID, t.Amount))
+	if err != nil {
+		return nil, err
+	}
+
+	h.HeldTransferID = &held.ID
+	if err = insertHit(ctx, tx, h); err != nil {
+		return nil, err
+	}
+	return held, nil
+}
+
+// GetClearedEntries возвращает записи списка, совпадение с которыми
+// оператор уже признал ложным для этого пользователя.
+func (r *ScreeningRepository) GetClearedEntries(ctx context.Context, userID int64) (map[string]bool, error) {
+	query := `
+		SELECT DISTINCT list_source || ':' || entry_id
+		FROM screening_hits
+		WHERE user_id = $1 AND status = $2
+	`
+	rows, err := r.db.Query(ctx, query, userID, screening.CLEARED)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cleared := make(map[string]bool)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		cleared[key] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return cleared, nil
+}
+
+func (r *ScreeningRepository) GetHits(ctx context.Context, status screening.HitStatus, limit int) ([]*screening.Hit, error) {
+	query := `
+		SELECT ` + hitColumns + `
+		FROM screening_hits
+		WHERE $1 = '' OR status = $1
+		ORDER BY id
+		LIMIT $2
+	`
+	rows, err := r.db.Query(ctx, query, string(status), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []*screening.Hit
+	for rows.Next() {
+		h, err := scanHit(rows)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, h)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+func (r *ScreeningRepository) GetHit(ctx context.Context, id int64) (*screening.Hit, error) {
+	query := `
+		SELECT ` + hitColumns + `
+		FROM screening_hits
+		WHERE id = $1
+	`
+	return scanHit(r.db.QueryRow(ctx, query, id))
+}
+
+// ResolveHit фиксирует решение оператора и применяет его в той же транзакции:
+//   - пользователь снимается с удержания, когда разобраны все его совпадения,
+//     или блокируется при подтверждении;
+//   - задержанный перевод освобождается для исполнения или отклоняется,
+//     а получатель при подтверждении блокируется.
+func (r *ScreeningRepository) ResolveHit(ctx context.Context, id int64, status screening.HitStatus,
+	comment string) (*screening.Hit, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE screening_hits
+		SET status = $1, review_comment = $2, reviewed_at = now()
+		WHERE id = $3 AND status = $4
+		RETURNING ` + hitColumns
+	h, err := scanHit(tx.QueryRow(ctx, query, status, comment, id, screening.PENDING))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrHitNotPending
+		}
+		return nil, err
+	}
+
+	if status == screening.CONFIRMED {
+		if _, err = tx.Exec(ctx, `UPDATE users SET screening_status = $1 WHERE id = $2`,
+			screening.USER_BLOCKED, h.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	switch h.SubjectType {
+	case screening.USER:
+		if status == screening.CLEARED {
+			clearQuery := `
+				UPDATE users
+				SET screening_status = $1
+				WHERE id = $2 AND screening_status = $3
+				  AND NOT EXISTS (SELECT 1 FROM screening_hits WHERE user_id = $2 AND subject_type = $4 AND status = $5)
+			`
+			_, err = tx.Exec(ctx, clearQuery, screening.USER_CLEAR, h.UserID, screening.USER_HELD, screening.USER,
+				screening.PENDING)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	case screening.TRANSFER:
+		transferStatus := screening.RELEASED
+		if status == screening.CONFIRMED {
+			transferStatus = screening.REJECTED
+		}
+		transferQuery := `
+			UPDATE held_transfers
+			SET status = $1, updated_at = now()
+			WHERE id = $2 AND status = $3
+		`
+		if _, err = tx.Exec(ctx, transferQuery, transferStatus, h.HeldTransferID, screening.HELD); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (r *ScreeningRepository) GetHeldTransfer(ctx context.Context, id int64) (*screening.HeldTransfer, error) {
+	query := `
+		SELECT ` + heldTransferColumns + `
+		FROM held_transfers
+		WHERE id = $1
+	`
+	return scanHeldTransfer(r.db.QueryRow(ctx, query, id))
+}
+
+// ClaimReleasedTransfer переводит один освобожденный перевод в PROCESSING.
+// Возвращает pgx.ErrNoRows, если очередь пуста.
+func (r *ScreeningRepository) ClaimReleasedTransfer(ctx context.Context) (*screening.HeldTransfer, error) {
+	query := `
+		UPDATE held_transfers
+		SET status = $1, updated_at = now()
+		WHERE id = (
+			SELECT id
+			FROM held_transfers
+			WHERE status = $2
+			ORDER BY id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + heldTransferColumns
+	return scanHeldTransfer(r.db.QueryRow(ctx, query, screening.PROCESSING, screening.RELEASED))
+}
+
+func (r *ScreeningRepository) FinishHeldTransfer(ctx context.Context, id int64, status screening.TransferStatus,
+	reason string) error {
+	query := `
+		UPDATE held_transfers
+		SET status = $1, failure_reason = $2, updated_at = now()
+		WHERE id = $3
+	`
+	_, err := r.db.Exec(ctx, query, status, reason, id)
+	return err
+}
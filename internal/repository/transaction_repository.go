@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 	"github.com/therealadik/bank-api/internal/models/transaction"
@@ -33,6 +34,27 @@ func (r *TransactionRepository) CreateTransaction(ctx context.Context, accountID
 	return &tx, nil
 }
 
+// insertTransaction записывает проведенную операцию по счету в рамках транзакции tx.
+func insertTransaction(ctx context.Context, tx pgx.Tx, accountID int64, amount decimal.Decimal, txType transaction.Type) error {
+	query := `
+		INSERT INTO transactions (account_id, amount, type, status)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err := tx.Exec(ctx, query, accountID, amount, txType, transaction.COMPLETED)
+	return err
+}
+
+// insertInterest записывает зачисление процентов: операцию DEPOSIT
+// с отметкой interest, по которой ее пропускают сценарии AML.
+func insertInterest(ctx context.Context, tx pgx.Tx, accountID int64, amount decimal.Decimal) error {
+	query := `
+		INSERT INTO transactions (account_id, amount, type, status, interest)
+		VALUES ($1, $2, $3, $4, TRUE)
+	`
+	_, err := tx.Exec(ctx, query, accountID, amount, transaction.DEPOSIT, transaction.COMPLETED)
+	return err
+}
+
 func (r *TransactionRepository) GetTransactionsByAccountID(ctx context.Context, accountID int64) ([]*transaction.Transaction, error) {
 	query := `
 		SELECT id, account_id, amount, type, status, created_at
@@ -6,15 +6,21 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/therealadik/bank-api/internal/events"
 	"github.com/therealadik/bank-api/internal/models"
+	"github.com/therealadik/bank-api/internal/models/screening"
 )
 
 var ErrUserNotFound = errors.New("пользователь не найден")
 
 type UserRepository interface {
-	Create(ctx context.Context, user *models.User) (int64, error)
+	Create(ctx context.Context, user *models.User, hit *screening.Hit) (int64, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetByPhone(ctx context.Context, phone string) (*models.User, error)
 	GetByID(ctx context.Context, id int64) (*models.User, error)
+	UpdateContacts(ctx context.Context, id int64, phone *string, discoverable bool) (*models.User, error)
+	VerifyPhone(ctx context.Context, id int64, phone string) (*models.User, error)
+	SetScreeningStatus(ctx context.Context, id int64, status screening.UserStatus) error
 }
 
 type UserRepositoryPgx struct {
@@ -25,19 +31,46 @@ func NewUserRepository(pool *pgxpool.Pool) UserRepository {
 	return &UserRepositoryPgx{pool: pool}
 }
 
-func (r *UserRepositoryPgx) Create(ctx context.Context, user *models.User) (int64, error) {
+// Create сохраняет пользователя. Совпадение с санкционным списком hit, если
+// оно найдено при регистрации, ставится на разбор в той же транзакции.
+func (r *UserRepositoryPgx) Create(ctx context.Context, user *models.User, hit *screening.Hit) (int64, error) {
 	var id int64
 
-	err := r.pool.QueryRow(ctx,
-		`INSERT INTO users (email, password_hash) 
-         VALUES ($1, $2) 
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		`INSERT INTO users (email, password_hash, full_name, screening_status) 
+         VALUES ($1, $2, $3, $4) 
          RETURNING id`,
-		user.Email, user.Password).Scan(&id)
+		user.Email, user.Password, user.FullName, user.ScreeningStatus).Scan(&id)
+
+	if err != nil {
+		return 0, err
+	}
 
+	if hit != nil {
+		hit.UserID = id
+		if err = insertHit(ctx, tx, hit); err != nil {
+			return 0, err
+		}
+	}
+
+	err = enqueueEvent(ctx, tx, events.AggregateUser, id, events.USER_REGISTERED, events.UserRegistered{
+		UserID: id,
+		Email:  user.Email,
+	})
 	if err != nil {
 		return 0, err
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
 	return id, nil
 }
 
@@ -45,10 +78,31 @@ func (r *UserRepositoryPgx) GetByEmail(ctx context.Context, email string) (*mode
 	user := &models.User{}
 
 	err := r.pool.QueryRow(ctx,
-		`SELECT id, email, password_hash, created_at 
+		`SELECT id, email, password_hash, full_name, phone, phone_verified_at, discoverable, screening_status, kyc_status, email_verified_at, token_version, created_at 
          FROM users 
          WHERE email = $1`,
-		email).Scan(&user.ID, &user.Email, &user.Password, &user.CreatedAt)
+		email).Scan(&user.ID, &user.Email, &user.Password, &user.FullName, &user.Phone, &user.PhoneVerifiedAt,
+		&user.Discoverable, &user.ScreeningStatus, &user.KYCStatus, &user.EmailVerifiedAt, &user.TokenVersion, &user.CreatedAt)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *UserRepositoryPgx) GetByPhone(ctx context.Context, phone string) (*models.User, error) {
+	user := &models.User{}
+
+	err := r.pool.QueryRow(ctx,
+		`SELECT id, email, password_hash, full_name, phone, phone_verified_at, discoverable, screening_status, kyc_status, email_verified_at, token_version, created_at 
+         FROM users 
+         WHERE phone = $1 AND phone_verified_at IS NOT NULL`,
+		phone).Scan(&user.ID, &user.Email, &user.Password, &user.FullName, &user.Phone, &user.PhoneVerifiedAt,
+		&user.Discoverable, &user.ScreeningStatus, &user.KYCStatus, &user.EmailVerifiedAt, &user.TokenVersion, &user.CreatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -64,10 +118,30 @@ func (r *UserRepositoryPgx) GetByID(ctx context.Context, id int64) (*models.User
 	user := &models.User{}
 
 	err := r.pool.QueryRow(ctx,
-		`SELECT id, email, password_hash, created_at 
+		`SELECT id, email, password_hash, full_name, phone, phone_verified_at, discoverable, screening_status, kyc_status, email_verified_at, token_version, created_at 
          FROM users 
          WHERE id = $1`,
-		id).Scan(&user.ID, &user.Email, &user.Password, &user.CreatedAt)
+		id).Scan(&user.ID, &user.Email, &user.Password, &user.FullName, &user.Phone, &user.PhoneVerifiedAt,
+		&user.Discoverable, &user.ScreeningStatus, &user.KYCStatus, &user.EmailVerifiedAt, &user.TokenVersion, &user.CreatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *UserRepositoryPgx) UpdateContacts(ctx context.Context, id int64, phone *string, discoverable bool) (*models.User, error) {
+	user := &models.User{}
+
+	err := r.pool.QueryRow(ctx,
+		`UPDATE users
+         SET phone = $1, discoverable = $2,
+             phone_verified_at = CASE WHEN phone IS NOT DISTINCT FROM $1 THEN phone_verified_at END
+         WHERE id = $3
+         RETURNING id, email, password_hash, full_name, phone, phone_verified_at, discoverable, screening_status, kyc_status, email_verified_at, token_version, created_at`,
+		phone, discoverable, id).Scan(&user.ID, &user.Email, &user.Password, &user.FullName, &user.Phone, &user.PhoneVerifiedAt,
+		&user.Discoverable, &user.ScreeningStatus, &user.KYCStatus, &user.EmailVerifiedAt, &user.TokenVersion, &user.CreatedAt)
 
 	if err != nil {
 		return nil, err
@@ -75,3 +149,29 @@ func (r *UserRepositoryPgx) GetByID(ctx context.Context, id int64) (*models.User
 
 	return user, nil
 }
+
+// VerifyPhone отмечает номер подтвержденным, если у пользователя все еще
+// указан именно он. Подтвержденный номер уникален: занятый номер нарушает
+// уникальный индекс.
+func (r *UserRepositoryPgx) VerifyPhone(ctx context.Context, id int64, phone string) (*models.User, error) {
+	user := &models.User{}
+
+	err := r.pool.QueryRow(ctx,
+		`UPDATE users
+         SET phone_verified_at = now()
+         WHERE id = $1 AND phone = $2
+         RETURNING id, email, password_hash, full_name, phone, phone_verified_at, discoverable, screening_status, kyc_status, email_verified_at, token_version, created_at`,
+		id, phone).Scan(&user.ID, &user.Email, &user.Password, &user.FullName, &user.Phone, &user.PhoneVerifiedAt,
+		&user.Discoverable, &user.ScreeningStatus, &user.KYCStatus, &user.EmailVerifiedAt, &user.TokenVersion, &user.CreatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (r *UserRepositoryPgx) SetScreeningStatus(ctx context.Context, id int64, status screening.UserStatus) error {
+	_, err := r.pool.Exec(ctx, `UPDATE users SET screening_status = $1 WHERE id = $2`, status, id)
+	return err
+}
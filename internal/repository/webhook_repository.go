@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/therealadik/bank-api/internal/events"
+	"github.com/therealadik/bank-api/internal/models/webhook"
+)
+
+const (
+	subscriptionColumns = `id, user_id, url, secret, event_types, active, created_at`
+
+	deliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+		last_status_code, last_error, created_at, delivered_at`
+)
+
+type WebhookRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookRepository(db *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func scanSubscription(row pgx.Row) (*webhook.Subscription, error) {
+	var s webhook.Subscription
+	err := row.Scan(&s.ID, &s.UserID, &s.URL, &s.Secret, &s.EventTypes, &s.Active, &s.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func scanDelivery(row pgx.Row) (*webhook.Delivery, error) {
+	var d webhook.Delivery
+	err := row.Scan(&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastStatusCode, &d.LastError, &d.CreatedAt, &d.DeliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, s *webhook.Subscription) (*webhook.Subscription, error) {
+	query := `
+		INSERT INTO webhook_subscriptions (user_id, url, secret, event_types)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + subscriptionColumns
+	return scanSubscription(r.db.QueryRow(ctx, query, s.UserID, s.URL, s.Secret, s.EventTypes))
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id, userID int64) (*webhook.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM webhook_subscriptions
+		WHERE id = $1 AND user_id = $2
+	`
+	return scanSubscription(r.db.QueryRow(ctx, query, id, userID))
+}
+
+func (r *WebhookRepository) GetSubscriptionByID(ctx context.Context, id int64) (*webhook.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM webhook_subscriptions
+		WHERE id = $1
+	`
+	return scanSubscription(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *WebhookRepository) GetSubscriptionsByUserID(ctx context.Context, userID int64) ([]*webhook.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM webhook_subscriptions
+		WHERE user_id = $1
+		ORDER BY id
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*webhook.Subscription
+	for rows.Next() {
+		s, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id, userID int64) (bool, error) {
+	query := `
+		DELETE FROM webhook_subscriptions
+		WHERE id = $1 AND user_id = $2
+	`
+	tag, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// EnqueueEvent создает доставки события для всех активных подписок
+// владельцев затронутых счетов и карт. Повторная публикация того же события
+// не создает дублей.
+func (r *WebhookRepository) EnqueueEvent(ctx context.Context, e events.Event, payload []byte,
+	accountIDs, cardIDs []int64) (int, error) {
+	query := `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT s.id, $1, $2, $3
+		FROM webhook_subscriptions s
+		WHERE s.active
+		  AND (cardinality(s.event_types) = 0 OR $2 = ANY (s.event_types))
+		  AND s.user_id IN (
+			SELECT user_id FROM accounts WHERE id = ANY ($4)
+			UNION
+			SELECT user_id FROM cards WHERE id = ANY ($5)
+		  )
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+	tag, err := r.db.Exec(ctx, query, e.ID, string(e.Type), payload, accountIDs, cardIDs)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// ClaimDue выбирает доставки, время попытки которых наступило, и сдвигает
+// их следующую попытку на lease, чтобы другой экземпляр не отправил их
+// одновременно.
+func (r *WebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*webhook.Delivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = now() + $3 * interval '1 millisecond'
+		WHERE id IN (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = $1 AND next_attempt_at <= now()
+			ORDER BY next_attempt_at, id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns
+	rows, err := r.db.Query(ctx, query, webhook.PENDING, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*webhook.Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// RecordAttempt сохраняет результат попытки в журнал и обновляет состояние
+// доставки в одной транзакции.
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, d *webhook.Delivery, a *webhook.Attempt) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	attemptQuery := `
+		INSERT INTO webhook_attempts (delivery_id, status_code, error, duration_ms)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err = tx.Exec(ctx, attemptQuery, d.ID, a.StatusCode, a.Error, a.DurationMs); err != nil {
+		return err
+	}
+
+	deliveryQuery := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = $2, next_attempt_at = $3, last_status_code = $4, last_error = $5, delivered_at = $6
+		WHERE id = $7
+	`
+	_, err = tx.Exec(ctx, deliveryQuery, d.Status, d.Attempts, d.NextAttemptAt, d.LastStatusCode, d.LastError,
+		d.DeliveredAt, d.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, subscriptionID int64, status webhook.Status,
+	limit int) ([]*webhook.Delivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`
+	rows, err := r.db.Query(ctx, query, subscriptionID, string(status), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*webhook.Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetDelivery возвращает доставку, только если подписка принадлежит пользователю.
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id, userID int64) (*webhook.Delivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE id = $1 AND subscription_id IN (SELECT id FROM webhook_subscriptions WHERE user_id = $2)
+	`
+	return scanDelivery(r.db.QueryRow(ctx, query, id, userID))
+}
+
+func (r *WebhookRepository) GetAttempts(ctx context.Context, deliveryID int64) ([]*webhook.Attempt, error) {
+	query := `
+		SELECT id, delivery_id, status_code, error, duration_ms, created_at
+		FROM webhook_attempts
+		WHERE delivery_id = $1
+		ORDER BY id
+	`
+	rows, err := r.db.Query(ctx, query, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []*webhook.Attempt
+	for rows.Next() {
+		var a webhook.Attempt
+		if err := rows.Scan(&a.ID, &a.DeliveryID, &a.StatusCode, &a.Error, &a.DurationMs, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, &a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// Redeliver возвращает доставку в очередь с обнулением счетчика попыток,
+// в том числе из очереди недоставленных.
+func (r *WebhookRepository) Redeliver(ctx context.Context, id int64) (*webhook.Delivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1, attempts = 0, next_attempt_at = now(), delivered_at = NULL
+		WHERE id = $2
+		RETURNING ` + deliveryColumns
+	return scanDelivery(r.db.QueryRow(ctx, query, webhook.PENDING, id))
+}
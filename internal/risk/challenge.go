@@ -0,0 +1,11 @@
+package risk
+
+// ChallengeStatus — состояние дополнительной проверки по решению CHALLENGE.
+type ChallengeStatus string
+
+const (
+	CHALLENGE_PENDING   ChallengeStatus = "PENDING"
+	CHALLENGE_CONFIRMED ChallengeStatus = "CONFIRMED"
+	// CHALLENGE_USED — по подтвержденной проверке уже пропущена операция.
+	CHALLENGE_USED ChallengeStatus = "USED"
+)
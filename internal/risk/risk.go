@@ -0,0 +1,141 @@
+// Package risk оценивает операции перед исполнением. Движок суммирует баллы
+// сработавших правил и по порогам принимает решение: пропустить, запросить
+// дополнительное подтверждение или отклонить. Пороги можно менять на лету.
+package risk
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type Decision string
+
+const (
+	ALLOW     Decision = "ALLOW"
+	CHALLENGE Decision = "CHALLENGE"
+	BLOCK     Decision = "BLOCK"
+)
+
+type OperationType string
+
+const (
+	TRANSFER     OperationType = "TRANSFER"
+	CARD_PAYMENT OperationType = "CARD_PAYMENT"
+)
+
+const maxScore = 100
+
+// Operation — оцениваемая операция вместе с признаками, собранными из
+// истории пользователя.
+type Operation struct {
+	Type      OperationType
+	UserID    int64
+	AccountID int64
+	// CounterpartyID — счет получателя перевода.
+	CounterpartyID *int64
+	CardID         *int64
+	Amount         decimal.Decimal
+
+	// RecentOperations — число операций пользователя за окно скорости.
+	RecentOperations int
+	// HistoryCount и AverageAmount описывают проведенные списания со
+	// счетов пользователя за период истории.
+	HistoryCount  int
+	AverageAmount decimal.Decimal
+	// NewCounterparty — чужой счет не сохранен у пользователя получателем
+	// или сохранен позже, чем NewPayeeAge назад.
+	NewCounterparty bool
+	// CVVFailures — число неверных CVV по карте за окно.
+	CVVFailures int
+}
+
+// Thresholds — настраиваемые пороги правил и решений.
+type Thresholds struct {
+	VelocityWindow time.Duration
+	VelocityLimit  int
+	VelocityScore  int
+
+	HistoryWindow   time.Duration
+	SpikeMultiplier decimal.Decimal
+	SpikeMinHistory int
+	SpikeScore      int
+
+	NewPayeeAmount decimal.Decimal
+	NewPayeeScore  int
+	NewPayeeAge    time.Duration
+
+	CVVWindow       time.Duration
+	CVVFailureLimit int
+	CVVScore        int
+
+	ChallengeScore int
+	BlockScore     int
+}
+
+// Hit — сработавшее правило.
+type Hit struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Reason string `json:"reason"`
+}
+
+type Assessment struct {
+	Decision Decision `json:"decision"`
+	Score    int      `json:"score"`
+	Hits     []Hit    `json:"hits"`
+}
+
+// Rule — правило оценки. Возвращает nil, если не сработало.
+type Rule interface {
+	Evaluate(op *Operation, th *Thresholds) *Hit
+}
+
+type Engine struct {
+	rules      []Rule
+	thresholds atomic.Pointer[Thresholds]
+}
+
+func NewEngine(th Thresholds, rules ...Rule) *Engine {
+	e := &Engine{rules: rules}
+	e.thresholds.Store(&th)
+	return e
+}
+
+// DefaultRules — правила скорости, всплеска суммы, нового получателя
+// и повторных неверных CVV.
+func DefaultRules() []Rule {
+	return []Rule{VelocityRule{}, AmountSpikeRule{}, NewPayeeRule{}, CVVFailureRule{}}
+}
+
+func (e *Engine) Thresholds() Thresholds {
+	return *e.thresholds.Load()
+}
+
+// SetThresholds атомарно заменяет пороги; оценки, уже начатые, используют
+// прежние значения.
+func (e *Engine) SetThresholds(th Thresholds) {
+	e.thresholds.Store(&th)
+}
+
+func (e *Engine) Evaluate(op *Operation) Assessment {
+	th := e.thresholds.Load()
+
+	a := Assessment{Decision: ALLOW, Hits: []Hit{}}
+	for _, rule := range e.rules {
+		if hit := rule.Evaluate(op, th); hit != nil {
+			a.Hits = append(a.Hits, *hit)
+			a.Score += hit.Score
+		}
+	}
+	a.Score = min(a.Score, maxScore)
+
+	switch {
+	case a.Score >= th.BlockScore:
+		a.Decision = BLOCK
+	case a.Score >= th.ChallengeScore:
+		a.Decision = CHALLENGE
+	}
+	return a
+}
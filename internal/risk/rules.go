@@ -0,0 +1,65 @@
+package risk
+
+import "fmt"
+
+// VelocityRule срабатывает, если за окно скорости пользователь уже совершил
+// не меньше VelocityLimit операций.
+type VelocityRule struct{}
+
+func (VelocityRule) Evaluate(op *Operation, th *Thresholds) *Hit {
+	if th.VelocityLimit <= 0 || op.RecentOperations < th.VelocityLimit {
+		return nil
+	}
+	return &Hit{
+		Rule:   "velocity",
+		Score:  th.VelocityScore,
+		Reason: fmt.Sprintf("%d операций за %s", op.RecentOperations, th.VelocityWindow),
+	}
+}
+
+// AmountSpikeRule срабатывает, если сумма превышает среднюю сумму операций
+// пользователя в SpikeMultiplier раз. Без достаточной истории не применяется.
+type AmountSpikeRule struct{}
+
+func (AmountSpikeRule) Evaluate(op *Operation, th *Thresholds) *Hit {
+	if op.HistoryCount < th.SpikeMinHistory || op.HistoryCount == 0 || !op.AverageAmount.IsPositive() {
+		return nil
+	}
+	if op.Amount.LessThanOrEqual(op.AverageAmount.Mul(th.SpikeMultiplier)) {
+		return nil
+	}
+	return &Hit{
+		Rule:   "amount_spike",
+		Score:  th.SpikeScore,
+		Reason: fmt.Sprintf("сумма %s при средней %s", op.Amount.StringFixed(2), op.AverageAmount.StringFixed(2)),
+	}
+}
+
+// NewPayeeRule срабатывает на перевод крупной суммы недавно добавленному
+// получателю.
+type NewPayeeRule struct{}
+
+func (NewPayeeRule) Evaluate(op *Operation, th *Thresholds) *Hit {
+	if op.Type != TRANSFER || !op.NewCounterparty || op.Amount.LessThan(th.NewPayeeAmount) {
+		return nil
+	}
+	return &Hit{
+		Rule:   "new_payee",
+		Score:  th.NewPayeeScore,
+		Reason: fmt.Sprintf("новый получатель и сумма %s", op.Amount.StringFixed(2)),
+	}
+}
+
+// CVVFailureRule срабатывает на платеж по карте после повторных неверных CVV.
+type CVVFailureRule struct{}
+
+func (CVVFailureRule) Evaluate(op *Operation, th *Thresholds) *Hit {
+	if op.Type != CARD_PAYMENT || th.CVVFailureLimit <= 0 || op.CVVFailures < th.CVVFailureLimit {
+		return nil
+	}
+	return &Hit{
+		Rule:   "cvv_failures",
+		Score:  th.CVVScore,
+		Reason: fmt.Sprintf("%d неверных CVV за %s", op.CVVFailures, th.CVVWindow),
+	}
+}
@@ -0,0 +1,142 @@
+// Package sanctions загружает санкционные списки и ищет в них имена
+// с нечетким сравнением и транслитерацией кириллицы в латиницу.
+package sanctions
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	SourceOFAC = "OFAC_SDN"
+	SourceEU   = "EU_CONSOLIDATED"
+)
+
+var ErrUnknownFormat = errors.New("неизвестный формат санкционного списка")
+
+// Entry — запись санкционного списка: основное имя и псевдонимы.
+type Entry struct {
+	ID      string   `json:"id"`
+	Source  string   `json:"source"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+	Program string   `json:"program,omitempty"`
+}
+
+// LoadFile читает список в формате OFAC SDN CSV (*.csv) или сводного
+// списка ЕС в XML (*.xml).
+func LoadFile(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ParseOFAC(f)
+	case ".xml":
+		return ParseEU(f)
+	}
+	return nil, ErrUnknownFormat
+}
+
+// ParseOFAC разбирает sdn.csv: файл без заголовка, первые столбцы —
+// ent_num, SDN_Name, SDN_Type, Program. Пустые значения записаны как "-0-".
+func ParseOFAC(r io.Reader) ([]Entry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var entries []Entry
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ошибка чтения SDN CSV: %w", err)
+		}
+
+		if len(record) < 2 {
+			continue
+		}
+
+		name := ofacValue(record[1])
+		if name == "" {
+			continue
+		}
+
+		entry := Entry{
+			ID:     strings.TrimSpace(record[0]),
+			Source: SourceOFAC,
+			Name:   name,
+		}
+		if len(record) > 3 {
+			entry.Program = ofacValue(record[3])
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func ofacValue(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "-0-" {
+		return ""
+	}
+	return s
+}
+
+type euExport struct {
+	Entities []euEntity `xml:"sanctionEntity"`
+}
+
+type euEntity struct {
+	LogicalID  string `xml:"logicalId,attr"`
+	Regulation struct {
+		Programme string `xml:"programme,attr"`
+	} `xml:"regulation"`
+	Aliases []struct {
+		WholeName string `xml:"wholeName,attr"`
+	} `xml:"nameAlias"`
+}
+
+// ParseEU разбирает сводный список ЕС (FSF XML): имя берется из первого
+// nameAlias, остальные становятся псевдонимами.
+func ParseEU(r io.Reader) ([]Entry, error) {
+	var export euExport
+	if err := xml.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("ошибка чтения XML списка ЕС: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(export.Entities))
+	for _, e := range export.Entities {
+		var names []string
+		for _, a := range e.Aliases {
+			if name := strings.TrimSpace(a.WholeName); name != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+
+		entries = append(entries, Entry{
+			ID:      e.LogicalID,
+			Source:  SourceEU,
+			Name:    names[0],
+			Aliases: names[1:],
+			Program: e.Regulation.Programme,
+		})
+	}
+
+	return entries, nil
+}
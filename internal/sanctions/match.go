@@ -0,0 +1,236 @@
+package sanctions
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// translit — транслитерация кириллицы по упрощенной схеме ICAO, которая
+// используется в загранпаспортах РФ.
+var translit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "iu",
+	'я': "ia", 'і': "i", 'ї': "i", 'є': "e", 'ґ': "g",
+}
+
+// latinVariants сводит распространенные варианты латинского написания
+// к одному, чтобы "Yuri" и "Iurii" сравнивались как близкие.
+var latinVariants = strings.NewReplacer(
+	"yu", "iu", "ya", "ia", "yo", "e", "ye", "e", "ii", "i", "iy", "i", "yi", "i",
+	"y", "i", "ck", "k", "x", "ks", "w", "v", "ph", "f", "kh", "h", "ff", "v",
+)
+
+// Normalize приводит имя к токенам в нижнем регистре латиницей без
+// диакритики и знаков препинания.
+func Normalize(name string) []string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case translit[r] != "" || r == 'ъ' || r == 'ь':
+			b.WriteString(translit[r])
+		case r >= 'a' && r <= 'z':
+			b.WriteRune(r)
+		case unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsLetter(r):
+			b.WriteString(foldDiacritic(r))
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	tokens := strings.Fields(b.String())
+	for i, t := range tokens {
+		tokens[i] = latinVariants.Replace(t)
+	}
+	return tokens
+}
+
+func foldDiacritic(r rune) string {
+	switch r {
+	case 'á', 'à', 'â', 'ä', 'ã', 'å', 'ā':
+		return "a"
+	case 'é', 'è', 'ê', 'ë', 'ē':
+		return "e"
+	case 'í', 'ì', 'î', 'ï', 'ī':
+		return "i"
+	case 'ó', 'ò', 'ô', 'ö', 'õ', 'ø', 'ō':
+		return "o"
+	case 'ú', 'ù', 'û', 'ü', 'ū':
+		return "u"
+	case 'ç', 'č', 'ć':
+		return "c"
+	case 'š', 'ś':
+		return "s"
+	case 'ž', 'ź', 'ż':
+		return "z"
+	case 'ñ', 'ń':
+		return "n"
+	case 'ý', 'ÿ':
+		return "y"
+	}
+	return ""
+}
+
+// minNameTokens — сколько слов должно быть в более коротком имени, если
+// в длинном их не меньше. Иначе одно распространенное имя («Иван»)
+// совпадало бы с каждой записью, где оно встречается.
+const minNameTokens = 2
+
+// Similarity оценивает близость двух имен от 0 до 1 независимо от порядка
+// слов: для каждого слова более короткого имени берется лучшее совпадение
+// по Джаро — Винклеру, результат усредняется. Короткое имя должно покрывать
+// хотя бы половину слов длинного и состоять не меньше чем из minNameTokens
+// слов, поэтому имя из одного слова похоже только на имя из одного слова.
+func Similarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(a) < min(minNameTokens, len(b)) || 2*len(a) < len(b) {
+		return 0
+	}
+
+	var total float64
+	for _, ta := range a {
+		best := 0.0
+		for _, tb := range b {
+			best = max(best, jaroWinkler(ta, tb))
+		}
+		total += best
+	}
+
+	// Штраф за слова длинного имени, которым не нашлось пары.
+	coverage := float64(len(a)) / float64(len(b))
+	return total / float64(len(a)) * (0.85 + 0.15*coverage)
+}
+
+func jaroWinkler(s1, s2 string) float64 {
+	if s1 == s2 {
+		return 1
+	}
+
+	r1, r2 := []rune(s1), []rune(s2)
+	window := max(len(r1), len(r2))/2 - 1
+	window = max(window, 0)
+
+	m1 := make([]bool, len(r1))
+	m2 := make([]bool, len(r2))
+
+	matches := 0
+	for i := range r1 {
+		lo, hi := max(0, i-window), min(len(r2), i+window+1)
+		for j := lo; j < hi; j++ {
+			if !m2[j] && r1[i] == r2[j] {
+				m1[i], m2[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, k := 0, 0
+	for i := range r1 {
+		if !m1[i] {
+			continue
+		}
+		for !m2[k] {
+			k++
+		}
+		if r1[i] != r2[k] {
+			transpositions++
+		}
+		k++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(r1)) + m/float64(len(r2)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for i := 0; i < min(4, len(r1), len(r2)) && r1[i] == r2[i]; i++ {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// Match — совпадение имени с записью списка.
+type Match struct {
+	Entry       Entry   `json:"entry"`
+	MatchedName string  `json:"matched_name"`
+	Score       float64 `json:"score"`
+}
+
+type indexedName struct {
+	entry  int
+	name   string
+	tokens []string
+}
+
+type index struct {
+	entries []Entry
+	names   []indexedName
+}
+
+// Screener ищет имена в загруженном списке. Список заменяется атомарно,
+// поэтому перезагрузка не блокирует проверки.
+type Screener struct {
+	threshold float64
+	idx       atomic.Pointer[index]
+}
+
+func NewScreener(threshold float64) *Screener {
+	s := &Screener{threshold: threshold}
+	s.idx.Store(&index{})
+	return s
+}
+
+func (s *Screener) Load(entries []Entry) {
+	idx := &index{entries: entries}
+	for i, e := range entries {
+		for _, name := range append([]string{e.Name}, e.Aliases...) {
+			idx.names = append(idx.names, indexedName{entry: i, name: name, tokens: Normalize(name)})
+		}
+	}
+	s.idx.Store(idx)
+}
+
+func (s *Screener) Size() int {
+	return len(s.idx.Load().entries)
+}
+
+// Screen возвращает записи, похожие на имя не меньше порога, по убыванию
+// оценки. Для записи учитывается лучший из ее вариантов имени.
+func (s *Screener) Screen(name string) []Match {
+	tokens := Normalize(name)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	idx := s.idx.Load()
+	best := make(map[int]Match)
+	for _, n := range idx.names {
+		score := Similarity(tokens, n.tokens)
+		if score < s.threshold {
+			continue
+		}
+		if m, ok := best[n.entry]; !ok || score > m.Score {
+			best[n.entry] = Match{Entry: idx.entries[n.entry], MatchedName: n.name, Score: score}
+		}
+	}
+
+	matches := make([]Match, 0, len(best))
+	for _, m := range best {
+		matches = append(matches, m)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	return matches
+}
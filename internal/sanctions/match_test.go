@@ -0,0 +1,83 @@
+package sanctions
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"Юрий Петров", []string{"iuri", "petrov"}},
+		{"Yuri PETROV", []string{"iuri", "petrov"}},
+		{"Щукин-Хазов, Ёжик", []string{"shchukin", "hazov", "ezhik"}},
+		{"José Müller", []string{"jose", "muller"}},
+		{"  ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.name); !slices.Equal(got, tt.want) {
+				t.Errorf("Normalize(%q) = %q, ожидалось %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScreen(t *testing.T) {
+	s := NewScreener(0.9)
+	s.Load([]Entry{
+		{ID: "1", Source: SourceOFAC, Name: "Ivan Sergeevich Petrov", Aliases: []string{"Ivan Petrof"}},
+		{ID: "2", Source: SourceEU, Name: "Muammar Gaddafi", Aliases: []string{"Qadhafi"}},
+		{ID: "3", Source: SourceEU, Name: "Ahmed Al-Rashid Mohammed Hassan Ibrahim"},
+	})
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"Иван Сергеевич Петров", []string{"1"}},
+		{"Петров Иван", []string{"1"}},
+		{"Ivan Petrov", []string{"1"}},
+		{"Muammar Qaddafi", []string{"2"}},
+		{"Qadhafi", []string{"2"}},
+		// Одно распространенное имя не совпадает с записью из нескольких слов.
+		{"Иван", nil},
+		{"Ivan", nil},
+		{"Petrov", nil},
+		{"Ahmed", nil},
+		{"Иван Сидоров", nil},
+		{"Мария Петрова", nil},
+		// Два слова из шести — слишком слабое покрытие записи.
+		{"Ahmed Hassan", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, m := range s.Screen(tt.name) {
+				got = append(got, m.Entry.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Screen(%q) = %v, ожидалось %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimilaritySymmetric(t *testing.T) {
+	pairs := [][2]string{
+		{"Ivan Petrov", "Ivan Sergeevich Petrov"},
+		{"Qadhafi", "Muammar Gaddafi"},
+		{"Юрий Петров", "Yuri Petrov"},
+	}
+	for _, p := range pairs {
+		a, b := Normalize(p[0]), Normalize(p[1])
+		if Similarity(a, b) != Similarity(b, a) {
+			t.Errorf("Similarity(%q, %q) несимметрична", p[0], p[1])
+		}
+	}
+}
@@ -3,43 +3,195 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/iban"
+	"github.com/therealadik/bank-api/internal/metrics"
 	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/fee"
+	"github.com/therealadik/bank-api/internal/models/screening"
 	"github.com/therealadik/bank-api/internal/models/transaction"
 	"github.com/therealadik/bank-api/internal/repository"
+	"github.com/therealadik/bank-api/internal/tracing"
 )
 
 var (
 	ErrInsufficientFunds = errors.New("недостаточно средств")
 	ErrSameAccount       = errors.New("нельзя переводить деньги на тот же счет")
 	ErrNegativeAmount    = errors.New("сумма не может быть отрицательной")
+	ErrZeroAmount        = errors.New("сумма должна быть отлична от нуля")
+	ErrUnknownProduct    = errors.New("неизвестный тип счета")
+	ErrDepositProduct    = errors.New("срочный вклад открывается через /api/deposits")
+	ErrAccountLocked     = errors.New("средства срочного вклада заблокированы до даты погашения")
+
+	ErrUnsupportedCurrency = errors.New("нет курса для конвертации валюты")
+	ErrCurrencyMismatch    = errors.New("валюта счета получателя отличается от валюты счета списания")
+
+	ErrInvalidAccountNumber = errors.New("неверный номер счета")
+	ErrInvalidAccountRef    = errors.New("неверный идентификатор счета")
+	ErrAccountNotFound      = errors.New("счет не найден")
 )
 
+const maxAccountNumberAttempts = 5
+
 type AccountService struct {
 	accountRepo     *repository.AccountRepository
 	transactionRepo *repository.TransactionRepository
+	feeService      *FeeService
+	approvalRepo    *repository.ApprovalRepository
+	riskService     *RiskService
+	screening       *ScreeningService
+	kycService      *KYCService
+	products        map[account.Product]config.ProductTerms
+	numberGen       iban.Generator
+	fxRates         map[account.Currency]decimal.Decimal
 }
 
-func NewAccountService(accountRepo *repository.AccountRepository, transactionRepo *repository.TransactionRepository) *AccountService {
+func NewAccountService(accountRepo *repository.AccountRepository, transactionRepo *repository.TransactionRepository,
+	feeService *FeeService, approvalRepo *repository.ApprovalRepository, riskService *RiskService,
+	screeningService *ScreeningService, kycService *KYCService, products map[account.Product]config.ProductTerms, numberCfg config.AccountNumberConfig,
+	fxCfg config.FXConfig) *AccountService {
 	return &AccountService{
 		accountRepo:     accountRepo,
 		transactionRepo: transactionRepo,
+		feeService:      feeService,
+		approvalRepo:    approvalRepo,
+		riskService:     riskService,
+		screening:       screeningService,
+		kycService:      kycService,
+		products:        products,
+		numberGen: iban.Generator{
+			CountryCode: numberCfg.CountryCode,
+			BankCode:    numberCfg.BankCode,
+		},
+		fxRates: fxCfg.Rates,
 	}
 }
 
-func (s *AccountService) CreateAccount(ctx context.Context, userID int64, currency account.Currency) (*account.Account, error) {
-	return s.accountRepo.CreateAccount(ctx, userID, currency)
+func (s *AccountService) CreateAccount(ctx context.Context, userID int64, currency account.Currency, product account.Product) (*account.Account, error) {
+	if product == account.DEPOSIT {
+		return nil, ErrDepositProduct
+	}
+
+	terms, ok := s.products[product]
+	if !ok {
+		return nil, ErrUnknownProduct
+	}
+
+	return s.createAccount(ctx, userID, currency, product, terms)
+}
+
+// createAccount открывает счет с новым внешним номером.
+func (s *AccountService) createAccount(ctx context.Context, userID int64, currency account.Currency, product account.Product,
+	terms config.ProductTerms) (*account.Account, error) {
+	var acc *account.Account
+	err := s.withNewAccountNumber(func(number string) error {
+		var err error
+		acc, err = s.accountRepo.CreateAccount(ctx, userID, number, currency, product, terms.InterestRate, terms.DayCount)
+		return err
+	})
+	return acc, err
+}
+
+// withNewAccountNumber вызывает create с новым внешним номером счета. При редкой
+// коллизии случайного номера попытка повторяется с другим номером.
+func (s *AccountService) withNewAccountNumber(create func(number string) error) error {
+	for attempt := 0; ; attempt++ {
+		number, err := s.numberGen.Generate()
+		if err != nil {
+			return fmt.Errorf("ошибка генерации номера счета: %w", err)
+		}
+
+		err = create(number)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && attempt < maxAccountNumberAttempts {
+			continue
+		}
+		return err
+	}
+}
+
+// AssignMissingNumbers выдает внешние номера счетам, открытым до их появления.
+func (s *AccountService) AssignMissingNumbers(ctx context.Context) (int, error) {
+	accounts, err := s.accountRepo.GetAccountsWithoutNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	assigned := 0
+	for _, acc := range accounts {
+		number, err := s.numberGen.Generate()
+		if err != nil {
+			return assigned, fmt.Errorf("ошибка генерации номера счета: %w", err)
+		}
+
+		if err := s.accountRepo.SetAccountNumber(ctx, acc.ID, number); err != nil {
+			return assigned, fmt.Errorf("ошибка присвоения номера счету %d: %w", acc.ID, err)
+		}
+		assigned++
+	}
+
+	return assigned, nil
+}
+
+// ResolveAccountRef принимает внутренний ID счета или его внешний номер
+// и возвращает ID. Контрольные цифры номера проверяются до обращения к БД.
+func (s *AccountService) ResolveAccountRef(ctx context.Context, ref string) (int64, error) {
+	if iban.LooksLikeNumber(ref) {
+		return s.ResolveAccountID(ctx, 0, ref)
+	}
+
+	id, err := strconv.ParseInt(ref, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAccountRef
+	}
+	return id, nil
 }
 
-func (s *AccountService) GetAccountByID(ctx context.Context, id int64, userID int64) (*account.Account, error) {
+// ResolveAccountID возвращает id, если он задан, иначе ищет счет по внешнему номеру.
+func (s *AccountService) ResolveAccountID(ctx context.Context, id int64, number string) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "AccountService.ResolveAccountID")
+	defer func() { tracing.End(span, err) }()
+
+	if id != 0 || number == "" {
+		return id, nil
+	}
+
+	number = iban.Normalize(number)
+	if err := iban.Validate(number); err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidAccountNumber, err)
+	}
+
+	acc, err := s.accountRepo.GetAccountByNumber(ctx, number)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrAccountNotFound
+		}
+		return 0, err
+	}
+	return acc.ID, nil
+}
+
+func (s *AccountService) GetAccountByID(ctx context.Context, id int64, userID int64) (_ *account.Account, err error) {
+	ctx, span := tracing.Start(ctx, "AccountService.GetAccountByID")
+	defer func() { tracing.End(span, err) }()
+
 	acc, err := s.accountRepo.GetAccountByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAccountNotFound
+		}
 		return nil, err
 	}
 
+	// Чужой счет неотличим от несуществующего.
 	if acc.UserID != userID {
-		return nil, errors.New("счет не принадлежит пользователю")
+		return nil, ErrAccountNotFound
 	}
 
 	return acc, nil
@@ -51,7 +203,7 @@ func (s *AccountService) GetAccountsByUserID(ctx context.Context, userID int64)
 
 func (s *AccountService) UpdateBalance(ctx context.Context, id int64, userID int64, amount decimal.Decimal) error {
 	if amount.Equal(decimal.Zero) {
-		return errors.New("сумма должна быть отлична от нуля")
+		return ErrZeroAmount
 	}
 
 	acc, err := s.GetAccountByID(ctx, id, userID)
@@ -59,27 +211,183 @@ func (s *AccountService) UpdateBalance(ctx context.Context, id int64, userID int
 		return err
 	}
 
-	if amount.LessThan(decimal.Zero) && acc.Balance.Add(amount).LessThan(decimal.Zero) {
-		return ErrInsufficientFunds
+	if acc.Product == account.DEPOSIT {
+		return ErrAccountLocked
 	}
 
-	txType := transaction.WITHDRAWAL
-	if amount.GreaterThan(decimal.Zero) {
-		txType = transaction.DEPOSIT
+	if amount.LessThan(decimal.Zero) && acc.Available().Add(amount).LessThan(decimal.Zero) {
+		return ErrInsufficientFunds
 	}
 
 	err = s.accountRepo.UpdateBalance(ctx, id, amount)
+	if errors.Is(err, repository.ErrLimitExceeded) {
+		return ErrInsufficientFunds
+	}
+	return err
+}
+
+// Transfer переводит средства между счетами с удержанием комиссии по тарифу.
+// Если передана expectedFee, перевод выполняется только при совпадении
+// с рассчитанной комиссией, показанной пользователю заранее.
+func (s *AccountService) Transfer(ctx context.Context, fromID, toID int64, userID int64, amount decimal.Decimal,
+	expectedFee decimal.NullDecimal) (_ *fee.Quote, err error) {
+	ctx, span := tracing.Start(ctx, "AccountService.Transfer")
+	defer func() { tracing.End(span, err) }()
+
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrNegativeAmount
+	}
+
+	// Перевод с чужого счета отклоняется до оценки: иначе он попадал бы
+	// в историю антифрода и задерживался бы проверкой получателя.
+	if _, err := s.GetAccountByID(ctx, fromID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.assessTransfer(ctx, fromID, toID, userID, amount); err != nil {
+		return nil, err
+	}
+
+	required, err := s.approvalRepo.RequiredApprovals(ctx, fromID, amount)
 	if err != nil {
+		return nil, err
+	}
+
+	if required > 0 {
+		return nil, ErrApprovalRequired
+	}
+
+	return s.transferWithFee(ctx, fromID, toID, userID, amount, expectedFee, 0)
+}
+
+// assessTransfer проверяет, что крупный перевод отправляет клиент
+// с подтвержденной личностью, оценивает перевод правилами антифрода и
+// проверяет получателя по санкционным спискам до исполнения. Перевод на тот
+// же счет отклоняется без оценки, чтобы не засорять историю.
+func (s *AccountService) assessTransfer(ctx context.Context, fromID, toID int64, userID int64, amount decimal.Decimal) (err error) {
+	ctx, span := tracing.Start(ctx, "AccountService.assessTransfer")
+	defer func() { tracing.End(span, err) }()
+
+	if fromID == toID {
+		return ErrSameAccount
+	}
+
+	if err := s.kycService.CheckTransfer(ctx, userID, amount); err != nil {
+		return err
+	}
+
+	if err := s.riskService.AssessTransfer(ctx, userID, fromID, toID, amount); err != nil {
 		return err
 	}
+	return s.screening.ScreenTransfer(ctx, userID, fromID, toID, amount)
+}
 
-	absAmount := amount.Abs()
-	_, err = s.transactionRepo.CreateTransaction(ctx, id, absAmount, txType, transaction.COMPLETED)
+// ProcessReleasedTransfers исполняет переводы, освобожденные оператором
+// после разбора совпадения. Антифрод и проверка получателя повторно не
+// выполняются. Перевод, которому по политике счета требуются подтверждения,
+// не исполняется: его нужно отправить повторно через /api/approvals.
+func (s *AccountService) ProcessReleasedTransfers(ctx context.Context) (int, error) {
+	processed := 0
+	for {
+		t, err := s.screening.ClaimReleasedTransfer(ctx)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return processed, nil
+			}
+			return processed, err
+		}
 
-	return err
+		status, reason := screening.EXECUTED, ""
+		required, err := s.approvalRepo.RequiredApprovals(ctx, t.FromAccountID, t.Amount)
+		switch {
+		case err != nil:
+			status, reason = screening.FAILED, err.Error()
+		case required > 0:
+			status, reason = screening.FAILED, ErrApprovalRequired.Error()
+		default:
+			if _, err := s.transferWithFee(ctx, t.FromAccountID, t.ToAccountID, t.UserID, t.Amount,
+				decimal.NullDecimal{}, 0); err != nil {
+				status, reason = screening.FAILED, err.Error()
+			}
+		}
+
+		if err := s.screening.FinishHeldTransfer(ctx, t.ID, status, reason); err != nil {
+			return processed, fmt.Errorf("ошибка сохранения перевода %d: %w", t.ID, err)
+		}
+		processed++
+	}
+}
+
+// transferWithFee выполняет перевод без проверки политики подтверждений.
+// Используется для платежей, уже одобренных по схеме maker-checker: для них
+// передается paymentID, и перевод фиксируется вместе со статусом платежа.
+// Перевод на счет в другой валюте тарифицируется как конвертация.
+func (s *AccountService) transferWithFee(ctx context.Context, fromID, toID int64, userID int64, amount decimal.Decimal,
+	expectedFee decimal.NullDecimal, paymentID int64) (*fee.Quote, error) {
+	operation, err := s.transferOperation(ctx, fromID, toID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := s.feeService.Quote(ctx, userID, operation, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckQuote(quote, expectedFee); err != nil {
+		return quote, err
+	}
+
+	return quote, s.transfer(ctx, fromID, toID, userID, amount, quote, paymentID)
+}
+
+// transferOperation выбирает тариф перевода по валютам счетов.
+func (s *AccountService) transferOperation(ctx context.Context, fromID, toID, userID int64) (fee.Operation, error) {
+	fromAcc, err := s.GetAccountByID(ctx, fromID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	toAcc, err := s.accountRepo.GetAccountByID(ctx, toID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrAccountNotFound
+		}
+		return "", err
+	}
+
+	if fromAcc.Currency != toAcc.Currency {
+		return fee.FX_CONVERSION, nil
+	}
+	return fee.TRANSFER, nil
+}
+
+// convert пересчитывает сумму по курсам к рублю с округлением до копеек.
+func convert(rates map[account.Currency]decimal.Decimal, amount decimal.Decimal, from, to account.Currency) (decimal.Decimal, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	fromRate, ok := rates[from]
+	if !ok {
+		return decimal.Zero, ErrUnsupportedCurrency
+	}
+	toRate, ok := rates[to]
+	if !ok {
+		return decimal.Zero, ErrUnsupportedCurrency
+	}
+
+	return amount.Mul(fromRate).Div(toRate).RoundBank(2), nil
 }
 
-func (s *AccountService) Transfer(ctx context.Context, fromID, toID int64, userID int64, amount decimal.Decimal) error {
+// transfer выполняет перевод. Счета срочных вкладов пополняются и закрываются
+// только через DepositService. Ненулевой paymentID исполняет одобренный платеж: перевод и статус EXECUTED
+// фиксируются одной транзакцией.
+func (s *AccountService) transfer(ctx context.Context, fromID, toID int64, userID int64, amount decimal.Decimal,
+	quote *fee.Quote, paymentID int64) (err error) {
+	ctx, span := tracing.Start(ctx, "AccountService.transfer")
+	defer func() { tracing.End(span, err) }()
+
 	if fromID == toID {
 		return ErrSameAccount
 	}
@@ -93,27 +401,47 @@ func (s *AccountService) Transfer(ctx context.Context, fromID, toID int64, userI
 		return err
 	}
 
-	if fromAcc.Balance.LessThan(amount) {
+	debit := amount
+	if quote != nil {
+		debit = quote.Total
+	}
+
+	if fromAcc.Available().LessThan(debit) {
 		return ErrInsufficientFunds
 	}
 
-	_, err = s.accountRepo.GetAccountByID(ctx, toID)
+	toAcc, err := s.accountRepo.GetAccountByID(ctx, toID)
 	if err != nil {
 		return err
 	}
 
-	err = s.accountRepo.TransferBetweenAccounts(ctx, fromID, toID, amount)
+	if fromAcc.Product == account.DEPOSIT || toAcc.Product == account.DEPOSIT {
+		return ErrAccountLocked
+	}
+
+	credit, err := convert(s.fxRates, amount, fromAcc.Currency, toAcc.Currency)
 	if err != nil {
 		return err
 	}
+	if !credit.IsPositive() {
+		return ErrNegativeAmount
+	}
 
-	_, err = s.transactionRepo.CreateTransaction(ctx, fromID, amount, transaction.DEPOSIT, transaction.COMPLETED)
+	if paymentID != 0 {
+		err = s.approvalRepo.ExecutePayment(ctx, paymentID, userID, amount, credit, quote)
+	} else {
+		err = s.accountRepo.TransferBetweenAccounts(ctx, fromID, toID, userID, amount, credit, quote)
+	}
 	if err != nil {
+		if errors.Is(err, repository.ErrLimitExceeded) {
+			return ErrInsufficientFunds
+		}
 		return err
 	}
 
-	_, err = s.transactionRepo.CreateTransaction(ctx, toID, amount, transaction.WITHDRAWAL, transaction.COMPLETED)
-	return err
+	metrics.TransfersTotal.WithLabelValues(string(fromAcc.Currency)).Inc()
+	metrics.TransferAmountTotal.WithLabelValues(string(fromAcc.Currency)).Add(amount.InexactFloat64())
+	return nil
 }
 
 func (s *AccountService) GetTransactionsByAccountID(ctx context.Context, accountID int64, userID int64) ([]*transaction.Transaction, error) {
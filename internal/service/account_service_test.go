@@ -0,0 +1,44 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models/account"
+)
+
+func TestConvert(t *testing.T) {
+	rates := map[account.Currency]decimal.Decimal{
+		account.RUB: decimal.NewFromInt(1),
+		account.USD: decimal.RequireFromString("92.5"),
+		account.EUR: decimal.RequireFromString("100.1"),
+	}
+
+	tests := []struct {
+		name     string
+		amount   string
+		from, to account.Currency
+		want     string
+		wantErr  error
+	}{
+		{"та же валюта", "123.45", account.USD, account.USD, "123.45", nil},
+		{"в рубли", "10", account.USD, account.RUB, "925", nil},
+		{"из рублей", "925", account.RUB, account.USD, "10", nil},
+		{"кросс-курс", "100", account.USD, account.EUR, "92.41", nil},
+		{"округление до копеек", "1", account.RUB, account.EUR, "0.01", nil},
+		{"нет курса", "1", account.Currency("GBP"), account.RUB, "0", ErrUnsupportedCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convert(rates, decimal.RequireFromString(tt.amount), tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ошибка %v, ожидалось %v", err, tt.wantErr)
+			}
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("convert(%s %s → %s) = %s, ожидалось %s", tt.amount, tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
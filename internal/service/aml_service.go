@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/models/aml"
+	"github.com/therealadik/bank-api/internal/models/transaction"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+const maxAMLCasesPage = 100
+
+var (
+	ErrAMLCaseNotFound      = errors.New("кейс не найден")
+	ErrAMLCaseNotOpen       = errors.New("кейс уже разобран")
+	ErrAMLCaseNotEscalated  = errors.New("сообщение формируется только по эскалированному кейсу")
+	ErrUnknownAMLCaseStatus = errors.New("неизвестный статус кейса")
+)
+
+// AMLService прогоняет сценарии мониторинга по операциям, заводит кейсы для
+// разбора операторами и формирует сообщения о подозрительных операциях.
+type AMLService struct {
+	amlRepo     *repository.AMLRepository
+	kycRepo     *repository.KYCRepository
+	accountRepo *repository.AccountRepository
+	cfg         config.AMLConfig
+}
+
+func NewAMLService(amlRepo *repository.AMLRepository, kycRepo *repository.KYCRepository,
+	accountRepo *repository.AccountRepository, cfg config.AMLConfig) *AMLService {
+	return &AMLService{
+		amlRepo:     amlRepo,
+		kycRepo:     kycRepo,
+		accountRepo: accountRepo,
+		cfg:         cfg,
+	}
+}
+
+// RunScenarios прогоняет все сценарии и заводит кейсы по срабатываниям.
+// Возвращает число заведенных кейсов.
+func (s *AMLService) RunScenarios(ctx context.Context) (int, error) {
+	low := s.cfg.StructuringThreshold.Mul(decimal.NewFromInt(1).Sub(s.cfg.StructuringMargin))
+
+	scenarios := []struct {
+		scenario aml.Scenario
+		find     func() ([]*aml.Detection, error)
+		summary  func(d *aml.Detection) string
+	}{
+		{
+			scenario: aml.STRUCTURING,
+			find: func() ([]*aml.Detection, error) {
+				return s.amlRepo.FindStructuring(ctx, low, s.cfg.StructuringThreshold, s.cfg.StructuringWindow,
+					s.cfg.StructuringMinCount)
+			},
+			summary: func(d *aml.Detection) string {
+				return fmt.Sprintf("%d операций на суммы от %s до %s за %s, всего %s",
+					d.Count, low.StringFixed(2), s.cfg.StructuringThreshold.StringFixed(2), s.cfg.StructuringWindow,
+					d.Total.StringFixed(2))
+			},
+		},
+		{
+			scenario: aml.RAPID_MOVEMENT,
+			find: func() ([]*aml.Detection, error) {
+				return s.amlRepo.FindRapidMovement(ctx, s.cfg.RapidWindow, s.cfg.RapidMinAmount, s.cfg.RapidOutRatio)
+			},
+			summary: func(d *aml.Detection) string {
+				return fmt.Sprintf("поступления списаны со счета в течение %s: %d операций, оборот %s",
+					s.cfg.RapidWindow, d.Count, d.Total.StringFixed(2))
+			},
+		},
+		{
+			scenario: aml.DORMANT_REACTIVATION,
+			find: func() ([]*aml.Detection, error) {
+				return s.amlRepo.FindDormantReactivation(ctx, s.cfg.DormantPeriod, s.cfg.DormantWindow,
+					s.cfg.DormantMinAmount)
+			},
+			summary: func(d *aml.Detection) string {
+				return fmt.Sprintf("%d операций на %s после простоя счета более %s",
+					d.Count, d.Total.StringFixed(2), s.cfg.DormantPeriod)
+			},
+		},
+	}
+
+	created := 0
+	for _, sc := range scenarios {
+		detections, err := sc.find()
+		if err != nil {
+			return created, fmt.Errorf("ошибка сценария %s: %w", sc.scenario, err)
+		}
+
+		for _, d := range detections {
+			c, err := s.amlRepo.CreateCase(ctx, d, sc.summary(d))
+			if err != nil {
+				return created, fmt.Errorf("ошибка создания кейса %s по счету %d: %w", sc.scenario, d.AccountID, err)
+			}
+			if c != nil {
+				created++
+			}
+		}
+	}
+
+	return created, nil
+}
+
+func (s *AMLService) GetCases(ctx context.Context, status aml.CaseStatus) ([]*aml.Case, error) {
+	switch status {
+	case "", aml.OPEN, aml.DISMISSED, aml.ESCALATED, aml.REPORTED:
+	default:
+		return nil, ErrUnknownAMLCaseStatus
+	}
+	return s.amlRepo.GetCases(ctx, status, maxAMLCasesPage)
+}
+
+// GetCase возвращает кейс вместе со связанными операциями.
+func (s *AMLService) GetCase(ctx context.Context, id int64) (*aml.Case, []*transaction.Transaction, error) {
+	c, err := s.amlRepo.GetCase(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrAMLCaseNotFound
+		}
+		return nil, nil, err
+	}
+
+	transactions, err := s.amlRepo.GetCaseTransactions(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, transactions, nil
+}
+
+// Escalate признает операции подозрительными; по кейсу можно сформировать
+// сообщение через ExportSAR.
+func (s *AMLService) Escalate(ctx context.Context, id int64, comment string) (*aml.Case, error) {
+	return s.transition(ctx, id, aml.OPEN, aml.ESCALATED, comment, ErrAMLCaseNotOpen)
+}
+
+// Dismiss закрывает кейс как ложное срабатывание.
+func (s *AMLService) Dismiss(ctx context.Context, id int64, comment string) (*aml.Case, error) {
+	return s.transition(ctx, id, aml.OPEN, aml.DISMISSED, comment, ErrAMLCaseNotOpen)
+}
+
+func (s *AMLService) transition(ctx context.Context, id int64, from, to aml.CaseStatus, comment string,
+	conflict error) (*aml.Case, error) {
+	c, err := s.amlRepo.UpdateStatus(ctx, id, from, to, comment)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		if _, err := s.amlRepo.GetCase(ctx, id); errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAMLCaseNotFound
+		}
+		return nil, conflict
+	}
+	return c, nil
+}
+
+// SuspiciousActivityReport — выгружаемое сообщение о подозрительной операции.
+type SuspiciousActivityReport struct {
+	ReportID     string              `json:"report_id"`
+	GeneratedAt  time.Time           `json:"generated_at"`
+	CaseID       int64               `json:"case_id"`
+	Scenario     aml.Scenario        `json:"scenario"`
+	Summary      string              `json:"summary"`
+	Narrative    string              `json:"narrative"`
+	DetectedAt   time.Time           `json:"detected_at"`
+	Subject      SARSubject          `json:"subject"`
+	Account      SARAccount          `json:"account"`
+	TotalAmount  decimal.Decimal     `json:"total_amount"`
+	Transactions []SARTransactionRow `json:"transactions"`
+}
+
+type SARSubject struct {
+	UserID      int64   `json:"user_id"`
+	FullName    *string `json:"full_name"`
+	DateOfBirth *string `json:"date_of_birth"`
+	Citizenship string  `json:"citizenship"`
+	Address     string  `json:"address"`
+	Phone       *string `json:"phone"`
+}
+
+type SARAccount struct {
+	ID       int64   `json:"id"`
+	Number   *string `json:"number"`
+	Currency string  `json:"currency"`
+}
+
+type SARTransactionRow struct {
+	ID        int64            `json:"id"`
+	Type      transaction.Type `json:"type"`
+	Amount    decimal.Decimal  `json:"amount"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// ExportSAR формирует сообщение о подозрительной операции в JSON. Первая
+// выгрузка переводит эскалированный кейс в REPORTED; повторная выгрузка
+// отчитанного кейса возвращает тот же состав данных.
+func (s *AMLService) ExportSAR(ctx context.Context, id int64) ([]byte, string, error) {
+	c, transactions, err := s.GetCase(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch c.Status {
+	case aml.ESCALATED:
+		c, err = s.transition(ctx, id, aml.ESCALATED, aml.REPORTED, "", ErrAMLCaseNotEscalated)
+		if err != nil {
+			return nil, "", err
+		}
+	case aml.REPORTED:
+	default:
+		return nil, "", ErrAMLCaseNotEscalated
+	}
+
+	profile, err := s.kycRepo.GetProfile(ctx, c.UserID)
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка получения анкеты клиента: %w", err)
+	}
+
+	acc, err := s.accountRepo.GetAccountByID(ctx, c.AccountID)
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка получения счета: %w", err)
+	}
+
+	report := SuspiciousActivityReport{
+		ReportID:    fmt.Sprintf("SAR-%d", c.ID),
+		GeneratedAt: time.Now().UTC(),
+		CaseID:      c.ID,
+		Scenario:    c.Scenario,
+		Summary:     c.Summary,
+		Narrative:   c.ReviewComment,
+		DetectedAt:  c.CreatedAt.UTC(),
+		Subject: SARSubject{
+			UserID:      c.UserID,
+			FullName:    profile.FullName,
+			Citizenship: profile.Citizenship,
+			Address:     profile.Address,
+			Phone:       profile.Phone,
+		},
+		Account: SARAccount{
+			ID:       acc.ID,
+			Number:   acc.AccountNumber,
+			Currency: string(acc.Currency),
+		},
+		TotalAmount:  c.TotalAmount,
+		Transactions: make([]SARTransactionRow, 0, len(transactions)),
+	}
+	if profile.DateOfBirth != nil {
+		dob := profile.DateOfBirth.Format("2006-01-02")
+		report.Subject.DateOfBirth = &dob
+	}
+	for _, t := range transactions {
+		report.Transactions = append(report.Transactions, SARTransactionRow{
+			ID:        t.ID,
+			Type:      t.Type,
+			Amount:    t.Amount,
+			CreatedAt: t.CreatedAt.UTC(),
+		})
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, "", err
+	}
+	return data, report.ReportID + ".json", nil
+}
@@ -0,0 +1,403 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/approval"
+	"github.com/therealadik/bank-api/internal/models/fee"
+	"github.com/therealadik/bank-api/internal/repository"
+	"github.com/therealadik/bank-api/internal/tracing"
+)
+
+var (
+	ErrApprovalRequired  = errors.New("перевод требует подтверждения по политике счета")
+	ErrNotAccountOwner   = errors.New("управлять участниками и политикой может только владелец счета")
+	ErrNotInitiator      = errors.New("нет прав на создание платежей по счету")
+	ErrNotApprover       = errors.New("нет прав на подтверждение платежей по счету")
+	ErrSelfApproval      = errors.New("инициатор не может подтверждать собственный платеж")
+	ErrAlreadyDecided    = errors.New("решение по платежу уже принято")
+	ErrPaymentNotFound   = errors.New("платеж не найден")
+	ErrPaymentNotPending = errors.New("платеж уже обработан")
+	ErrPaymentFailed     = errors.New("платеж одобрен, но не исполнен")
+	ErrInvalidRole       = errors.New("неизвестная роль участника")
+	ErrSelfMember        = errors.New("владелец счета не может быть добавлен участником")
+	ErrMemberNotFound    = errors.New("участник счета не найден")
+	ErrMemberUserUnknown = errors.New("пользователь с таким email не найден")
+	ErrInvalidPolicy     = errors.New("неверная политика подтверждений")
+)
+
+// ApprovalService реализует схему maker-checker для счетов с несколькими
+// участниками: переводы выше порога политики попадают в очередь и
+// исполняются после набора нужного числа подтверждений.
+type ApprovalService struct {
+	accountService     *AccountService
+	beneficiaryService *BeneficiaryService
+	accountRepo        *repository.AccountRepository
+	approvalRepo       *repository.ApprovalRepository
+	userRepo           repository.UserRepository
+	cfg                config.ApprovalConfig
+}
+
+func NewApprovalService(accountService *AccountService, beneficiaryService *BeneficiaryService, accountRepo *repository.AccountRepository,
+	approvalRepo *repository.ApprovalRepository, userRepo repository.UserRepository, cfg config.ApprovalConfig) *ApprovalService {
+	return &ApprovalService{
+		accountService:     accountService,
+		beneficiaryService: beneficiaryService,
+		accountRepo:        accountRepo,
+		approvalRepo:       approvalRepo,
+		userRepo:           userRepo,
+		cfg:                cfg,
+	}
+}
+
+// authorize возвращает счет и участие пользователя в нем. Для владельца
+// участие равно nil: владелец обладает всеми ролями.
+func (s *ApprovalService) authorize(ctx context.Context, accountID, userID int64) (*account.Account, *approval.Member, error) {
+	acc, err := s.accountRepo.GetAccountByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrAccountNotFound
+		}
+		return nil, nil, err
+	}
+
+	if acc.UserID == userID {
+		return acc, nil, nil
+	}
+
+	member, err := s.approvalRepo.GetMember(ctx, accountID, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrAccountNotFound
+		}
+		return nil, nil, err
+	}
+
+	return acc, member, nil
+}
+
+func (s *ApprovalService) ownedAccount(ctx context.Context, accountID, userID int64) (*account.Account, error) {
+	acc, member, err := s.authorize(ctx, accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if member != nil {
+		return nil, ErrNotAccountOwner
+	}
+
+	return acc, nil
+}
+
+func (s *ApprovalService) AddMember(ctx context.Context, accountID, ownerID int64, email string,
+	role approval.Role) (*approval.Member, error) {
+	switch role {
+	case approval.INITIATOR, approval.APPROVER, approval.VIEWER:
+	default:
+		return nil, ErrInvalidRole
+	}
+
+	if _, err := s.ownedAccount(ctx, accountID, ownerID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrMemberUserUnknown
+		}
+		return nil, err
+	}
+
+	if user.ID == ownerID {
+		return nil, ErrSelfMember
+	}
+
+	return s.approvalRepo.AddMember(ctx, accountID, user.ID, role)
+}
+
+func (s *ApprovalService) RemoveMember(ctx context.Context, accountID, ownerID, memberID int64) error {
+	if _, err := s.ownedAccount(ctx, accountID, ownerID); err != nil {
+		return err
+	}
+
+	removed, err := s.approvalRepo.RemoveMember(ctx, accountID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if !removed {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+func (s *ApprovalService) GetMembers(ctx context.Context, accountID, userID int64) ([]*approval.Member, error) {
+	if _, _, err := s.authorize(ctx, accountID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.approvalRepo.GetMembers(ctx, accountID)
+}
+
+func (s *ApprovalService) GetPolicy(ctx context.Context, accountID, userID int64) ([]*approval.PolicyBand, error) {
+	if _, _, err := s.authorize(ctx, accountID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.approvalRepo.GetPolicy(ctx, accountID)
+}
+
+// SetPolicy заменяет диапазоны политики. Нижние границы должны быть
+// уникальными и неотрицательными, а число подтверждений — выполнимым
+// текущим составом подтверждающих вместе с владельцем.
+func (s *ApprovalService) SetPolicy(ctx context.Context, accountID, ownerID int64,
+	bands []approval.PolicyBand) ([]*approval.PolicyBand, error) {
+	if _, err := s.ownedAccount(ctx, accountID, ownerID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.approvalRepo.GetMembers(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	approvers := 1
+	for _, m := range members {
+		if m.Role == approval.APPROVER {
+			approvers++
+		}
+	}
+
+	seen := make(map[string]bool, len(bands))
+	for _, b := range bands {
+		key := b.AmountFrom.String()
+		if b.AmountFrom.IsNegative() || b.RequiredApprovals < 0 || b.RequiredApprovals > approvers || seen[key] {
+			return nil, ErrInvalidPolicy
+		}
+		seen[key] = true
+	}
+
+	if err := s.approvalRepo.ReplacePolicy(ctx, accountID, bands); err != nil {
+		return nil, err
+	}
+
+	return s.approvalRepo.GetPolicy(ctx, accountID)
+}
+
+// SubmitTransfer исполняет перевод сразу, если политика счета не требует
+// подтверждений, иначе ставит платеж в очередь. Чужой счет должен быть
+// в списке получателей инициатора, и период охлаждения должен истечь. Ровно один из результатов
+// — квитанция или платеж в очереди — будет ненулевым.
+func (s *ApprovalService) SubmitTransfer(ctx context.Context, fromID, toID, userID int64, amount decimal.Decimal,
+	expectedFee decimal.NullDecimal) (_ *fee.Quote, _ *approval.Payment, err error) {
+	ctx, span := tracing.Start(ctx, "ApprovalService.SubmitTransfer")
+	defer func() { tracing.End(span, err) }()
+
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, nil, ErrNegativeAmount
+	}
+
+	if fromID == toID {
+		return nil, nil, ErrSameAccount
+	}
+
+	acc, member, err := s.authorize(ctx, fromID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if member != nil && member.Role != approval.INITIATOR {
+		return nil, nil, ErrNotInitiator
+	}
+
+	if err := s.beneficiaryService.CheckRecipient(ctx, userID, acc.UserID, toID); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.accountService.assessTransfer(ctx, fromID, toID, userID, amount); err != nil {
+		return nil, nil, err
+	}
+
+	required, err := s.approvalRepo.RequiredApprovals(ctx, fromID, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if required == 0 {
+		quote, err := s.accountService.transferWithFee(ctx, fromID, toID, acc.UserID, amount, expectedFee, 0)
+		return quote, nil, err
+	}
+
+	if _, err := s.accountRepo.GetAccountByID(ctx, toID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrAccountNotFound
+		}
+		return nil, nil, err
+	}
+
+	p, err := s.approvalRepo.CreatePayment(ctx, &approval.Payment{
+		AccountID:         fromID,
+		ToAccountID:       toID,
+		InitiatorID:       userID,
+		Amount:            amount,
+		RequiredApprovals: required,
+		Status:            approval.PENDING,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, p, nil
+}
+
+func (s *ApprovalService) GetPendingPayments(ctx context.Context, userID int64) ([]*approval.Payment, error) {
+	return s.approvalRepo.GetPaymentsForUser(ctx, userID, approval.PENDING)
+}
+
+// GetPayment возвращает платеж вместе с историей решений по нему.
+func (s *ApprovalService) GetPayment(ctx context.Context, id, userID int64) (*approval.Payment,
+	[]*approval.PaymentDecision, error) {
+	p, err := s.approvalRepo.GetPayment(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrPaymentNotFound
+		}
+		return nil, nil, err
+	}
+
+	if _, _, err := s.authorize(ctx, p.AccountID, userID); err != nil {
+		if errors.Is(err, ErrAccountNotFound) {
+			return nil, nil, ErrPaymentNotFound
+		}
+		return nil, nil, err
+	}
+
+	decisions, err := s.approvalRepo.GetDecisions(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return p, decisions, nil
+}
+
+func (s *ApprovalService) Approve(ctx context.Context, id, userID int64, comment string) (*approval.Payment, error) {
+	return s.decide(ctx, id, userID, approval.APPROVE, comment)
+}
+
+func (s *ApprovalService) Reject(ctx context.Context, id, userID int64, comment string) (*approval.Payment, error) {
+	return s.decide(ctx, id, userID, approval.REJECT, comment)
+}
+
+// decide записывает решение и, если кворум набран, исполняет перевод от
+// имени владельца счета.
+func (s *ApprovalService) decide(ctx context.Context, id, userID int64, decision approval.Decision,
+	comment string) (*approval.Payment, error) {
+	p, err := s.approvalRepo.GetPayment(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, err
+	}
+
+	acc, member, err := s.authorize(ctx, p.AccountID, userID)
+	if err != nil {
+		if errors.Is(err, ErrAccountNotFound) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, err
+	}
+
+	if member != nil && member.Role != approval.APPROVER {
+		return nil, ErrNotApprover
+	}
+
+	if p.InitiatorID == userID {
+		return nil, ErrSelfApproval
+	}
+
+	p, err = s.approvalRepo.RecordDecision(ctx, &approval.PaymentDecision{
+		PaymentID: id,
+		UserID:    userID,
+		Decision:  decision,
+		Comment:   strings.TrimSpace(comment),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.Is(err, repository.ErrPaymentNotPending):
+			return nil, ErrPaymentNotPending
+		case errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode:
+			return nil, ErrAlreadyDecided
+		}
+		return nil, err
+	}
+
+	if p.Status != approval.PROCESSING {
+		return p, nil
+	}
+
+	return s.execute(ctx, p, acc.UserID)
+}
+
+// execute исполняет платеж с набранным кворумом от имени владельца счета.
+// Перевод и статус EXECUTED фиксируются одной транзакцией, неудачный перевод
+// переводит платеж в FAILED. Платеж, который уже исполнил другой процесс,
+// возвращает ErrPaymentNotPending.
+func (s *ApprovalService) execute(ctx context.Context, p *approval.Payment, ownerID int64) (*approval.Payment, error) {
+	_, err := s.accountService.transferWithFee(ctx, p.AccountID, p.ToAccountID, ownerID, p.Amount,
+		decimal.NullDecimal{}, p.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentNotProcessing) {
+			return nil, ErrPaymentNotPending
+		}
+		if updErr := s.approvalRepo.FailPayment(ctx, p.ID); updErr != nil {
+			if errors.Is(updErr, repository.ErrPaymentNotProcessing) {
+				return nil, ErrPaymentNotPending
+			}
+			return nil, updErr
+		}
+		p.Status = approval.FAILED
+		return p, fmt.Errorf("%w: %w", ErrPaymentFailed, err)
+	}
+
+	p.Status = approval.EXECUTED
+	return p, nil
+}
+
+// ResumeStalePayments исполняет платежи, которые дольше cfg.StaleAfter
+// остаются в PROCESSING: кворум набран, но процесс остановился до исполнения.
+// Перевод фиксируется вместе со статусом, поэтому платеж в PROCESSING
+// гарантированно не исполнен и его можно исполнить повторно.
+func (s *ApprovalService) ResumeStalePayments(ctx context.Context) (int, error) {
+	processed := 0
+	for {
+		p, err := s.approvalRepo.ClaimStalePayment(ctx, s.cfg.StaleAfter)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return processed, nil
+			}
+			return processed, err
+		}
+
+		acc, err := s.accountRepo.GetAccountByID(ctx, p.AccountID)
+		if err != nil {
+			return processed, fmt.Errorf("ошибка загрузки счета платежа %d: %w", p.ID, err)
+		}
+
+		_, err = s.execute(ctx, p, acc.UserID)
+		if err != nil && !errors.Is(err, ErrPaymentFailed) && !errors.Is(err, ErrPaymentNotPending) {
+			return processed, fmt.Errorf("ошибка исполнения платежа %d: %w", p.ID, err)
+		}
+		processed++
+	}
+}
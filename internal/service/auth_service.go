@@ -2,73 +2,183 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
 	"github.com/therealadik/bank-api/internal/config"
 	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/i18n"
+	"github.com/therealadik/bank-api/internal/mailer"
 	"github.com/therealadik/bank-api/internal/models"
+	"github.com/therealadik/bank-api/internal/models/lockout"
+	"github.com/therealadik/bank-api/internal/models/screening"
 	"github.com/therealadik/bank-api/internal/repository"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/therealadik/bank-api/internal/tracing"
 )
 
 var (
 	ErrInvalidCredentials = errors.New("неверные учетные данные")
 	ErrUserExists         = errors.New("пользователь уже существует")
+	ErrFullNameRequired   = errors.New("укажите полное имя")
+	ErrUserHeld           = errors.New("учетная запись проходит проверку")
+	ErrUserBlocked        = errors.New("учетная запись заблокирована")
+	ErrEmailNotVerified   = errors.New("адрес электронной почты не подтвержден")
+	ErrInvalidToken       = errors.New("ссылка недействительна или устарела")
+	ErrWeakPassword       = errors.New("пароль должен быть не короче 6 символов")
 )
 
+const minPasswordLength = 6
+
 type AuthService interface {
 	Register(ctx context.Context, req dto.RegisterRequest) (int64, error)
-	Login(ctx context.Context, req dto.LoginRequest) (string, error)
-	ParseToken(tokenString string) (int64, error)
+	Login(ctx context.Context, req dto.LoginRequest, ip string) (string, error)
+	ParseToken(ctx context.Context, tokenString string) (int64, error)
+	RequestEmailVerification(ctx context.Context, email string) error
+	VerifyEmail(ctx context.Context, token string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
 }
 
 type authService struct {
-	userRepo repository.UserRepository
-	jwtCfg   config.JWTConfig
+	userRepo         repository.UserRepository
+	tokenRepo        *repository.AuthTokenRepository
+	screeningService *ScreeningService
+	lockoutService   *LockoutService
+	mailer           mailer.Mailer
+	jwtCfg           config.JWTConfig
+	tokensCfg        config.AuthTokensConfig
+	logger           *logrus.Logger
 }
 
-func NewAuthService(userRepo repository.UserRepository, jwtCfg config.JWTConfig) AuthService {
+func NewAuthService(userRepo repository.UserRepository, tokenRepo *repository.AuthTokenRepository,
+	screeningService *ScreeningService, lockoutService *LockoutService, m mailer.Mailer, jwtCfg config.JWTConfig, tokensCfg config.AuthTokensConfig,
+	logger *logrus.Logger) AuthService {
 	return &authService{
-		userRepo: userRepo,
-		jwtCfg:   jwtCfg,
+		userRepo:         userRepo,
+		tokenRepo:        tokenRepo,
+		screeningService: screeningService,
+		lockoutService:   lockoutService,
+		mailer:           m,
+		jwtCfg:           jwtCfg,
+		tokensCfg:        tokensCfg,
+		logger:           logger,
 	}
 }
 
-func (s *authService) Register(ctx context.Context, req dto.RegisterRequest) (int64, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+// Register создает пользователя, проверяет его имя по санкционным спискам и
+// отправляет письмо для подтверждения адреса. Войти можно после
+// подтверждения. При совпадении со списком учетная запись создается
+// в статусе HELD и не может войти, пока оператор не разберет совпадение.
+func (s *authService) Register(ctx context.Context, req dto.RegisterRequest) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Register")
+	defer func() { tracing.End(span, err) }()
+
+	fullName := strings.TrimSpace(req.FullName)
+	if fullName == "" {
+		return 0, ErrFullNameRequired
+	}
+
+	if len(req.Password) < minPasswordLength {
+		return 0, ErrWeakPassword
+	}
+
+	hashedPassword, err := hashSecret(ctx, req.Password)
 	if err != nil {
 		return 0, err
 	}
 
+	matches := s.screeningService.Screen(fullName)
+
 	user := &models.User{
-		Email:    req.Email,
-		Password: string(hashedPassword),
+		Email:           req.Email,
+		Password:        hashedPassword,
+		FullName:        &fullName,
+		ScreeningStatus: screening.USER_CLEAR,
 	}
 
-	id, err := s.userRepo.Create(ctx, user)
+	// Совпадение сохраняется вместе с пользователем: иначе сбой между
+	// записями оставил бы пользователя в HELD без совпадения на разборе.
+	var hit *screening.Hit
+	if len(matches) > 0 {
+		user.ScreeningStatus = screening.USER_HELD
+		if hit, err = s.screeningService.NewUserHit(fullName, matches); err != nil {
+			return 0, err
+		}
+	}
+
+	id, err := s.userRepo.Create(ctx, user, hit)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return 0, ErrUserExists
+		}
+		return 0, err
+	}
+
+	user.ID = id
+	if err := s.sendToken(ctx, user, models.EMAIL_VERIFY); err != nil {
 		return 0, err
 	}
 
 	return id, nil
 }
 
-func (s *authService) Login(ctx context.Context, req dto.LoginRequest) (string, error) {
+// Login проверяет пароль с защитой от перебора: неудачи считаются отдельно
+// по email и по адресу клиента ip. Попытки по незарегистрированным адресам
+// учитываются так же, чтобы блокировка не выдавала, есть ли учетная запись.
+func (s *authService) Login(ctx context.Context, req dto.LoginRequest, ip string) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	defer func() { tracing.End(span, err) }()
+
+	attempts, err := s.beginLogin(ctx, req.Email, ip)
+	if err != nil {
+		return "", err
+	}
+
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
+			if err := s.lockoutService.Fail(ctx, attempts...); err != nil {
+				return "", err
+			}
 			return "", ErrInvalidCredentials
 		}
+		s.lockoutService.Cancel(ctx, attempts...)
 		return "", err
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+	if err := compareSecret(ctx, user.Password, req.Password); err != nil {
+		if err := s.lockoutService.Fail(ctx, attempts...); err != nil {
+			return "", err
+		}
 		return "", ErrInvalidCredentials
 	}
 
-	token, err := s.generateToken(user.ID)
+	if err := s.lockoutService.Succeed(ctx, attempts...); err != nil {
+		return "", err
+	}
+
+	if user.EmailVerifiedAt == nil {
+		return "", ErrEmailNotVerified
+	}
+
+	switch user.ScreeningStatus {
+	case screening.USER_HELD:
+		return "", ErrUserHeld
+	case screening.USER_BLOCKED:
+		return "", ErrUserBlocked
+	}
+
+	token, err := s.generateToken(user)
 	if err != nil {
 		return "", err
 	}
@@ -76,9 +186,32 @@ func (s *authService) Login(ctx context.Context, req dto.LoginRequest) (string,
 	return token, nil
 }
 
-func (s *authService) generateToken(userID int64) (string, error) {
+// beginLogin регистрирует попытку входа для учетной записи и, если адрес
+// клиента известен, для адреса.
+func (s *authService) beginLogin(ctx context.Context, email, ip string) ([]*lockout.Attempt, error) {
+	a, err := s.lockoutService.Begin(ctx, lockout.ACCOUNT, accountSubject(email))
+	if err != nil {
+		return nil, err
+	}
+	attempts := []*lockout.Attempt{a}
+
+	if ip != "" {
+		a, err := s.lockoutService.Begin(ctx, lockout.IP, ip)
+		if err != nil {
+			s.lockoutService.Cancel(ctx, attempts...)
+			return nil, err
+		}
+		attempts = append(attempts, a)
+	}
+	return attempts, nil
+}
+
+// generateToken выпускает JWT с версией токенов пользователя: после сброса
+// пароля версия меняется, и выданные ранее JWT перестают приниматься.
+func (s *authService) generateToken(user *models.User) (string, error) {
 	claims := jwt.MapClaims{
-		"sub": userID,
+		"sub": user.ID,
+		"ver": user.TokenVersion,
 		"exp": time.Now().Add(s.jwtCfg.ExpiresIn).Unix(),
 		"iat": time.Now().Unix(),
 	}
@@ -93,7 +226,7 @@ func (s *authService) generateToken(userID int64) (string, error) {
 	return tokenString, nil
 }
 
-func (s *authService) ParseToken(tokenString string) (int64, error) {
+func (s *authService) ParseToken(ctx context.Context, tokenString string) (int64, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("неожиданный метод подписи токена")
@@ -119,5 +252,134 @@ func (s *authService) ParseToken(tokenString string) (int64, error) {
 		return 0, errors.New("невалидный ID пользователя")
 	}
 
+	// Токены, выпущенные до появления версии, считаются версией 0.
+	version, _ := claims["ver"].(float64)
+
+	user, err := s.userRepo.GetByID(ctx, int64(userID))
+	if err != nil {
+		return 0, err
+	}
+
+	if user.TokenVersion != int(version) {
+		return 0, errors.New("токен отозван")
+	}
+
 	return int64(userID), nil
 }
+
+// RequestEmailVerification повторно отправляет письмо для подтверждения
+// адреса. Ответ не зависит от того, зарегистрирован ли адрес.
+func (s *authService) RequestEmailVerification(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	return s.sendToken(ctx, user, models.EMAIL_VERIFY)
+}
+
+func (s *authService) VerifyEmail(ctx context.Context, token string) error {
+	if _, err := s.tokenRepo.VerifyEmail(ctx, hashToken(token)); err != nil {
+		if errors.Is(err, repository.ErrTokenInvalid) {
+			return ErrInvalidToken
+		}
+		return err
+	}
+	return nil
+}
+
+// ForgotPassword отправляет ссылку для сброса пароля. Ответ не зависит от
+// того, зарегистрирован ли адрес.
+func (s *authService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return s.sendToken(ctx, user, models.PASSWORD_RESET)
+}
+
+// ResetPassword меняет пароль по токену из письма. Все выданные JWT
+// и остальные ссылки для сброса отзываются.
+func (s *authService) ResetPassword(ctx context.Context, token, password string) error {
+	if len(password) < minPasswordLength {
+		return ErrWeakPassword
+	}
+
+	hashedPassword, err := hashSecret(ctx, password)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.tokenRepo.ResetPassword(ctx, hashToken(token), hashedPassword); err != nil {
+		if errors.Is(err, repository.ErrTokenInvalid) {
+			return ErrInvalidToken
+		}
+		return err
+	}
+	return nil
+}
+
+// sendToken сохраняет хеш нового одноразового токена и отправляет ссылку
+// с ним. Письмо уходит в фоне, чтобы время ответа не выдавало, существует
+// ли адрес.
+func (s *authService) sendToken(ctx context.Context, user *models.User, purpose models.TokenPurpose) error {
+	token, err := newToken()
+	if err != nil {
+		return err
+	}
+
+	ttl, path, key := s.tokensCfg.VerifyTTL, "/verify-email", "mail.verify_email"
+	if purpose == models.PASSWORD_RESET {
+		ttl, path, key = s.tokensCfg.ResetTTL, "/reset-password", "mail.reset_password"
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	if err := s.tokenRepo.Create(ctx, user.ID, purpose, hashToken(token), expiresAt); err != nil {
+		return fmt.Errorf("ошибка сохранения токена: %w", err)
+	}
+
+	// Письмо отправляется на языке запроса.
+	locale := i18n.FromContext(ctx)
+	expires := expiresAt.UTC().Format(i18n.T(locale, "format.datetime"))
+	link := strings.TrimRight(s.tokensCfg.BaseURL, "/") + path + "?token=" + token
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: i18n.T(locale, key+".subject"),
+		Body:    i18n.T(locale, key+".body", link, expires),
+	}
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.Background(), s.tokensCfg.SendTimeout)
+		defer cancel()
+
+		if err := s.mailer.Send(sendCtx, msg); err != nil {
+			s.logger.Errorf("Ошибка отправки письма %s пользователю %d: %v", purpose, user.ID, err)
+		}
+	}()
+	return nil
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken возвращает SHA-256 токена. Токен случаен и длинный, поэтому
+// медленное хеширование не требуется.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,496 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/batch"
+	"github.com/therealadik/bank-api/internal/models/fee"
+	"github.com/therealadik/bank-api/internal/models/screening"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+const maxBatchReferenceLength = 140
+
+var (
+	ErrBatchNotFound     = errors.New("пакет платежей не найден")
+	ErrBatchEmpty        = errors.New("пакет не содержит строк для исполнения")
+	ErrBatchTooLarge     = errors.New("превышено максимальное число строк в пакете")
+	ErrBatchInvalidRows  = errors.New("в режиме «все или ничего» пакет не должен содержать ошибочных строк")
+	ErrBatchNotDraft     = errors.New("пакет уже отправлен на исполнение")
+	ErrInvalidBatchMode  = errors.New("неизвестный режим исполнения пакета")
+	ErrBatchRowHeld      = errors.New("в режиме «все или ничего» получатель строки требует проверки по санкционным спискам")
+	errBatchRowAmount    = errors.New("неверная сумма")
+	errBatchRowPrecision = errors.New("сумма должна содержать не более двух знаков после запятой")
+	errBatchRowAccount   = errors.New("не указан счет получателя")
+	errBatchRowReference = errors.New("назначение платежа длиннее 140 символов")
+)
+
+// BatchRow — строка загруженного файла до проверки.
+type BatchRow struct {
+	Account   string
+	Amount    string
+	Reference string
+}
+
+// BatchService проводит пакетные выплаты (например, зарплатные ведомости).
+// Все строки проверяются при загрузке, а исполнение выполняет BatchJob:
+// каждая строка проводится атомарно вместе со сменой своего статуса,
+// поэтому после сбоя пакет безопасно продолжить с того же места.
+type BatchService struct {
+	accountService     *AccountService
+	accountRepo        *repository.AccountRepository
+	beneficiaryService *BeneficiaryService
+	feeService         *FeeService
+	approvalRepo       *repository.ApprovalRepository
+	batchRepo          repository.BatchRepository
+	kycService         *KYCService
+	riskService        *RiskService
+	screening          *ScreeningService
+	maxRows            int
+}
+
+func NewBatchService(accountService *AccountService, accountRepo *repository.AccountRepository,
+	beneficiaryService *BeneficiaryService, feeService *FeeService, approvalRepo *repository.ApprovalRepository,
+	batchRepo repository.BatchRepository, kycService *KYCService, riskService *RiskService,
+	screeningService *ScreeningService, cfg config.BatchConfig) *BatchService {
+	return &BatchService{
+		accountService:     accountService,
+		accountRepo:        accountRepo,
+		beneficiaryService: beneficiaryService,
+		feeService:         feeService,
+		approvalRepo:       approvalRepo,
+		batchRepo:          batchRepo,
+		kycService:         kycService,
+		riskService:        riskService,
+		screening:          screeningService,
+		maxRows:            cfg.MaxRows,
+	}
+}
+
+// CreateBatch проверяет все строки и сохраняет пакет в статусе DRAFT с
+// итоговой суммой и комиссией по корректным строкам. Ошибки строк не
+// прерывают загрузку, а сохраняются в отчете по строке.
+func (s *BatchService) CreateBatch(ctx context.Context, userID, fromID int64, mode batch.Mode,
+	rows []BatchRow) (*batch.Batch, []*batch.Item, error) {
+	switch mode {
+	case batch.ALL_OR_NOTHING, batch.BEST_EFFORT:
+	default:
+		return nil, nil, ErrInvalidBatchMode
+	}
+
+	if len(rows) == 0 {
+		return nil, nil, ErrBatchEmpty
+	}
+
+	if len(rows) > s.maxRows {
+		return nil, nil, ErrBatchTooLarge
+	}
+
+	fromAcc, err := s.accountRepo.GetAccountByID(ctx, fromID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrAccountNotFound
+		}
+		return nil, nil, err
+	}
+
+	if fromAcc.UserID != userID {
+		return nil, nil, ErrAccountNotFound
+	}
+
+	if fromAcc.Product == account.DEPOSIT {
+		return nil, nil, ErrAccountLocked
+	}
+
+	b := &batch.Batch{
+		UserID:    userID,
+		AccountID: fromID,
+		Mode:      mode,
+		Status:    batch.DRAFT,
+		ItemCount: len(rows),
+		Total:     decimal.Zero,
+		TotalFee:  decimal.Zero,
+	}
+
+	items := make([]*batch.Item, 0, len(rows))
+	for i, row := range rows {
+		it := &batch.Item{
+			RowNumber:  i + 1,
+			AccountRef: strings.TrimSpace(row.Account),
+			Amount:     decimal.Zero,
+			Fee:        decimal.Zero,
+			Reference:  strings.TrimSpace(row.Reference),
+			Status:     batch.ITEM_PENDING,
+		}
+
+		if err := s.validateRow(ctx, userID, fromAcc, it, row.Amount); err != nil {
+			if !isBatchRowError(err) {
+				return nil, nil, err
+			}
+			it.Status = batch.ITEM_INVALID
+			it.Error = err.Error()
+		} else {
+			b.ValidCount++
+			b.Total = b.Total.Add(it.Amount)
+			b.TotalFee = b.TotalFee.Add(it.Fee)
+		}
+
+		items = append(items, it)
+	}
+
+	created, err := s.batchRepo.CreateBatch(ctx, b, items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items, err = s.batchRepo.GetItems(ctx, created.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return created, items, nil
+}
+
+// validateRow проверяет строку пакета. Пакет исполняется без конвертации,
+// поэтому счет получателя должен быть в валюте счета списания. Чужой счет
+// получателя, как и в одиночном переводе, должен быть сохранен в списке
+// получателей и пройти период ожидания.
+func (s *BatchService) validateRow(ctx context.Context, userID int64, fromAcc *account.Account, it *batch.Item, rawAmount string) error {
+	if it.AccountRef == "" {
+		return errBatchRowAccount
+	}
+
+	if utf8.RuneCountInString(it.Reference) > maxBatchReferenceLength {
+		return errBatchRowReference
+	}
+
+	amount, err := decimal.NewFromString(strings.ReplaceAll(strings.TrimSpace(rawAmount), ",", "."))
+	if err != nil {
+		return errBatchRowAmount
+	}
+
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return ErrNegativeAmount
+	}
+
+	if amount.Exponent() < -2 && !amount.Equal(amount.Round(2)) {
+		return errBatchRowPrecision
+	}
+	it.Amount = amount
+
+	toID, err := s.accountService.ResolveAccountRef(ctx, it.AccountRef)
+	if err != nil {
+		return err
+	}
+
+	if toID == fromAcc.ID {
+		return ErrSameAccount
+	}
+
+	toAcc, err := s.accountRepo.GetAccountByID(ctx, toID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAccountNotFound
+		}
+		return err
+	}
+
+	if toAcc.Product == account.DEPOSIT {
+		return ErrAccountLocked
+	}
+
+	if toAcc.Currency != fromAcc.Currency {
+		return ErrCurrencyMismatch
+	}
+
+	if err := s.beneficiaryService.CheckRecipient(ctx, userID, fromAcc.UserID, toAcc.ID); err != nil {
+		return err
+	}
+	it.ToAccountID = &toAcc.ID
+
+	quote, err := s.feeService.Quote(ctx, userID, fee.TRANSFER, amount)
+	if err != nil {
+		return err
+	}
+	it.Fee = quote.Fee
+
+	return nil
+}
+
+// isBatchRowError отделяет ошибки данных строки от сбоев базы, при которых
+// загрузку нужно прервать целиком.
+func isBatchRowError(err error) bool {
+	for _, target := range []error{
+		errBatchRowAmount, errBatchRowPrecision, errBatchRowAccount, errBatchRowReference,
+		ErrNegativeAmount, ErrSameAccount, ErrAccountLocked, ErrCurrencyMismatch,
+		ErrInvalidAccountRef, ErrInvalidAccountNumber, ErrAccountNotFound,
+		ErrBeneficiaryRequired, ErrBeneficiaryCoolingOff,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *BatchService) GetBatches(ctx context.Context, userID int64) ([]*batch.Batch, error) {
+	return s.batchRepo.GetBatchesByUserID(ctx, userID)
+}
+
+func (s *BatchService) GetBatch(ctx context.Context, id, userID int64) (*batch.Batch, []*batch.Item, error) {
+	b, err := s.batchRepo.GetBatch(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrBatchNotFound
+		}
+		return nil, nil, err
+	}
+
+	items, err := s.batchRepo.GetItems(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return b, items, nil
+}
+
+// Execute ставит пакет в очередь на исполнение. Комиссии пересчитываются:
+// если они изменились с момента загрузки, пакет остается в DRAFT с новыми
+// суммами и возвращается ErrFeeChanged. Перед постановкой в очередь каждая
+// строка проходит антифрод и проверку получателя, как одиночный перевод.
+func (s *BatchService) Execute(ctx context.Context, id, userID int64) (*batch.Batch, error) {
+	b, err := s.batchRepo.GetBatch(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBatchNotFound
+		}
+		return nil, err
+	}
+
+	if b.Status != batch.DRAFT {
+		return nil, ErrBatchNotDraft
+	}
+
+	if b.ValidCount == 0 {
+		return nil, ErrBatchEmpty
+	}
+
+	if b.Mode == batch.ALL_OR_NOTHING && b.ValidCount < b.ItemCount {
+		return nil, ErrBatchInvalidRows
+	}
+
+	if err := s.kycService.CheckTransfer(ctx, userID, b.Total); err != nil {
+		return nil, err
+	}
+
+	required, err := s.approvalRepo.RequiredApprovals(ctx, b.AccountID, b.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	if required > 0 {
+		return nil, ErrApprovalRequired
+	}
+
+	items, err := s.batchRepo.GetPendingItems(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := false
+	totalFee := decimal.Zero
+	for _, it := range items {
+		quote, err := s.feeService.Quote(ctx, userID, fee.TRANSFER, it.Amount)
+		if err != nil {
+			return nil, err
+		}
+		if !quote.Fee.Equal(it.Fee) {
+			it.Fee = quote.Fee
+			changed = true
+		}
+		totalFee = totalFee.Add(it.Fee)
+	}
+
+	if changed {
+		b.TotalFee = totalFee
+		if err := s.batchRepo.UpdateFees(ctx, b, items); err != nil {
+			return nil, err
+		}
+		return b, ErrFeeChanged
+	}
+
+	if err := s.screenItems(ctx, b, userID, items); err != nil {
+		return nil, err
+	}
+
+	ok, err := s.batchRepo.UpdateStatus(ctx, b.ID, batch.DRAFT, batch.QUEUED)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrBatchNotDraft
+	}
+	b.Status = batch.QUEUED
+
+	return b, nil
+}
+
+// screenItems повторно проверяет получателей по списку сохраненных (с
+// момента загрузки получателя могли удалить), оценивает строки правилами
+// антифрода и проверяет получателей по санкционным спискам. В режиме «все
+// или ничего» отказ или совпадение по любой строке отклоняет пакет
+// целиком. Иначе отклоненная строка помечается
+// FAILED, а строка с совпадением уходит в очередь задержанных переводов и
+// исполняется отдельно после решения оператора. Требование подтверждения
+// возвращается как есть: после подтверждения пакет отправляют повторно.
+func (s *BatchService) screenItems(ctx context.Context, b *batch.Batch, userID int64, items []*batch.Item) error {
+	for _, it := range items {
+		err := s.beneficiaryService.CheckRecipient(ctx, userID, b.UserID, *it.ToAccountID)
+		if err == nil {
+			err = s.riskService.AssessTransfer(ctx, userID, b.AccountID, *it.ToAccountID, it.Amount)
+		}
+		if err == nil {
+			var h *screening.Hit
+			h, err = s.screening.matchRecipient(ctx, userID, *it.ToAccountID)
+			if err == nil && h != nil {
+				if b.Mode == batch.ALL_OR_NOTHING {
+					return fmt.Errorf("%w: строка %d", ErrBatchRowHeld, it.RowNumber)
+				}
+				err = s.batchRepo.HoldItem(ctx, it.ID, ErrTransferHeld.Error(), &screening.HeldTransfer{
+					UserID:        userID,
+					FromAccountID: b.AccountID,
+					ToAccountID:   *it.ToAccountID,
+					Amount:        it.Amount,
+				}, h)
+				if err != nil {
+					return fmt.Errorf("ошибка задержания строки %d: %w", it.RowNumber, err)
+				}
+				continue
+			}
+		}
+		if err == nil {
+			continue
+		}
+
+		if b.Mode == batch.ALL_OR_NOTHING || !isScreenRowError(err) {
+			return err
+		}
+		if err := s.batchRepo.FailItem(ctx, it.ID, err.Error()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isScreenRowError отделяет отказы по строке, после которых остальные строки
+// пакета в режиме BEST_EFFORT исполняются, от ошибок, прерывающих Execute.
+func isScreenRowError(err error) bool {
+	return errors.Is(err, ErrRiskBlocked) || errors.Is(err, ErrCounterpartyBlocked) ||
+		errors.Is(err, ErrBeneficiaryRequired) || errors.Is(err, ErrBeneficiaryCoolingOff)
+}
+
+// ProcessQueued исполняет пакеты в очереди и дообрабатывает прерванные.
+func (s *BatchService) ProcessQueued(ctx context.Context) (int, error) {
+	batches, err := s.batchRepo.GetRunnableBatches(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения пакетов к исполнению: %w", err)
+	}
+
+	processed := 0
+	for _, b := range batches {
+		if err := s.process(ctx, b); err != nil {
+			return processed, fmt.Errorf("ошибка исполнения пакета %d: %w", b.ID, err)
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+func (s *BatchService) process(ctx context.Context, b *batch.Batch) error {
+	if b.Status == batch.QUEUED {
+		ok, err := s.batchRepo.UpdateStatus(ctx, b.ID, batch.QUEUED, batch.PROCESSING)
+		if err != nil || !ok {
+			return err
+		}
+	}
+
+	items, err := s.batchRepo.GetPendingItems(ctx, b.ID)
+	if err != nil {
+		return err
+	}
+
+	if b.Mode == batch.ALL_OR_NOTHING {
+		if err := s.batchRepo.ExecuteAll(ctx, b, items); err != nil {
+			reason, rejected := batchRejectReason(err)
+			if !rejected {
+				return err
+			}
+			if err := s.batchRepo.FailItems(ctx, b.ID, reason); err != nil {
+				return err
+			}
+		}
+	} else {
+		for _, it := range items {
+			if err := s.batchRepo.ExecuteItem(ctx, b, it); err != nil {
+				reason, rejected := batchRejectReason(err)
+				if !rejected {
+					return err
+				}
+				if err := s.batchRepo.FailItem(ctx, it.ID, reason); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	items, err = s.batchRepo.GetItems(ctx, b.ID)
+	if err != nil {
+		return err
+	}
+
+	completed, failed, held := 0, 0, 0
+	for _, it := range items {
+		switch it.Status {
+		case batch.ITEM_COMPLETED:
+			completed++
+		case batch.ITEM_FAILED:
+			failed++
+		case batch.ITEM_HELD:
+			held++
+		}
+	}
+
+	final := batch.COMPLETED
+	switch {
+	case completed == 0 && held == 0:
+		final = batch.FAILED
+	case failed > 0 || held > 0:
+		final = batch.PARTIAL
+	}
+
+	_, err = s.batchRepo.UpdateStatus(ctx, b.ID, batch.PROCESSING, final)
+	return err
+}
+
+// batchRejectReason возвращает причину отказа для ошибок, повтор которых не
+// поможет. Прочие ошибки считаются временными: пакет останется в PROCESSING
+// и будет продолжен при следующем запуске задания.
+func batchRejectReason(err error) (string, bool) {
+	switch {
+	case errors.Is(err, repository.ErrLimitExceeded):
+		return ErrInsufficientFunds.Error(), true
+	case errors.Is(err, pgx.ErrNoRows):
+		return ErrAccountNotFound.Error(), true
+	}
+	return "", false
+}
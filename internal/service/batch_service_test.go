@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/therealadik/bank-api/internal/models/batch"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+var errCrash = errors.New("соединение с базой потеряно")
+
+// fakeBatchRepo хранит пакет в памяти и повторяет транзакционную семантику
+// BatchRepositoryPgx: проведение строки и смена ее статуса либо происходят
+// вместе, либо не происходят вовсе.
+type fakeBatchRepo struct {
+	repository.BatchRepository
+
+	batch *batch.Batch
+	items []*batch.Item
+	// errs — ошибка проведения строки. Временная ошибка (errCrash)
+	// срабатывает один раз, отказ повторяется при каждой попытке.
+	errs     map[int64]error
+	executed map[int64]int
+}
+
+func (r *fakeBatchRepo) GetRunnableBatches(context.Context) ([]*batch.Batch, error) {
+	if r.batch.Status != batch.QUEUED && r.batch.Status != batch.PROCESSING {
+		return nil, nil
+	}
+	b := *r.batch
+	return []*batch.Batch{&b}, nil
+}
+
+func (r *fakeBatchRepo) GetItems(context.Context, int64) ([]*batch.Item, error) {
+	return r.copyItems(func(*batch.Item) bool { return true }), nil
+}
+
+func (r *fakeBatchRepo) GetPendingItems(context.Context, int64) ([]*batch.Item, error) {
+	return r.copyItems(func(it *batch.Item) bool { return it.Status == batch.ITEM_PENDING }), nil
+}
+
+func (r *fakeBatchRepo) copyItems(keep func(*batch.Item) bool) []*batch.Item {
+	var items []*batch.Item
+	for _, it := range r.items {
+		if keep(it) {
+			c := *it
+			items = append(items, &c)
+		}
+	}
+	return items
+}
+
+func (r *fakeBatchRepo) UpdateStatus(_ context.Context, _ int64, from, to batch.Status) (bool, error) {
+	if r.batch.Status != from {
+		return false, nil
+	}
+	r.batch.Status = to
+	return true, nil
+}
+
+func (r *fakeBatchRepo) FailItems(_ context.Context, _ int64, reason string) error {
+	for _, it := range r.items {
+		r.fail(it, reason)
+	}
+	return nil
+}
+
+func (r *fakeBatchRepo) FailItem(_ context.Context, id int64, reason string) error {
+	r.fail(r.item(id), reason)
+	return nil
+}
+
+func (r *fakeBatchRepo) fail(it *batch.Item, reason string) {
+	if it.Status == batch.ITEM_PENDING {
+		it.Status = batch.ITEM_FAILED
+		it.Error = reason
+	}
+}
+
+func (r *fakeBatchRepo) ExecuteItem(_ context.Context, _ *batch.Batch, it *batch.Item) error {
+	return r.execute([]*batch.Item{it})
+}
+
+func (r *fakeBatchRepo) ExecuteAll(_ context.Context, _ *batch.Batch, items []*batch.Item) error {
+	return r.execute(items)
+}
+
+// execute проводит строки одной «транзакцией»: при ошибке ни одна строка
+// не меняется.
+func (r *fakeBatchRepo) execute(items []*batch.Item) error {
+	var claimed []*batch.Item
+	for _, it := range items {
+		stored := r.item(it.ID)
+		if stored.Status != batch.ITEM_PENDING {
+			continue
+		}
+		if err := r.errs[it.ID]; err != nil {
+			if errors.Is(err, errCrash) {
+				delete(r.errs, it.ID)
+			}
+			return err
+		}
+		claimed = append(claimed, stored)
+	}
+
+	for _, it := range claimed {
+		it.Status = batch.ITEM_COMPLETED
+		r.executed[it.ID]++
+	}
+	return nil
+}
+
+func (r *fakeBatchRepo) item(id int64) *batch.Item {
+	for _, it := range r.items {
+		if it.ID == id {
+			return it
+		}
+	}
+	panic("строка не найдена")
+}
+
+func TestProcessQueuedResume(t *testing.T) {
+	const (
+		P = batch.ITEM_PENDING
+		C = batch.ITEM_COMPLETED
+		F = batch.ITEM_FAILED
+		H = batch.ITEM_HELD
+	)
+
+	tests := []struct {
+		name   string
+		mode   batch.Mode
+		status batch.Status
+		items  []batch.ItemStatus
+		errs   map[int64]error
+		// crash — первый запуск прерывается и оставляет пакет в PROCESSING.
+		crash     bool
+		want      batch.Status
+		wantItems []batch.ItemStatus
+		// wantExecuted — сколько раз проведена каждая строка за все запуски.
+		wantExecuted []int
+	}{
+		{
+			name: "все строки проведены", mode: batch.BEST_EFFORT, status: batch.QUEUED,
+			items: []batch.ItemStatus{P, P, P},
+			want:  batch.COMPLETED, wantItems: []batch.ItemStatus{C, C, C}, wantExecuted: []int{1, 1, 1},
+		},
+		{
+			name: "сбой посреди пакета продолжается со следующей строки", mode: batch.BEST_EFFORT, status: batch.QUEUED,
+			items: []batch.ItemStatus{P, P, P}, errs: map[int64]error{2: errCrash}, crash: true,
+			want: batch.COMPLETED, wantItems: []batch.ItemStatus{C, C, C}, wantExecuted: []int{1, 1, 1},
+		},
+		{
+			name: "прерванный пакет с частью проведенных строк", mode: batch.BEST_EFFORT, status: batch.PROCESSING,
+			items: []batch.ItemStatus{C, P, P},
+			want:  batch.COMPLETED, wantItems: []batch.ItemStatus{C, C, C}, wantExecuted: []int{0, 1, 1},
+		},
+		{
+			name: "сбой после всех строк до смены статуса пакета", mode: batch.BEST_EFFORT, status: batch.PROCESSING,
+			items: []batch.ItemStatus{C, F, C},
+			want:  batch.PARTIAL, wantItems: []batch.ItemStatus{C, F, C}, wantExecuted: []int{0, 0, 0},
+		},
+		{
+			name: "отказ строки не останавливает пакет", mode: batch.BEST_EFFORT, status: batch.QUEUED,
+			items: []batch.ItemStatus{P, P, P}, errs: map[int64]error{2: repository.ErrLimitExceeded},
+			want: batch.PARTIAL, wantItems: []batch.ItemStatus{C, F, C}, wantExecuted: []int{1, 0, 1},
+		},
+		{
+			name: "задержанная строка не исполняется в пакете", mode: batch.BEST_EFFORT, status: batch.QUEUED,
+			items: []batch.ItemStatus{P, H, P},
+			want:  batch.PARTIAL, wantItems: []batch.ItemStatus{C, H, C}, wantExecuted: []int{1, 0, 1},
+		},
+		{
+			name: "все строки задержаны", mode: batch.BEST_EFFORT, status: batch.QUEUED,
+			items: []batch.ItemStatus{H, H},
+			want:  batch.PARTIAL, wantItems: []batch.ItemStatus{H, H}, wantExecuted: []int{0, 0},
+		},
+		{
+			name: "отказ всех строк", mode: batch.BEST_EFFORT, status: batch.QUEUED,
+			items: []batch.ItemStatus{P, P},
+			errs:  map[int64]error{1: repository.ErrLimitExceeded, 2: repository.ErrLimitExceeded},
+			want:  batch.FAILED, wantItems: []batch.ItemStatus{F, F}, wantExecuted: []int{0, 0},
+		},
+		{
+			name: "все или ничего: отказ отменяет все строки", mode: batch.ALL_OR_NOTHING, status: batch.QUEUED,
+			items: []batch.ItemStatus{P, P, P}, errs: map[int64]error{3: repository.ErrLimitExceeded},
+			want: batch.FAILED, wantItems: []batch.ItemStatus{F, F, F}, wantExecuted: []int{0, 0, 0},
+		},
+		{
+			name: "все или ничего: сбой откатывает пакет и повторяется", mode: batch.ALL_OR_NOTHING, status: batch.QUEUED,
+			items: []batch.ItemStatus{P, P, P}, errs: map[int64]error{2: errCrash}, crash: true,
+			want: batch.COMPLETED, wantItems: []batch.ItemStatus{C, C, C}, wantExecuted: []int{1, 1, 1},
+		},
+		{
+			name: "завершенный пакет не исполняется повторно", mode: batch.BEST_EFFORT, status: batch.COMPLETED,
+			items: []batch.ItemStatus{C, C},
+			want:  batch.COMPLETED, wantItems: []batch.ItemStatus{C, C}, wantExecuted: []int{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeBatchRepo{
+				batch:    &batch.Batch{ID: 1, Mode: tt.mode, Status: tt.status},
+				errs:     tt.errs,
+				executed: map[int64]int{},
+			}
+			for i, status := range tt.items {
+				repo.items = append(repo.items, &batch.Item{ID: int64(i + 1), BatchID: 1, RowNumber: i + 1, Status: status})
+			}
+			s := &BatchService{batchRepo: repo}
+			ctx := context.Background()
+
+			if tt.crash {
+				if _, err := s.ProcessQueued(ctx); !errors.Is(err, errCrash) {
+					t.Fatalf("первый запуск: ошибка %v, ожидался сбой", err)
+				}
+				if repo.batch.Status != batch.PROCESSING {
+					t.Fatalf("после сбоя пакет в статусе %s, ожидался PROCESSING", repo.batch.Status)
+				}
+			}
+
+			if _, err := s.ProcessQueued(ctx); err != nil {
+				t.Fatalf("ProcessQueued: %v", err)
+			}
+			// Повторный запуск после завершения ничего не меняет.
+			if _, err := s.ProcessQueued(ctx); err != nil {
+				t.Fatalf("повторный ProcessQueued: %v", err)
+			}
+
+			if repo.batch.Status != tt.want {
+				t.Errorf("статус пакета %s, ожидался %s", repo.batch.Status, tt.want)
+			}
+			for i, it := range repo.items {
+				if it.Status != tt.wantItems[i] {
+					t.Errorf("строка %d: статус %s, ожидался %s", it.RowNumber, it.Status, tt.wantItems[i])
+				}
+				if n := repo.executed[it.ID]; n != tt.wantExecuted[i] {
+					t.Errorf("строка %d проведена %d раз, ожидалось %d", it.RowNumber, n, tt.wantExecuted[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/therealadik/bank-api/internal/tracing"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// hashSecret хеширует пароль или CVV. bcrypt намеренно медленный, поэтому
+// хеширование выделено в отдельный спан.
+func hashSecret(ctx context.Context, secret string) (string, error) {
+	_, span := tracing.Start(ctx, "bcrypt.GenerateFromPassword")
+	defer span.End()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// compareSecret сверяет пароль или CVV с хешем.
+func compareSecret(ctx context.Context, hash, secret string) error {
+	_, span := tracing.Start(ctx, "bcrypt.CompareHashAndPassword")
+	defer span.End()
+
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(secret))
+}
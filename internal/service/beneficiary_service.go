@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/models"
+	"github.com/therealadik/bank-api/internal/models/beneficiary"
+	"github.com/therealadik/bank-api/internal/models/lockout"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+const maxNicknameLength = 100
+
+var (
+	ErrBeneficiaryNotFound   = errors.New("получатель не найден в списке")
+	ErrBeneficiaryExists     = errors.New("получатель с этим счетом уже добавлен")
+	ErrBeneficiaryCoolingOff = errors.New("новый получатель станет доступен после периода ожидания или подтверждения кодом")
+	ErrBeneficiaryRequired   = errors.New("переводы на чужие счета выполняются через сохраненного получателя")
+	ErrInvalidNickname       = errors.New("название получателя должно содержать от 1 до 100 символов")
+	ErrOwnAccountBeneficiary = errors.New("нельзя добавить собственный счет в список получателей")
+	ErrBeneficiaryVerified   = errors.New("получатель уже подтвержден")
+)
+
+type BeneficiaryService struct {
+	accountService  *AccountService
+	accountRepo     *repository.AccountRepository
+	beneficiaryRepo *repository.BeneficiaryRepository
+	userRepo        repository.UserRepository
+	lockoutService  *LockoutService
+	otpService      *OTPService
+	coolingOff      time.Duration
+}
+
+func NewBeneficiaryService(accountService *AccountService, accountRepo *repository.AccountRepository,
+	beneficiaryRepo *repository.BeneficiaryRepository, userRepo repository.UserRepository, lockoutService *LockoutService,
+	otpService *OTPService, cfg config.BeneficiaryConfig) *BeneficiaryService {
+	return &BeneficiaryService{
+		accountService:  accountService,
+		accountRepo:     accountRepo,
+		beneficiaryRepo: beneficiaryRepo,
+		userRepo:        userRepo,
+		lockoutService:  lockoutService,
+		otpService:      otpService,
+		coolingOff:      cfg.CoolingOff,
+	}
+}
+
+// AddBeneficiary сохраняет получателя. Переводы на него становятся доступны
+// после периода охлаждения либо сразу после подтверждения паролем и кодом
+// из письма через VerifyBeneficiary.
+func (s *BeneficiaryService) AddBeneficiary(ctx context.Context, userID int64, nickname string, accountID int64,
+	accountNumber string) (*beneficiary.Beneficiary, error) {
+	nickname, err := normalizeNickname(nickname)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, err = s.accountService.ResolveAccountID(ctx, accountID, accountNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := s.accountRepo.GetAccountByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	if acc.UserID == userID {
+		return nil, ErrOwnAccountBeneficiary
+	}
+
+	if acc.AccountNumber == nil {
+		return nil, fmt.Errorf("у счета %d нет внешнего номера", acc.ID)
+	}
+
+	b, err := s.beneficiaryRepo.CreateBeneficiary(ctx, &beneficiary.Beneficiary{
+		UserID:        userID,
+		Nickname:      nickname,
+		AccountID:     acc.ID,
+		AccountNumber: *acc.AccountNumber,
+		Status:        beneficiary.PENDING,
+		AvailableAt:   time.Now().Add(s.coolingOff),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return nil, ErrBeneficiaryExists
+		}
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (s *BeneficiaryService) GetBeneficiaries(ctx context.Context, userID int64) ([]*beneficiary.Beneficiary, error) {
+	return s.beneficiaryRepo.GetBeneficiariesByUserID(ctx, userID)
+}
+
+// RenameBeneficiary меняет только название. Для смены счета получателя
+// нужно добавить нового получателя, чтобы на него распространился период охлаждения.
+func (s *BeneficiaryService) RenameBeneficiary(ctx context.Context, id, userID int64, nickname string) (*beneficiary.Beneficiary, error) {
+	nickname, err := normalizeNickname(nickname)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.beneficiaryRepo.UpdateNickname(ctx, id, userID, nickname)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBeneficiaryNotFound
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *BeneficiaryService) DeleteBeneficiary(ctx context.Context, id, userID int64) error {
+	deleted, err := s.beneficiaryRepo.DeleteBeneficiary(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrBeneficiaryNotFound
+	}
+	return nil
+}
+
+// SendVerificationCode отправляет на адрес пользователя код для
+// подтверждения получателя.
+func (s *BeneficiaryService) SendVerificationCode(ctx context.Context, id, userID int64) error {
+	b, err := s.pendingBeneficiary(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.otpService.Send(ctx, user, models.BENEFICIARY_VERIFY, beneficiaryCodeValue(b))
+}
+
+// VerifyBeneficiary снимает период охлаждения, если пользователь ввел
+// пароль и код из письма. Оба секрета проверяются в рамках одной попытки
+// входа в учетную запись, поэтому их перебор ограничен так же, как вход.
+func (s *BeneficiaryService) VerifyBeneficiary(ctx context.Context, id, userID int64, password, code string) (*beneficiary.Beneficiary, error) {
+	b, err := s.pendingBeneficiary(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := s.lockoutService.Begin(ctx, lockout.ACCOUNT, accountSubject(user.Email))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := compareSecret(ctx, user.Password, password); err != nil {
+		if err := s.lockoutService.Fail(ctx, a); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := s.otpService.consume(ctx, user, models.BENEFICIARY_VERIFY, beneficiaryCodeValue(b), code); err != nil {
+		if errors.Is(err, ErrInvalidCode) {
+			if err := s.lockoutService.Fail(ctx, a); err != nil {
+				return nil, err
+			}
+			return nil, ErrInvalidCode
+		}
+		s.lockoutService.Cancel(ctx, a)
+		return nil, err
+	}
+
+	if err := s.lockoutService.Succeed(ctx, a); err != nil {
+		return nil, err
+	}
+
+	b, err = s.beneficiaryRepo.MarkVerified(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBeneficiaryNotFound
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+// pendingBeneficiary возвращает получателя, ожидающего подтверждения.
+// Для уже доступного получателя возвращается ErrBeneficiaryVerified.
+func (s *BeneficiaryService) pendingBeneficiary(ctx context.Context, id, userID int64) (*beneficiary.Beneficiary, error) {
+	b, err := s.beneficiaryRepo.GetBeneficiary(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBeneficiaryNotFound
+		}
+		return nil, err
+	}
+
+	if b.EffectiveStatus(time.Now()) == beneficiary.VERIFIED {
+		return nil, ErrBeneficiaryVerified
+	}
+	return b, nil
+}
+
+// beneficiaryCodeValue привязывает код к получателю и его счету.
+func beneficiaryCodeValue(b *beneficiary.Beneficiary) string {
+	return strconv.FormatInt(b.ID, 10) + ":" + b.AccountNumber
+}
+
+// CheckRecipient разрешает перевод по номеру или ID счета только на счета
+// владельца ownerID и участника userID либо на получателя из списка
+// userID, для которого истек период охлаждения. Иначе период охлаждения
+// обходился бы переводом на тот же счет без beneficiary_id.
+func (s *BeneficiaryService) CheckRecipient(ctx context.Context, userID, ownerID, toID int64) error {
+	toAcc, err := s.accountRepo.GetAccountByID(ctx, toID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAccountNotFound
+		}
+		return err
+	}
+
+	if toAcc.UserID == ownerID || toAcc.UserID == userID {
+		return nil
+	}
+
+	b, err := s.beneficiaryRepo.GetBeneficiaryByAccount(ctx, userID, toID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrBeneficiaryRequired
+		}
+		return err
+	}
+
+	if b.EffectiveStatus(time.Now()) != beneficiary.VERIFIED {
+		return ErrBeneficiaryCoolingOff
+	}
+	return nil
+}
+
+// ResolveForTransfer возвращает счет получателя, если переводы на него уже разрешены.
+func (s *BeneficiaryService) ResolveForTransfer(ctx context.Context, id, userID int64) (int64, error) {
+	b, err := s.beneficiaryRepo.GetBeneficiary(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrBeneficiaryNotFound
+		}
+		return 0, err
+	}
+
+	if b.EffectiveStatus(time.Now()) != beneficiary.VERIFIED {
+		return 0, ErrBeneficiaryCoolingOff
+	}
+
+	return b.AccountID, nil
+}
+
+func normalizeNickname(nickname string) (string, error) {
+	nickname = strings.TrimSpace(nickname)
+	if nickname == "" || utf8.RuneCountInString(nickname) > maxNicknameLength {
+		return "", ErrInvalidNickname
+	}
+	return nickname, nil
+}
@@ -2,33 +2,54 @@ package service
 
 import (
 	"context"
-	"crypto/hmac"
 	"crypto/rand"
-	"crypto/sha256"
-	"database/sql"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/metrics"
 	"github.com/therealadik/bank-api/internal/models"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/fee"
+	"github.com/therealadik/bank-api/internal/models/lockout"
 	"github.com/therealadik/bank-api/internal/repository"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/therealadik/bank-api/internal/tracing"
+)
+
+var (
+	ErrNoPaymentAccount = errors.New("у владельца карты нет текущего счета для списания")
+	ErrCardNotFound     = errors.New("карта не найдена")
+	ErrInvalidCVV       = errors.New("неверный CVV код")
+	ErrCardExpired      = errors.New("карта просрочена")
 )
 
 type CardService struct {
-	cardRepo      *repository.CardRepository
-	db            *pgxpool.Pool
-	encryptionKey []byte
+	cardRepo       *repository.CardRepository
+	accountRepo    *repository.AccountRepository
+	feeService     *FeeService
+	riskService    *RiskService
+	kycService     *KYCService
+	lockoutService *LockoutService
+	db             *pgxpool.Pool
+	encryptionKey  []byte
 }
 
-func NewCardService(cardRepo *repository.CardRepository, db *pgxpool.Pool, encryptionKey string) *CardService {
+func NewCardService(cardRepo *repository.CardRepository, accountRepo *repository.AccountRepository, feeService *FeeService,
+	riskService *RiskService, kycService *KYCService, lockoutService *LockoutService, db *pgxpool.Pool,
+	encryptionKey string) *CardService {
 	return &CardService{
-		cardRepo:      cardRepo,
-		db:            db,
-		encryptionKey: []byte(encryptionKey),
+		cardRepo:       cardRepo,
+		accountRepo:    accountRepo,
+		feeService:     feeService,
+		riskService:    riskService,
+		kycService:     kycService,
+		lockoutService: lockoutService,
+		db:             db,
+		encryptionKey:  []byte(encryptionKey),
 	}
 }
 
@@ -106,20 +127,22 @@ func (s *CardService) decryptWithPGP(ctx context.Context, data []byte, key strin
 	return decrypted, err
 }
 
-func (s *CardService) hashCVV(cvv string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(cvv), bcrypt.DefaultCost)
-	if err != nil {
-		return "", err
-	}
-	return string(hash), nil
+func (s *CardService) hashCVV(ctx context.Context, cvv string) (string, error) {
+	return hashSecret(ctx, cvv)
 }
 
-func (s *CardService) validateCVV(cvv string, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(cvv))
-	return err == nil
+func (s *CardService) validateCVV(ctx context.Context, cvv string, hash string) bool {
+	return compareSecret(ctx, hash, cvv) == nil
 }
 
-func (s *CardService) CreateCard(ctx context.Context, userID int64, pgpKey string) (*models.Card, map[string]string, error) {
+func (s *CardService) CreateCard(ctx context.Context, userID int64, pgpKey string) (_ *models.Card, _ map[string]string, err error) {
+	ctx, span := tracing.Start(ctx, "CardService.CreateCard")
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.kycService.RequireVerified(ctx, userID); err != nil {
+		return nil, nil, err
+	}
+
 	cardNumber, err := s.generateCardNumber()
 	if err != nil {
 		return nil, nil, fmt.Errorf("ошибка генерации номера карты: %w", err)
@@ -141,7 +164,7 @@ func (s *CardService) CreateCard(ctx context.Context, userID int64, pgpKey strin
 		return nil, nil, fmt.Errorf("ошибка шифрования срока действия: %w", err)
 	}
 
-	cvvHash, err := s.hashCVV(cvv)
+	cvvHash, err := s.hashCVV(ctx, cvv)
 	if err != nil {
 		return nil, nil, fmt.Errorf("ошибка хеширования CVV: %w", err)
 	}
@@ -150,6 +173,7 @@ func (s *CardService) CreateCard(ctx context.Context, userID int64, pgpKey strin
 	if err != nil {
 		return nil, nil, fmt.Errorf("ошибка создания карты в БД: %w", err)
 	}
+	metrics.CardsIssuedTotal.Inc()
 
 	message := fmt.Sprintf("%d:%s:%s:%s", card.ID, cardNumber, expireDate, cvv)
 	signature := s.generateHMAC(message)
@@ -167,14 +191,15 @@ func (s *CardService) CreateCard(ctx context.Context, userID int64, pgpKey strin
 func (s *CardService) GetCardDetails(ctx context.Context, cardID int64, userID int64, pgpKey string) (map[string]string, error) {
 	card, err := s.cardRepo.GetCardByID(ctx, cardID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("карта не найдена")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCardNotFound
 		}
 		return nil, fmt.Errorf("ошибка получения карты: %w", err)
 	}
 
+	// Чужая карта неотличима от несуществующей.
 	if card.UserID != userID {
-		return nil, errors.New("доступ запрещен: карта не принадлежит пользователю")
+		return nil, ErrCardNotFound
 	}
 
 	cardNumber, err := s.decryptWithPGP(ctx, card.CardNumber, pgpKey)
@@ -201,18 +226,39 @@ func (s *CardService) GetUserCards(ctx context.Context, userID int64) ([]*models
 	return s.cardRepo.GetCardsByUserID(ctx, userID)
 }
 
-func (s *CardService) VerifyCardPayment(ctx context.Context, cardID int64, cvv string, pgpKey string) (bool, error) {
+// VerifyCardPayment проверяет CVV и срок действия карты. Неверные CVV
+// считаются по карте: после нескольких неудач проверки замедляются, затем
+// карта временно блокируется, так что перебрать 900 значений не выйдет.
+func (s *CardService) VerifyCardPayment(ctx context.Context, cardID int64, cvv string, pgpKey string) (_ bool, err error) {
+	ctx, span := tracing.Start(ctx, "CardService.VerifyCardPayment")
+	defer func() { tracing.End(span, err) }()
+
 	card, err := s.cardRepo.GetCardByID(ctx, cardID)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return false, errors.New("карта не найдена")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, ErrCardNotFound
 		}
 		return false, fmt.Errorf("ошибка получения карты: %w", err)
 	}
 
-	isValidCVV := s.validateCVV(cvv, card.CVVHash)
+	attempt, err := s.lockoutService.Begin(ctx, lockout.CARD, strconv.FormatInt(cardID, 10))
+	if err != nil {
+		return false, err
+	}
+
+	isValidCVV := s.validateCVV(ctx, cvv, card.CVVHash)
 	if !isValidCVV {
-		return false, errors.New("неверный CVV код")
+		if err := s.lockoutService.Fail(ctx, attempt); err != nil {
+			return false, err
+		}
+		if err := s.riskService.RecordCVVFailure(ctx, cardID); err != nil {
+			return false, fmt.Errorf("ошибка учета неверного CVV: %w", err)
+		}
+		return false, ErrInvalidCVV
+	}
+
+	if err := s.lockoutService.Succeed(ctx, attempt); err != nil {
+		return false, err
 	}
 
 	expire, err := s.decryptWithPGP(ctx, card.Expire, pgpKey)
@@ -238,7 +284,7 @@ func (s *CardService) VerifyCardPayment(ctx context.Context, cardID int64, cvv s
 	expiryDate = expiryDate.AddDate(0, 1, -1)
 
 	if now.After(expiryDate) {
-		return false, errors.New("карта просрочена")
+		return false, ErrCardExpired
 	}
 
 	message := fmt.Sprintf("%d:%s:%s:%s", cardID, cardNumber, expire, cvv)
@@ -250,19 +296,105 @@ func (s *CardService) VerifyCardPayment(ctx context.Context, cardID int64, cvv s
 	return true, nil
 }
 
-func (s *CardService) generateHMAC(message string) string {
-	h := hmac.New(sha256.New, s.encryptionKey)
-	h.Write([]byte(message))
-	return hex.EncodeToString(h.Sum(nil))
+// ProcessPayment проверяет данные карты и списывает сумму платежа вместе с комиссией
+// с основного текущего счета владельца карты. Платеж оценивается правилами
+// антифрода до проверки CVV, чтобы после серии неверных CVV отказ не
+// подсказывал, что очередной код верен.
+func (s *CardService) ProcessPayment(ctx context.Context, cardID int64, cvv string, pgpKey string, amount decimal.Decimal,
+	expectedFee decimal.NullDecimal) (_ *fee.Quote, err error) {
+	ctx, span := tracing.Start(ctx, "CardService.ProcessPayment")
+	defer func() { tracing.End(span, err) }()
+
+	quote, err := s.processPayment(ctx, cardID, cvv, pgpKey, amount, expectedFee)
+	if err != nil {
+		metrics.CardPaymentsFailedTotal.WithLabelValues(paymentFailureReason(err)).Inc()
+		return quote, err
+	}
+
+	metrics.CardPaymentsTotal.Inc()
+	return quote, nil
 }
 
-func (s *CardService) verifyHMAC(message, signature string) bool {
-	expectedMAC, err := hex.DecodeString(signature)
+// paymentFailureReasons — причины отказа в платеже для метрик; прочие
+// ошибки учитываются как other.
+var paymentFailureReasons = []struct {
+	err    error
+	reason string
+}{
+	{ErrInsufficientFunds, "insufficient_funds"},
+	{ErrInvalidCVV, "invalid_cvv"},
+	{ErrCardExpired, "card_expired"},
+	{ErrCardNotFound, "card_not_found"},
+	{ErrNoPaymentAccount, "no_payment_account"},
+	{ErrTooManyAttempts, "too_many_attempts"},
+	{ErrRiskBlocked, "risk_blocked"},
+	{ErrRiskChallenge, "risk_challenge"},
+	{ErrFeeChanged, "fee_changed"},
+	{ErrNegativeAmount, "invalid_amount"},
+}
+
+func paymentFailureReason(err error) string {
+	for _, r := range paymentFailureReasons {
+		if errors.Is(err, r.err) {
+			return r.reason
+		}
+	}
+	return "other"
+}
+
+func (s *CardService) processPayment(ctx context.Context, cardID int64, cvv string, pgpKey string, amount decimal.Decimal,
+	expectedFee decimal.NullDecimal) (*fee.Quote, error) {
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrNegativeAmount
+	}
+
+	card, err := s.cardRepo.GetCardByID(ctx, cardID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCardNotFound
+		}
+		return nil, fmt.Errorf("ошибка получения карты: %w", err)
+	}
+
+	acc, err := s.accountRepo.GetPrimaryAccount(ctx, card.UserID, account.RUB)
 	if err != nil {
-		return false
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoPaymentAccount
+		}
+		return nil, fmt.Errorf("ошибка получения счета для списания: %w", err)
+	}
+
+	if err := s.riskService.AssessCardPayment(ctx, card.UserID, cardID, acc.ID, amount); err != nil {
+		return nil, err
 	}
 
-	mac := hmac.New(sha256.New, s.encryptionKey)
-	mac.Write([]byte(message))
-	return hmac.Equal(mac.Sum(nil), expectedMAC)
+	if _, err := s.VerifyCardPayment(ctx, cardID, cvv, pgpKey); err != nil {
+		return nil, err
+	}
+
+	quote, err := s.feeService.Quote(ctx, card.UserID, fee.CARD_PAYMENT, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckQuote(quote, expectedFee); err != nil {
+		return quote, err
+	}
+
+	if err := s.accountRepo.ChargeAccount(ctx, acc.ID, card.UserID, quote); err != nil {
+		if errors.Is(err, repository.ErrLimitExceeded) {
+			return quote, ErrInsufficientFunds
+		}
+		return quote, fmt.Errorf("ошибка списания платежа: %w", err)
+	}
+
+	return quote, nil
+}
+
+func (s *CardService) generateHMAC(message string) string {
+	return signHMAC(s.encryptionKey, message)
+}
+
+func (s *CardService) verifyHMAC(message, signature string) bool {
+	return checkHMAC(s.encryptionKey, message, signature)
 }
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/deposit"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+const maxDepositTermMonths = 60
+
+var (
+	ErrInvalidTerm          = errors.New("срок вклада должен быть от 1 до 60 месяцев")
+	ErrDepositNotFound      = errors.New("вклад не найден")
+	ErrDepositNotActive     = errors.New("вклад уже закрыт")
+	ErrInvalidSourceAccount = errors.New("вклад можно пополнить только с текущего счета")
+)
+
+type DepositService struct {
+	accountService *AccountService
+	depositRepo    *repository.DepositRepository
+	terms          config.ProductTerms
+	penaltyRate    decimal.Decimal
+}
+
+func NewDepositService(accountService *AccountService, depositRepo *repository.DepositRepository,
+	interestCfg config.InterestConfig) *DepositService {
+	return &DepositService{
+		accountService: accountService,
+		depositRepo:    depositRepo,
+		terms:          interestCfg.Products[account.DEPOSIT],
+		penaltyRate:    interestCfg.DepositPenaltyRate,
+	}
+}
+
+// OpenDeposit открывает срочный вклад: создает счет вклада и переводит на него
+// сумму с текущего счета пользователя. Средства блокируются до даты погашения.
+func (s *DepositService) OpenDeposit(ctx context.Context, userID, sourceAccountID int64, amount decimal.Decimal,
+	termMonths int, autoRollover bool) (*deposit.TermDeposit, error) {
+	if termMonths < 1 || termMonths > maxDepositTermMonths {
+		return nil, ErrInvalidTerm
+	}
+
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, ErrNegativeAmount
+	}
+
+	source, err := s.accountService.GetAccountByID(ctx, sourceAccountID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.Product == account.DEPOSIT {
+		return nil, ErrInvalidSourceAccount
+	}
+
+	if source.Balance.LessThan(amount) {
+		return nil, ErrInsufficientFunds
+	}
+
+	start := truncateToDay(time.Now().UTC())
+	var d *deposit.TermDeposit
+	err = s.accountService.withNewAccountNumber(func(number string) error {
+		var err error
+		d, err = s.depositRepo.OpenDeposit(ctx, &deposit.TermDeposit{
+			UserID:          userID,
+			SourceAccountID: sourceAccountID,
+			Principal:       amount,
+			InterestRate:    s.terms.InterestRate,
+			PenaltyRate:     s.penaltyRate,
+			DayCount:        s.terms.DayCount,
+			TermMonths:      termMonths,
+			StartDate:       start,
+			MaturityDate:    start.AddDate(0, termMonths, 0),
+			AutoRollover:    autoRollover,
+		}, number, source.Currency)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrLimitExceeded) {
+			return nil, ErrInsufficientFunds
+		}
+		return nil, fmt.Errorf("ошибка открытия вклада: %w", err)
+	}
+
+	return d, nil
+}
+
+func (s *DepositService) GetUserDeposits(ctx context.Context, userID int64) ([]*deposit.TermDeposit, error) {
+	return s.depositRepo.GetDepositsByUserID(ctx, userID)
+}
+
+func (s *DepositService) getUserDeposit(ctx context.Context, id, userID int64) (*deposit.TermDeposit, error) {
+	d, err := s.depositRepo.GetDepositByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDepositNotFound
+		}
+		return nil, err
+	}
+
+	if d.UserID != userID {
+		return nil, ErrDepositNotFound
+	}
+
+	return d, nil
+}
+
+// CloseEarly досрочно расторгает вклад. Проценты за фактический срок
+// пересчитываются по штрафной ставке и выплачиваются вместе с телом вклада.
+func (s *DepositService) CloseEarly(ctx context.Context, id, userID int64) (*deposit.TermDeposit, error) {
+	d, err := s.getUserDeposit(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := truncateToDay(time.Now().UTC())
+	interest := DepositInterest(d.Principal, d.PenaltyRate, d.DayCount, d.StartDate, today)
+
+	ok, err := s.depositRepo.Settle(ctx, d, deposit.CLOSED_EARLY, interest)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrDepositNotActive
+	}
+	d.Status = deposit.CLOSED_EARLY
+
+	return d, nil
+}
+
+// ProcessMatured погашает вклады с наступившей датой погашения: выплачивает проценты
+// и либо возвращает средства на исходный счет, либо пролонгирует вклад на тот же срок.
+// Каждый вклад погашается одной транзакцией, поэтому после сбоя вклад остается
+// активным и будет погашен следующим запуском.
+func (s *DepositService) ProcessMatured(ctx context.Context, date time.Time) (int, error) {
+	deposits, err := s.depositRepo.GetMaturedDeposits(ctx, truncateToDay(date))
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения вкладов к погашению: %w", err)
+	}
+
+	processed := 0
+	for _, d := range deposits {
+		interest := DepositInterest(d.Principal, d.InterestRate, d.DayCount, d.StartDate, d.MaturityDate)
+
+		if d.AutoRollover {
+			start := d.MaturityDate
+			_, err = s.depositRepo.Rollover(ctx, d, interest, start, start.AddDate(0, d.TermMonths, 0))
+		} else {
+			_, err = s.depositRepo.Settle(ctx, d, deposit.MATURED, interest)
+		}
+		if err != nil {
+			return processed, fmt.Errorf("ошибка погашения вклада %d: %w", d.ID, err)
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+// DepositInterest рассчитывает простые проценты за период [start, end) с округлением до копеек.
+func DepositInterest(principal, rate decimal.Decimal, dayCount account.DayCount, start, end time.Time) decimal.Decimal {
+	if !end.After(start) {
+		return decimal.Zero
+	}
+
+	var interest decimal.Decimal
+	switch dayCount {
+	case account.THIRTY360:
+		days := days30360(start, end)
+		interest = principal.Mul(rate).Mul(decimal.NewFromInt(int64(days))).Div(decimal.NewFromInt(360))
+	default:
+		days := int64(end.Sub(start).Hours() / 24)
+		interest = principal.Mul(rate).Mul(decimal.NewFromInt(days)).Div(decimal.NewFromInt(365))
+	}
+
+	return interest.RoundBank(2)
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/fee"
+	"github.com/therealadik/bank-api/internal/repository"
+	"github.com/therealadik/bank-api/internal/tracing"
+)
+
+var (
+	ErrUnknownOperation = errors.New("неизвестный тип операции")
+	ErrFeeChanged       = errors.New("комиссия изменилась, подтвердите операцию повторно")
+)
+
+type FeeService struct {
+	feeRepo     *repository.FeeRepository
+	accountRepo *repository.AccountRepository
+}
+
+func NewFeeService(feeRepo *repository.FeeRepository, accountRepo *repository.AccountRepository) *FeeService {
+	return &FeeService{
+		feeRepo:     feeRepo,
+		accountRepo: accountRepo,
+	}
+}
+
+func (s *FeeService) GetRules(ctx context.Context) ([]*fee.Rule, error) {
+	return s.feeRepo.GetAllRules(ctx)
+}
+
+// Quote рассчитывает комиссию за операцию пользователя с учетом его оборота за текущий месяц.
+func (s *FeeService) Quote(ctx context.Context, userID int64, operation fee.Operation, amount decimal.Decimal) (_ *fee.Quote, err error) {
+	ctx, span := tracing.Start(ctx, "FeeService.Quote")
+	defer func() { tracing.End(span, err) }()
+
+	switch operation {
+	case fee.TRANSFER, fee.CARD_PAYMENT, fee.FX_CONVERSION, fee.MAINTENANCE:
+	default:
+		return nil, ErrUnknownOperation
+	}
+
+	if amount.IsNegative() {
+		return nil, ErrNegativeAmount
+	}
+
+	rules, err := s.feeRepo.GetActiveRules(ctx, operation)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения тарифов: %w", err)
+	}
+
+	quote := &fee.Quote{
+		Operation: operation,
+		Amount:    amount,
+		Fee:       decimal.Zero,
+		Total:     amount,
+	}
+
+	if len(rules) == 0 {
+		return quote, nil
+	}
+
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	volume, err := s.feeRepo.GetMonthlyVolume(ctx, userID, operation, monthStart)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расчета оборота: %w", err)
+	}
+
+	// Правила отсортированы по убыванию порога оборота
+	rule := rules[len(rules)-1]
+	for _, r := range rules {
+		if volume.GreaterThanOrEqual(r.VolumeFrom) {
+			rule = r
+			break
+		}
+	}
+
+	quote.RuleID = rule.ID
+	quote.Fee = CalculateFee(rule, amount)
+	quote.Total = amount.Add(quote.Fee)
+
+	return quote, nil
+}
+
+// CheckQuote сверяет рассчитанную комиссию с комиссией, показанной пользователю.
+func CheckQuote(q *fee.Quote, expectedFee decimal.NullDecimal) error {
+	if expectedFee.Valid && !expectedFee.Decimal.Equal(q.Fee) {
+		return ErrFeeChanged
+	}
+	return nil
+}
+
+// CalculateFee применяет правило к сумме операции: фиксированная часть плюс процент,
+// ограниченные минимумом и максимумом.
+func CalculateFee(rule *fee.Rule, amount decimal.Decimal) decimal.Decimal {
+	value := rule.Fixed.Add(amount.Mul(rule.Percent))
+
+	if value.LessThan(rule.MinAmount) {
+		value = rule.MinAmount
+	}
+
+	if rule.MaxAmount.Valid && value.GreaterThan(rule.MaxAmount.Decimal) {
+		value = rule.MaxAmount.Decimal
+	}
+
+	return value.RoundBank(2)
+}
+
+// ChargeMaintenance списывает ежемесячную плату за обслуживание текущих счетов за месяц даты date.
+// Повторный запуск в том же месяце не приводит к двойному списанию. Счета,
+// на которых не хватает средств с учетом овердрафта, пропускаются и
+// возвращаются в skipped: плата будет списана при следующем запуске.
+func (s *FeeService) ChargeMaintenance(ctx context.Context, date time.Time) (charged, skipped int, err error) {
+	period := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	accounts, err := s.accountRepo.GetAccountsByProduct(ctx, account.CURRENT)
+	if err != nil {
+		return 0, 0, fmt.Errorf("ошибка получения текущих счетов: %w", err)
+	}
+
+	for _, acc := range accounts {
+		quote, err := s.Quote(ctx, acc.UserID, fee.MAINTENANCE, decimal.Zero)
+		if err != nil {
+			return charged, skipped, err
+		}
+		if !quote.Fee.IsPositive() {
+			continue
+		}
+
+		created, err := s.feeRepo.ChargePeriodic(ctx, acc.UserID, acc.ID, period, quote)
+		if err != nil {
+			if errors.Is(err, repository.ErrLimitExceeded) {
+				skipped++
+				continue
+			}
+			return charged, skipped, fmt.Errorf("ошибка списания платы за обслуживание счета %d: %w", acc.ID, err)
+		}
+		if created {
+			charged++
+		}
+	}
+
+	return charged, skipped, nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+const readinessTimeout = 2 * time.Second
+
+// Readiness — результат проверки готовности принимать запросы.
+type Readiness struct {
+	Ready            bool
+	Database         error
+	MigrationVersion uint
+	ExpectedVersion  uint
+	Dirty            bool
+}
+
+// HealthService проверяет зависимости сервиса для проб Kubernetes.
+type HealthService struct {
+	schemaRepo      *repository.SchemaRepository
+	expectedVersion uint
+}
+
+// NewHealthService принимает версию схемы, до которой миграции довели БД при
+// запуске: реплика не готова, пока схема старее, например после отката
+// миграций другим экземпляром. Более новая схема допускается: при
+// постепенном обновлении ее накатывает новая версия сервиса, а старые
+// реплики продолжают принимать запросы.
+func NewHealthService(schemaRepo *repository.SchemaRepository, expectedVersion uint) *HealthService {
+	return &HealthService{
+		schemaRepo:      schemaRepo,
+		expectedVersion: expectedVersion,
+	}
+}
+
+func (s *HealthService) Readiness(ctx context.Context) Readiness {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	r := Readiness{ExpectedVersion: s.expectedVersion}
+
+	if err := s.schemaRepo.Ping(ctx); err != nil {
+		r.Database = err
+		return r
+	}
+
+	version, dirty, err := s.schemaRepo.MigrationVersion(ctx)
+	if err != nil {
+		r.Database = err
+		return r
+	}
+
+	r.MigrationVersion = version
+	r.Dirty = dirty
+	r.Ready = !dirty && version >= s.expectedVersion
+	return r
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// signHMAC возвращает HMAC-SHA256 сообщения в шестнадцатеричном виде.
+func signHMAC(key []byte, message string) string {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(message))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// checkHMAC сравнивает подпись с ожидаемой за постоянное время.
+func checkHMAC(key []byte, message, signature string) bool {
+	expectedMAC, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return hmac.Equal(mac.Sum(nil), expectedMAC)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+type InterestService struct {
+	accountRepo  *repository.AccountRepository
+	interestRepo *repository.InterestRepository
+}
+
+func NewInterestService(accountRepo *repository.AccountRepository, interestRepo *repository.InterestRepository) *InterestService {
+	return &InterestService{
+		accountRepo:  accountRepo,
+		interestRepo: interestRepo,
+	}
+}
+
+// AccrueDaily начисляет проценты за день date по всем процентным счетам
+// исходя из остатка на конец этого дня, а не на момент запуска задачи.
+// Повторный запуск за ту же дату ничего не меняет.
+func (s *InterestService) AccrueDaily(ctx context.Context, date time.Time) (int, error) {
+	date = truncateToDay(date)
+
+	accounts, err := s.accountRepo.GetInterestBearingAccounts(ctx, date.AddDate(0, 0, 1))
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения процентных счетов: %w", err)
+	}
+
+	accrued := 0
+	for _, acc := range accounts {
+		amount := DailyInterest(acc.Balance, acc.InterestRate, acc.DayCount, date)
+		if !amount.IsPositive() {
+			continue
+		}
+
+		created, err := s.interestRepo.CreateAccrual(ctx, acc.ID, date, amount)
+		if err != nil {
+			return accrued, fmt.Errorf("ошибка начисления процентов по счету %d: %w", acc.ID, err)
+		}
+		if created {
+			accrued++
+		}
+	}
+
+	return accrued, nil
+}
+
+// Capitalize зачисляет на баланс проценты, начисленные за месяцы до месяца даты date.
+func (s *InterestService) Capitalize(ctx context.Context, date time.Time) (decimal.Decimal, error) {
+	monthStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	accountIDs, err := s.interestRepo.GetAccountsWithPendingAccruals(ctx, monthStart)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("ошибка получения счетов для капитализации: %w", err)
+	}
+
+	total := decimal.Zero
+	for _, id := range accountIDs {
+		amount, err := s.interestRepo.CapitalizeAccruals(ctx, id, monthStart)
+		if err != nil {
+			return total, fmt.Errorf("ошибка капитализации процентов по счету %d: %w", id, err)
+		}
+		total = total.Add(amount)
+	}
+
+	return total, nil
+}
+
+// ChargeOverdraft списывает проценты за день date по счетам с отрицательным
+// остатком на конец этого дня. Выход баланса за лимит овердрафта после
+// списания публикуется событием OverdraftLimitBreached.
+func (s *InterestService) ChargeOverdraft(ctx context.Context, date time.Time) (int, error) {
+	date = truncateToDay(date)
+
+	accounts, err := s.accountRepo.GetOverdrawnAccounts(ctx, date.AddDate(0, 0, 1))
+	if err != nil {
+		return 0, fmt.Errorf("ошибка получения счетов в овердрафте: %w", err)
+	}
+
+	charged := 0
+	for _, acc := range accounts {
+		amount := DailyInterest(acc.Balance.Abs(), acc.OverdraftRate, acc.DayCount, date).RoundBank(2)
+		if !amount.IsPositive() {
+			continue
+		}
+
+		created, err := s.interestRepo.ChargeOverdraftInterest(ctx, acc.ID, date, acc.Balance, amount)
+		if err != nil {
+			return charged, fmt.Errorf("ошибка списания процентов по овердрафту счета %d: %w", acc.ID, err)
+		}
+		if created {
+			charged++
+		}
+	}
+
+	return charged, nil
+}
+
+// DailyInterest рассчитывает проценты за один день date по годовой ставке rate.
+func DailyInterest(balance, rate decimal.Decimal, dayCount account.DayCount, date time.Time) decimal.Decimal {
+	switch dayCount {
+	case account.THIRTY360:
+		days := days30360(date, date.AddDate(0, 0, 1))
+		return balance.Mul(rate).Mul(decimal.NewFromInt(int64(days))).Div(decimal.NewFromInt(360))
+	default:
+		return balance.Mul(rate).Div(decimal.NewFromInt(365))
+	}
+}
+
+// days30360 считает число дней между датами по конвенции 30/360 (bond basis),
+// благодаря чему сумма дневных начислений за любой месяц равна 30 дням.
+func days30360(start, end time.Time) int {
+	d1, d2 := start.Day(), end.Day()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+
+	return 360*(end.Year()-start.Year()) + 30*(int(end.Month())-int(start.Month())) + d2 - d1
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models/account"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDays30360(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       int
+	}{
+		{"обычный день", date(2024, 3, 14), date(2024, 3, 15), 1},
+		{"30-е число месяца из 31 дня", date(2024, 1, 30), date(2024, 1, 31), 0},
+		{"31-е число", date(2024, 1, 31), date(2024, 2, 1), 1},
+		{"конец февраля", date(2023, 2, 28), date(2023, 3, 1), 3},
+		{"конец февраля високосного года", date(2024, 2, 29), date(2024, 3, 1), 2},
+		{"переход года", date(2023, 12, 31), date(2024, 1, 1), 1},
+		{"полный месяц", date(2024, 1, 1), date(2024, 2, 1), 30},
+		{"полный год", date(2023, 6, 15), date(2024, 6, 15), 360},
+		{"от 31-го до 31-го", date(2024, 1, 31), date(2024, 3, 31), 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := days30360(tt.start, tt.end); got != tt.want {
+				t.Errorf("days30360(%s, %s) = %d, ожидалось %d",
+					tt.start.Format("2006-01-02"), tt.end.Format("2006-01-02"), got, tt.want)
+			}
+		})
+	}
+}
+
+// Сумма дневных начислений по 30/360 за любой месяц должна равняться 30 дням.
+func TestDays30360MonthSum(t *testing.T) {
+	for _, year := range []int{2023, 2024} {
+		for month := time.January; month <= time.December; month++ {
+			total := 0
+			for d := date(year, month, 1); d.Month() == month; d = d.AddDate(0, 0, 1) {
+				total += days30360(d, d.AddDate(0, 0, 1))
+			}
+			if total != 30 {
+				t.Errorf("%d-%02d: сумма дней %d, ожидалось 30", year, month, total)
+			}
+		}
+	}
+}
+
+func TestDailyInterest(t *testing.T) {
+	balance := decimal.RequireFromString("1000")
+	rate := decimal.RequireFromString("0.0365")
+
+	tests := []struct {
+		name     string
+		dayCount account.DayCount
+		day      time.Time
+		want     string
+	}{
+		{"ACT/365", account.ACT365, date(2024, 3, 14), "0.1"},
+		{"ACT/365 в високосный год", account.ACT365, date(2024, 2, 29), "0.1"},
+		{"30/360 обычный день", account.THIRTY360, date(2024, 3, 14), "0.1013888888888889"},
+		{"30/360 за 30-е число", account.THIRTY360, date(2024, 1, 30), "0"},
+		{"30/360 за 28 февраля", account.THIRTY360, date(2023, 2, 28), "0.3041666666666667"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DailyInterest(balance, rate, tt.dayCount, tt.day)
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("DailyInterest = %s, ожидалось %s", got, tt.want)
+			}
+		})
+	}
+}
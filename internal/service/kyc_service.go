@@ -0,0 +1,325 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/blob"
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/models/kyc"
+	"github.com/therealadik/bank-api/internal/models/screening"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+const (
+	maxKYCQueueSize = 100
+	minCustomerAge  = 18
+)
+
+var (
+	ErrKYCRequired          = errors.New("операция доступна после подтверждения личности")
+	ErrKYCProfileLocked     = errors.New("анкета на проверке или уже подтверждена")
+	ErrKYCProfileIncomplete = errors.New("заполните анкету: имя, дата рождения, гражданство и адрес")
+	ErrKYCDocumentsMissing  = errors.New("загрузите документ, удостоверяющий личность")
+	ErrKYCNotPending        = errors.New("анкета не ожидает проверки")
+	ErrKYCRejectReason      = errors.New("укажите причину отказа")
+	ErrInvalidDateOfBirth   = errors.New("неверная дата рождения")
+	ErrInvalidCitizenship   = errors.New("гражданство указывается двухбуквенным кодом ISO 3166")
+	ErrUnknownDocumentType  = errors.New("неизвестный тип документа")
+	ErrDocumentTooLarge     = errors.New("файл документа слишком большой")
+	ErrDocumentFormat       = errors.New("документ должен быть в формате JPEG, PNG или PDF")
+	ErrDocumentNotFound     = errors.New("документ не найден")
+)
+
+// documentContentTypes — допустимые форматы документов. Формат определяется
+// по содержимому файла, а не по заявленному клиентом типу.
+var documentContentTypes = []string{"image/jpeg", "image/png", "application/pdf"}
+
+// KYCService ведет анкету клиента, документы и проверку личности.
+// Выпуск карт и крупные переводы доступны только после подтверждения.
+type KYCService struct {
+	kycRepo          *repository.KYCRepository
+	userRepo         repository.UserRepository
+	screeningService *ScreeningService
+	store            blob.Store
+	cfg              config.KYCConfig
+}
+
+func NewKYCService(kycRepo *repository.KYCRepository, userRepo repository.UserRepository,
+	screeningService *ScreeningService, store blob.Store, cfg config.KYCConfig) *KYCService {
+	return &KYCService{
+		kycRepo:          kycRepo,
+		userRepo:         userRepo,
+		screeningService: screeningService,
+		store:            store,
+		cfg:              cfg,
+	}
+}
+
+func (s *KYCService) GetProfile(ctx context.Context, userID int64) (*kyc.Profile, error) {
+	return s.kycRepo.GetProfile(ctx, userID)
+}
+
+// UpdateProfile сохраняет анкету, пока она не отправлена на проверку.
+// Новое имя проверяется по санкционным спискам так же, как при регистрации.
+func (s *KYCService) UpdateProfile(ctx context.Context, userID int64, fullName, dateOfBirth, citizenship,
+	address string) (*kyc.Profile, error) {
+	fullName = strings.TrimSpace(fullName)
+	if fullName == "" {
+		return nil, ErrFullNameRequired
+	}
+
+	var dob *time.Time
+	if dateOfBirth != "" {
+		d, err := time.Parse("2006-01-02", dateOfBirth)
+		if err != nil || d.After(time.Now()) {
+			return nil, ErrInvalidDateOfBirth
+		}
+		dob = &d
+	}
+
+	citizenship = strings.ToUpper(strings.TrimSpace(citizenship))
+	if citizenship != "" && (len(citizenship) != 2 || strings.Trim(citizenship, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "") {
+		return nil, ErrInvalidCitizenship
+	}
+
+	current, err := s.kycRepo.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.kycRepo.SaveProfile(ctx, &kyc.Profile{
+		UserID:      userID,
+		FullName:    &fullName,
+		DateOfBirth: dob,
+		Citizenship: citizenship,
+		Address:     strings.TrimSpace(address),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrProfileLocked) {
+			return nil, ErrKYCProfileLocked
+		}
+		return nil, err
+	}
+
+	if current.FullName == nil || *current.FullName != fullName {
+		if matches := s.screeningService.Screen(fullName); len(matches) > 0 {
+			if err := s.userRepo.SetScreeningStatus(ctx, userID, screening.USER_HELD); err != nil {
+				return nil, err
+			}
+			if err := s.screeningService.RecordUserHit(ctx, userID, fullName, matches); err != nil {
+				return nil, fmt.Errorf("ошибка сохранения совпадения: %w", err)
+			}
+		}
+	}
+
+	return s.kycRepo.GetProfile(ctx, userID)
+}
+
+func (s *KYCService) GetDocuments(ctx context.Context, userID int64) ([]*kyc.Document, error) {
+	return s.kycRepo.GetDocuments(ctx, userID)
+}
+
+// UploadDocument сохраняет документ в хранилище файлов. Загружать документы
+// можно, пока анкета не отправлена на проверку.
+func (s *KYCService) UploadDocument(ctx context.Context, userID int64, docType kyc.DocumentType, fileName string,
+	r io.Reader) (*kyc.Document, error) {
+	if !slices.Contains(kyc.DocumentTypes, docType) {
+		return nil, ErrUnknownDocumentType
+	}
+
+	status, err := s.kycRepo.GetStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if status != kyc.UNVERIFIED && status != kyc.REJECTED {
+		return nil, ErrKYCProfileLocked
+	}
+
+	br := bufio.NewReaderSize(r, 512)
+	head, err := br.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	contentType := http.DetectContentType(head)
+	if !slices.Contains(documentContentTypes, contentType) {
+		return nil, ErrDocumentFormat
+	}
+
+	key, err := documentKey(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	size, err := s.store.Put(ctx, key, io.TeeReader(io.LimitReader(br, s.cfg.MaxDocumentSize+1), hash))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка сохранения документа: %w", err)
+	}
+
+	if size > s.cfg.MaxDocumentSize {
+		_ = s.store.Delete(ctx, key)
+		return nil, ErrDocumentTooLarge
+	}
+
+	d := &kyc.Document{
+		UserID:      userID,
+		Type:        docType,
+		FileName:    filepath.Base(fileName),
+		ContentType: contentType,
+		Size:        size,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+	}
+	if err := s.kycRepo.CreateDocument(ctx, d); err != nil {
+		_ = s.store.Delete(ctx, key)
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func documentKey(userID int64) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("kyc/%d/%s", userID, hex.EncodeToString(b)), nil
+}
+
+// OpenDocument возвращает документ клиента и его содержимое. Вызывающий
+// закрывает reader.
+func (s *KYCService) OpenDocument(ctx context.Context, userID, id int64) (*kyc.Document, io.ReadCloser, error) {
+	d, err := s.kycRepo.GetDocument(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrDocumentNotFound
+		}
+		return nil, nil, err
+	}
+
+	rc, err := s.store.Get(ctx, d.StorageKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, nil, ErrDocumentNotFound
+		}
+		return nil, nil, err
+	}
+	return d, rc, nil
+}
+
+// Submit отправляет заполненную анкету с документом, удостоверяющим
+// личность, на проверку оператору.
+func (s *KYCService) Submit(ctx context.Context, userID int64) (*kyc.Profile, error) {
+	p, err := s.kycRepo.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Status != kyc.UNVERIFIED && p.Status != kyc.REJECTED {
+		return nil, ErrKYCProfileLocked
+	}
+
+	if p.FullName == nil || p.DateOfBirth == nil || p.Citizenship == "" || p.Address == "" {
+		return nil, ErrKYCProfileIncomplete
+	}
+
+	if age(*p.DateOfBirth, time.Now()) < minCustomerAge {
+		return nil, ErrInvalidDateOfBirth
+	}
+
+	documents, err := s.kycRepo.GetDocuments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.ContainsFunc(documents, func(d *kyc.Document) bool {
+		return slices.Contains(kyc.IdentityDocuments, d.Type)
+	}) {
+		return nil, ErrKYCDocumentsMissing
+	}
+
+	ok, err := s.kycRepo.Transition(ctx, userID, []kyc.Status{kyc.UNVERIFIED, kyc.REJECTED}, kyc.PENDING, "")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrKYCProfileLocked
+	}
+
+	return s.kycRepo.GetProfile(ctx, userID)
+}
+
+func age(dob, now time.Time) int {
+	years := now.Year() - dob.Year()
+	if now.Month() < dob.Month() || now.Month() == dob.Month() && now.Day() < dob.Day() {
+		years--
+	}
+	return years
+}
+
+// GetPending возвращает анкеты, ожидающие проверки.
+func (s *KYCService) GetPending(ctx context.Context) ([]*kyc.Profile, error) {
+	return s.kycRepo.GetProfilesByStatus(ctx, kyc.PENDING, maxKYCQueueSize)
+}
+
+func (s *KYCService) Verify(ctx context.Context, userID int64) (*kyc.Profile, error) {
+	return s.review(ctx, userID, kyc.VERIFIED, "")
+}
+
+// Reject отклоняет анкету с причиной; клиент может исправить анкету
+// и отправить ее повторно.
+func (s *KYCService) Reject(ctx context.Context, userID int64, reason string) (*kyc.Profile, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrKYCRejectReason
+	}
+	return s.review(ctx, userID, kyc.REJECTED, reason)
+}
+
+func (s *KYCService) review(ctx context.Context, userID int64, status kyc.Status, reason string) (*kyc.Profile, error) {
+	ok, err := s.kycRepo.Transition(ctx, userID, []kyc.Status{kyc.PENDING}, status, reason)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrKYCNotPending
+	}
+	return s.kycRepo.GetProfile(ctx, userID)
+}
+
+// RequireVerified возвращает ErrKYCRequired, если личность клиента не
+// подтверждена.
+func (s *KYCService) RequireVerified(ctx context.Context, userID int64) error {
+	status, err := s.kycRepo.GetStatus(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if status != kyc.VERIFIED {
+		return ErrKYCRequired
+	}
+	return nil
+}
+
+// CheckTransfer требует подтвержденной личности для переводов выше
+// KYC_TRANSFER_LIMIT.
+func (s *KYCService) CheckTransfer(ctx context.Context, userID int64, amount decimal.Decimal) error {
+	if amount.LessThanOrEqual(s.cfg.TransferLimit) {
+		return nil
+	}
+	return s.RequireVerified(ctx, userID)
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/models/lockout"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+var (
+	ErrTooManyAttempts     = errors.New("слишком много неудачных попыток")
+	ErrLockoutNotFound     = errors.New("активная блокировка не найдена")
+	ErrUnknownLockoutScope = errors.New("неизвестный вид блокировки")
+)
+
+// AttemptsError — попытка отклонена до проверки: субъект заблокирован или
+// не выждал паузу после предыдущей неудачи.
+type AttemptsError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+func (e *AttemptsError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("%s: временная блокировка еще %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("%s: повторите через %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *AttemptsError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+// LockoutService защищает проверки секретов от перебора. Вызывающий код
+// регистрирует попытку через Begin до проверки и по ее итогу вызывает
+// Succeed, Fail или Cancel.
+type LockoutService struct {
+	lockoutRepo *repository.LockoutRepository
+	cfg         config.LockoutConfig
+	logger      *logrus.Logger
+}
+
+func NewLockoutService(lockoutRepo *repository.LockoutRepository, cfg config.LockoutConfig,
+	logger *logrus.Logger) *LockoutService {
+	return &LockoutService{
+		lockoutRepo: lockoutRepo,
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+func (s *LockoutService) policy(scope lockout.Scope) lockout.Policy {
+	switch scope {
+	case lockout.IP:
+		return s.cfg.IP
+	case lockout.CARD:
+		return s.cfg.Card
+	default:
+		return s.cfg.Account
+	}
+}
+
+// accountSubject — субъект блокировки учетной записи: нормализованный email.
+func accountSubject(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Begin регистрирует попытку субъекта или возвращает *AttemptsError.
+func (s *LockoutService) Begin(ctx context.Context, scope lockout.Scope, subject string) (*lockout.Attempt, error) {
+	a, retryAfter, locked, err := s.lockoutRepo.Begin(ctx, scope, subject, s.policy(scope))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка учета попытки: %w", err)
+	}
+	if a == nil {
+		return nil, &AttemptsError{RetryAfter: retryAfter, Locked: locked}
+	}
+	return a, nil
+}
+
+// Succeed снимает попытки с учета. Для учетной записи и карты успешная
+// проверка сбрасывает счетчик неудач, для адреса снимается только эта
+// попытка: иначе вход в свою учетную запись обнулял бы перебор чужих.
+func (s *LockoutService) Succeed(ctx context.Context, attempts ...*lockout.Attempt) error {
+	for _, a := range attempts {
+		var err error
+		if a.Scope == lockout.IP {
+			err = s.lockoutRepo.DeleteAttempt(ctx, a.ID)
+		} else {
+			err = s.lockoutRepo.ClearAttempts(ctx, a.Scope, a.Subject)
+		}
+		if err != nil {
+			return fmt.Errorf("ошибка сброса попыток: %w", err)
+		}
+	}
+	return nil
+}
+
+// Fail засчитывает неудачу и при превышении порога блокирует субъект.
+func (s *LockoutService) Fail(ctx context.Context, attempts ...*lockout.Attempt) error {
+	for _, a := range attempts {
+		l, err := s.lockoutRepo.Fail(ctx, a, s.policy(a.Scope))
+		if err != nil {
+			return fmt.Errorf("ошибка учета неудачной попытки: %w", err)
+		}
+		if l != nil {
+			s.logger.Warnf("Блокировка %d: %s %s заблокирован до %s после %d неудачных попыток",
+				l.ID, l.Scope, l.Subject, l.LockedUntil.Format(time.RFC3339), l.Failures)
+		}
+	}
+	return nil
+}
+
+// Cancel снимает попытки, проверка которых не состоялась из-за внутренней
+// ошибки. Ошибки только логируются, чтобы не скрыть исходную.
+func (s *LockoutService) Cancel(ctx context.Context, attempts ...*lockout.Attempt) {
+	for _, a := range attempts {
+		if err := s.lockoutRepo.DeleteAttempt(ctx, a.ID); err != nil {
+			s.logger.Errorf("Ошибка отмены попытки %d: %v", a.ID, err)
+		}
+	}
+}
+
+// PruneAttempts удаляет попытки, вышедшие за окно своей политики.
+func (s *LockoutService) PruneAttempts(ctx context.Context) (int64, error) {
+	var total int64
+	for _, scope := range []lockout.Scope{lockout.ACCOUNT, lockout.IP, lockout.CARD} {
+		n, err := s.lockoutRepo.PruneAttempts(ctx, scope, s.policy(scope).Window)
+		if err != nil {
+			return total, fmt.Errorf("ошибка удаления устаревших попыток %s: %w", scope, err)
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// GetActive возвращает действующие блокировки; пустой scope — всех видов.
+func (s *LockoutService) GetActive(ctx context.Context, scope lockout.Scope) ([]*lockout.Lockout, error) {
+	switch scope {
+	case "", lockout.ACCOUNT, lockout.IP, lockout.CARD:
+	default:
+		return nil, ErrUnknownLockoutScope
+	}
+	return s.lockoutRepo.GetActive(ctx, scope)
+}
+
+// Release досрочно снимает блокировку по решению оператора.
+func (s *LockoutService) Release(ctx context.Context, id int64) (*lockout.Lockout, error) {
+	l, err := s.lockoutRepo.Release(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrLockoutNotFound) {
+			return nil, ErrLockoutNotFound
+		}
+		return nil, fmt.Errorf("ошибка снятия блокировки: %w", err)
+	}
+
+	s.logger.Infof("Блокировка %d снята оператором: %s %s", l.ID, l.Scope, l.Subject)
+	return l, nil
+}
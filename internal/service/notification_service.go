@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/events"
+	"github.com/therealadik/bank-api/internal/i18n"
+	"github.com/therealadik/bank-api/internal/mailer"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/notification"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+const (
+	maxInboxSize = 100
+	// emailClaimLease — время, на которое выбранное письмо скрывается от
+	// других экземпляров на время отправки.
+	emailClaimLease = 5 * time.Minute
+)
+
+var (
+	ErrUnsupportedLocale       = errors.New("язык уведомлений не поддерживается")
+	ErrUnknownNotificationKind = errors.New("неизвестный вид уведомления")
+	ErrUnknownChannel          = errors.New("неизвестный канал уведомлений")
+	ErrNotificationNotFound    = errors.New("уведомление не найдено")
+)
+
+// NotificationService формирует уведомления пользователей по доменным
+// событиям и реализует events.EventPublisher. Уведомление попадает во
+// входящие и/или в очередь писем в зависимости от настроек пользователя;
+// письма отправляет SendDueEmails.
+type NotificationService struct {
+	notificationRepo *repository.NotificationRepository
+	userRepo         repository.UserRepository
+	accountRepo      *repository.AccountRepository
+	mailer           mailer.Mailer
+	cfg              config.NotificationConfig
+}
+
+func NewNotificationService(notificationRepo *repository.NotificationRepository, userRepo repository.UserRepository,
+	accountRepo *repository.AccountRepository, m mailer.Mailer, cfg config.NotificationConfig) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		accountRepo:      accountRepo,
+		mailer:           m,
+		cfg:              cfg,
+	}
+}
+
+// Publish создает уведомление, если событие его предусматривает. Повторная
+// публикация того же события не создает дублей.
+func (s *NotificationService) Publish(ctx context.Context, e events.Event) error {
+	switch e.Type {
+	case events.USER_REGISTERED:
+		var payload events.UserRegistered
+		if err := json.Unmarshal(e.Payload, &payload); err != nil {
+			return fmt.Errorf("ошибка разбора события %d: %w", e.ID, err)
+		}
+		return s.notify(ctx, payload.UserID, e.ID, notification.REGISTRATION, func(notification.Locale) any {
+			return map[string]any{"Email": payload.Email}
+		})
+
+	case events.CARD_ISSUED:
+		var payload events.CardIssued
+		if err := json.Unmarshal(e.Payload, &payload); err != nil {
+			return fmt.Errorf("ошибка разбора события %d: %w", e.ID, err)
+		}
+		return s.notify(ctx, payload.UserID, e.ID, notification.CARD_ISSUED, func(notification.Locale) any {
+			return map[string]any{"CardID": payload.CardID}
+		})
+
+	case events.TRANSFER_COMPLETED:
+		var payload events.TransferCompleted
+		if err := json.Unmarshal(e.Payload, &payload); err != nil {
+			return fmt.Errorf("ошибка разбора события %d: %w", e.ID, err)
+		}
+		if payload.Amount.LessThan(s.cfg.LargeTransferThreshold) {
+			return nil
+		}
+
+		acc, err := s.accountRepo.GetAccountByID(ctx, payload.FromAccountID)
+		if err != nil {
+			return fmt.Errorf("ошибка получения счета %d: %w", payload.FromAccountID, err)
+		}
+		return s.notify(ctx, acc.UserID, e.ID, notification.LARGE_TRANSFER, func(notification.Locale) any {
+			return map[string]any{
+				"AccountNumber": accountLabel(acc),
+				"Amount":        payload.Amount.StringFixed(2),
+				"Fee":           payload.Fee.StringFixed(2),
+				"Currency":      acc.Currency,
+			}
+		})
+
+	case events.OVERDRAFT_LIMIT_BREACHED:
+		var payload events.OverdraftLimitBreached
+		if err := json.Unmarshal(e.Payload, &payload); err != nil {
+			return fmt.Errorf("ошибка разбора события %d: %w", e.ID, err)
+		}
+
+		acc, err := s.accountRepo.GetAccountByID(ctx, payload.AccountID)
+		if err != nil {
+			return fmt.Errorf("ошибка получения счета %d: %w", payload.AccountID, err)
+		}
+		return s.notify(ctx, acc.UserID, e.ID, notification.OVERDRAFT_LIMIT_BREACHED, func(notification.Locale) any {
+			return map[string]any{
+				"AccountNumber":  accountLabel(acc),
+				"Balance":        payload.Balance.StringFixed(2),
+				"OverdraftLimit": payload.OverdraftLimit.StringFixed(2),
+				"Currency":       acc.Currency,
+			}
+		})
+	}
+
+	return nil
+}
+
+// notify формирует уведомление на языке пользователя и сохраняет его для
+// включенных каналов. data получает выбранный язык, чтобы форматировать даты.
+func (s *NotificationService) notify(ctx context.Context, userID, eventID int64, kind notification.Kind,
+	data func(notification.Locale) any) error {
+	settings, err := s.GetSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	inApp := settings.Enabled(kind, notification.IN_APP)
+	email := settings.Enabled(kind, notification.EMAIL)
+	if !inApp && !email {
+		return nil
+	}
+
+	subject, body, err := renderNotification(kind, settings.Locale, data(settings.Locale))
+	if err != nil {
+		return fmt.Errorf("ошибка формирования уведомления: %w", err)
+	}
+
+	var recipient string
+	if email {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("ошибка получения пользователя %d: %w", userID, err)
+		}
+		recipient = user.Email
+	}
+
+	_, err = s.notificationRepo.Create(ctx, &notification.Notification{
+		UserID:  userID,
+		EventID: eventID,
+		Kind:    kind,
+		Subject: subject,
+		Body:    body,
+		InApp:   inApp,
+	}, recipient)
+	return err
+}
+
+// GetSettings возвращает настройки пользователя; если язык не выбран,
+// подставляется язык по умолчанию.
+func (s *NotificationService) GetSettings(ctx context.Context, userID int64) (*notification.Settings, error) {
+	settings, err := s.notificationRepo.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.Locale == "" {
+		settings.Locale = notification.Locale(s.cfg.DefaultLocale)
+	}
+	return settings, nil
+}
+
+// UpdateSettings меняет язык и предпочтения каналов. Пустой язык оставляет
+// текущий без изменений.
+func (s *NotificationService) UpdateSettings(ctx context.Context, userID int64, locale notification.Locale,
+	prefs []notification.Preference) (*notification.Settings, error) {
+	if locale != "" && !i18n.Supported(locale) {
+		return nil, ErrUnsupportedLocale
+	}
+
+	for _, p := range prefs {
+		if !slices.Contains(notification.Kinds, p.Kind) {
+			return nil, ErrUnknownNotificationKind
+		}
+		if !slices.Contains(notification.Channels, p.Channel) {
+			return nil, ErrUnknownChannel
+		}
+	}
+
+	if err := s.notificationRepo.SaveSettings(ctx, userID, locale, prefs); err != nil {
+		return nil, err
+	}
+
+	return s.GetSettings(ctx, userID)
+}
+
+// GetInbox возвращает последние уведомления входящих и число непрочитанных.
+func (s *NotificationService) GetInbox(ctx context.Context, userID int64,
+	unreadOnly bool) ([]*notification.Notification, int, error) {
+	notifications, err := s.notificationRepo.GetInbox(ctx, userID, unreadOnly, maxInboxSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	unread, err := s.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, unread, nil
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, id, userID int64) error {
+	updated, err := s.notificationRepo.MarkRead(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if !updated {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID int64) (int, error) {
+	return s.notificationRepo.MarkAllRead(ctx, userID)
+}
+
+// SendDueEmails отправляет письма, время попытки которых наступило.
+// Неуспешная отправка повторяется с экспоненциальной задержкой, а после
+// исчерпания попыток письмо помечается FAILED.
+func (s *NotificationService) SendDueEmails(ctx context.Context) (int, error) {
+	deliveries, err := s.notificationRepo.ClaimDueEmails(ctx, s.cfg.BatchSize, emailClaimLease)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка выбора писем: %w", err)
+	}
+
+	sent := 0
+	for _, d := range deliveries {
+		err := s.mailer.Send(ctx, mailer.Message{
+			To:      d.Recipient,
+			Subject: d.Subject,
+			Body:    d.Body,
+		})
+
+		d.Attempts++
+		if err == nil {
+			now := time.Now()
+			d.Status = notification.EMAIL_SENT
+			d.SentAt = &now
+			d.LastError = ""
+			sent++
+		} else {
+			d.LastError = err.Error()
+			if d.Attempts >= s.cfg.MaxAttempts {
+				d.Status = notification.EMAIL_FAILED
+			} else {
+				d.NextAttemptAt = time.Now().Add(retryBackoff(s.cfg.BaseBackoff, s.cfg.MaxBackoff, d.Attempts))
+			}
+		}
+
+		if err := s.notificationRepo.UpdateEmailDelivery(ctx, d); err != nil {
+			return sent, fmt.Errorf("ошибка сохранения письма %d: %w", d.ID, err)
+		}
+	}
+
+	return sent, nil
+}
+
+// accountLabel возвращает номер счета, а для счетов без номера — ID.
+func accountLabel(acc *account.Account) string {
+	if acc.AccountNumber != nil {
+		return *acc.AccountNumber
+	}
+	return "#" + strconv.FormatInt(acc.ID, 10)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/therealadik/bank-api/internal/i18n"
+	"github.com/therealadik/bank-api/internal/models/notification"
+)
+
+type notificationTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// notificationTemplates разбирает тексты уведомлений из каталога сообщений
+// для каждого вида и языка.
+var notificationTemplates = func() map[notification.Kind]map[notification.Locale]notificationTemplate {
+	templates := make(map[notification.Kind]map[notification.Locale]notificationTemplate, len(notification.Kinds))
+	for _, kind := range notification.Kinds {
+		byLocale := make(map[notification.Locale]notificationTemplate, len(i18n.Locales))
+		for _, locale := range i18n.Locales {
+			byLocale[locale] = newNotificationTemplate(
+				i18n.T(locale, "notification."+string(kind)+".subject"),
+				i18n.T(locale, "notification."+string(kind)+".body"),
+			)
+		}
+		templates[kind] = byLocale
+	}
+	return templates
+}()
+
+func newNotificationTemplate(subject, body string) notificationTemplate {
+	return notificationTemplate{
+		subject: template.Must(template.New("subject").Parse(subject)),
+		body:    template.Must(template.New("body").Parse(body)),
+	}
+}
+
+// renderNotification подставляет данные в шаблон на языке пользователя.
+// Если перевода нет, используется русский шаблон.
+func renderNotification(kind notification.Kind, locale notification.Locale, data any) (string, string, error) {
+	byLocale, ok := notificationTemplates[kind]
+	if !ok {
+		return "", "", fmt.Errorf("нет шаблона уведомления %s", kind)
+	}
+
+	tmpl, ok := byLocale[locale]
+	if !ok {
+		tmpl = byLocale[i18n.Default]
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&body, data); err != nil {
+		return "", "", err
+	}
+
+	return subject.String(), body.String(), nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/i18n"
+	"github.com/therealadik/bank-api/internal/mailer"
+	"github.com/therealadik/bank-api/internal/models"
+	"github.com/therealadik/bank-api/internal/models/lockout"
+	"github.com/therealadik/bank-api/internal/repository"
+	"github.com/therealadik/bank-api/internal/sms"
+)
+
+var ErrInvalidCode = errors.New("неверный или устаревший код подтверждения")
+
+const otpDigits = 6
+
+// OTPService выпускает и проверяет одноразовые коды подтверждения. Код
+// привязан к пользователю, назначению и подтверждаемому значению, поэтому
+// код, отправленный для одного номера, не подтвердит другой. Шесть цифр
+// перебираются быстро, так что проверки учитываются в блокировке учетной
+// записи наравне с попытками входа.
+type OTPService struct {
+	tokenRepo      *repository.AuthTokenRepository
+	lockoutService *LockoutService
+	mailer         mailer.Mailer
+	sms            sms.Sender
+	cfg            config.AuthTokensConfig
+	logger         *logrus.Logger
+}
+
+func NewOTPService(tokenRepo *repository.AuthTokenRepository, lockoutService *LockoutService, m mailer.Mailer,
+	smsSender sms.Sender, cfg config.AuthTokensConfig, logger *logrus.Logger) *OTPService {
+	return &OTPService{
+		tokenRepo:      tokenRepo,
+		lockoutService: lockoutService,
+		mailer:         m,
+		sms:            smsSender,
+		cfg:            cfg,
+		logger:         logger,
+	}
+}
+
+// Send выпускает новый код для подтверждения value и отзывает прежние коды
+// того же назначения. Код подтверждения телефона уходит по SMS на сам номер,
+// остальные — письмом на адрес пользователя. Отправка идет в фоне.
+func (s *OTPService) Send(ctx context.Context, user *models.User, purpose models.TokenPurpose, value string) error {
+	code, err := newCode()
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(s.cfg.CodeTTL)
+	if err := s.tokenRepo.Create(ctx, user.ID, purpose, codeHash(user.ID, purpose, value, code), expiresAt); err != nil {
+		return fmt.Errorf("ошибка сохранения кода: %w", err)
+	}
+
+	locale := i18n.FromContext(ctx)
+	key := "otp." + strings.ToLower(string(purpose))
+	text := i18n.T(locale, key+".body", code, int(s.cfg.CodeTTL.Minutes()))
+	subject := i18n.T(locale, key+".subject")
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.Background(), s.cfg.SendTimeout)
+		defer cancel()
+
+		var err error
+		if purpose == models.PHONE_VERIFY {
+			err = s.sms.Send(sendCtx, value, text)
+		} else {
+			err = s.mailer.Send(sendCtx, mailer.Message{To: user.Email, Subject: subject, Body: text})
+		}
+		if err != nil {
+			s.logger.Errorf("Ошибка отправки кода %s пользователю %d: %v", purpose, user.ID, err)
+		}
+	}()
+	return nil
+}
+
+// Verify гасит код, выпущенный для value. Неверный код засчитывается как
+// неудачная попытка входа в учетную запись пользователя.
+func (s *OTPService) Verify(ctx context.Context, user *models.User, purpose models.TokenPurpose, value, code string) error {
+	a, err := s.lockoutService.Begin(ctx, lockout.ACCOUNT, accountSubject(user.Email))
+	if err != nil {
+		return err
+	}
+
+	if err := s.consume(ctx, user, purpose, value, code); err != nil {
+		if errors.Is(err, ErrInvalidCode) {
+			if failErr := s.lockoutService.Fail(ctx, a); failErr != nil {
+				return failErr
+			}
+			return ErrInvalidCode
+		}
+		s.lockoutService.Cancel(ctx, a)
+		return err
+	}
+
+	return s.lockoutService.Succeed(ctx, a)
+}
+
+// consume гасит код без учета попытки. Вызывающий код сам регистрирует
+// попытку, если проверяет код вместе с другим секретом.
+func (s *OTPService) consume(ctx context.Context, user *models.User, purpose models.TokenPurpose, value, code string) error {
+	err := s.tokenRepo.ConsumeCode(ctx, user.ID, purpose, codeHash(user.ID, purpose, value, strings.TrimSpace(code)))
+	if errors.Is(err, repository.ErrTokenInvalid) {
+		return ErrInvalidCode
+	}
+	return err
+}
+
+func newCode() (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(otpDigits), nil)
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", otpDigits, n), nil
+}
+
+// codeHash привязывает короткий код к пользователю, назначению и значению:
+// хеш самого кода совпадал бы у разных пользователей.
+func codeHash(userID int64, purpose models.TokenPurpose, value, code string) string {
+	return hashToken(fmt.Sprintf("%d:%s:%s:%s", userID, purpose, value, code))
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/events"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+// OutboxService пересылает события из таблицы outbox в EventPublisher.
+type OutboxService struct {
+	outboxRepo *repository.OutboxRepository
+	publisher  events.EventPublisher
+	batchSize  int
+}
+
+func NewOutboxService(outboxRepo *repository.OutboxRepository, publisher events.EventPublisher,
+	cfg config.OutboxConfig) *OutboxService {
+	return &OutboxService{
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+		batchSize:  cfg.BatchSize,
+	}
+}
+
+type aggregateKey struct {
+	aggregateType string
+	aggregateID   int64
+}
+
+// Relay публикует очередную порцию неопубликованных событий. Событие
+// отмечается опубликованным только после успешной отправки. Если отправка
+// не удалась, остальные события того же агрегата в порции пропускаются,
+// чтобы не нарушить порядок.
+func (s *OutboxService) Relay(ctx context.Context) (int, error) {
+	published := 0
+	var firstErr error
+
+	_, err := s.outboxRepo.WithRelayLock(ctx, func(ctx context.Context) error {
+		pending, err := s.outboxRepo.GetUnpublished(ctx, s.batchSize)
+		if err != nil {
+			return fmt.Errorf("ошибка чтения outbox: %w", err)
+		}
+
+		blocked := make(map[aggregateKey]bool)
+		for _, e := range pending {
+			key := aggregateKey{aggregateType: e.AggregateType, aggregateID: e.AggregateID}
+			if blocked[key] {
+				continue
+			}
+
+			if err := s.publisher.Publish(ctx, e); err != nil {
+				blocked[key] = true
+				if firstErr == nil {
+					firstErr = fmt.Errorf("ошибка публикации события %d: %w", e.ID, err)
+				}
+				continue
+			}
+
+			if err := s.outboxRepo.MarkPublished(ctx, e.ID); err != nil {
+				return fmt.Errorf("ошибка отметки события %d: %w", e.ID, err)
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		return published, err
+	}
+
+	return published, firstErr
+}
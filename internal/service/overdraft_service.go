@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/models/account"
+	"github.com/therealadik/bank-api/internal/models/overdraft"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+const maxOverdraftRequestsPage = 100
+
+var (
+	ErrOverdraftProduct              = errors.New("овердрафт доступен только для текущих счетов")
+	ErrOverdraftLimit                = errors.New("запрошенный лимит овердрафта превышает допустимый")
+	ErrOverdraftInUse                = errors.New("задолженность по овердрафту превышает новый лимит")
+	ErrOverdraftRequestPending       = errors.New("по счету уже есть заявка на овердрафт")
+	ErrOverdraftRequestNotFound      = errors.New("заявка на овердрафт не найдена")
+	ErrOverdraftRequestNotPending    = errors.New("заявка на овердрафт уже рассмотрена")
+	ErrUnknownOverdraftRequestStatus = errors.New("неизвестный статус заявки на овердрафт")
+)
+
+// OverdraftService управляет лимитами овердрафта. Снижение лимита клиент
+// делает сам, а увеличение — кредит банка — одобряет оператор после
+// подтверждения личности клиента.
+type OverdraftService struct {
+	accountService *AccountService
+	accountRepo    *repository.AccountRepository
+	overdraftRepo  *repository.OverdraftRepository
+	kycService     *KYCService
+	cfg            config.OverdraftConfig
+}
+
+func NewOverdraftService(accountService *AccountService, accountRepo *repository.AccountRepository,
+	overdraftRepo *repository.OverdraftRepository, kycService *KYCService, cfg config.OverdraftConfig) *OverdraftService {
+	return &OverdraftService{
+		accountService: accountService,
+		accountRepo:    accountRepo,
+		overdraftRepo:  overdraftRepo,
+		kycService:     kycService,
+		cfg:            cfg,
+	}
+}
+
+// SetLimit меняет лимит овердрафта по текущему счету. Лимит не выше
+// действующего применяется сразу; больший лимит оформляется заявкой на
+// одобрение оператором. Ровно один из результатов — счет или заявка —
+// будет ненулевым.
+func (s *OverdraftService) SetLimit(ctx context.Context, accountID, userID int64,
+	limit decimal.Decimal) (*account.Account, *overdraft.Request, error) {
+	if limit.LessThan(decimal.Zero) {
+		return nil, nil, ErrNegativeAmount
+	}
+
+	if limit.GreaterThan(s.cfg.MaxLimit) {
+		return nil, nil, ErrOverdraftLimit
+	}
+
+	acc, err := s.accountService.GetAccountByID(ctx, accountID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if acc.Product != account.CURRENT {
+		return nil, nil, ErrOverdraftProduct
+	}
+
+	if limit.LessThanOrEqual(acc.OverdraftLimit) {
+		if acc.Balance.Add(limit).IsNegative() {
+			return nil, nil, ErrOverdraftInUse
+		}
+		acc, err = s.accountRepo.SetOverdraft(ctx, accountID, limit, acc.OverdraftRate)
+		return acc, nil, err
+	}
+
+	if err := s.kycService.RequireVerified(ctx, userID); err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.overdraftRepo.CreateRequest(ctx, accountID, userID, limit)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return nil, nil, ErrOverdraftRequestPending
+		}
+		return nil, nil, err
+	}
+	return nil, req, nil
+}
+
+func (s *OverdraftService) GetRequests(ctx context.Context, status overdraft.Status) ([]*overdraft.Request, error) {
+	switch status {
+	case "", overdraft.PENDING, overdraft.APPROVED, overdraft.REJECTED:
+	default:
+		return nil, ErrUnknownOverdraftRequestStatus
+	}
+	return s.overdraftRepo.GetRequests(ctx, status, maxOverdraftRequestsPage)
+}
+
+func (s *OverdraftService) GetRequest(ctx context.Context, id int64) (*overdraft.Request, error) {
+	req, err := s.overdraftRepo.GetRequest(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOverdraftRequestNotFound
+		}
+		return nil, err
+	}
+	return req, nil
+}
+
+// Approve одобряет заявку и устанавливает лимит по ставке из конфигурации.
+// Личность клиента проверяется повторно: подтверждение могли отозвать,
+// пока заявка ждала решения.
+func (s *OverdraftService) Approve(ctx context.Context, id int64, comment string) (*overdraft.Request, error) {
+	req, err := s.GetRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.kycService.RequireVerified(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	return s.resolve(ctx, id, overdraft.APPROVED, comment)
+}
+
+func (s *OverdraftService) Reject(ctx context.Context, id int64, comment string) (*overdraft.Request, error) {
+	if _, err := s.GetRequest(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.resolve(ctx, id, overdraft.REJECTED, comment)
+}
+
+func (s *OverdraftService) resolve(ctx context.Context, id int64, status overdraft.Status,
+	comment string) (*overdraft.Request, error) {
+	req, err := s.overdraftRepo.ResolveRequest(ctx, id, status, comment, s.cfg.Rate)
+	if err != nil {
+		if errors.Is(err, repository.ErrOverdraftRequestNotPending) {
+			return nil, ErrOverdraftRequestNotPending
+		}
+		return nil, err
+	}
+	return req, nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/models"
+	"github.com/therealadik/bank-api/internal/models/fee"
+	"github.com/therealadik/bank-api/internal/models/p2p"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+const p2pConfirmationTTL = 5 * time.Minute
+
+var (
+	ErrRecipientNotFound = errors.New("получатель не найден")
+	ErrSelfTransfer      = errors.New("для перевода между своими счетами используйте /api/transfer")
+	ErrP2PNotPending     = errors.New("перевод не найден, уже выполнен или срок подтверждения истек")
+)
+
+type P2PService struct {
+	accountService *AccountService
+	feeService     *FeeService
+	accountRepo    *repository.AccountRepository
+	userRepo       repository.UserRepository
+	p2pRepo        *repository.P2PRepository
+}
+
+func NewP2PService(accountService *AccountService, feeService *FeeService, accountRepo *repository.AccountRepository,
+	userRepo repository.UserRepository, p2pRepo *repository.P2PRepository) *P2PService {
+	return &P2PService{
+		accountService: accountService,
+		feeService:     feeService,
+		accountRepo:    accountRepo,
+		userRepo:       userRepo,
+		p2pRepo:        p2pRepo,
+	}
+}
+
+// Prepare находит получателя по email или телефону и его основной счет, рассчитывает
+// комиссию и создает перевод, ожидающий подтверждения. Наличие пользователя,
+// скрытого из справочника, не раскрывается.
+func (s *P2PService) Prepare(ctx context.Context, senderID, fromAccountID int64, recipient string,
+	amount decimal.Decimal) (*p2p.Transfer, string, error) {
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return nil, "", ErrNegativeAmount
+	}
+
+	fromAcc, err := s.accountService.GetAccountByID(ctx, fromAccountID, senderID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	user, err := s.resolveRecipient(ctx, recipient)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if user.ID == senderID {
+		return nil, "", ErrSelfTransfer
+	}
+
+	toAcc, err := s.accountRepo.GetPrimaryAccount(ctx, user.ID, fromAcc.Currency)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", ErrRecipientNotFound
+		}
+		return nil, "", fmt.Errorf("ошибка получения счета получателя: %w", err)
+	}
+
+	quote, err := s.feeService.Quote(ctx, senderID, fee.TRANSFER, amount)
+	if err != nil {
+		return nil, "", err
+	}
+
+	t, err := s.p2pRepo.CreateTransfer(ctx, &p2p.Transfer{
+		SenderID:      senderID,
+		FromAccountID: fromAccountID,
+		RecipientID:   user.ID,
+		ToAccountID:   toAcc.ID,
+		Amount:        amount,
+		Fee:           quote.Fee,
+		Status:        p2p.PENDING,
+		ExpiresAt:     time.Now().Add(p2pConfirmationTTL),
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("ошибка создания перевода: %w", err)
+	}
+
+	return t, MaskRecipient(user), nil
+}
+
+// Confirm выполняет ранее подготовленный перевод с той комиссией, которая была показана отправителю.
+func (s *P2PService) Confirm(ctx context.Context, id, senderID int64) (*p2p.Transfer, error) {
+	t, err := s.p2pRepo.ClaimPending(ctx, id, senderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrP2PNotPending
+		}
+		return nil, err
+	}
+
+	expectedFee := decimal.NewNullDecimal(t.Fee)
+	_, err = s.accountService.Transfer(ctx, t.FromAccountID, t.ToAccountID, senderID, t.Amount, expectedFee)
+	if errors.Is(err, ErrTransferHeld) {
+		// Перевод исполнится после разбора совпадения оператором.
+		if err := s.p2pRepo.UpdateStatus(ctx, t.ID, p2p.HELD); err != nil {
+			return nil, err
+		}
+		t.Status = p2p.HELD
+		return t, nil
+	}
+	if err != nil {
+		if statusErr := s.p2pRepo.UpdateStatus(ctx, t.ID, p2p.FAILED); statusErr != nil {
+			return nil, fmt.Errorf("ошибка обновления статуса перевода %d: %w", t.ID, statusErr)
+		}
+		return nil, err
+	}
+
+	if err := s.p2pRepo.UpdateStatus(ctx, t.ID, p2p.COMPLETED); err != nil {
+		return nil, err
+	}
+	t.Status = p2p.COMPLETED
+
+	return t, nil
+}
+
+func (s *P2PService) resolveRecipient(ctx context.Context, recipient string) (*models.User, error) {
+	recipient = strings.TrimSpace(recipient)
+
+	var (
+		user *models.User
+		err  error
+	)
+	if strings.Contains(recipient, "@") {
+		user, err = s.userRepo.GetByEmail(ctx, recipient)
+	} else {
+		phone, phoneErr := NormalizePhone(recipient)
+		if phoneErr != nil {
+			return nil, ErrRecipientNotFound
+		}
+		user, err = s.userRepo.GetByPhone(ctx, phone)
+	}
+
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrRecipientNotFound
+		}
+		return nil, err
+	}
+
+	if !user.Discoverable {
+		return nil, ErrRecipientNotFound
+	}
+
+	return user, nil
+}
+
+// MaskRecipient возвращает имя получателя для подтверждения перевода,
+// не раскрывая его полностью: "Иван П.". Первое слово имени показывается
+// целиком, от остальных остаются инициалы. Если имя не указано,
+// показывается маскированный адрес: "iv*******@mail.ru".
+func MaskRecipient(user *models.User) string {
+	if user.FullName != nil {
+		if words := strings.Fields(*user.FullName); len(words) > 0 {
+			masked := []string{words[0]}
+			for _, w := range words[1:] {
+				masked = append(masked, string([]rune(w)[:1])+".")
+			}
+			return strings.Join(masked, " ")
+		}
+	}
+
+	local, domain, found := strings.Cut(user.Email, "@")
+	if !found {
+		return maskString(user.Email, 2)
+	}
+	return maskString(local, 2) + "@" + domain
+}
+
+func maskString(value string, visible int) string {
+	runes := []rune(value)
+	if len(runes) <= visible {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:visible]) + strings.Repeat("*", len(runes)-visible)
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/therealadik/bank-api/internal/models"
+)
+
+func TestMaskRecipient(t *testing.T) {
+	name := func(s string) *string { return &s }
+
+	tests := []struct {
+		name string
+		user models.User
+		want string
+	}{
+		{"имя и фамилия", models.User{FullName: name("Иван Петров"), Email: "ivan@mail.ru"}, "Иван П."},
+		{"полное имя", models.User{FullName: name("Петров  Иван Сергеевич"), Email: "ivan@mail.ru"}, "Петров И. С."},
+		{"одно слово", models.User{FullName: name("Иван"), Email: "ivan@mail.ru"}, "Иван"},
+		{"пустое имя", models.User{FullName: name("  "), Email: "ivanov@mail.ru"}, "iv****@mail.ru"},
+		{"без имени", models.User{Email: "ivanov@mail.ru"}, "iv****@mail.ru"},
+		{"короткий адрес", models.User{Email: "iv@mail.ru"}, "**@mail.ru"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MaskRecipient(&tt.user); got != tt.want {
+				t.Errorf("MaskRecipient() = %q, ожидалось %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/models/lockout"
+	"github.com/therealadik/bank-api/internal/repository"
+	"github.com/therealadik/bank-api/internal/risk"
+)
+
+var (
+	ErrRiskBlocked       = errors.New("операция отклонена системой безопасности")
+	ErrRiskChallenge     = errors.New("операция требует дополнительного подтверждения")
+	ErrChallengeNotFound = errors.New("проверка не найдена или уже подтверждена")
+)
+
+// ChallengeError сообщает, что операцию нужно подтвердить паролем через
+// ConfirmChallenge и повторить.
+type ChallengeError struct {
+	ChallengeID int64
+}
+
+func (e *ChallengeError) Error() string {
+	return fmt.Sprintf("%s (проверка %d)", ErrRiskChallenge, e.ChallengeID)
+}
+
+func (e *ChallengeError) Unwrap() error {
+	return ErrRiskChallenge
+}
+
+// RiskService оценивает переводы и платежи по карте перед исполнением
+// и сохраняет каждое решение. Пороги правил перечитываются из файла
+// RISK_RULES_FILE без перезапуска.
+type RiskService struct {
+	riskRepo       *repository.RiskRepository
+	userRepo       repository.UserRepository
+	lockoutService *LockoutService
+	engine         *risk.Engine
+	cfg            config.RiskConfig
+	modTime        time.Time
+}
+
+func NewRiskService(riskRepo *repository.RiskRepository, userRepo repository.UserRepository, lockoutService *LockoutService,
+	engine *risk.Engine, cfg config.RiskConfig) *RiskService {
+	return &RiskService{
+		riskRepo:       riskRepo,
+		userRepo:       userRepo,
+		lockoutService: lockoutService,
+		engine:         engine,
+		cfg:            cfg,
+	}
+}
+
+func (s *RiskService) AssessTransfer(ctx context.Context, userID, fromID, toID int64, amount decimal.Decimal) error {
+	return s.assess(ctx, &risk.Operation{
+		Type:           risk.TRANSFER,
+		UserID:         userID,
+		AccountID:      fromID,
+		CounterpartyID: &toID,
+		Amount:         amount,
+	})
+}
+
+func (s *RiskService) AssessCardPayment(ctx context.Context, userID, cardID, accountID int64, amount decimal.Decimal) error {
+	return s.assess(ctx, &risk.Operation{
+		Type:      risk.CARD_PAYMENT,
+		UserID:    userID,
+		AccountID: accountID,
+		CardID:    &cardID,
+		Amount:    amount,
+	})
+}
+
+// assess оценивает операцию и сохраняет решение. Операция, требующая
+// проверки, пропускается, если пользователь недавно подтвердил проверку
+// точно такой же операции.
+func (s *RiskService) assess(ctx context.Context, op *risk.Operation) error {
+	th := s.engine.Thresholds()
+	if err := s.riskRepo.CollectSignals(ctx, op, th); err != nil {
+		return fmt.Errorf("ошибка сбора признаков операции: %w", err)
+	}
+
+	a := s.engine.Evaluate(op)
+
+	var challengeID *int64
+	if a.Decision == risk.CHALLENGE {
+		id, err := s.riskRepo.UseConfirmedChallenge(ctx, op, s.cfg.ChallengeTTL)
+		if err != nil {
+			return err
+		}
+		if id != nil {
+			challengeID = id
+			a.Decision = risk.ALLOW
+		}
+	}
+
+	id, err := s.riskRepo.RecordDecision(ctx, op, a, challengeID)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения решения: %w", err)
+	}
+
+	switch a.Decision {
+	case risk.BLOCK:
+		return ErrRiskBlocked
+	case risk.CHALLENGE:
+		return &ChallengeError{ChallengeID: id}
+	}
+	return nil
+}
+
+// ConfirmChallenge подтверждает проверку повторным вводом пароля. После
+// этого та же операция может быть повторена в течение RISK_CHALLENGE_TTL.
+// Неверный пароль засчитывается как неудачная попытка входа в учетную запись.
+func (s *RiskService) ConfirmChallenge(ctx context.Context, id, userID int64, password string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	a, err := s.lockoutService.Begin(ctx, lockout.ACCOUNT, accountSubject(user.Email))
+	if err != nil {
+		return err
+	}
+
+	if err := compareSecret(ctx, user.Password, password); err != nil {
+		if err := s.lockoutService.Fail(ctx, a); err != nil {
+			return err
+		}
+		return ErrInvalidCredentials
+	}
+
+	if err := s.lockoutService.Succeed(ctx, a); err != nil {
+		return err
+	}
+
+	confirmed, err := s.riskRepo.ConfirmChallenge(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		return ErrChallengeNotFound
+	}
+	return nil
+}
+
+func (s *RiskService) RecordCVVFailure(ctx context.Context, cardID int64) error {
+	return s.riskRepo.RecordCVVFailure(ctx, cardID)
+}
+
+// ReloadThresholds перечитывает файл порогов, если он изменился с прошлой
+// загрузки. Ошибочный файл не применяется, действующие пороги сохраняются.
+func (s *RiskService) ReloadThresholds() (bool, error) {
+	if s.cfg.RulesFile == "" {
+		return false, nil
+	}
+
+	info, err := os.Stat(s.cfg.RulesFile)
+	if err != nil {
+		return false, fmt.Errorf("ошибка чтения файла порогов: %w", err)
+	}
+
+	if info.ModTime().Equal(s.modTime) {
+		return false, nil
+	}
+
+	th, err := config.LoadRiskThresholds(s.cfg.RulesFile, s.cfg.Thresholds)
+	if err != nil {
+		return false, err
+	}
+
+	s.engine.SetThresholds(th)
+	s.modTime = info.ModTime()
+	return true, nil
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/models/screening"
+	"github.com/therealadik/bank-api/internal/repository"
+	"github.com/therealadik/bank-api/internal/sanctions"
+)
+
+const maxHitsPage = 100
+
+var (
+	ErrTransferHeld        = errors.New("перевод задержан до проверки получателя")
+	ErrCounterpartyBlocked = errors.New("переводы этому получателю запрещены")
+	ErrHitNotFound         = errors.New("совпадение не найдено")
+	ErrHitNotPending       = errors.New("совпадение уже разобрано")
+	ErrUnknownHitStatus    = errors.New("неизвестный статус совпадения")
+)
+
+// HeldTransferError сообщает, что перевод не исполнен, а задержан до
+// разбора совпадения оператором; после освобождения он исполнится сам.
+type HeldTransferError struct {
+	Transfer *screening.HeldTransfer
+}
+
+func (e *HeldTransferError) Error() string {
+	return fmt.Sprintf("%s (перевод %d)", ErrTransferHeld, e.Transfer.ID)
+}
+
+func (e *HeldTransferError) Unwrap() error {
+	return ErrTransferHeld
+}
+
+// ScreeningService проверяет пользователей при регистрации и получателей
+// переводов по санкционным спискам. Найденные совпадения ставятся в очередь
+// разбора оператором; до решения пользователь или перевод задерживаются.
+type ScreeningService struct {
+	screeningRepo *repository.ScreeningRepository
+	userRepo      repository.UserRepository
+	accountRepo   *repository.AccountRepository
+	screener      *sanctions.Screener
+	cfg           config.SanctionsConfig
+	modTime       time.Time
+}
+
+func NewScreeningService(screeningRepo *repository.ScreeningRepository, userRepo repository.UserRepository,
+	accountRepo *repository.AccountRepository, screener *sanctions.Screener, cfg config.SanctionsConfig) *ScreeningService {
+	return &ScreeningService{
+		screeningRepo: screeningRepo,
+		userRepo:      userRepo,
+		accountRepo:   accountRepo,
+		screener:      screener,
+		cfg:           cfg,
+	}
+}
+
+// Screen возвращает записи списка, похожие на имя.
+func (s *ScreeningService) Screen(name string) []sanctions.Match {
+	return s.screener.Screen(name)
+}
+
+// NewUserHit готовит совпадения, найденные при регистрации, к разбору.
+// Пользователь проставляется при сохранении вместе с ним.
+func (s *ScreeningService) NewUserHit(name string, matches []sanctions.Match) (*screening.Hit, error) {
+	return newHit(screening.USER, 0, name, matches)
+}
+
+// RecordUserHit ставит совпадения по уже созданному пользователю на разбор.
+func (s *ScreeningService) RecordUserHit(ctx context.Context, userID int64, name string, matches []sanctions.Match) error {
+	h, err := newHit(screening.USER, userID, name, matches)
+	if err != nil {
+		return err
+	}
+	return s.screeningRepo.CreateUserHit(ctx, h)
+}
+
+// ScreenTransfer проверяет владельца счета получателя. Переводы между своими
+// счетами не проверяются. Записи списка, которые оператор уже признал
+// ложным совпадением для этого получателя, не учитываются.
+func (s *ScreeningService) ScreenTransfer(ctx context.Context, userID, fromID, toID int64, amount decimal.Decimal) error {
+	h, err := s.matchRecipient(ctx, userID, toID)
+	if err != nil || h == nil {
+		return err
+	}
+
+	held, err := s.screeningRepo.HoldTransfer(ctx, &screening.HeldTransfer{
+		UserID:        userID,
+		FromAccountID: fromID,
+		ToAccountID:   toID,
+		Amount:        amount,
+	}, h)
+	if err != nil {
+		return fmt.Errorf("ошибка задержания перевода: %w", err)
+	}
+
+	return &HeldTransferError{Transfer: held}
+}
+
+// matchRecipient проверяет владельца счета toID и возвращает совпадение для
+// разбора или nil, если перевод можно исполнять.
+func (s *ScreeningService) matchRecipient(ctx context.Context, userID, toID int64) (*screening.Hit, error) {
+	toAcc, err := s.accountRepo.GetAccountByID(ctx, toID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	if toAcc.UserID == userID {
+		return nil, nil
+	}
+
+	owner, err := s.userRepo.GetByID(ctx, toAcc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения получателя: %w", err)
+	}
+
+	if owner.ScreeningStatus == screening.USER_BLOCKED {
+		return nil, ErrCounterpartyBlocked
+	}
+
+	if owner.FullName == nil {
+		return nil, nil
+	}
+
+	matches := s.screener.Screen(*owner.FullName)
+	if len(matches) == 0 {
+		return nil, nil
+	}
+
+	cleared, err := s.screeningRepo.GetClearedEntries(ctx, owner.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := matches[:0]
+	for _, m := range matches {
+		if !cleared[m.Entry.Source+":"+m.Entry.ID] {
+			pending = append(pending, m)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	return newHit(screening.TRANSFER, owner.ID, *owner.FullName, pending)
+}
+
+// newHit описывает совпадение лучшей записью; все найденные записи
+// сохраняются в Matches для оператора.
+func newHit(subject screening.SubjectType, userID int64, name string, matches []sanctions.Match) (*screening.Hit, error) {
+	raw, err := json.Marshal(matches)
+	if err != nil {
+		return nil, err
+	}
+
+	best := matches[0]
+	return &screening.Hit{
+		SubjectType:  subject,
+		UserID:       userID,
+		ScreenedName: name,
+		EntryID:      best.Entry.ID,
+		EntryName:    best.Entry.Name,
+		ListSource:   best.Entry.Source,
+		Score:        decimal.NewFromFloat(best.Score).Round(3),
+		Matches:      raw,
+	}, nil
+}
+
+func (s *ScreeningService) GetHits(ctx context.Context, status screening.HitStatus) ([]*screening.Hit, error) {
+	switch status {
+	case "", screening.PENDING, screening.CLEARED, screening.CONFIRMED:
+	default:
+		return nil, ErrUnknownHitStatus
+	}
+	return s.screeningRepo.GetHits(ctx, status, maxHitsPage)
+}
+
+func (s *ScreeningService) GetHit(ctx context.Context, id int64) (*screening.Hit, error) {
+	h, err := s.screeningRepo.GetHit(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrHitNotFound
+		}
+		return nil, err
+	}
+	return h, nil
+}
+
+// Clear признает совпадение ложным: пользователь снимается с удержания,
+// задержанный перевод освобождается для исполнения.
+func (s *ScreeningService) Clear(ctx context.Context, id int64, comment string) (*screening.Hit, error) {
+	return s.resolve(ctx, id, screening.CLEARED, comment)
+}
+
+// Confirm подтверждает совпадение: пользователь блокируется, задержанный
+// перевод отклоняется.
+func (s *ScreeningService) Confirm(ctx context.Context, id int64, comment string) (*screening.Hit, error) {
+	return s.resolve(ctx, id, screening.CONFIRMED, comment)
+}
+
+func (s *ScreeningService) resolve(ctx context.Context, id int64, status screening.HitStatus,
+	comment string) (*screening.Hit, error) {
+	if _, err := s.GetHit(ctx, id); err != nil {
+		return nil, err
+	}
+
+	h, err := s.screeningRepo.ResolveHit(ctx, id, status, comment)
+	if err != nil {
+		if errors.Is(err, repository.ErrHitNotPending) {
+			return nil, ErrHitNotPending
+		}
+		return nil, err
+	}
+	return h, nil
+}
+
+// ClaimReleasedTransfer выбирает освобожденный перевод для исполнения.
+// Возвращает pgx.ErrNoRows, если таких нет.
+func (s *ScreeningService) ClaimReleasedTransfer(ctx context.Context) (*screening.HeldTransfer, error) {
+	return s.screeningRepo.ClaimReleasedTransfer(ctx)
+}
+
+func (s *ScreeningService) FinishHeldTransfer(ctx context.Context, id int64, status screening.TransferStatus,
+	reason string) error {
+	return s.screeningRepo.FinishHeldTransfer(ctx, id, status, reason)
+}
+
+// ReloadList перечитывает файл списка, если он изменился с прошлой загрузки.
+// Ошибочный файл не применяется, действующий список сохраняется.
+func (s *ScreeningService) ReloadList() (bool, int, error) {
+	if s.cfg.ListFile == "" {
+		return false, 0, nil
+	}
+
+	info, err := os.Stat(s.cfg.ListFile)
+	if err != nil {
+		return false, 0, fmt.Errorf("ошибка чтения санкционного списка: %w", err)
+	}
+
+	if info.ModTime().Equal(s.modTime) {
+		return false, s.screener.Size(), nil
+	}
+
+	entries, err := sanctions.LoadFile(s.cfg.ListFile)
+	if err != nil {
+		return false, 0, fmt.Errorf("ошибка загрузки санкционного списка: %w", err)
+	}
+
+	s.screener.Load(entries)
+	s.modTime = info.ModTime()
+	return true, len(entries), nil
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/events"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+const (
+	streamBufferSize   = 64
+	streamReplayLimit  = 500
+	streamCatchUpLimit = 1000
+)
+
+// AccountBalance — баланс счета на момент отправки сообщения.
+type AccountBalance struct {
+	AccountID int64           `json:"account_id"`
+	Balance   decimal.Decimal `json:"balance"`
+}
+
+// StreamMessage — событие из outbox, адресованное конкретному пользователю,
+// с текущими балансами затронутых счетов этого пользователя.
+type StreamMessage struct {
+	Event    events.Event     `json:"event"`
+	Balances []AccountBalance `json:"balances,omitempty"`
+}
+
+// StreamSubscription получает сообщения для одного подключения. Канал C
+// закрывается, если клиент не успевает читать: клиент должен
+// переподключиться с Last-Event-ID.
+type StreamSubscription struct {
+	C      <-chan StreamMessage
+	ch     chan StreamMessage
+	userID int64
+}
+
+// StreamService рассылает события пользователям в реальном времени.
+// Источник — уведомления Postgres LISTEN/NOTIFY о новых записях outbox,
+// поэтому событие, записанное любой репликой, доходит до подключений на
+// всех репликах.
+type StreamService struct {
+	outboxRepo  *repository.OutboxRepository
+	accountRepo *repository.AccountRepository
+	cardRepo    *repository.CardRepository
+
+	mu          sync.Mutex
+	subscribers map[int64]map[*StreamSubscription]struct{}
+	lastID      int64
+	started     bool
+}
+
+func NewStreamService(outboxRepo *repository.OutboxRepository, accountRepo *repository.AccountRepository,
+	cardRepo *repository.CardRepository) *StreamService {
+	return &StreamService{
+		outboxRepo:  outboxRepo,
+		accountRepo: accountRepo,
+		cardRepo:    cardRepo,
+		subscribers: make(map[int64]map[*StreamSubscription]struct{}),
+	}
+}
+
+// Listen рассылает события до отмены ctx или обрыва соединения с базой.
+// При повторном вызове сначала досылаются события, записанные после
+// последнего разосланного, — они могли прийти, пока соединения не было.
+func (s *StreamService) Listen(ctx context.Context) error {
+	if !s.started {
+		lastID, err := s.outboxRepo.GetLastEventID(ctx)
+		if err != nil {
+			return fmt.Errorf("ошибка получения последнего события: %w", err)
+		}
+		s.lastID = lastID
+		s.started = true
+	} else if err := s.catchUp(ctx); err != nil {
+		return fmt.Errorf("ошибка досылки событий: %w", err)
+	}
+
+	return s.outboxRepo.Listen(ctx, func(id int64) error {
+		// Транзакции фиксируются не в порядке ID, поэтому событие читается
+		// по ID из уведомления, а не «все после последнего».
+		e, err := s.outboxRepo.GetEvent(ctx, id)
+		if err != nil {
+			return fmt.Errorf("ошибка чтения события %d: %w", id, err)
+		}
+		return s.dispatch(ctx, e)
+	})
+}
+
+func (s *StreamService) catchUp(ctx context.Context) error {
+	for {
+		pending, err := s.outboxRepo.GetEventsAfter(ctx, s.lastID, streamCatchUpLimit)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range pending {
+			if err := s.dispatch(ctx, e); err != nil {
+				return err
+			}
+		}
+
+		if len(pending) < streamCatchUpLimit {
+			return nil
+		}
+	}
+}
+
+func (s *StreamService) dispatch(ctx context.Context, e events.Event) error {
+	s.lastID = max(s.lastID, e.ID)
+
+	if !s.hasSubscribers() {
+		return nil
+	}
+
+	messages, err := s.buildMessages(ctx, e)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for userID, msg := range messages {
+		for sub := range s.subscribers[userID] {
+			select {
+			case sub.ch <- msg:
+			default:
+				s.removeLocked(sub)
+			}
+		}
+	}
+	return nil
+}
+
+// buildMessages определяет владельцев затронутых счетов и карт и собирает
+// для каждого сообщение с балансами его счетов.
+func (s *StreamService) buildMessages(ctx context.Context, e events.Event) (map[int64]StreamMessage, error) {
+	messages := make(map[int64]StreamMessage)
+
+	var accountIDs []int64
+	switch e.AggregateType {
+	case events.AggregateAccount:
+		accountIDs = append(accountIDs, e.AggregateID)
+	case events.AggregateCard:
+		card, err := s.cardRepo.GetCardByID(ctx, e.AggregateID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return messages, nil
+			}
+			return nil, err
+		}
+		messages[card.UserID] = StreamMessage{Event: e}
+		return messages, nil
+	}
+
+	if e.Type == events.TRANSFER_COMPLETED {
+		var payload events.TransferCompleted
+		if err := json.Unmarshal(e.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("ошибка разбора события %d: %w", e.ID, err)
+		}
+		accountIDs = append(accountIDs, payload.ToAccountID)
+	}
+
+	accounts, err := s.accountRepo.GetAccountsByIDs(ctx, accountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, acc := range accounts {
+		msg := messages[acc.UserID]
+		msg.Event = e
+		msg.Balances = append(msg.Balances, AccountBalance{AccountID: acc.ID, Balance: acc.Balance})
+		messages[acc.UserID] = msg
+	}
+	return messages, nil
+}
+
+func (s *StreamService) hasSubscribers() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.subscribers) > 0
+}
+
+func (s *StreamService) Subscribe(userID int64) *StreamSubscription {
+	ch := make(chan StreamMessage, streamBufferSize)
+	sub := &StreamSubscription{C: ch, ch: ch, userID: userID}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subscribers[userID] == nil {
+		s.subscribers[userID] = make(map[*StreamSubscription]struct{})
+	}
+	s.subscribers[userID][sub] = struct{}{}
+	return sub
+}
+
+func (s *StreamService) Unsubscribe(sub *StreamSubscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeLocked(sub)
+}
+
+func (s *StreamService) removeLocked(sub *StreamSubscription) {
+	subs, ok := s.subscribers[sub.userID]
+	if !ok {
+		return
+	}
+
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	close(sub.ch)
+	if len(subs) == 0 {
+		delete(s.subscribers, sub.userID)
+	}
+}
+
+// Replay возвращает события пользователя после afterID для возобновления
+// потока по Last-Event-ID. Балансы в повторе не передаются: актуальные
+// значения клиент получает из снимка Balances.
+func (s *StreamService) Replay(ctx context.Context, userID, afterID int64) ([]StreamMessage, error) {
+	pending, err := s.outboxRepo.GetUserEventsAfter(ctx, userID, afterID, streamReplayLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]StreamMessage, 0, len(pending))
+	for _, e := range pending {
+		messages = append(messages, StreamMessage{Event: e})
+	}
+	return messages, nil
+}
+
+// Balances возвращает текущие балансы всех счетов пользователя.
+func (s *StreamService) Balances(ctx context.Context, userID int64) ([]AccountBalance, error) {
+	accounts, err := s.accountRepo.GetAccountsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]AccountBalance, 0, len(accounts))
+	for _, acc := range accounts {
+		balances = append(balances, AccountBalance{AccountID: acc.ID, Balance: acc.Balance})
+	}
+	return balances, nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/therealadik/bank-api/internal/models"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+const uniqueViolationCode = "23505"
+
+var (
+	ErrInvalidPhone  = errors.New("неверный формат номера телефона")
+	ErrPhoneTaken    = errors.New("номер телефона уже используется")
+	ErrNoPhone       = errors.New("номер телефона не указан")
+	ErrPhoneVerified = errors.New("номер телефона уже подтвержден")
+)
+
+type UserService struct {
+	userRepo   repository.UserRepository
+	otpService *OTPService
+}
+
+func NewUserService(userRepo repository.UserRepository, otpService *OTPService) *UserService {
+	return &UserService{
+		userRepo:   userRepo,
+		otpService: otpService,
+	}
+}
+
+func (s *UserService) GetUser(ctx context.Context, userID int64) (*models.User, error) {
+	return s.userRepo.GetByID(ctx, userID)
+}
+
+// UpdateContacts обновляет номер телефона и признак видимости пользователя
+// в справочнике P2P-переводов. Поля со значением nil не меняются, пустой phone удаляет номер.
+// Новый номер сохраняется неподтвержденным, и на него отправляется код:
+// найти получателя по номеру можно только после ConfirmPhone.
+func (s *UserService) UpdateContacts(ctx context.Context, userID int64, phone *string, discoverable *bool) (*models.User, error) {
+	current, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	newPhone := current.Phone
+	if phone != nil {
+		if *phone == "" {
+			newPhone = nil
+		} else {
+			normalized, err := NormalizePhone(*phone)
+			if err != nil {
+				return nil, err
+			}
+			newPhone = &normalized
+		}
+	}
+
+	newDiscoverable := current.Discoverable
+	if discoverable != nil {
+		newDiscoverable = *discoverable
+	}
+
+	user, err := s.userRepo.UpdateContacts(ctx, userID, newPhone, newDiscoverable)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Phone != nil && user.PhoneVerifiedAt == nil && (current.Phone == nil || *current.Phone != *user.Phone) {
+		if err := s.otpService.Send(ctx, user, models.PHONE_VERIFY, *user.Phone); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+// SendPhoneCode повторно отправляет код подтверждения на указанный номер.
+func (s *UserService) SendPhoneCode(ctx context.Context, userID int64) error {
+	user, err := s.unverifiedPhoneUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.otpService.Send(ctx, user, models.PHONE_VERIFY, *user.Phone)
+}
+
+// ConfirmPhone подтверждает номер кодом из SMS. Если тот же номер уже
+// подтвердил другой пользователь, возвращается ErrPhoneTaken.
+func (s *UserService) ConfirmPhone(ctx context.Context, userID int64, code string) (*models.User, error) {
+	user, err := s.unverifiedPhoneUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.otpService.Verify(ctx, user, models.PHONE_VERIFY, *user.Phone, code); err != nil {
+		return nil, err
+	}
+
+	user, err = s.userRepo.VerifyPhone(ctx, userID, *user.Phone)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return nil, ErrPhoneTaken
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvalidCode
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *UserService) unverifiedPhoneUser(ctx context.Context, userID int64) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Phone == nil {
+		return nil, ErrNoPhone
+	}
+
+	if user.PhoneVerifiedAt != nil {
+		return nil, ErrPhoneVerified
+	}
+
+	return user, nil
+}
+
+// NormalizePhone приводит номер к формату E.164. Российские номера,
+// начинающиеся с 8, преобразуются к коду +7.
+func NormalizePhone(phone string) (string, error) {
+	var digits strings.Builder
+	for i, r := range strings.TrimSpace(phone) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '(' || r == ')':
+		default:
+			return "", ErrInvalidPhone
+		}
+	}
+
+	number := digits.String()
+	if len(number) == 11 && number[0] == '8' {
+		number = "7" + number[1:]
+	}
+
+	if len(number) < 10 || len(number) > 15 {
+		return "", ErrInvalidPhone
+	}
+
+	return "+" + number, nil
+}
@@ -0,0 +1,336 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/therealadik/bank-api/internal/config"
+	"github.com/therealadik/bank-api/internal/events"
+	"github.com/therealadik/bank-api/internal/models/webhook"
+	"github.com/therealadik/bank-api/internal/repository"
+)
+
+const maxDeliveriesPerPage = 100
+
+var (
+	ErrInvalidWebhookURL    = errors.New("адрес webhook должен быть абсолютным HTTPS URL")
+	ErrWebhookHostForbidden = errors.New("адрес webhook указывает на внутреннюю сеть")
+	ErrUnknownEventType     = errors.New("неизвестный тип события")
+	ErrSubscriptionNotFound = errors.New("подписка не найдена")
+	ErrDeliveryNotFound     = errors.New("доставка не найдена")
+	ErrInvalidStatusFilter  = errors.New("неизвестный статус доставки")
+)
+
+// WebhookService управляет подписками и доставляет события на адреса
+// клиентов. Он же реализует events.EventPublisher: при публикации из outbox
+// событие превращается в доставки для подходящих подписок.
+type WebhookService struct {
+	webhookRepo *repository.WebhookRepository
+	client      *http.Client
+	cfg         config.WebhookConfig
+}
+
+func NewWebhookService(webhookRepo *repository.WebhookRepository, cfg config.WebhookConfig) *WebhookService {
+	// Адрес проверяется и при подключении: DNS подписчика может после
+	// создания подписки начать отдавать внутренний адрес. Прокси отключен,
+	// иначе проверялся бы адрес прокси, а не получателя.
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return fmt.Errorf("%w: %s", ErrWebhookHostForbidden, host)
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		cfg: cfg,
+	}
+}
+
+// publicIP сообщает, что адрес принадлежит публичной сети. Webhook не должен
+// обращаться к самому сервису, соседям во внутренней сети и метаданным облака.
+func publicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// checkHost разрешает имя хоста и отклоняет его, если хотя бы один из адресов
+// не публичный.
+func (s *WebhookService) checkHost(ctx context.Context, host string) error {
+	if s.cfg.AllowPrivate {
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return ErrInvalidWebhookURL
+	}
+	for _, addr := range addrs {
+		if !publicIP(addr.IP) {
+			return ErrWebhookHostForbidden
+		}
+	}
+	return nil
+}
+
+// CreateSubscription создает подписку и генерирует секрет подписи. Секрет
+// возвращается клиенту только в ответе на создание. Пустой список типов
+// означает подписку на все события.
+func (s *WebhookService) CreateSubscription(ctx context.Context, userID int64, rawURL string,
+	eventTypes []string) (*webhook.Subscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && !(s.cfg.AllowHTTP && u.Scheme == "http")) {
+		return nil, ErrInvalidWebhookURL
+	}
+
+	if err := s.checkHost(ctx, u.Hostname()); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(eventTypes))
+	types := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		switch events.Type(t) {
+		case events.ACCOUNT_CREATED, events.BALANCE_UPDATED, events.TRANSFER_COMPLETED,
+			events.CARD_ISSUED, events.PAYMENT_AUTHORIZED, events.FEE_CHARGED, events.OVERDRAFT_LIMIT_BREACHED:
+		default:
+			return nil, ErrUnknownEventType
+		}
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("ошибка генерации секрета: %w", err)
+	}
+
+	return s.webhookRepo.CreateSubscription(ctx, &webhook.Subscription{
+		UserID:     userID,
+		URL:        u.String(),
+		Secret:     hex.EncodeToString(secret),
+		EventTypes: types,
+	})
+}
+
+func (s *WebhookService) GetSubscriptions(ctx context.Context, userID int64) ([]*webhook.Subscription, error) {
+	return s.webhookRepo.GetSubscriptionsByUserID(ctx, userID)
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id, userID int64) error {
+	deleted, err := s.webhookRepo.DeleteSubscription(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if !deleted {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// GetDeliveries возвращает журнал доставок подписки. Фильтр DEAD показывает
+// очередь недоставленных событий.
+func (s *WebhookService) GetDeliveries(ctx context.Context, subscriptionID, userID int64,
+	status webhook.Status) ([]*webhook.Delivery, error) {
+	switch status {
+	case "", webhook.PENDING, webhook.DELIVERED, webhook.DEAD:
+	default:
+		return nil, ErrInvalidStatusFilter
+	}
+
+	if _, err := s.webhookRepo.GetSubscription(ctx, subscriptionID, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	return s.webhookRepo.GetDeliveries(ctx, subscriptionID, status, maxDeliveriesPerPage)
+}
+
+func (s *WebhookService) GetDelivery(ctx context.Context, id, userID int64) (*webhook.Delivery, []*webhook.Attempt, error) {
+	d, err := s.webhookRepo.GetDelivery(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrDeliveryNotFound
+		}
+		return nil, nil, err
+	}
+
+	attempts, err := s.webhookRepo.GetAttempts(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return d, attempts, nil
+}
+
+// Redeliver ставит доставку в очередь повторно, в том числе из DEAD.
+func (s *WebhookService) Redeliver(ctx context.Context, id, userID int64) (*webhook.Delivery, error) {
+	if _, err := s.webhookRepo.GetDelivery(ctx, id, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	return s.webhookRepo.Redeliver(ctx, id)
+}
+
+// Publish создает доставки события для подписок владельцев затронутых
+// счетов и карт. Для перевода уведомляются и отправитель, и получатель.
+func (s *WebhookService) Publish(ctx context.Context, e events.Event) error {
+	var accountIDs, cardIDs []int64
+
+	switch e.AggregateType {
+	case events.AggregateAccount:
+		accountIDs = append(accountIDs, e.AggregateID)
+	case events.AggregateCard:
+		cardIDs = append(cardIDs, e.AggregateID)
+	}
+
+	if e.Type == events.TRANSFER_COMPLETED {
+		var payload events.TransferCompleted
+		if err := json.Unmarshal(e.Payload, &payload); err != nil {
+			return fmt.Errorf("ошибка разбора события %d: %w", e.ID, err)
+		}
+		accountIDs = append(accountIDs, payload.ToAccountID)
+	}
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.webhookRepo.EnqueueEvent(ctx, e, body, accountIDs, cardIDs)
+	return err
+}
+
+// DeliverDue отправляет доставки, время попытки которых наступило.
+// Неуспешная доставка повторяется с экспоненциальной задержкой, а после
+// исчерпания попыток переводится в DEAD.
+func (s *WebhookService) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := s.webhookRepo.ClaimDue(ctx, s.cfg.BatchSize, 2*s.cfg.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("ошибка выбора доставок: %w", err)
+	}
+
+	delivered := 0
+	for _, d := range deliveries {
+		sub, err := s.webhookRepo.GetSubscriptionByID(ctx, d.SubscriptionID)
+		if err != nil {
+			return delivered, fmt.Errorf("ошибка получения подписки %d: %w", d.SubscriptionID, err)
+		}
+
+		attempt := s.send(ctx, sub, d)
+		now := time.Now().UTC()
+
+		d.Attempts++
+		d.LastStatusCode = attempt.StatusCode
+		d.LastError = attempt.Error
+		switch {
+		case attempt.Error == "":
+			d.Status = webhook.DELIVERED
+			d.DeliveredAt = &now
+			delivered++
+		case d.Attempts >= s.cfg.MaxAttempts:
+			d.Status = webhook.DEAD
+		default:
+			d.NextAttemptAt = now.Add(retryBackoff(s.cfg.BaseBackoff, s.cfg.MaxBackoff, d.Attempts))
+		}
+
+		if err := s.webhookRepo.RecordAttempt(ctx, d, attempt); err != nil {
+			return delivered, fmt.Errorf("ошибка сохранения попытки доставки %d: %w", d.ID, err)
+		}
+	}
+
+	return delivered, nil
+}
+
+func (s *WebhookService) send(ctx context.Context, sub *webhook.Subscription, d *webhook.Delivery) *webhook.Attempt {
+	attempt := &webhook.Attempt{DeliveryID: d.ID}
+	started := time.Now()
+	defer func() {
+		attempt.DurationMs = int(time.Since(started).Milliseconds())
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	timestamp := strconv.FormatInt(started.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Id", strconv.FormatInt(d.ID, 10))
+	req.Header.Set("X-Webhook-Event", d.EventType)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", signHMAC([]byte(sub.Secret), timestamp+"."+string(d.Payload)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	attempt.StatusCode = &resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		attempt.Error = fmt.Sprintf("неуспешный ответ: %d", resp.StatusCode)
+	}
+	return attempt
+}
+
+// retryBackoff возвращает задержку перед следующей попыткой:
+// base·2^(attempts-1), но не больше max.
+func retryBackoff(base, max time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return min(delay, max)
+}
+
+// VerifyWebhookSignature проверяет подпись входящего webhook на стороне
+// получателя: HMAC-SHA256 от "<X-Webhook-Timestamp>.<тело запроса>" с
+// секретом подписки.
+func VerifyWebhookSignature(secret, timestamp string, body []byte, signature string) bool {
+	return checkHMAC([]byte(secret), timestamp+"."+string(body), signature)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/therealadik/bank-api/internal/config"
+)
+
+func TestPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"fd00::1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"::ffff:127.0.0.1", false},
+		{"224.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := publicIP(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("publicIP(%s) = %v, ожидалось %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+// Проверка при подключении срабатывает и тогда, когда адрес прошел проверку
+// при создании подписки, а затем DNS стал отдавать внутренний адрес.
+func TestWebhookClientRejectsPrivateAddress(t *testing.T) {
+	s := NewWebhookService(nil, config.WebhookConfig{Timeout: time.Second})
+
+	resp, err := s.client.Get("http://127.0.0.1:1/")
+	if err == nil {
+		resp.Body.Close()
+	}
+	if !errors.Is(err, ErrWebhookHostForbidden) {
+		t.Fatalf("ошибка %v, ожидалось %v", err, ErrWebhookHostForbidden)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	s := &WebhookService{}
+	for _, host := range []string{"127.0.0.1", "::1", "10.0.0.1", "169.254.169.254", "localhost"} {
+		if err := s.checkHost(context.Background(), host); !errors.Is(err, ErrWebhookHostForbidden) {
+			t.Errorf("checkHost(%s) = %v, ожидалось %v", host, err, ErrWebhookHostForbidden)
+		}
+	}
+
+	s.cfg.AllowPrivate = true
+	if err := s.checkHost(context.Background(), "127.0.0.1"); err != nil {
+		t.Errorf("checkHost с AllowPrivate = %v", err)
+	}
+}
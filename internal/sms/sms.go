@@ -0,0 +1,77 @@
+// Package sms отправляет SMS. Сервисы зависят только от интерфейса Sender;
+// сообщения уходят в HTTP-шлюз оператора связи, а без шлюза пишутся в лог.
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/therealadik/bank-api/internal/config"
+)
+
+type Sender interface {
+	Send(ctx context.Context, phone, text string) error
+}
+
+// HTTPSender передает сообщение шлюзу POST-запросом с телом
+// {"to": "+79991234567", "text": "..."}.
+type HTTPSender struct {
+	cfg    config.SMSConfig
+	client *http.Client
+}
+
+func NewHTTPSender(cfg config.SMSConfig) *HTTPSender {
+	return &HTTPSender{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, phone, text string) error {
+	body, err := json.Marshal(struct {
+		To   string `json:"to"`
+		Text string `json:"text"`
+	}{To: phone, Text: text})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.GatewayURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.Token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ошибка подключения к SMS-шлюзу: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("SMS-шлюз ответил %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// LogSender пишет факт отправки в лог вместо отправки. Текст не логируется:
+// в нем одноразовый код.
+type LogSender struct {
+	logger *logrus.Logger
+}
+
+func NewLogSender(logger *logrus.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+func (s *LogSender) Send(_ context.Context, phone, _ string) error {
+	s.logger.WithField("to", phone).Info("SMS не отправлено: SMS-шлюз не настроен")
+	return nil
+}
@@ -0,0 +1,89 @@
+// Package tracing настраивает OpenTelemetry: провайдер трейсов с экспортом
+// в stdout или OTLP, спаны HTTP-запросов, методов сервисов и SQL-запросов
+// pgx, а также передачу trace_id и span_id в логи logrus.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/therealadik/bank-api/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/therealadik/bank-api"
+
+// tracer берет провайдер из otel при каждом старте спана, поэтому спаны
+// пакетов, инициализированных до Setup, тоже экспортируются.
+var tracer = otel.Tracer(instrumentationName)
+
+// Setup устанавливает глобальный провайдер трейсов и пропагатор W3C Trace
+// Context. Возвращает функцию, которая досылает накопленные спаны при
+// остановке сервиса. Экспортер none оставляет трейсинг выключенным.
+func Setup(ctx context.Context, cfg config.TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case "none", "":
+		return func(context.Context) error { return nil }, nil
+	case "stdout":
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("ошибка создания экспортера stdout: %w", err)
+		}
+		exporter = exp
+	case "otlp":
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка создания экспортера OTLP: %w", err)
+		}
+		exporter = exp
+	default:
+		return nil, fmt.Errorf("неизвестный экспортер трейсов %q", cfg.Exporter)
+	}
+
+	res, err := resource.Merge(resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка описания ресурса трейсов: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start начинает внутренний спан, например для метода сервиса.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, opts...)
+}
+
+// End завершает спан и отмечает его ошибкой, если err не nil. Вызывается
+// через defer с именованным результатом err.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,236 @@
+// Package validation проверяет DTO по тегам validate. Правила перечисляются
+// через запятую, параметр отделяется знаком «=»:
+//
+//	Email  string          `json:"email" validate:"required,email"`
+//	Amount decimal.Decimal `json:"amount" validate:"positive"`
+//	Mode   string          `json:"mode" validate:"omitempty,oneof=ATOMIC BEST_EFFORT"`
+//
+// Правила: required, omitempty, email, min, max, len, oneof, digits,
+// decimal (строка с числом), positive. Для строк min, max и len задают
+// длину в символах, для срезов — число элементов, для чисел и decimal —
+// значение. Вложенные структуры и элементы срезов структур проверяются
+// рекурсивно. Сообщения об ошибках берутся из каталога i18n на указанном
+// языке.
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/i18n"
+	"github.com/therealadik/bank-api/internal/problem"
+)
+
+var decimalType = reflect.TypeOf(decimal.Decimal{})
+
+// Struct проверяет структуру (или указатель на нее) и возвращает ошибки
+// по полям с сообщениями на языке locale; nil — ошибок нет.
+func Struct(v any, locale i18n.Locale) []problem.FieldError {
+	var errs []problem.FieldError
+	validateValue(reflect.ValueOf(v), "", locale, &errs)
+	return errs
+}
+
+func validateValue(v reflect.Value, path string, locale i18n.Locale, errs *[]problem.FieldError) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Kind() == reflect.Struct && v.Type() != decimalType:
+		validateStruct(v, path, locale, errs)
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			validateValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), locale, errs)
+		}
+	}
+}
+
+func validateStruct(v reflect.Value, path string, locale i18n.Locale, errs *[]problem.FieldError) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := jsonName(f)
+		if name == "-" {
+			continue
+		}
+		if path != "" {
+			name = path + "." + name
+		}
+
+		fv := v.Field(i)
+		if tag := f.Tag.Get("validate"); tag != "" {
+			if fe, ok := checkField(fv, name, tag, locale); !ok {
+				*errs = append(*errs, fe)
+				continue
+			}
+		}
+		validateValue(fv, name, locale, errs)
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// checkField применяет правила тега по порядку и останавливается на первом
+// нарушенном.
+func checkField(v reflect.Value, name, tag string, locale i18n.Locale) (problem.FieldError, bool) {
+	rules := strings.Split(tag, ",")
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			if rules[0] == "required" {
+				return problem.FieldError{Field: name, Code: "required", Message: i18n.T(locale, "validation.required")}, false
+			}
+			return problem.FieldError{}, true
+		}
+		v = v.Elem()
+	}
+
+	for _, rule := range rules {
+		rule, param, _ := strings.Cut(rule, "=")
+		switch rule {
+		case "omitempty":
+			if isEmpty(v) {
+				return problem.FieldError{}, true
+			}
+			continue
+		case "required":
+			if isEmpty(v) {
+				return problem.FieldError{Field: name, Code: rule, Message: i18n.T(locale, "validation.required")}, false
+			}
+			continue
+		}
+
+		if key, args := check(v, rule, param); key != "" {
+			return problem.FieldError{Field: name, Code: rule, Message: i18n.T(locale, key, args...)}, false
+		}
+	}
+	return problem.FieldError{}, true
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return strings.TrimSpace(v.String()) == ""
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// check возвращает ключ сообщения о нарушении правила с аргументами или
+// пустой ключ.
+func check(v reflect.Value, rule, param string) (string, []any) {
+	switch rule {
+	case "email":
+		addr, err := mail.ParseAddress(v.String())
+		if err != nil || addr.Address != v.String() {
+			return "validation.email", nil
+		}
+	case "digits":
+		s := v.String()
+		if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+			return "validation.digits", nil
+		}
+	case "decimal":
+		if _, err := decimal.NewFromString(v.String()); err != nil {
+			return "validation.decimal", nil
+		}
+	case "positive":
+		d, ok := number(v)
+		if !ok {
+			if v.Kind() == reflect.String {
+				return "validation.decimal", nil
+			}
+			panic(fmt.Sprintf("validation: правило positive неприменимо к %s", v.Type()))
+		}
+		if d.Sign() <= 0 {
+			return "validation.positive", nil
+		}
+	case "oneof":
+		allowed := strings.Fields(param)
+		for _, a := range allowed {
+			if fmt.Sprint(v.Interface()) == a {
+				return "", nil
+			}
+		}
+		return "validation.oneof", []any{strings.Join(allowed, ", ")}
+	case "min", "max", "len":
+		return checkBound(v, rule, param)
+	default:
+		panic(fmt.Sprintf("validation: неизвестное правило %q", rule))
+	}
+	return "", nil
+}
+
+func checkBound(v reflect.Value, rule, param string) (string, []any) {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			panic(fmt.Sprintf("validation: неверный параметр %s=%s", rule, param))
+		}
+
+		size, unit := v.Len(), "items"
+		if v.Kind() == reflect.String {
+			size, unit = utf8.RuneCountInString(v.String()), "chars"
+		}
+
+		if (rule == "min" && size < n) || (rule == "max" && size > n) || (rule == "len" && size != n) {
+			return "validation." + rule + "." + unit, []any{n}
+		}
+		return "", nil
+	}
+
+	bound, err := decimal.NewFromString(param)
+	if err != nil {
+		panic(fmt.Sprintf("validation: неверный параметр %s=%s", rule, param))
+	}
+
+	value, ok := number(v)
+	if !ok {
+		panic(fmt.Sprintf("validation: правило %s неприменимо к %s", rule, v.Type()))
+	}
+
+	if (rule == "min" && value.LessThan(bound)) || (rule == "max" && value.GreaterThan(bound)) ||
+		(rule == "len" && !value.Equal(bound)) {
+		return "validation." + rule + ".value", []any{param}
+	}
+	return "", nil
+}
+
+func number(v reflect.Value) (decimal.Decimal, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return decimal.NewFromInt(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return decimal.NewFromUint64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return decimal.NewFromFloat(v.Float()), true
+	case reflect.String:
+		d, err := decimal.NewFromString(v.String())
+		return d, err == nil
+	}
+	if v.Type() == decimalType {
+		return v.Interface().(decimal.Decimal), true
+	}
+	return decimal.Decimal{}, false
+}
@@ -0,0 +1,179 @@
+package validation
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/therealadik/bank-api/internal/dto"
+	"github.com/therealadik/bank-api/internal/i18n"
+)
+
+type item struct {
+	Amount decimal.Decimal `json:"amount" validate:"positive"`
+}
+
+type request struct {
+	Email    string          `json:"email" validate:"required,email"`
+	Password string          `json:"password" validate:"required,min=6,max=8"`
+	CVV      string          `json:"cvv" validate:"omitempty,digits,len=3"`
+	Mode     string          `json:"mode" validate:"omitempty,oneof=ATOMIC BEST_EFFORT"`
+	Price    string          `json:"price" validate:"omitempty,decimal"`
+	Term     int             `json:"term" validate:"omitempty,min=1,max=60"`
+	Rate     decimal.Decimal `json:"rate" validate:"max=1"`
+	Note     *string         `json:"note" validate:"omitempty,max=3"`
+	Card     *int64          `json:"card" validate:"required"`
+	Tags     []string        `json:"tags" validate:"omitempty,max=2"`
+	Items    []item          `json:"items"`
+	Skipped  string          `json:"-" validate:"required"`
+}
+
+func valid() request {
+	card := int64(1)
+	return request{
+		Email:    "ivan@example.com",
+		Password: "secret1",
+		Card:     &card,
+		Items:    []item{{Amount: decimal.NewFromInt(1)}},
+	}
+}
+
+func TestStruct(t *testing.T) {
+	long := "long"
+
+	tests := []struct {
+		name   string
+		modify func(*request)
+		// want — поле и код каждой ошибки в порядке полей.
+		want []string
+	}{
+		{"корректный запрос", func(*request) {}, nil},
+		{"пустые обязательные поля", func(r *request) { r.Email, r.Password, r.Card = " ", "", nil },
+			[]string{"email:required", "password:required", "card:required"}},
+		{"неверный email", func(r *request) { r.Email = "Иван <ivan@example.com>" }, []string{"email:email"}},
+		{"короткая строка", func(r *request) { r.Password = "парол" }, []string{"password:min"}},
+		// Шесть символов, но двенадцать байт.
+		{"длина строки в символах", func(r *request) { r.Password = "пароль" }, nil},
+		{"слишком длинная строка", func(r *request) { r.Password = "123456789" }, []string{"password:max"}},
+		{"не цифры", func(r *request) { r.CVV = "12a" }, []string{"cvv:digits"}},
+		{"неверная длина", func(r *request) { r.CVV = "1234" }, []string{"cvv:len"}},
+		{"значение не из списка", func(r *request) { r.Mode = "ALL" }, []string{"mode:oneof"}},
+		{"значение из списка", func(r *request) { r.Mode = "BEST_EFFORT" }, nil},
+		{"не число", func(r *request) { r.Price = "1,5" }, []string{"price:decimal"}},
+		{"число вне диапазона", func(r *request) { r.Term = 61 }, []string{"term:max"}},
+		{"decimal больше предела", func(r *request) { r.Rate = decimal.RequireFromString("1.01") }, []string{"rate:max"}},
+		{"указатель проверяется по значению", func(r *request) { r.Note = &long }, []string{"note:max"}},
+		{"число элементов среза", func(r *request) { r.Tags = []string{"a", "b", "c"} }, []string{"tags:max"}},
+		{"элементы среза структур", func(r *request) {
+			r.Items = append(r.Items, item{Amount: decimal.Zero}, item{Amount: decimal.NewFromInt(-1)})
+		}, []string{"items[1].amount:positive", "items[2].amount:positive"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid()
+			tt.modify(&r)
+
+			var got []string
+			for _, fe := range Struct(&r, i18n.RU) {
+				got = append(got, fe.Field+":"+fe.Code)
+				if fe.Message == "" || strings.HasPrefix(fe.Message, "validation.") {
+					t.Errorf("%s: нет сообщения для кода %s", fe.Field, fe.Code)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ошибки %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Ошибка в теге — ошибка программиста, поэтому валидатор паникует.
+func TestStructPanicsOnBadTag(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"неизвестное правило", &struct {
+			A string `validate:"uuid"`
+		}{A: "x"}},
+		{"нечисловой параметр длины", &struct {
+			A string `validate:"max=ten"`
+		}{A: "x"}},
+		{"нечисловая граница значения", &struct {
+			A int `validate:"min=one"`
+		}{A: 1}},
+		{"positive для логического поля", &struct {
+			A bool `validate:"positive"`
+		}{A: true}},
+		{"min для логического поля", &struct {
+			A bool `validate:"min=1"`
+		}{A: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("ожидалась паника")
+				}
+			}()
+			Struct(tt.v, i18n.RU)
+		})
+	}
+}
+
+// Правило проверяется только после required и omitempty, поэтому ошибку
+// в теге запрос с пустыми полями не покажет. Тест применяет каждое правило
+// каждого DTO к нулевому значению поля напрямую.
+func TestDTOTags(t *testing.T) {
+	for _, v := range []any{
+		dto.CreateAccountRequest{}, dto.TransferRequest{}, dto.UpdateBalanceRequest{},
+		dto.AddMemberRequest{}, dto.ApprovalPolicyRequest{},
+		dto.EmailRequest{}, dto.LoginRequest{}, dto.RegisterRequest{}, dto.ResetPasswordRequest{}, dto.VerifyEmailRequest{},
+		dto.CreateBeneficiaryRequest{}, dto.UpdateBeneficiaryRequest{}, dto.VerifyBeneficiaryRequest{},
+		dto.CardPaymentRequest{}, dto.CreateCardRequest{}, dto.OpenDepositRequest{}, dto.RejectKYCRequest{},
+		dto.P2PTransferRequest{}, dto.ConfirmChallengeRequest{}, dto.ConfirmCodeRequest{}, dto.CreateWebhookRequest{},
+	} {
+		checkTags(t, reflect.TypeOf(v))
+	}
+}
+
+func checkTags(t *testing.T, typ reflect.Type) {
+	t.Helper()
+
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+
+		nested := ft
+		for nested.Kind() == reflect.Pointer || nested.Kind() == reflect.Slice || nested.Kind() == reflect.Array {
+			nested = nested.Elem()
+		}
+		if nested.Kind() == reflect.Struct && nested != decimalType {
+			checkTags(t, nested)
+		}
+
+		for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+			rule, param, _ := strings.Cut(rule, "=")
+			switch rule {
+			case "", "required", "omitempty":
+				continue
+			}
+			func() {
+				defer func() {
+					if p := recover(); p != nil {
+						t.Errorf("%s.%s: %v", typ.Name(), f.Name, p)
+					}
+				}()
+				check(reflect.Zero(ft), rule, param)
+			}()
+		}
+	}
+}
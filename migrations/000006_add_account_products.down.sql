@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_interest_accruals_daily;
+DROP INDEX IF EXISTS idx_interest_accruals_uncapitalized;
+DROP TABLE IF EXISTS interest_accruals;
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS day_count,
+    DROP COLUMN IF EXISTS interest_rate,
+    DROP COLUMN IF EXISTS product;
@@ -0,0 +1,19 @@
+ALTER TABLE accounts
+    ADD COLUMN product       VARCHAR(20)    NOT NULL DEFAULT 'CURRENT',
+    ADD COLUMN interest_rate NUMERIC(5, 4)  NOT NULL DEFAULT 0,
+    ADD COLUMN day_count     VARCHAR(10)    NOT NULL DEFAULT 'ACT/365';
+
+CREATE TABLE interest_accruals
+(
+    id           BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    account_id   BIGINT         NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    accrual_date DATE           NOT NULL,
+    amount       NUMERIC(20, 10) NOT NULL,
+    capitalized  BOOLEAN        NOT NULL DEFAULT FALSE,
+    carry_over   BOOLEAN        NOT NULL DEFAULT FALSE,
+    created_at   TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_interest_accruals_daily ON interest_accruals (account_id, accrual_date) WHERE NOT carry_over;
+
+CREATE INDEX idx_interest_accruals_uncapitalized ON interest_accruals (account_id) WHERE NOT capitalized;
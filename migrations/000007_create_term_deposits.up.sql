@@ -0,0 +1,20 @@
+CREATE TABLE term_deposits
+(
+    id                BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id           BIGINT         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    account_id        BIGINT         NOT NULL UNIQUE REFERENCES accounts (id) ON DELETE CASCADE,
+    source_account_id BIGINT         NOT NULL REFERENCES accounts (id),
+    principal         NUMERIC(12, 2) NOT NULL,
+    interest_rate     NUMERIC(5, 4)  NOT NULL,
+    penalty_rate      NUMERIC(5, 4)  NOT NULL,
+    day_count         VARCHAR(10)    NOT NULL,
+    term_months       INT            NOT NULL,
+    start_date        DATE           NOT NULL,
+    maturity_date     DATE           NOT NULL,
+    auto_rollover     BOOLEAN        NOT NULL DEFAULT FALSE,
+    status            VARCHAR(20)    NOT NULL DEFAULT 'PENDING',
+    created_at        TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_term_deposits_user_id ON term_deposits (user_id);
+CREATE INDEX idx_term_deposits_maturity ON term_deposits (maturity_date) WHERE status = 'ACTIVE';
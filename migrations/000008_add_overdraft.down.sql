@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS overdraft_charges;
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS overdraft_rate,
+    DROP COLUMN IF EXISTS overdraft_limit;
@@ -0,0 +1,14 @@
+ALTER TABLE accounts
+    ADD COLUMN overdraft_limit NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (overdraft_limit >= 0),
+    ADD COLUMN overdraft_rate  NUMERIC(5, 4)  NOT NULL DEFAULT 0;
+
+CREATE TABLE overdraft_charges
+(
+    id          BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    account_id  BIGINT         NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    charge_date DATE           NOT NULL,
+    balance     NUMERIC(12, 2) NOT NULL,
+    amount      NUMERIC(12, 2) NOT NULL,
+    created_at  TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (account_id, charge_date)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_fee_charges_period;
+DROP INDEX IF EXISTS idx_fee_charges_user_operation;
+DROP TABLE IF EXISTS fee_charges;
+DROP TABLE IF EXISTS fee_rules;
@@ -0,0 +1,36 @@
+CREATE TABLE fee_rules
+(
+    id          BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    operation   VARCHAR(20)    NOT NULL,
+    volume_from NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    fixed       NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    percent     NUMERIC(7, 6)  NOT NULL DEFAULT 0,
+    min_amount  NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    max_amount  NUMERIC(12, 2),
+    active      BOOLEAN        NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (operation, volume_from)
+);
+
+CREATE TABLE fee_charges
+(
+    id         BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id    BIGINT         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    account_id BIGINT         NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    operation  VARCHAR(20)    NOT NULL,
+    amount     NUMERIC(12, 2) NOT NULL,
+    fee        NUMERIC(12, 2) NOT NULL,
+    period     DATE,
+    created_at TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_fee_charges_user_operation ON fee_charges (user_id, operation, created_at);
+CREATE UNIQUE INDEX idx_fee_charges_period ON fee_charges (account_id, operation, period) WHERE period IS NOT NULL;
+
+INSERT INTO fee_rules (operation, volume_from, fixed, percent, min_amount, max_amount)
+VALUES ('TRANSFER', 0, 0, 0.005, 10, 500),
+       ('TRANSFER', 100000, 0, 0.0025, 0, 250),
+       ('TRANSFER', 500000, 0, 0, 0, NULL),
+       ('CARD_PAYMENT', 0, 0, 0.01, 0, 1000),
+       ('FX_CONVERSION', 0, 0, 0.015, 50, NULL),
+       ('MAINTENANCE', 0, 99, 0, 0, NULL);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_p2p_transfers_sender_id;
+DROP TABLE IF EXISTS p2p_transfers;
+ALTER TABLE users
+    DROP COLUMN IF EXISTS discoverable,
+    DROP COLUMN IF EXISTS phone;
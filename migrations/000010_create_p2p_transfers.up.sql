@@ -0,0 +1,19 @@
+ALTER TABLE users
+    ADD COLUMN phone        VARCHAR(20) UNIQUE,
+    ADD COLUMN discoverable BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE TABLE p2p_transfers
+(
+    id              BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    sender_id       BIGINT         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    from_account_id BIGINT         NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    recipient_id    BIGINT         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    to_account_id   BIGINT         NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    amount          NUMERIC(12, 2) NOT NULL,
+    fee             NUMERIC(12, 2) NOT NULL,
+    status          VARCHAR(20)    NOT NULL DEFAULT 'PENDING',
+    expires_at      TIMESTAMPTZ    NOT NULL,
+    created_at      TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_p2p_transfers_sender_id ON p2p_transfers (sender_id);
@@ -0,0 +1,2 @@
+ALTER TABLE accounts
+    ADD COLUMN account_number VARCHAR(34) UNIQUE;
@@ -0,0 +1,15 @@
+CREATE TABLE beneficiaries
+(
+    id             BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id        BIGINT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    nickname       VARCHAR(100) NOT NULL,
+    account_id     BIGINT       NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    account_number VARCHAR(34)  NOT NULL,
+    status         VARCHAR(20)  NOT NULL DEFAULT 'PENDING',
+    available_at   TIMESTAMPTZ  NOT NULL,
+    verified_at    TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, account_id)
+);
+
+CREATE INDEX idx_beneficiaries_user_id ON beneficiaries (user_id);
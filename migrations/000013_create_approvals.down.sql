@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS payment_decisions;
+DROP TABLE IF EXISTS pending_payments;
+DROP TABLE IF EXISTS approval_policies;
+DROP TABLE IF EXISTS account_members;
@@ -0,0 +1,44 @@
+CREATE TABLE account_members
+(
+    account_id BIGINT      NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role       VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account_id, user_id)
+);
+
+CREATE INDEX idx_account_members_user_id ON account_members (user_id);
+
+CREATE TABLE approval_policies
+(
+    id                 BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    account_id         BIGINT         NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    amount_from        NUMERIC(12, 2) NOT NULL,
+    required_approvals INT            NOT NULL CHECK (required_approvals >= 0),
+    UNIQUE (account_id, amount_from)
+);
+
+CREATE TABLE pending_payments
+(
+    id                 BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    account_id         BIGINT         NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    to_account_id      BIGINT         NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    initiator_id       BIGINT         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    amount             NUMERIC(12, 2) NOT NULL,
+    required_approvals INT            NOT NULL,
+    status             VARCHAR(20)    NOT NULL DEFAULT 'PENDING',
+    created_at         TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pending_payments_account_status ON pending_payments (account_id, status);
+
+CREATE TABLE payment_decisions
+(
+    payment_id BIGINT      NOT NULL REFERENCES pending_payments (id) ON DELETE CASCADE,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    decision   VARCHAR(20) NOT NULL,
+    comment    TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (payment_id, user_id)
+);
@@ -0,0 +1,33 @@
+CREATE TABLE payment_batches
+(
+    id          BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id     BIGINT         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    account_id  BIGINT         NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    mode        VARCHAR(20)    NOT NULL,
+    status      VARCHAR(20)    NOT NULL DEFAULT 'DRAFT',
+    item_count  INT            NOT NULL,
+    valid_count INT            NOT NULL,
+    total       NUMERIC(14, 2) NOT NULL,
+    total_fee   NUMERIC(14, 2) NOT NULL,
+    created_at  TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_payment_batches_user_id ON payment_batches (user_id);
+CREATE INDEX idx_payment_batches_status ON payment_batches (status);
+
+CREATE TABLE payment_batch_items
+(
+    id            BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    batch_id      BIGINT         NOT NULL REFERENCES payment_batches (id) ON DELETE CASCADE,
+    row_number    INT            NOT NULL,
+    account_ref   VARCHAR(64)    NOT NULL,
+    to_account_id BIGINT REFERENCES accounts (id) ON DELETE SET NULL,
+    amount        NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    fee           NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    reference     VARCHAR(140)   NOT NULL DEFAULT '',
+    status        VARCHAR(20)    NOT NULL,
+    error         TEXT           NOT NULL DEFAULT '',
+    processed_at  TIMESTAMPTZ,
+    UNIQUE (batch_id, row_number)
+);
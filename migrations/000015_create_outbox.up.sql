@@ -0,0 +1,12 @@
+CREATE TABLE outbox_events
+(
+    id             BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id   BIGINT      NOT NULL,
+    event_type     VARCHAR(50) NOT NULL,
+    payload        JSONB       NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at   TIMESTAMPTZ
+);
+
+CREATE INDEX idx_outbox_events_unpublished ON outbox_events (id) WHERE published_at IS NULL;
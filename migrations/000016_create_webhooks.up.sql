@@ -0,0 +1,43 @@
+CREATE TABLE webhook_subscriptions
+(
+    id          BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id     BIGINT        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    url         VARCHAR(2048) NOT NULL,
+    secret      VARCHAR(64)   NOT NULL,
+    event_types TEXT[]        NOT NULL DEFAULT '{}',
+    active      BOOLEAN       NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_subscriptions_user_id ON webhook_subscriptions (user_id);
+
+CREATE TABLE webhook_deliveries
+(
+    id               BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    subscription_id  BIGINT      NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id         BIGINT      NOT NULL,
+    event_type       VARCHAR(50) NOT NULL,
+    payload          JSONB       NOT NULL,
+    status           VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    attempts         INT         NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_status_code INT,
+    last_error       TEXT        NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at     TIMESTAMPTZ,
+    UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'PENDING';
+
+CREATE TABLE webhook_attempts
+(
+    id          BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    delivery_id BIGINT      NOT NULL REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+    status_code INT,
+    error       TEXT        NOT NULL DEFAULT '',
+    duration_ms INT         NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_attempts_delivery_id ON webhook_attempts (delivery_id);
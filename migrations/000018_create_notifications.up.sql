@@ -0,0 +1,46 @@
+CREATE TABLE notification_settings
+(
+    user_id    BIGINT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    locale     VARCHAR(5)  NOT NULL DEFAULT 'ru',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE notification_preferences
+(
+    user_id BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    kind    VARCHAR(50) NOT NULL,
+    channel VARCHAR(10) NOT NULL,
+    enabled BOOLEAN     NOT NULL,
+    PRIMARY KEY (user_id, kind, channel)
+);
+
+CREATE TABLE notifications
+(
+    id         BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    event_id   BIGINT      NOT NULL,
+    kind       VARCHAR(50) NOT NULL,
+    subject    TEXT        NOT NULL,
+    body       TEXT        NOT NULL,
+    in_app     BOOLEAN     NOT NULL,
+    read_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, event_id, kind)
+);
+
+CREATE INDEX idx_notifications_inbox ON notifications (user_id, id DESC) WHERE in_app;
+
+CREATE TABLE email_deliveries
+(
+    id              BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    notification_id BIGINT       NOT NULL UNIQUE REFERENCES notifications (id) ON DELETE CASCADE,
+    recipient       VARCHAR(255) NOT NULL,
+    status          VARCHAR(20)  NOT NULL DEFAULT 'PENDING',
+    attempts        INT          NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error      TEXT         NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at         TIMESTAMPTZ
+);
+
+CREATE INDEX idx_email_deliveries_due ON email_deliveries (next_attempt_at) WHERE status = 'PENDING';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS card_cvv_failures;
+DROP TABLE IF EXISTS risk_decisions;
@@ -0,0 +1,31 @@
+CREATE TABLE risk_decisions
+(
+    id                      BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id                 BIGINT         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    operation               VARCHAR(20)    NOT NULL,
+    account_id              BIGINT         NOT NULL,
+    counterparty_account_id BIGINT,
+    card_id                 BIGINT,
+    amount                  NUMERIC(12, 2) NOT NULL,
+    score                   INT            NOT NULL,
+    decision                VARCHAR(10)    NOT NULL,
+    hits                    JSONB          NOT NULL DEFAULT '[]',
+    -- Для решения CHALLENGE: PENDING → CONFIRMED (пароль подтвержден) → USED.
+    challenge_status        VARCHAR(10),
+    -- Подтвержденная проверка, по которой пропущена операция.
+    challenge_id            BIGINT REFERENCES risk_decisions (id),
+    created_at              TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    confirmed_at            TIMESTAMPTZ
+);
+
+CREATE INDEX idx_risk_decisions_user ON risk_decisions (user_id, created_at);
+CREATE INDEX idx_risk_decisions_review ON risk_decisions (created_at) WHERE decision <> 'ALLOW';
+
+CREATE TABLE card_cvv_failures
+(
+    id         BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    card_id    BIGINT      NOT NULL REFERENCES cards (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_card_cvv_failures_card ON card_cvv_failures (card_id, created_at);
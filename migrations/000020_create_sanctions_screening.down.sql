@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS screening_hits;
+DROP TABLE IF EXISTS held_transfers;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS screening_status,
+    DROP COLUMN IF EXISTS full_name;
@@ -0,0 +1,39 @@
+ALTER TABLE users
+    ADD COLUMN full_name        VARCHAR(255),
+    ADD COLUMN screening_status VARCHAR(10) NOT NULL DEFAULT 'CLEAR';
+
+CREATE TABLE held_transfers
+(
+    id              BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id         BIGINT         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    from_account_id BIGINT         NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    to_account_id   BIGINT         NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    amount          NUMERIC(12, 2) NOT NULL,
+    status          VARCHAR(10)    NOT NULL DEFAULT 'HELD',
+    failure_reason  TEXT           NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_held_transfers_released ON held_transfers (id) WHERE status = 'RELEASED';
+
+CREATE TABLE screening_hits
+(
+    id               BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    subject_type     VARCHAR(10)   NOT NULL,
+    user_id          BIGINT        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    screened_name    VARCHAR(255)  NOT NULL,
+    entry_id         VARCHAR(64)   NOT NULL,
+    entry_name       TEXT          NOT NULL,
+    list_source      VARCHAR(20)   NOT NULL,
+    score            NUMERIC(4, 3) NOT NULL,
+    matches          JSONB         NOT NULL,
+    held_transfer_id BIGINT REFERENCES held_transfers (id) ON DELETE CASCADE,
+    status           VARCHAR(10)   NOT NULL DEFAULT 'PENDING',
+    review_comment   TEXT          NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at      TIMESTAMPTZ
+);
+
+CREATE INDEX idx_screening_hits_pending ON screening_hits (created_at) WHERE status = 'PENDING';
+CREATE INDEX idx_screening_hits_user ON screening_hits (user_id, entry_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS kyc_documents;
+DROP TABLE IF EXISTS customer_profiles;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS kyc_status;
@@ -0,0 +1,30 @@
+ALTER TABLE users
+    ADD COLUMN kyc_status VARCHAR(12) NOT NULL DEFAULT 'UNVERIFIED';
+
+CREATE TABLE customer_profiles
+(
+    user_id       BIGINT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    date_of_birth DATE,
+    citizenship   VARCHAR(2)  NOT NULL DEFAULT '',
+    address       TEXT        NOT NULL DEFAULT '',
+    reject_reason TEXT        NOT NULL DEFAULT '',
+    submitted_at  TIMESTAMPTZ,
+    reviewed_at   TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE kyc_documents
+(
+    id           BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id      BIGINT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    doc_type     VARCHAR(20)  NOT NULL,
+    file_name    VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size         BIGINT       NOT NULL,
+    sha256       CHAR(64)     NOT NULL,
+    storage_key  VARCHAR(255) NOT NULL UNIQUE,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_kyc_documents_user ON kyc_documents (user_id);
+CREATE INDEX idx_users_kyc_pending ON users (id) WHERE kyc_status = 'PENDING';
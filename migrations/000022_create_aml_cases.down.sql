@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_transactions_created_at;
+DROP TABLE IF EXISTS aml_case_transactions;
+DROP TABLE IF EXISTS aml_cases;
@@ -0,0 +1,30 @@
+CREATE TABLE aml_cases
+(
+    id                BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    scenario          VARCHAR(30)    NOT NULL,
+    account_id        BIGINT         NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    user_id           BIGINT         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    summary           TEXT           NOT NULL,
+    total_amount      NUMERIC(14, 2) NOT NULL,
+    transaction_count INT            NOT NULL,
+    status            VARCHAR(10)    NOT NULL DEFAULT 'OPEN',
+    review_comment    TEXT           NOT NULL DEFAULT '',
+    created_at        TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at        TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reported_at       TIMESTAMPTZ
+);
+
+CREATE INDEX idx_aml_cases_status ON aml_cases (status, created_at);
+
+-- Операция попадает не более чем в один кейс каждого сценария, поэтому
+-- повторные прогоны и параллельные экземпляры не заводят дублей.
+CREATE TABLE aml_case_transactions
+(
+    case_id        BIGINT      NOT NULL REFERENCES aml_cases (id) ON DELETE CASCADE,
+    transaction_id BIGINT      NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+    scenario       VARCHAR(30) NOT NULL,
+    PRIMARY KEY (case_id, transaction_id),
+    UNIQUE (scenario, transaction_id)
+);
+
+CREATE INDEX idx_transactions_created_at ON transactions (created_at);
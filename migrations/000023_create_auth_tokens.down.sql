@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS auth_tokens;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS token_version,
+    DROP COLUMN IF EXISTS email_verified_at;
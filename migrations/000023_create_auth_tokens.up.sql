@@ -0,0 +1,19 @@
+-- Уже зарегистрированные пользователи считаются подтвердившими адрес.
+ALTER TABLE users
+    ADD COLUMN email_verified_at TIMESTAMPTZ,
+    ADD COLUMN token_version     INT NOT NULL DEFAULT 0;
+
+UPDATE users SET email_verified_at = created_at;
+
+CREATE TABLE auth_tokens
+(
+    id         BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    purpose    VARCHAR(20) NOT NULL,
+    token_hash CHAR(64)    NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_auth_tokens_user ON auth_tokens (user_id, purpose) WHERE used_at IS NULL;
@@ -0,0 +1,25 @@
+-- Неудачные и еще не завершенные попытки входа и проверки CVV. Успешная
+-- попытка удаляется.
+CREATE TABLE auth_attempts
+(
+    id         BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    scope      VARCHAR(10)  NOT NULL,
+    subject    VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_auth_attempts_subject ON auth_attempts (scope, subject, created_at);
+
+CREATE TABLE lockouts
+(
+    id           BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    scope        VARCHAR(10)  NOT NULL,
+    subject      VARCHAR(255) NOT NULL,
+    failures     INT          NOT NULL,
+    locked_until TIMESTAMPTZ  NOT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Время досрочного снятия блокировки оператором.
+    released_at  TIMESTAMPTZ
+);
+
+CREATE INDEX idx_lockouts_subject ON lockouts (scope, subject, locked_until) WHERE released_at IS NULL;
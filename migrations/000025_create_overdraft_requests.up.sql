@@ -0,0 +1,14 @@
+CREATE TABLE overdraft_requests
+(
+    id              BIGINT PRIMARY KEY GENERATED BY DEFAULT AS IDENTITY,
+    account_id      BIGINT         NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+    user_id         BIGINT         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    requested_limit NUMERIC(12, 2) NOT NULL CHECK (requested_limit > 0),
+    status          VARCHAR(20)    NOT NULL DEFAULT 'PENDING',
+    review_comment  TEXT           NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    reviewed_at     TIMESTAMPTZ
+);
+
+-- По счету может ждать решения только одна заявка.
+CREATE UNIQUE INDEX idx_overdraft_requests_pending ON overdraft_requests (account_id) WHERE status = 'PENDING';
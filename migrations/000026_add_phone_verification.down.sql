@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_users_verified_phone;
+
+UPDATE users SET phone = NULL WHERE phone_verified_at IS NULL;
+
+ALTER TABLE users
+    ADD CONSTRAINT users_phone_key UNIQUE (phone),
+    DROP COLUMN IF EXISTS phone_verified_at;
@@ -0,0 +1,11 @@
+-- Номера до этой миграции не подтверждались. Чтобы по номеру снова можно
+-- было найти получателя, владелец должен подтвердить его кодом из SMS.
+ALTER TABLE users
+    ADD COLUMN phone_verified_at TIMESTAMPTZ;
+
+-- Уникален только подтвержденный номер: иначе чужой номер можно было бы
+-- занять, не владея им.
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_phone_key;
+
+CREATE UNIQUE INDEX idx_users_verified_phone ON users (phone) WHERE phone_verified_at IS NOT NULL;
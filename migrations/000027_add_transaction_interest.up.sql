@@ -0,0 +1,6 @@
+-- Зачисление процентов по счету или вкладу. Оно записывается как DEPOSIT,
+-- но сценарии AML его не учитывают: проценты начисляются и по
+-- простаивающему счету и не являются движением средств клиента.
+-- Зачисления до этой миграции не отмечены.
+ALTER TABLE transactions
+    ADD COLUMN interest BOOLEAN NOT NULL DEFAULT FALSE;